@@ -0,0 +1,114 @@
+// migrate управляет схемой базы данных через встроенные миграции:
+//
+//	migrate up            применить все новые миграции
+//	migrate down [n]      откатить n последних миграций (по умолчанию одну)
+//	migrate to <version>  привести схему к версии: применить или откатить миграции; 0 - откатить все
+//	migrate status        показать миграции и время их применения
+//
+// База берётся из DB_DSN либо из флага -dsn:
+//
+//	go run ./cmd/migrate -dsn ./courseforge.db status
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/config"
+	"github.com/Foxpunk/courseforge/internal/drivers"
+)
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), "usage: migrate [-dsn path] up | down [n] | to <version> | status\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	dsn := flag.String("dsn", "", "база данных; по умолчанию DB_DSN")
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+	if *dsn == "" {
+		*dsn = config.Load().Database.DSN
+	}
+
+	db, err := drivers.OpenDB(*dsn)
+	if err != nil {
+		log.Fatalf("failed to open database: %v", err)
+	}
+	migrator, err := drivers.NewMigrator(db)
+	if err != nil {
+		log.Fatalf("failed to load migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	args := flag.Args()
+	switch args[0] {
+	case "up":
+		n, err := migrator.Up(ctx)
+		report(ctx, migrator, "applied", n, err)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				log.Fatalf("invalid number of steps %q", args[1])
+			}
+		}
+		n, err := migrator.Down(ctx, steps)
+		report(ctx, migrator, "rolled back", n, err)
+	case "to":
+		if len(args) < 2 {
+			usage()
+			os.Exit(2)
+		}
+		version, err := strconv.ParseUint(args[1], 10, 32)
+		if err != nil {
+			log.Fatalf("invalid version %q", args[1])
+		}
+		n, err := migrator.To(ctx, uint(version))
+		report(ctx, migrator, "applied or rolled back", n, err)
+	case "status":
+		printStatus(ctx, migrator)
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+// report печатает итог команды и текущую версию схемы
+func report(ctx context.Context, migrator *drivers.Migrator, action string, n int, err error) {
+	if err != nil {
+		log.Fatalf("%s %d migrations, then failed: %v", action, n, err)
+	}
+	version, err := migrator.Version(ctx)
+	if err != nil {
+		log.Fatalf("failed to get schema version: %v", err)
+	}
+	log.Printf("%s %d migrations, schema version %d", action, n, version)
+}
+
+func printStatus(ctx context.Context, migrator *drivers.Migrator) {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		log.Fatalf("failed to get migration status: %v", err)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, s := range statuses {
+		applied := "pending"
+		if s.AppliedAt != nil {
+			applied = s.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, s.Name, applied)
+	}
+	w.Flush()
+}
@@ -5,10 +5,10 @@ import (
 	"log"
 
 	"golang.org/x/crypto/bcrypt"
-	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 
 	"github.com/Foxpunk/courseforge/internal/config"
+	"github.com/Foxpunk/courseforge/internal/drivers"
 	"github.com/Foxpunk/courseforge/internal/models"
 )
 
@@ -18,23 +18,9 @@ func main() {
 		log.Fatal("Invalid config:", err)
 	}
 
-	db, err := gorm.Open(sqlite.Open(cfg.Database.DSN), &gorm.Config{})
+	db, err := drivers.InitDB(cfg.Database.DSN)
 	if err != nil {
-		log.Fatal("failed to connect database:", err)
-	}
-
-	if err := db.AutoMigrate(
-		&models.Coursework{},
-		&models.Department{},
-		&models.StudentProfile{},
-		&models.TeacherProfile{},
-		&models.StudentCoursework{},
-		&models.StudentGroup{},
-		&models.Subject{},
-		&models.TeacherSubject{},
-		&models.User{},
-	); err != nil {
-		log.Fatal("AutoMigrate failed:", err)
+		log.Fatal("failed to prepare database:", err)
 	}
 
 	// Seeder: admin user
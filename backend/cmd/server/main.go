@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"log"
+	"time"
 
 	"github.com/Foxpunk/courseforge/internal/config"
 	"github.com/Foxpunk/courseforge/internal/drivers"
@@ -25,16 +27,79 @@ func main() {
 	studentCourseworkRepo := drivers.NewStudentCourseworkRepository(db)
 	teacherSubjectRepo := drivers.NewTeacherSubjectRepository(db)
 	teacherProfileRepo := drivers.NewTeacherProfileRepository(db)
-	//studentProfileRepo = drivers.NewStudentProfileRepository(db)
-	//studentGroupRepo = drivers.NewStudentGroupRepository(db)
-	//departamentRepo = drivers.NewDepartmentRepository(db)
+	defenseRoomRepo := drivers.NewDefenseRoomRepository(db)
+	defenseSessionRepo := drivers.NewDefenseSessionRepository(db)
+	defenseSlotRepo := drivers.NewDefenseSlotRepository(db)
+	proposalRepo := drivers.NewTopicProposalRepository(db)
+	roundRepo := drivers.NewSelectionRoundRepository(db)
+	preferenceRepo := drivers.NewSelectionPreferenceRepository(db)
+	waitlistRepo := drivers.NewWaitlistRepository(db)
+	teamRepo := drivers.NewTeamRepository(db)
+	teamInvitationRepo := drivers.NewTeamInvitationRepository(db)
+	departmentRepo := drivers.NewDepartmentRepository(db)
+	quotaRepo := drivers.NewSupervisionQuotaRepository(db)
+	termRepo := drivers.NewTermRepository(db)
+	studentProfileRepo := drivers.NewStudentProfileRepository(db)
+	studentGroupRepo := drivers.NewStudentGroupRepository(db)
+	groupSubjectRepo := drivers.NewGroupSubjectRepository(db)
+	importJobRepo := drivers.NewImportJobRepository(db)
+	documentTemplateRepo := drivers.NewDocumentTemplateRepository(db)
+	issuedDocumentRepo := drivers.NewIssuedDocumentRepository(db)
+	submissionRepo := drivers.NewSubmissionRepository(db)
+	discussionRepo := drivers.NewDiscussionRepository(db)
+	notificationRepo := drivers.NewNotificationRepository(db)
+	emailRepo := drivers.NewEmailRepository(db)
+	telegramRepo := drivers.NewTelegramRepository(db)
+	fileStorage, err := drivers.NewLocalFileStorage(cfg.Storage.Dir)
+	if err != nil {
+		log.Fatalf("failed to init file storage: %v", err)
+	}
+	mailer, err := drivers.NewMailer(cfg.Mail)
+	if err != nil {
+		log.Fatalf("failed to init mailer: %v", err)
+	}
+	telegramClient := drivers.NewTelegramClient(cfg.Telegram)
+	pubSub := drivers.NewMemoryPubSub(cfg.Realtime.BufferSize)
+	webhookRepo := drivers.NewWebhookRepository(db)
+	outboxRepo := drivers.NewOutboxRepository(db)
+	auditRepo := drivers.NewAuditRepository(db)
+	unitOfWork := drivers.NewUnitOfWork(db)
+	idempotencyRepo := drivers.NewIdempotencyRepository(db)
+	webhookClient := drivers.NewWebhookClient(cfg.Webhooks.Timeout)
 	// Initialize managers
-	authManager := managers.NewAuthManager(userRepo, cfg.JWT)
-	userManager := managers.NewUserManager(userRepo)
-	subjectManager := managers.NewSubjectManager(subjectRepo, teacherSubjectRepo, teacherProfileRepo)
-	courseworkManager := managers.NewCourseworkManager(courseworkRepo, studentCourseworkRepo)
-	studentCourseworkManager := managers.NewStudentCourseworkManager(studentCourseworkRepo, courseworkRepo)
-	//departamentManager = managers.NewDepartmentManager(departamentRepo,teacherProfileRepo)
+	webhookManager := managers.NewWebhookManager(webhookRepo, webhookClient, cfg.Webhooks)
+	eventBus := managers.NewEventBus(outboxRepo, cfg.Outbox)
+	idempotencyManager := managers.NewIdempotencyManager(idempotencyRepo, cfg.Idempotency)
+	authManager := managers.NewAuthManager(userRepo, webhookManager, cfg.JWT)
+	userManager := managers.NewUserManager(userRepo, webhookManager)
+	subjectManager := managers.NewSubjectManager(subjectRepo, teacherSubjectRepo, teacherProfileRepo, termRepo)
+	termManager := managers.NewTermManager(termRepo)
+	curriculumManager := managers.NewCurriculumManager(groupSubjectRepo, studentGroupRepo, studentProfileRepo, subjectRepo, termRepo)
+	emailManager, err := managers.NewEmailManager(emailRepo, notificationRepo, userRepo, mailer, cfg.Mail)
+	if err != nil {
+		log.Fatalf("failed to init email manager: %v", err)
+	}
+	telegramChannel := managers.NewTelegramChannel(telegramRepo, telegramClient)
+	realtimeManager := managers.NewRealtimeManager(pubSub, courseworkRepo, waitlistRepo, cfg.Realtime.TicketTTL)
+	notificationManager := managers.NewNotificationManager(notificationRepo, emailManager, telegramChannel, realtimeManager)
+	workloadManager := managers.NewWorkloadManager(quotaRepo, teacherProfileRepo, departmentRepo, userRepo, courseworkRepo, studentCourseworkRepo, termRepo, cfg.Workload)
+	waitlistManager := managers.NewWaitlistManager(waitlistRepo, courseworkRepo, studentCourseworkRepo, workloadManager, unitOfWork, eventBus, cfg.Waitlist.OfferTTL)
+	courseworkManager := managers.NewCourseworkManager(courseworkRepo, studentCourseworkRepo, termRepo, teacherSubjectRepo, curriculumManager, waitlistManager, workloadManager, realtimeManager)
+	studentCourseworkManager := managers.NewStudentCourseworkManager(studentCourseworkRepo, courseworkRepo, roundRepo, termRepo, waitlistManager, workloadManager, unitOfWork, eventBus)
+	defenseManager := managers.NewDefenseManager(defenseRoomRepo, defenseSessionRepo, defenseSlotRepo, studentCourseworkRepo, userRepo, termRepo, unitOfWork, cfg.JWT.SecretKey)
+	deadlineManager := managers.NewDeadlineManager(defenseManager, defenseSlotRepo, waitlistRepo, roundRepo, termRepo, curriculumManager, userRepo, notificationRepo, notificationManager, cfg.Deadlines)
+	telegramManager := managers.NewTelegramManager(telegramRepo, telegramClient, studentCourseworkRepo, termRepo, userRepo, deadlineManager, cfg.Telegram)
+	proposalManager := managers.NewTopicProposalManager(proposalRepo, userRepo, subjectRepo, courseworkRepo, studentCourseworkRepo, termRepo, studentCourseworkManager, workloadManager, notificationManager, unitOfWork)
+	selectionManager := managers.NewSelectionManager(roundRepo, preferenceRepo, subjectRepo, courseworkRepo, studentCourseworkRepo, termRepo, waitlistManager, workloadManager, unitOfWork, eventBus)
+	teamManager := managers.NewTeamManager(teamRepo, teamInvitationRepo, userRepo, studentCourseworkRepo, studentCourseworkManager, notificationManager, unitOfWork)
+	departmentManager := managers.NewDepartmentManager(departmentRepo, teacherProfileRepo)
+	groupManager := managers.NewGroupManager(studentGroupRepo, studentProfileRepo, departmentRepo)
+	profileManager := managers.NewProfileManager(userRepo, studentProfileRepo, teacherProfileRepo, studentGroupRepo, departmentRepo)
+	gradebookManager := managers.NewGradebookManager(studentCourseworkRepo, subjectRepo, studentGroupRepo, studentProfileRepo, termRepo, teacherSubjectRepo)
+	documentManager := managers.NewDocumentManager(documentTemplateRepo, issuedDocumentRepo, studentGroupRepo, userRepo, termRepo, gradebookManager)
+	submissionManager := managers.NewSubmissionManager(submissionRepo, studentCourseworkRepo, studentCourseworkManager, fileStorage, unitOfWork, eventBus, cfg.Similarity)
+	discussionManager := managers.NewDiscussionManager(discussionRepo, courseworkRepo, studentCourseworkRepo, userRepo, courseworkManager, fileStorage, notificationManager)
+	importManager := managers.NewImportManager(importJobRepo, userRepo, studentGroupRepo, departmentRepo, studentProfileRepo, teacherProfileRepo, webhookManager, unitOfWork)
 	// Setup router
 	router := handlers.NewRouter(
 		authManager,
@@ -42,9 +107,142 @@ func main() {
 		subjectManager,
 		courseworkManager,
 		studentCourseworkManager,
+		defenseManager,
+		proposalManager,
+		selectionManager,
+		waitlistManager,
+		teamManager,
+		workloadManager,
+		termManager,
+		curriculumManager,
+		departmentManager,
+		groupManager,
+		profileManager,
+		importManager,
+		gradebookManager,
+		documentManager,
+		submissionManager,
+		discussionManager,
+		notificationManager,
+		emailManager,
+		deadlineManager,
+		telegramManager,
+		realtimeManager,
+		webhookManager,
+		idempotencyManager,
+		cfg.Storage.MaxUploadSize,
+		cfg.Realtime.Heartbeat,
+		cfg.Server.RequireIfMatch,
 		cfg.JWT.SecretKey,
 	)
 
+	// Просроченные предложения из листов ожидания передаются следующим студентам
+	go func() {
+		ticker := time.NewTicker(cfg.Waitlist.SweepInterval)
+		defer ticker.Stop()
+		for now := range ticker.C {
+			if n, err := waitlistManager.ExpireOffers(context.Background(), now); err != nil {
+				log.Printf("waitlist sweep failed: %v", err)
+			} else if n > 0 {
+				log.Printf("waitlist sweep: %d offers expired", n)
+			}
+		}
+	}()
+
+	// Сданные работы сравниваются с архивом в фоне, чтобы не задерживать загрузку
+	go func() {
+		ticker := time.NewTicker(cfg.Similarity.PollInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if n, err := submissionManager.ProcessPending(context.Background()); err != nil {
+				log.Printf("similarity check failed: %v", err)
+			} else if n > 0 {
+				log.Printf("similarity check: %d submissions processed", n)
+			}
+		}
+	}()
+
+	// Письма отправляются из очереди в фоне, неудачные - повторно с растущей паузой;
+	// в час рассылки собираются ежедневные сводки
+	if mailer != nil {
+		go func() {
+			ticker := time.NewTicker(cfg.Mail.PollInterval)
+			defer ticker.Stop()
+			for now := range ticker.C {
+				if n, err := emailManager.SendDigests(context.Background(), now); err != nil {
+					log.Printf("email digest failed: %v", err)
+				} else if n > 0 {
+					log.Printf("email digest: %d digests queued", n)
+				}
+				if n, err := emailManager.ProcessQueue(context.Background(), now); err != nil {
+					log.Printf("email queue failed: %v", err)
+				} else if n > 0 {
+					log.Printf("email queue: %d emails sent", n)
+				}
+			}
+		}()
+	}
+
+	// Напоминания о защитах и истекающих предложениях из листов ожидания
+	go func() {
+		ticker := time.NewTicker(cfg.Deadlines.SweepInterval)
+		defer ticker.Stop()
+		for now := range ticker.C {
+			if n, err := deadlineManager.SendReminders(context.Background(), now); err != nil {
+				log.Printf("deadline reminders failed: %v", err)
+			} else if n > 0 {
+				log.Printf("deadline reminders: %d sent", n)
+			}
+		}
+	}()
+
+	// События из outbox доставляются внутренним подписчикам хотя бы один раз
+	managers.NewAssignmentSubscribers(studentCourseworkRepo, submissionRepo, notificationManager, realtimeManager, webhookManager).Register(eventBus)
+	managers.NewWaitlistSubscribers(waitlistRepo, notificationManager, realtimeManager).Register(eventBus)
+	managers.NewAuditSubscriber(auditRepo).Register(eventBus)
+	go func() {
+		ticker := time.NewTicker(cfg.Outbox.PollInterval)
+		defer ticker.Stop()
+		for now := range ticker.C {
+			if n, err := eventBus.ProcessOutbox(context.Background(), now); err != nil {
+				log.Printf("outbox dispatch failed: %v", err)
+			} else if n > 0 {
+				log.Printf("outbox dispatch: %d events processed", n)
+			}
+		}
+	}()
+
+	// Вебхуки отправляются из очереди в фоне, неудачные - повторно с растущей паузой
+	go func() {
+		ticker := time.NewTicker(cfg.Webhooks.PollInterval)
+		defer ticker.Stop()
+		for now := range ticker.C {
+			if n, err := webhookManager.ProcessQueue(context.Background(), now); err != nil {
+				log.Printf("webhook queue failed: %v", err)
+			} else if n > 0 {
+				log.Printf("webhook queue: %d deliveries sent", n)
+			}
+		}
+	}()
+
+	// Сохранённые ответы на запросы с Idempotency-Key удаляются по истечении срока хранения
+	go func() {
+		ticker := time.NewTicker(cfg.Idempotency.PurgeInterval)
+		defer ticker.Stop()
+		for now := range ticker.C {
+			if n, err := idempotencyManager.PurgeExpired(context.Background(), now); err != nil {
+				log.Printf("idempotency purge failed: %v", err)
+			} else if n > 0 {
+				log.Printf("idempotency purge: %d keys removed", n)
+			}
+		}
+	}()
+
+	// Бот Telegram получает сообщения через long polling
+	if telegramClient != nil {
+		go telegramManager.Run(context.Background())
+	}
+
 	addr := cfg.GetServerAddress()
 	log.Printf("starting server on %s", addr)
 	if err := router.Run(addr); err != nil {
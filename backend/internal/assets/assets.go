@@ -0,0 +1,14 @@
+// Package assets содержит файлы, встроенные в бинарник: шрифты для печатных форм и шаблоны писем
+package assets
+
+import "embed"
+
+// Fonts - шрифты DejaVu Serif с кириллицей для PDF (лицензия в fonts/LICENSE)
+//
+//go:embed fonts/*.ttf
+var Fonts embed.FS
+
+// Mail - шаблоны писем по языкам: mail/<язык>/<вид>.txt с темой в блоке subject и mail/<язык>/<вид>.html
+//
+//go:embed mail
+var Mail embed.FS
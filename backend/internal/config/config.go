@@ -11,18 +11,30 @@ import (
 
 // Config содержит все конфигурационные параметры приложения
 type Config struct {
-	Server   ServerConfig   `json:"server"`
-	Database DatabaseConfig `json:"database"`
-	JWT      JWTConfig      `json:"jwt"`
+	Server      ServerConfig      `json:"server"`
+	Database    DatabaseConfig    `json:"database"`
+	JWT         JWTConfig         `json:"jwt"`
+	Waitlist    WaitlistConfig    `json:"waitlist"`
+	Workload    WorkloadConfig    `json:"workload"`
+	Storage     StorageConfig     `json:"storage"`
+	Similarity  SimilarityConfig  `json:"similarity"`
+	Mail        MailConfig        `json:"mail"`
+	Telegram    TelegramConfig    `json:"telegram"`
+	Deadlines   DeadlineConfig    `json:"deadlines"`
+	Realtime    RealtimeConfig    `json:"realtime"`
+	Webhooks    WebhookConfig     `json:"webhooks"`
+	Outbox      OutboxConfig      `json:"outbox"`
+	Idempotency IdempotencyConfig `json:"idempotency"`
 }
 
 // ServerConfig содержит параметры HTTP сервера
 type ServerConfig struct {
-	Host         string        `json:"host"`
-	Port         string        `json:"port"`
-	ReadTimeout  time.Duration `json:"read_timeout"`
-	WriteTimeout time.Duration `json:"write_timeout"`
-	IdleTimeout  time.Duration `json:"idle_timeout"`
+	Host           string        `json:"host"`
+	Port           string        `json:"port"`
+	ReadTimeout    time.Duration `json:"read_timeout"`
+	WriteTimeout   time.Duration `json:"write_timeout"`
+	IdleTimeout    time.Duration `json:"idle_timeout"`
+	RequireIfMatch bool          `json:"require_if_match"` // PUT и DELETE тем и пользователей без If-Match - 428
 }
 
 // DatabaseConfig содержит параметры подключения к базе данных
@@ -42,15 +54,107 @@ type JWTConfig struct {
 	Issuer               string        `json:"issuer"`
 }
 
+// WaitlistConfig содержит параметры листов ожидания
+type WaitlistConfig struct {
+	OfferTTL      time.Duration `json:"offer_ttl"`      // сколько студент может подтверждать освободившееся место
+	SweepInterval time.Duration `json:"sweep_interval"` // как часто проверять просроченные предложения
+}
+
+// WorkloadConfig содержит лимиты руководства, действующие без индивидуальной квоты
+type WorkloadConfig struct {
+	DefaultMaxStudents     int     `json:"default_max_students"`      // 0 - без ограничения
+	DefaultMaxTopics       int     `json:"default_max_topics"`        // 0 - без ограничения
+	DefaultHoursPerStudent float64 `json:"default_hours_per_student"` // нагрузка в часах за одного студента
+}
+
+// StorageConfig содержит параметры хранения загруженных файлов
+type StorageConfig struct {
+	Dir           string `json:"dir"`             // каталог для файлов сданных работ
+	MaxUploadSize int64  `json:"max_upload_size"` // предельный размер файла в байтах
+}
+
+// SimilarityConfig содержит параметры проверки работ на заимствования
+type SimilarityConfig struct {
+	ShingleSize        int           `json:"shingle_size"`        // длина шингла в словах
+	CandidateThreshold float64       `json:"candidate_threshold"` // оценка MinHash, с которой работа сравнивается точно
+	ReportThreshold    float64       `json:"report_threshold"`    // доля совпавших шинглов, с которой совпадение попадает в отчёт
+	PollInterval       time.Duration `json:"poll_interval"`       // как часто проверять очередь работ
+	BatchSize          int           `json:"batch_size"`          // сколько работ проверять за один проход
+}
+
+// MailConfig содержит параметры отправки уведомлений по почте
+type MailConfig struct {
+	Driver       string        `json:"driver"`    // smtp, file или пусто - письма не отправляются
+	From         string        `json:"from"`      // адрес отправителя, можно с именем
+	AppURL       string        `json:"app_url"`   // адрес приложения для ссылок в письмах
+	SMTPHost     string        `json:"smtp_host"` // сервер SMTP
+	SMTPPort     int           `json:"smtp_port"`
+	SMTPUsername string        `json:"smtp_username"` // пусто - без авторизации
+	SMTPPassword string        `json:"-"`
+	SMTPTLS      bool          `json:"smtp_tls"`      // сразу TLS (порт 465), иначе STARTTLS, если сервер его предлагает
+	FileDir      string        `json:"file_dir"`      // каталог для писем .eml при driver=file
+	DigestHour   int           `json:"digest_hour"`   // час отправки ежедневной сводки по времени сервера
+	MaxAttempts  int           `json:"max_attempts"`  // попыток отправки, после которых письмо считается неотправленным
+	RetryBackoff time.Duration `json:"retry_backoff"` // пауза перед первой повторной попыткой, дальше удваивается
+	PollInterval time.Duration `json:"poll_interval"` // как часто разбирать очередь писем
+	BatchSize    int           `json:"batch_size"`    // сколько писем отправлять за один проход
+}
+
+// TelegramConfig содержит параметры бота Telegram
+type TelegramConfig struct {
+	BotToken    string        `json:"-"`             // пусто - бот выключен
+	APIURL      string        `json:"api_url"`       // адрес Bot API, для проверок можно указать локальный сервер
+	BotUsername string        `json:"bot_username"`  // имя бота для ссылки привязки t.me/<имя>?start=<код>
+	PollTimeout time.Duration `json:"poll_timeout"`  // длительность long polling getUpdates
+	LinkCodeTTL time.Duration `json:"link_code_ttl"` // сколько действует код привязки чата
+}
+
+// DeadlineConfig содержит параметры напоминаний о сроках
+type DeadlineConfig struct {
+	RemindBefore  time.Duration `json:"remind_before"`  // за сколько до срока напоминать
+	SweepInterval time.Duration `json:"sweep_interval"` // как часто искать приближающиеся сроки
+}
+
+// RealtimeConfig содержит параметры обновлений в реальном времени
+type RealtimeConfig struct {
+	Heartbeat  time.Duration `json:"heartbeat"`   // как часто слать клиенту пустой комментарий, чтобы прокси не рвали соединение
+	BufferSize int           `json:"buffer_size"` // сколько обновлений ждут медленного клиента, прежде чем его отключить
+	TicketTTL  time.Duration `json:"ticket_ttl"`  // сколько действует билет на подключение к потоку
+}
+
+// WebhookConfig содержит параметры исходящих вебхуков
+type WebhookConfig struct {
+	Timeout      time.Duration `json:"timeout"`       // сколько ждать ответа получателя
+	MaxAttempts  int           `json:"max_attempts"`  // попыток доставки до статуса failed
+	RetryBackoff time.Duration `json:"retry_backoff"` // пауза перед первым повтором, дальше удваивается
+	PollInterval time.Duration `json:"poll_interval"` // как часто проверять очередь доставок
+	BatchSize    int           `json:"batch_size"`    // доставок за один проход
+}
+
+// OutboxConfig содержит параметры доставки событий из outbox внутренним подписчикам
+type OutboxConfig struct {
+	PollInterval time.Duration `json:"poll_interval"` // как часто проверять outbox
+	BatchSize    int           `json:"batch_size"`    // событий за один проход
+	MaxAttempts  int           `json:"max_attempts"`  // попыток до статуса failed
+	RetryBackoff time.Duration `json:"retry_backoff"` // пауза перед первым повтором, дальше удваивается
+}
+
+// IdempotencyConfig содержит параметры хранения ключей Idempotency-Key
+type IdempotencyConfig struct {
+	TTL           time.Duration `json:"ttl"`            // сколько хранится ответ для повторов
+	PurgeInterval time.Duration `json:"purge_interval"` // как часто удалять истёкшие ключи
+}
+
 // Load загружает конфигурацию из переменных окружения
 func Load() *Config {
 	return &Config{
 		Server: ServerConfig{
-			Host:         getEnv("SERVER_HOST", "localhost"),
-			Port:         getEnv("SERVER_PORT", "8080"),
-			ReadTimeout:  getDurationEnv("SERVER_READ_TIMEOUT", "30s"),
-			WriteTimeout: getDurationEnv("SERVER_WRITE_TIMEOUT", "30s"),
-			IdleTimeout:  getDurationEnv("SERVER_IDLE_TIMEOUT", "60s"),
+			Host:           getEnv("SERVER_HOST", "localhost"),
+			Port:           getEnv("SERVER_PORT", "8080"),
+			ReadTimeout:    getDurationEnv("SERVER_READ_TIMEOUT", "30s"),
+			WriteTimeout:   getDurationEnv("SERVER_WRITE_TIMEOUT", "30s"),
+			IdleTimeout:    getDurationEnv("SERVER_IDLE_TIMEOUT", "60s"),
+			RequireIfMatch: getBoolEnv("REQUIRE_IF_MATCH", false),
 		},
 		Database: DatabaseConfig{
 			Driver:   getEnv("DB_DRIVER", "sqlite3"),
@@ -65,6 +169,75 @@ func Load() *Config {
 			RefreshTokenDuration: getDurationEnv("JWT_REFRESH_TOKEN_DURATION", "168h"), // 7 дней
 			Issuer:               getEnv("JWT_ISSUER", "courseforge"),
 		},
+		Waitlist: WaitlistConfig{
+			OfferTTL:      getDurationEnv("WAITLIST_OFFER_TTL", "48h"),
+			SweepInterval: getDurationEnv("WAITLIST_SWEEP_INTERVAL", "1m"),
+		},
+		Workload: WorkloadConfig{
+			DefaultMaxStudents:     getIntEnv("WORKLOAD_DEFAULT_MAX_STUDENTS", 0),
+			DefaultMaxTopics:       getIntEnv("WORKLOAD_DEFAULT_MAX_TOPICS", 0),
+			DefaultHoursPerStudent: getFloatEnv("WORKLOAD_DEFAULT_HOURS_PER_STUDENT", 3),
+		},
+		Storage: StorageConfig{
+			Dir:           getEnv("STORAGE_DIR", "./uploads"),
+			MaxUploadSize: getInt64Env("STORAGE_MAX_UPLOAD_SIZE", 20<<20),
+		},
+		Similarity: SimilarityConfig{
+			ShingleSize:        getIntEnv("SIMILARITY_SHINGLE_SIZE", 5),
+			CandidateThreshold: getFloatEnv("SIMILARITY_CANDIDATE_THRESHOLD", 0.02),
+			ReportThreshold:    getFloatEnv("SIMILARITY_REPORT_THRESHOLD", 0.1),
+			PollInterval:       getDurationEnv("SIMILARITY_POLL_INTERVAL", "10s"),
+			BatchSize:          getIntEnv("SIMILARITY_BATCH_SIZE", 5),
+		},
+		Mail: MailConfig{
+			Driver:       getEnv("MAIL_DRIVER", ""),
+			From:         getEnv("MAIL_FROM", "CourseForge <noreply@courseforge.local>"),
+			AppURL:       getEnv("APP_URL", "http://localhost:3000"),
+			SMTPHost:     getEnv("SMTP_HOST", "localhost"),
+			SMTPPort:     getIntEnv("SMTP_PORT", 587),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+			SMTPTLS:      getBoolEnv("SMTP_TLS", false),
+			FileDir:      getEnv("MAIL_FILE_DIR", "./mail"),
+			DigestHour:   getIntEnv("MAIL_DIGEST_HOUR", 8),
+			MaxAttempts:  getIntEnv("MAIL_MAX_ATTEMPTS", 6),
+			RetryBackoff: getDurationEnv("MAIL_RETRY_BACKOFF", "1m"),
+			PollInterval: getDurationEnv("MAIL_POLL_INTERVAL", "30s"),
+			BatchSize:    getIntEnv("MAIL_BATCH_SIZE", 20),
+		},
+		Telegram: TelegramConfig{
+			BotToken:    getEnv("TELEGRAM_BOT_TOKEN", ""),
+			APIURL:      getEnv("TELEGRAM_API_URL", "https://api.telegram.org"),
+			BotUsername: getEnv("TELEGRAM_BOT_USERNAME", ""),
+			PollTimeout: getDurationEnv("TELEGRAM_POLL_TIMEOUT", "30s"),
+			LinkCodeTTL: getDurationEnv("TELEGRAM_LINK_CODE_TTL", "15m"),
+		},
+		Deadlines: DeadlineConfig{
+			RemindBefore:  getDurationEnv("DEADLINE_REMIND_BEFORE", "24h"),
+			SweepInterval: getDurationEnv("DEADLINE_SWEEP_INTERVAL", "10m"),
+		},
+		Realtime: RealtimeConfig{
+			Heartbeat:  getDurationEnv("REALTIME_HEARTBEAT", "25s"),
+			BufferSize: getIntEnv("REALTIME_BUFFER_SIZE", 64),
+			TicketTTL:  getDurationEnv("REALTIME_TICKET_TTL", "30s"),
+		},
+		Webhooks: WebhookConfig{
+			Timeout:      getDurationEnv("WEBHOOK_TIMEOUT", "10s"),
+			MaxAttempts:  getIntEnv("WEBHOOK_MAX_ATTEMPTS", 8),
+			RetryBackoff: getDurationEnv("WEBHOOK_RETRY_BACKOFF", "30s"),
+			PollInterval: getDurationEnv("WEBHOOK_POLL_INTERVAL", "10s"),
+			BatchSize:    getIntEnv("WEBHOOK_BATCH_SIZE", 20),
+		},
+		Outbox: OutboxConfig{
+			PollInterval: getDurationEnv("OUTBOX_POLL_INTERVAL", "1s"),
+			BatchSize:    getIntEnv("OUTBOX_BATCH_SIZE", 50),
+			MaxAttempts:  getIntEnv("OUTBOX_MAX_ATTEMPTS", 10),
+			RetryBackoff: getDurationEnv("OUTBOX_RETRY_BACKOFF", "5s"),
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:           getDurationEnv("IDEMPOTENCY_TTL", "24h"),
+			PurgeInterval: getDurationEnv("IDEMPOTENCY_PURGE_INTERVAL", "1h"),
+		},
 	}
 }
 
@@ -120,6 +293,16 @@ func getIntEnv(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getFloatEnv(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+		log.Printf("Warning: invalid float value for %s: %s, using default: %g", key, value, defaultValue)
+	}
+	return defaultValue
+}
+
 func getInt64Env(key string, defaultValue int64) int64 {
 	if value := os.Getenv(key); value != "" {
 		if int64Value, err := strconv.ParseInt(value, 10, 64); err == nil {
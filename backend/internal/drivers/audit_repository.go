@@ -0,0 +1,38 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository создаёт новый репозиторий журнала аудита
+func NewAuditRepository(db *gorm.DB) interfaces.AuditRepository {
+	return &auditRepository{db: db}
+}
+
+// Create добавляет запись; запись о том же событии outbox уже есть - ничего не делает
+func (r *auditRepository) Create(ctx context.Context, entry *models.AuditEntry) error {
+	if entry == nil {
+		return errors.New("audit entry cannot be nil")
+	}
+	result := conn(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "event_id"}},
+			DoNothing: true,
+		}).
+		Create(entry)
+	if result.Error != nil {
+		return fmt.Errorf("failed to write audit entry: %w", result.Error)
+	}
+	return nil
+}
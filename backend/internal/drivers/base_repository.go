@@ -25,12 +25,12 @@ func (r *baseRepository[T]) Create(ctx context.Context, e *T) error {
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
-	return r.db.WithContext(ctx).Create(e).Error
+	return conn(ctx, r.db).Create(e).Error
 }
 
 func (r *baseRepository[T]) GetByID(ctx context.Context, id uint) (*T, error) {
 	var entity T
-	res := r.db.WithContext(ctx).First(&entity, id)
+	res := conn(ctx, r.db).First(&entity, id)
 	if res.Error != nil {
 		return nil, res.Error
 	}
@@ -43,11 +43,11 @@ func (r *baseRepository[T]) Update(ctx context.Context, e *T) error {
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
-	return r.db.WithContext(ctx).Save(e).Error
+	return conn(ctx, r.db).Save(e).Error
 }
 
 func (r *baseRepository[T]) Delete(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(new(T), id).Error
+	return conn(ctx, r.db).Delete(new(T), id).Error
 }
 
 func (r *baseRepository[T]) List(ctx context.Context, limit, offset int) ([]T, error) {
@@ -60,7 +60,7 @@ func (r *baseRepository[T]) List(ctx context.Context, limit, offset int) ([]T, e
 	}
 
 	var entities []T
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Limit(limit).
 		Offset(offset).
 		Find(&entities).Error
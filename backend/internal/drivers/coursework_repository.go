@@ -8,6 +8,7 @@ import (
 	"github.com/Foxpunk/courseforge/internal/interfaces"
 	"github.com/Foxpunk/courseforge/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type courseworkRepository struct {
@@ -36,12 +37,27 @@ func (r *courseworkRepository) Create(ctx context.Context, cw *models.Coursework
 	if cw.DifficultyLevel != models.Easy && cw.DifficultyLevel != models.Medium && cw.DifficultyLevel != models.Hard {
 		return fmt.Errorf("invalid difficulty level: %s", cw.DifficultyLevel)
 	}
-
-	result := r.db.WithContext(ctx).Create(cw)
-	if result.Error != nil {
-		return fmt.Errorf("failed to create coursework: %w", result.Error)
+	// тема без явного семестра относится к текущему
+	if cw.TermID == 0 {
+		termID, err := activeTermID(conn(ctx, r.db))
+		if err != nil {
+			return err
+		}
+		cw.TermID = termID
 	}
-	return nil
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(cw).Error; err != nil {
+			return fmt.Errorf("failed to create coursework: %w", err)
+		}
+		// у is_available в схеме default true, и GORM не вставляет false - снимаем признак отдельно
+		if !cw.IsAvailable {
+			if err := tx.Model(cw).UpdateColumn("is_available", false).Error; err != nil {
+				return fmt.Errorf("failed to create coursework: %w", err)
+			}
+		}
+		return nil
+	})
 }
 
 // GetByID возвращает курсовую по ID, подгружая предмет и преподавателя
@@ -51,7 +67,7 @@ func (r *courseworkRepository) GetByID(ctx context.Context, id uint) (*models.Co
 	}
 
 	var cw models.Coursework
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Preload("Subject").
 		Preload("Teacher").
 		First(&cw, id)
@@ -74,32 +90,59 @@ func (r *courseworkRepository) Update(ctx context.Context, cw *models.Coursework
 		return errors.New("coursework ID cannot be zero")
 	}
 
-	result := r.db.WithContext(ctx).Save(cw)
+	// запись меняется, только если её версия совпадает с прочитанной
+	expected := cw.Version
+	cw.Version = expected + 1
+	result := conn(ctx, r.db).
+		Model(cw).
+		Where("version = ?", expected).
+		Select("*").
+		Omit("id", "created_at", clause.Associations).
+		Updates(cw)
 	if result.Error != nil {
+		cw.Version = expected
 		return fmt.Errorf("failed to update coursework: %w", result.Error)
 	}
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("coursework with ID %d not found", cw.ID)
+		cw.Version = expected
+		return r.versionMismatch(ctx, cw.ID)
 	}
 	return nil
 }
 
-// Delete выполняет мягкое удаление курсовой работы
-func (r *courseworkRepository) Delete(ctx context.Context, id uint) error {
+// Delete выполняет мягкое удаление курсовой работы; при version != 0 - только этой версии
+func (r *courseworkRepository) Delete(ctx context.Context, id uint, version uint) error {
 	if id == 0 {
 		return errors.New("invalid coursework ID")
 	}
 
-	result := r.db.WithContext(ctx).Delete(&models.Coursework{}, id)
+	query := conn(ctx, r.db)
+	if version != 0 {
+		query = query.Where("version = ?", version)
+	}
+	result := query.Delete(&models.Coursework{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete coursework: %w", result.Error)
 	}
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("coursework with ID %d not found", id)
+		return r.versionMismatch(ctx, id)
 	}
 	return nil
 }
 
+// versionMismatch объясняет, почему условное изменение не затронуло строк:
+// темы нет либо её версия уже другая
+func (r *courseworkRepository) versionMismatch(ctx context.Context, id uint) error {
+	var count int64
+	if err := conn(ctx, r.db).Model(&models.Coursework{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check coursework version: %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("coursework with ID %d not found", id)
+	}
+	return interfaces.ErrVersionConflict
+}
+
 // List возвращает список курсовых работ с пагинацией
 func (r *courseworkRepository) List(ctx context.Context, limit, offset int) ([]models.Coursework, error) {
 	if limit <= 0 {
@@ -110,7 +153,7 @@ func (r *courseworkRepository) List(ctx context.Context, limit, offset int) ([]m
 	}
 
 	var list []models.Coursework
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Preload("Subject").
 		Preload("Teacher").
 		Limit(limit).
@@ -130,7 +173,7 @@ func (r *courseworkRepository) GetBySubject(ctx context.Context, subjectID uint)
 	}
 
 	var list []models.Coursework
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Where("subject_id = ?", subjectID).
 		Preload("Teacher").
 		Find(&list)
@@ -148,7 +191,7 @@ func (r *courseworkRepository) GetByTeacher(ctx context.Context, teacherID uint)
 	}
 
 	var list []models.Coursework
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Where("teacher_id = ?", teacherID).
 		Preload("Subject").
 		Find(&list)
@@ -166,7 +209,7 @@ func (r *courseworkRepository) GetAvailable(ctx context.Context, subjectID uint)
 	}
 
 	var list []models.Coursework
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Where("subject_id = ? AND is_available = ?", subjectID, true).
 		Preload("Subject").
 		Preload("Teacher").
@@ -184,10 +227,10 @@ func (r *courseworkRepository) SetAvailable(ctx context.Context, courseworkID ui
 		return errors.New("invalid coursework ID")
 	}
 
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Model(&models.Coursework{}).
 		Where("id = ?", courseworkID).
-		Update("is_available", available)
+		Updates(map[string]interface{}{"is_available": available, "version": gorm.Expr("version + 1")})
 
 	if result.Error != nil {
 		return fmt.Errorf("failed to set availability: %w", result.Error)
@@ -211,7 +254,7 @@ func (r *courseworkRepository) GetWithStudentCount(ctx context.Context, coursewo
 
 	var count int64
 	// Предполагаем, что есть модель StudentCoursework с полем CourseworkID
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Model(&models.StudentCoursework{}).
 		Where("coursework_id = ?", courseworkID).
 		Count(&count)
@@ -221,3 +264,65 @@ func (r *courseworkRepository) GetWithStudentCount(ctx context.Context, coursewo
 
 	return cw, int(count), nil
 }
+
+// CountByTeacher считает темы преподавателя в семестрах учебного года
+func (r *courseworkRepository) CountByTeacher(ctx context.Context, teacherID, academicYearID uint) (int, error) {
+	if teacherID == 0 {
+		return 0, errors.New("invalid teacher ID")
+	}
+
+	var count int64
+	result := conn(ctx, r.db).
+		Model(&models.Coursework{}).
+		Where("teacher_id = ? AND term_id IN (?)", teacherID,
+			conn(ctx, r.db).Model(&models.Term{}).Select("id").Where("academic_year_id = ?", academicYearID)).
+		Count(&count)
+
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to count teacher courseworks: %w", result.Error)
+	}
+	return int(count), nil
+}
+
+// Search возвращает страницу курсовых работ по фильтру и общее число подходящих записей
+func (r *courseworkRepository) Search(ctx context.Context, filter interfaces.ListCourseworksRequest) ([]models.Coursework, int, error) {
+	query := conn(ctx, r.db).Model(&models.Coursework{})
+	if filter.TermID != nil {
+		query = query.Where("term_id = ?", *filter.TermID)
+	}
+	if filter.SubjectID != nil {
+		query = query.Where("subject_id = ?", *filter.SubjectID)
+	}
+	if filter.SubjectIDs != nil {
+		query = query.Where("subject_id IN ?", filter.SubjectIDs)
+	}
+	if filter.TeacherID != nil {
+		query = query.Where("teacher_id = ?", *filter.TeacherID)
+	}
+	if filter.Available != nil {
+		query = query.Where("is_available = ?", *filter.Available)
+	}
+	if filter.Difficulty != nil {
+		query = query.Where("difficulty_level = ?", *filter.Difficulty)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count courseworks: %w", err)
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit).Offset(filter.Offset)
+	}
+	var list []models.Coursework
+	result := query.
+		Preload("Subject").
+		Preload("Teacher").
+		Order("id").
+		Find(&list)
+
+	if result.Error != nil {
+		return nil, 0, fmt.Errorf("failed to search courseworks: %w", result.Error)
+	}
+	return list, int(total), nil
+}
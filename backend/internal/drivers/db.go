@@ -1,34 +1,78 @@
 package drivers
 
 import (
+	"context"
 	"log"
+	"strings"
 
 	"github.com/Foxpunk/courseforge/internal/models"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
+// OpenDB открывает базу без изменения схемы
+func OpenDB(dsn string) (*gorm.DB, error) {
+	// TranslateError превращает нарушения уникальности в gorm.ErrDuplicatedKey
+	db, err := gorm.Open(sqlite.Open(SQLiteDSN(dsn)), &gorm.Config{TranslateError: true})
+	if err != nil {
+		return nil, err
+	}
+	if err := SetupJoinTables(db); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// InitDB открывает базу и применяет к ней недостающие миграции
 func InitDB(dsn string) (*gorm.DB, error) {
-	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	db, err := OpenDB(dsn)
 	if err != nil {
 		return nil, err
 	}
 
-	// ⚠️ Автоматическая миграция
-	err = db.AutoMigrate(
-		&models.Coursework{},
-		&models.Department{},
-		&models.StudentProfile{},
-		&models.TeacherProfile{},
-		&models.StudentCoursework{},
-		&models.StudentGroup{},
-		&models.Subject{},
-		&models.TeacherSubject{},
-		&models.User{})
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		return nil, err
+	}
+	version, err := migrator.Version(context.Background())
 	if err != nil {
 		return nil, err
 	}
 
-	log.Println(" Миграция прошла успешно")
+	log.Printf("Схема базы данных в актуальной версии %d", version)
 	return db, nil
 }
+
+// sqliteParams - параметры подключения для параллельных запросов: WAL не блокирует чтение
+// записью, busy_timeout ждёт занятую базу вместо мгновенной ошибки SQLITE_BUSY, а
+// txlock=immediate берёт блокировку записи в начале транзакции, поэтому две транзакции
+// не прочитают одни и те же свободные места, чтобы затем обе записать назначение
+var sqliteParams = []string{"_journal_mode=WAL", "_busy_timeout=5000", "_txlock=immediate"}
+
+// SQLiteDSN дополняет строку подключения параметрами sqliteParams, которые не заданы явно
+func SQLiteDSN(dsn string) string {
+	for _, param := range sqliteParams {
+		key := param[:strings.Index(param, "=")+1]
+		if strings.Contains(dsn, "?"+key) || strings.Contains(dsn, "&"+key) {
+			continue
+		}
+		if strings.Contains(dsn, "?") {
+			dsn += "&" + param
+		} else {
+			dsn += "?" + param
+		}
+	}
+	return dsn
+}
+
+// SetupJoinTables регистрирует TeacherSubject как join-таблицу связей many2many, чтобы
+// связи читались и сохранялись через модель со всеми её полями
+func SetupJoinTables(db *gorm.DB) error {
+	if err := db.SetupJoinTable(&models.Subject{}, "Teachers", &models.TeacherSubject{}); err != nil {
+		return err
+	}
+	return db.SetupJoinTable(&models.User{}, "TeacherSubjects", &models.TeacherSubject{})
+}
@@ -0,0 +1,79 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"gorm.io/gorm"
+)
+
+type defenseRoomRepository struct {
+	db *gorm.DB
+}
+
+// NewDefenseRoomRepository создаёт новый репозиторий аудиторий
+func NewDefenseRoomRepository(db *gorm.DB) interfaces.DefenseRoomRepository {
+	return &defenseRoomRepository{db: db}
+}
+
+// Create добавляет новую аудиторию
+func (r *defenseRoomRepository) Create(ctx context.Context, room *models.DefenseRoom) error {
+	if room == nil {
+		return errors.New("room cannot be nil")
+	}
+	if room.Name == "" {
+		return errors.New("room name is required")
+	}
+
+	result := conn(ctx, r.db).Create(room)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create defense room: %w", result.Error)
+	}
+	return nil
+}
+
+// GetByID возвращает аудиторию по ID
+func (r *defenseRoomRepository) GetByID(ctx context.Context, id uint) (*models.DefenseRoom, error) {
+	if id == 0 {
+		return nil, errors.New("invalid room ID")
+	}
+
+	var room models.DefenseRoom
+	result := conn(ctx, r.db).First(&room, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("defense room with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get defense room by ID: %w", result.Error)
+	}
+	return &room, nil
+}
+
+// Delete выполняет мягкое удаление аудитории
+func (r *defenseRoomRepository) Delete(ctx context.Context, id uint) error {
+	if id == 0 {
+		return errors.New("invalid room ID")
+	}
+
+	result := conn(ctx, r.db).Delete(&models.DefenseRoom{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete defense room: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("defense room with ID %d not found", id)
+	}
+	return nil
+}
+
+// List возвращает все аудитории
+func (r *defenseRoomRepository) List(ctx context.Context) ([]models.DefenseRoom, error) {
+	var rooms []models.DefenseRoom
+	result := conn(ctx, r.db).Order("name").Find(&rooms)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list defense rooms: %w", result.Error)
+	}
+	return rooms, nil
+}
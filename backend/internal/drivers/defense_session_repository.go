@@ -0,0 +1,165 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"gorm.io/gorm"
+)
+
+type defenseSessionRepository struct {
+	db *gorm.DB
+}
+
+// NewDefenseSessionRepository создаёт новый репозиторий сессий защит
+func NewDefenseSessionRepository(db *gorm.DB) interfaces.DefenseSessionRepository {
+	return &defenseSessionRepository{db: db}
+}
+
+// Create создаёт новую сессию защит вместе с комиссией
+func (r *defenseSessionRepository) Create(ctx context.Context, session *models.DefenseSession) error {
+	if session == nil {
+		return errors.New("session cannot be nil")
+	}
+	if session.SubjectID == 0 || session.RoomID == 0 {
+		return errors.New("subject ID and room ID are required")
+	}
+	if !session.StartsAt.Before(session.EndsAt) {
+		return errors.New("session must start before it ends")
+	}
+
+	result := conn(ctx, r.db).Create(session)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create defense session: %w", result.Error)
+	}
+	return nil
+}
+
+// GetByID возвращает сессию с аудиторией, комиссией и слотами
+func (r *defenseSessionRepository) GetByID(ctx context.Context, id uint) (*models.DefenseSession, error) {
+	if id == 0 {
+		return nil, errors.New("invalid session ID")
+	}
+
+	var session models.DefenseSession
+	result := conn(ctx, r.db).
+		Preload("Subject").
+		Preload("Room").
+		Preload("Committee.Teacher").
+		Preload("Slots", func(db *gorm.DB) *gorm.DB { return db.Order("starts_at") }).
+		Preload("Slots.StudentCoursework.Student").
+		Preload("Slots.StudentCoursework.Coursework").
+		First(&session, id)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("defense session with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get defense session by ID: %w", result.Error)
+	}
+	return &session, nil
+}
+
+// Delete удаляет сессию вместе со слотами и составом комиссии
+func (r *defenseSessionRepository) Delete(ctx context.Context, id uint) error {
+	if id == 0 {
+		return errors.New("invalid session ID")
+	}
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("session_id = ?", id).Delete(&models.DefenseSlot{}).Error; err != nil {
+			return fmt.Errorf("failed to delete defense slots: %w", err)
+		}
+		if err := tx.Where("session_id = ?", id).Delete(&models.DefenseCommitteeMember{}).Error; err != nil {
+			return fmt.Errorf("failed to delete committee: %w", err)
+		}
+		result := tx.Delete(&models.DefenseSession{}, id)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete defense session: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("defense session with ID %d not found", id)
+		}
+		return nil
+	})
+}
+
+// List возвращает все сессии, отсортированные по времени начала
+func (r *defenseSessionRepository) List(ctx context.Context) ([]models.DefenseSession, error) {
+	var sessions []models.DefenseSession
+	result := conn(ctx, r.db).
+		Preload("Subject").
+		Preload("Room").
+		Preload("Committee.Teacher").
+		Order("starts_at").
+		Find(&sessions)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list defense sessions: %w", result.Error)
+	}
+	return sessions, nil
+}
+
+// GetOverlapping возвращает сессии, пересекающиеся с интервалом [start, end)
+func (r *defenseSessionRepository) GetOverlapping(ctx context.Context, start, end time.Time) ([]models.DefenseSession, error) {
+	var sessions []models.DefenseSession
+	result := conn(ctx, r.db).
+		Preload("Room").
+		Preload("Committee").
+		Where("starts_at < ? AND ends_at > ?", end, start).
+		Find(&sessions)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get overlapping sessions: %w", result.Error)
+	}
+	return sessions, nil
+}
+
+// GetByCommitteeMember возвращает сессии, в комиссии которых состоит преподаватель
+func (r *defenseSessionRepository) GetByCommitteeMember(ctx context.Context, teacherID uint) ([]models.DefenseSession, error) {
+	if teacherID == 0 {
+		return nil, errors.New("invalid teacher ID")
+	}
+
+	var sessions []models.DefenseSession
+	result := conn(ctx, r.db).
+		Joins("JOIN defense_committee_members ON defense_committee_members.session_id = defense_sessions.id").
+		Where("defense_committee_members.teacher_id = ?", teacherID).
+		Preload("Subject").
+		Preload("Room").
+		Order("defense_sessions.starts_at").
+		Find(&sessions)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get sessions by committee member: %w", result.Error)
+	}
+	return sessions, nil
+}
+
+// SetCommittee полностью заменяет состав комиссии сессии
+func (r *defenseSessionRepository) SetCommittee(ctx context.Context, sessionID uint, members []models.DefenseCommitteeMember) error {
+	if sessionID == 0 {
+		return errors.New("invalid session ID")
+	}
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("session_id = ?", sessionID).Delete(&models.DefenseCommitteeMember{}).Error; err != nil {
+			return fmt.Errorf("failed to clear committee: %w", err)
+		}
+		if len(members) == 0 {
+			return nil
+		}
+		for i := range members {
+			members[i].ID = 0
+			members[i].SessionID = sessionID
+		}
+		if err := tx.Create(&members).Error; err != nil {
+			return fmt.Errorf("failed to set committee: %w", err)
+		}
+		return nil
+	})
+}
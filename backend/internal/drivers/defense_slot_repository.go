@@ -0,0 +1,198 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"gorm.io/gorm"
+)
+
+type defenseSlotRepository struct {
+	db *gorm.DB
+}
+
+// NewDefenseSlotRepository создаёт новый репозиторий слотов защит
+func NewDefenseSlotRepository(db *gorm.DB) interfaces.DefenseSlotRepository {
+	return &defenseSlotRepository{db: db}
+}
+
+// CreateBatch создаёт набор слотов одной операцией
+func (r *defenseSlotRepository) CreateBatch(ctx context.Context, slots []models.DefenseSlot) error {
+	if len(slots) == 0 {
+		return nil
+	}
+
+	result := conn(ctx, r.db).Create(&slots)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create defense slots: %w", result.Error)
+	}
+	return nil
+}
+
+// GetByID возвращает слот по ID вместе с сессией
+func (r *defenseSlotRepository) GetByID(ctx context.Context, id uint) (*models.DefenseSlot, error) {
+	if id == 0 {
+		return nil, errors.New("invalid slot ID")
+	}
+
+	var slot models.DefenseSlot
+	result := conn(ctx, r.db).
+		Preload("Session").
+		Preload("StudentCoursework").
+		First(&slot, id)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("defense slot with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get defense slot by ID: %w", result.Error)
+	}
+	return &slot, nil
+}
+
+// GetBySession возвращает слоты сессии по порядку
+func (r *defenseSlotRepository) GetBySession(ctx context.Context, sessionID uint) ([]models.DefenseSlot, error) {
+	if sessionID == 0 {
+		return nil, errors.New("invalid session ID")
+	}
+
+	var slots []models.DefenseSlot
+	result := conn(ctx, r.db).
+		Preload("StudentCoursework.Student").
+		Preload("StudentCoursework.Coursework").
+		Where("session_id = ?", sessionID).
+		Order("starts_at").
+		Find(&slots)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get slots by session: %w", result.Error)
+	}
+	return slots, nil
+}
+
+// GetByAssignment возвращает слот, занятый назначением студента
+func (r *defenseSlotRepository) GetByAssignment(ctx context.Context, assignmentID uint) (*models.DefenseSlot, error) {
+	if assignmentID == 0 {
+		return nil, errors.New("invalid assignment ID")
+	}
+
+	var slot models.DefenseSlot
+	result := conn(ctx, r.db).
+		Preload("Session").
+		Where("student_coursework_id = ?", assignmentID).
+		First(&slot)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("no defense slot for assignment %d", assignmentID)
+		}
+		return nil, fmt.Errorf("failed to get slot by assignment: %w", result.Error)
+	}
+	return &slot, nil
+}
+
+// GetByStudent возвращает слоты защит студента
+func (r *defenseSlotRepository) GetByStudent(ctx context.Context, studentID uint) ([]models.DefenseSlot, error) {
+	if studentID == 0 {
+		return nil, errors.New("invalid student ID")
+	}
+
+	var slots []models.DefenseSlot
+	result := conn(ctx, r.db).
+		Joins("JOIN student_courseworks ON student_courseworks.id = defense_slots.student_coursework_id").
+		Where("student_courseworks.student_id = ? AND student_courseworks.deleted_at IS NULL", studentID).
+		Preload("Session.Room").
+		Preload("StudentCoursework.Coursework").
+		Order("defense_slots.starts_at").
+		Find(&slots)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get slots by student: %w", result.Error)
+	}
+	return slots, nil
+}
+
+// GetBySupervisor возвращает слоты защит студентов, которыми руководит преподаватель
+func (r *defenseSlotRepository) GetBySupervisor(ctx context.Context, teacherID uint) ([]models.DefenseSlot, error) {
+	if teacherID == 0 {
+		return nil, errors.New("invalid teacher ID")
+	}
+
+	var slots []models.DefenseSlot
+	result := conn(ctx, r.db).
+		Joins("JOIN student_courseworks ON student_courseworks.id = defense_slots.student_coursework_id").
+		Joins("JOIN courseworks ON courseworks.id = student_courseworks.coursework_id").
+		Where("courseworks.teacher_id = ? AND student_courseworks.deleted_at IS NULL", teacherID).
+		Preload("Session.Room").
+		Preload("StudentCoursework.Student").
+		Preload("StudentCoursework.Coursework").
+		Order("defense_slots.starts_at").
+		Find(&slots)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get slots by supervisor: %w", result.Error)
+	}
+	return slots, nil
+}
+
+// GetAssignedBetween возвращает занятые слоты, начинающиеся в [from, to)
+func (r *defenseSlotRepository) GetAssignedBetween(ctx context.Context, from, to time.Time) ([]models.DefenseSlot, error) {
+	var slots []models.DefenseSlot
+	result := conn(ctx, r.db).
+		Joins("JOIN student_courseworks ON student_courseworks.id = defense_slots.student_coursework_id").
+		Where("student_courseworks.deleted_at IS NULL").
+		Where("defense_slots.starts_at >= ? AND defense_slots.starts_at < ?", from, to).
+		Preload("Session.Room").
+		Preload("StudentCoursework.Student").
+		Preload("StudentCoursework.Coursework").
+		Order("defense_slots.starts_at").
+		Find(&slots)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get upcoming slots: %w", result.Error)
+	}
+	return slots, nil
+}
+
+// Assign занимает свободный слот назначением студента.
+// Обновление условное, поэтому занятый слот повторно не выдаётся.
+func (r *defenseSlotRepository) Assign(ctx context.Context, slotID, assignmentID uint) error {
+	if slotID == 0 || assignmentID == 0 {
+		return errors.New("slot ID and assignment ID are required")
+	}
+
+	result := conn(ctx, r.db).
+		Model(&models.DefenseSlot{}).
+		Where("id = ? AND student_coursework_id IS NULL", slotID).
+		Update("student_coursework_id", assignmentID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to assign defense slot: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("defense slot %d is not available", slotID)
+	}
+	return nil
+}
+
+// Release освобождает слот
+func (r *defenseSlotRepository) Release(ctx context.Context, slotID uint) error {
+	if slotID == 0 {
+		return errors.New("invalid slot ID")
+	}
+
+	result := conn(ctx, r.db).
+		Model(&models.DefenseSlot{}).
+		Where("id = ?", slotID).
+		Update("student_coursework_id", nil)
+	if result.Error != nil {
+		return fmt.Errorf("failed to release defense slot: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("defense slot with ID %d not found", slotID)
+	}
+	return nil
+}
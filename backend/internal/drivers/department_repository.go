@@ -8,6 +8,7 @@ import (
 	"github.com/Foxpunk/courseforge/internal/interfaces"
 	"github.com/Foxpunk/courseforge/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type departmentRepository struct {
@@ -28,7 +29,7 @@ func (r *departmentRepository) Create(ctx context.Context, department *models.De
 		return errors.New("department code and name are required")
 	}
 
-	result := r.db.WithContext(ctx).Create(department)
+	result := conn(ctx, r.db).Create(department)
 	if result.Error != nil {
 		return fmt.Errorf("failed to create department: %w", result.Error)
 	}
@@ -42,7 +43,7 @@ func (r *departmentRepository) GetByID(ctx context.Context, id uint) (*models.De
 	}
 
 	var department models.Department
-	result := r.db.WithContext(ctx).Preload("TeacherProfiles").Preload("Subjects").Preload("StudentGroups").First(&department, id)
+	result := conn(ctx, r.db).Preload("TeacherProfiles").Preload("StudentGroups").First(&department, id)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("department with ID %d not found", id)
@@ -59,7 +60,7 @@ func (r *departmentRepository) GetByCode(ctx context.Context, code string) (*mod
 	}
 
 	var department models.Department
-	result := r.db.WithContext(ctx).Preload("TeacherProfiles").Preload("Subjects").Preload("StudentGroups").
+	result := conn(ctx, r.db).Preload("TeacherProfiles").Preload("StudentGroups").
 		Where("department_code = ?", code).
 		First(&department)
 
@@ -81,7 +82,7 @@ func (r *departmentRepository) Update(ctx context.Context, department *models.De
 		return errors.New("department ID is required")
 	}
 
-	result := r.db.WithContext(ctx).Save(department)
+	result := conn(ctx, r.db).Omit(clause.Associations).Save(department)
 	if result.Error != nil {
 		return fmt.Errorf("failed to update department: %w", result.Error)
 	}
@@ -97,7 +98,7 @@ func (r *departmentRepository) Delete(ctx context.Context, id uint) error {
 		return errors.New("invalid department ID")
 	}
 
-	result := r.db.WithContext(ctx).Delete(&models.Department{}, id)
+	result := conn(ctx, r.db).Delete(&models.Department{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete department: %w", result.Error)
 	}
@@ -110,10 +111,41 @@ func (r *departmentRepository) Delete(ctx context.Context, id uint) error {
 // List возвращает все кафедры
 func (r *departmentRepository) List(ctx context.Context) ([]models.Department, error) {
 	var departments []models.Department
-	result := r.db.WithContext(ctx).Preload("TeacherProfiles").Preload("Subjects").Preload("StudentGroups").Find(&departments)
+	result := conn(ctx, r.db).Preload("TeacherProfiles").Preload("StudentGroups").Find(&departments)
 
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to list departments: %w", result.Error)
 	}
 	return departments, nil
 }
+
+// GetByHead возвращает кафедры, которыми заведует пользователь
+func (r *departmentRepository) GetByHead(ctx context.Context, userID uint) ([]models.Department, error) {
+	var departments []models.Department
+	result := conn(ctx, r.db).Where("head_user_id = ?", userID).Find(&departments)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get departments by head: %w", result.Error)
+	}
+	return departments, nil
+}
+
+// SetHead назначает или снимает заведующего кафедрой
+func (r *departmentRepository) SetHead(ctx context.Context, departmentID uint, userID *uint) error {
+	if departmentID == 0 {
+		return errors.New("invalid department ID")
+	}
+
+	result := conn(ctx, r.db).
+		Model(&models.Department{}).
+		Where("id = ?", departmentID).
+		Update("head_user_id", userID)
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to set department head: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("department with ID %d not found", departmentID)
+	}
+	return nil
+}
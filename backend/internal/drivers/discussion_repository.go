@@ -0,0 +1,312 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type discussionRepository struct {
+	db *gorm.DB
+}
+
+// NewDiscussionRepository создаёт новый репозиторий обсуждений
+func NewDiscussionRepository(db *gorm.DB) interfaces.DiscussionRepository {
+	return &discussionRepository{db: db}
+}
+
+// GetOrCreateThread возвращает обсуждение, создавая его при первом обращении
+func (r *discussionRepository) GetOrCreateThread(ctx context.Context, kind models.ThreadKind, courseworkID, studentCourseworkID uint) (*models.DiscussionThread, error) {
+	if courseworkID == 0 {
+		return nil, errors.New("invalid coursework ID")
+	}
+
+	thread := models.DiscussionThread{
+		Kind:                kind,
+		CourseworkID:        courseworkID,
+		StudentCourseworkID: studentCourseworkID,
+	}
+	// Два первых обращения одновременно не должны создать два обсуждения
+	if err := conn(ctx, r.db).
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&thread).Error; err != nil {
+		return nil, fmt.Errorf("failed to create thread: %w", err)
+	}
+
+	var existing models.DiscussionThread
+	result := conn(ctx, r.db).
+		Preload("Coursework").
+		Where("kind = ? AND coursework_id = ? AND student_coursework_id = ?", kind, courseworkID, studentCourseworkID).
+		First(&existing)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get thread: %w", result.Error)
+	}
+	return &existing, nil
+}
+
+// GetThread возвращает обсуждение с темой
+func (r *discussionRepository) GetThread(ctx context.Context, id uint) (*models.DiscussionThread, error) {
+	if id == 0 {
+		return nil, errors.New("invalid thread ID")
+	}
+
+	var thread models.DiscussionThread
+	result := conn(ctx, r.db).Preload("Coursework").First(&thread, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("thread with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get thread: %w", result.Error)
+	}
+	return &thread, nil
+}
+
+// CreateComment сохраняет сообщение вместе с упоминаниями
+func (r *discussionRepository) CreateComment(ctx context.Context, comment *models.Comment) error {
+	if comment == nil {
+		return errors.New("comment cannot be nil")
+	}
+	if comment.ThreadID == 0 || comment.AuthorID == 0 {
+		return errors.New("thread and author are required")
+	}
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(comment).Error; err != nil {
+			return fmt.Errorf("failed to create comment: %w", err)
+		}
+		if err := saveMentions(tx, comment); err != nil {
+			return err
+		}
+		if err := tx.Model(&models.DiscussionThread{}).
+			Where("id = ?", comment.ThreadID).
+			Update("last_comment_at", comment.CreatedAt).Error; err != nil {
+			return fmt.Errorf("failed to update thread: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetComment возвращает сообщение с автором, вложениями и упоминаниями
+func (r *discussionRepository) GetComment(ctx context.Context, id uint) (*models.Comment, error) {
+	if id == 0 {
+		return nil, errors.New("invalid comment ID")
+	}
+
+	var comment models.Comment
+	result := preloadComment(conn(ctx, r.db)).First(&comment, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("comment with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get comment: %w", result.Error)
+	}
+	return &comment, nil
+}
+
+// ListComments возвращает сообщения по порядку, включая удалённые
+func (r *discussionRepository) ListComments(ctx context.Context, threadID uint) ([]models.Comment, error) {
+	var comments []models.Comment
+	result := preloadComment(conn(ctx, r.db).Unscoped()).
+		Where("thread_id = ?", threadID).
+		Order("id").
+		Find(&comments)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", result.Error)
+	}
+	return comments, nil
+}
+
+// UpdateComment сохраняет новый текст и упоминания, прежний текст уходит в историю
+func (r *discussionRepository) UpdateComment(ctx context.Context, comment *models.Comment, revision *models.CommentRevision) error {
+	if comment == nil || revision == nil {
+		return errors.New("comment and revision cannot be nil")
+	}
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(revision).Error; err != nil {
+			return fmt.Errorf("failed to save comment revision: %w", err)
+		}
+		result := tx.Model(&models.Comment{}).
+			Where("id = ?", comment.ID).
+			Updates(map[string]interface{}{
+				"body":      comment.Body,
+				"body_html": comment.BodyHTML,
+				"edited_at": comment.EditedAt,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to update comment: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("comment with ID %d not found", comment.ID)
+		}
+		if err := tx.Where("comment_id = ?", comment.ID).Delete(&models.CommentMention{}).Error; err != nil {
+			return fmt.Errorf("failed to update mentions: %w", err)
+		}
+		return saveMentions(tx, comment)
+	})
+}
+
+// DeleteComment мягко удаляет сообщение; ответы на него остаются
+func (r *discussionRepository) DeleteComment(ctx context.Context, id uint) error {
+	result := conn(ctx, r.db).Delete(&models.Comment{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete comment: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("comment with ID %d not found", id)
+	}
+	return nil
+}
+
+// GetRevisions возвращает прежние версии сообщения, старые сверху
+func (r *discussionRepository) GetRevisions(ctx context.Context, commentID uint) ([]models.CommentRevision, error) {
+	var revisions []models.CommentRevision
+	result := conn(ctx, r.db).
+		Preload("Editor").
+		Where("comment_id = ?", commentID).
+		Order("id").
+		Find(&revisions)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get comment revisions: %w", result.Error)
+	}
+	return revisions, nil
+}
+
+// AddAttachment сохраняет сведения о приложенном файле
+func (r *discussionRepository) AddAttachment(ctx context.Context, attachment *models.CommentAttachment) error {
+	if attachment == nil {
+		return errors.New("attachment cannot be nil")
+	}
+	if err := conn(ctx, r.db).Create(attachment).Error; err != nil {
+		return fmt.Errorf("failed to create attachment: %w", err)
+	}
+	return nil
+}
+
+// GetAttachment возвращает приложенный файл
+func (r *discussionRepository) GetAttachment(ctx context.Context, id uint) (*models.CommentAttachment, error) {
+	var attachment models.CommentAttachment
+	result := conn(ctx, r.db).First(&attachment, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("attachment with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get attachment: %w", result.Error)
+	}
+	return &attachment, nil
+}
+
+// CountAttachments возвращает число файлов сообщения
+func (r *discussionRepository) CountAttachments(ctx context.Context, commentID uint) (int64, error) {
+	var count int64
+	if err := conn(ctx, r.db).
+		Model(&models.CommentAttachment{}).
+		Where("comment_id = ?", commentID).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count attachments: %w", err)
+	}
+	return count, nil
+}
+
+// LatestCommentID возвращает ID последнего сообщения обсуждения (0, если сообщений нет)
+func (r *discussionRepository) LatestCommentID(ctx context.Context, threadID uint) (uint, error) {
+	var id uint
+	if err := conn(ctx, r.db).
+		Model(&models.Comment{}).
+		Where("thread_id = ?", threadID).
+		Select("COALESCE(MAX(id), 0)").
+		Scan(&id).Error; err != nil {
+		return 0, fmt.Errorf("failed to get latest comment: %w", err)
+	}
+	return id, nil
+}
+
+// GetReadMark возвращает ID последнего прочитанного сообщения (0 - ничего не прочитано)
+func (r *discussionRepository) GetReadMark(ctx context.Context, threadID, userID uint) (uint, error) {
+	var mark models.ThreadReadMark
+	result := conn(ctx, r.db).
+		Where("thread_id = ? AND user_id = ?", threadID, userID).
+		Limit(1).
+		Find(&mark)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to get read mark: %w", result.Error)
+	}
+	return mark.LastReadCommentID, nil
+}
+
+// MarkRead сдвигает отметку прочтения вперёд: ответ, пришедший из старой вкладки, её не откатит
+func (r *discussionRepository) MarkRead(ctx context.Context, threadID, userID, commentID uint) error {
+	mark := models.ThreadReadMark{
+		ThreadID:          threadID,
+		UserID:            userID,
+		LastReadCommentID: commentID,
+		ReadAt:            time.Now(),
+	}
+	result := conn(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "thread_id"}, {Name: "user_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"last_read_comment_id": gorm.Expr("MAX(thread_read_marks.last_read_comment_id, excluded.last_read_comment_id)"),
+				"read_at":              gorm.Expr("excluded.read_at"),
+			}),
+		}).
+		Create(&mark)
+	if result.Error != nil {
+		return fmt.Errorf("failed to mark thread as read: %w", result.Error)
+	}
+	return nil
+}
+
+// ListUnread возвращает обсуждения пользователя с непрочитанными чужими сообщениями
+func (r *discussionRepository) ListUnread(ctx context.Context, userID uint) ([]models.ThreadUnread, error) {
+	var rows []models.ThreadUnread
+	result := conn(ctx, r.db).Raw(`
+		SELECT t.id AS thread_id, COUNT(c.id) AS unread
+		FROM discussion_threads t
+		JOIN courseworks cw ON cw.id = t.coursework_id
+		LEFT JOIN student_courseworks sc ON sc.id = t.student_coursework_id
+		LEFT JOIN thread_read_marks rm ON rm.thread_id = t.id AND rm.user_id = @user
+		JOIN comments c ON c.thread_id = t.id AND c.deleted_at IS NULL
+			AND c.author_id <> @user AND c.id > COALESCE(rm.last_read_comment_id, 0)
+		WHERE cw.teacher_id = @user
+			OR sc.student_id = @user
+			OR EXISTS (SELECT 1 FROM comments own
+				WHERE own.thread_id = t.id AND own.author_id = @user AND own.deleted_at IS NULL)
+			OR EXISTS (SELECT 1 FROM comment_mentions m JOIN comments mc ON mc.id = m.comment_id
+				WHERE mc.thread_id = t.id AND m.user_id = @user AND mc.deleted_at IS NULL)
+		GROUP BY t.id
+		ORDER BY MAX(c.id) DESC`,
+		map[string]interface{}{"user": userID}).
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list unread threads: %w", result.Error)
+	}
+	return rows, nil
+}
+
+func preloadComment(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Author").
+		Preload("Attachments", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Preload("Mentions.User")
+}
+
+func saveMentions(tx *gorm.DB, comment *models.Comment) error {
+	if len(comment.Mentions) == 0 {
+		return nil
+	}
+	for i := range comment.Mentions {
+		comment.Mentions[i].CommentID = comment.ID
+	}
+	if err := tx.Omit(clause.Associations).Create(&comment.Mentions).Error; err != nil {
+		return fmt.Errorf("failed to save mentions: %w", err)
+	}
+	return nil
+}
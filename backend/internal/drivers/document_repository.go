@@ -0,0 +1,257 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"gorm.io/gorm"
+)
+
+type documentTemplateRepository struct {
+	db *gorm.DB
+}
+
+// NewDocumentTemplateRepository создаёт новый репозиторий шаблонов документов
+func NewDocumentTemplateRepository(db *gorm.DB) interfaces.DocumentTemplateRepository {
+	return &documentTemplateRepository{db: db}
+}
+
+// Create сохраняет шаблон; новый шаблон по умолчанию снимает этот признак с остальных шаблонов вида
+func (r *documentTemplateRepository) Create(ctx context.Context, tpl *models.DocumentTemplate) error {
+	if tpl == nil {
+		return errors.New("template cannot be nil")
+	}
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if tpl.IsDefault {
+			if err := clearDefaultTemplate(tx, tpl.Kind); err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(tpl).Error; err != nil {
+			return fmt.Errorf("failed to create document template: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetByID возвращает шаблон по ID
+func (r *documentTemplateRepository) GetByID(ctx context.Context, id uint) (*models.DocumentTemplate, error) {
+	if id == 0 {
+		return nil, errors.New("invalid template ID")
+	}
+
+	var tpl models.DocumentTemplate
+	result := conn(ctx, r.db).First(&tpl, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("document template with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get document template: %w", result.Error)
+	}
+	return &tpl, nil
+}
+
+// GetDefault возвращает шаблон вида по умолчанию
+func (r *documentTemplateRepository) GetDefault(ctx context.Context, kind models.DocumentKind) (*models.DocumentTemplate, error) {
+	var tpl models.DocumentTemplate
+	result := conn(ctx, r.db).
+		Where("kind = ? AND is_default = ?", kind, true).
+		First(&tpl)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("default template for %s not found", kind)
+		}
+		return nil, fmt.Errorf("failed to get default template: %w", result.Error)
+	}
+	return &tpl, nil
+}
+
+// Update сохраняет изменения шаблона
+func (r *documentTemplateRepository) Update(ctx context.Context, tpl *models.DocumentTemplate) error {
+	if tpl == nil || tpl.ID == 0 {
+		return errors.New("invalid template")
+	}
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if tpl.IsDefault {
+			if err := clearDefaultTemplate(tx, tpl.Kind); err != nil {
+				return err
+			}
+		}
+		if err := tx.Save(tpl).Error; err != nil {
+			return fmt.Errorf("failed to update document template: %w", err)
+		}
+		return nil
+	})
+}
+
+// Delete удаляет шаблон; выпущенные по нему документы остаются
+func (r *documentTemplateRepository) Delete(ctx context.Context, id uint) error {
+	if id == 0 {
+		return errors.New("invalid template ID")
+	}
+
+	result := conn(ctx, r.db).Delete(&models.DocumentTemplate{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete document template: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("document template with ID %d not found", id)
+	}
+	return nil
+}
+
+// List возвращает шаблоны вида (пустой вид - все шаблоны)
+func (r *documentTemplateRepository) List(ctx context.Context, kind models.DocumentKind) ([]models.DocumentTemplate, error) {
+	query := conn(ctx, r.db)
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+
+	var list []models.DocumentTemplate
+	if err := query.Order("kind, name").Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("failed to list document templates: %w", err)
+	}
+	return list, nil
+}
+
+func clearDefaultTemplate(tx *gorm.DB, kind models.DocumentKind) error {
+	err := tx.Model(&models.DocumentTemplate{}).
+		Where("kind = ? AND is_default = ?", kind, true).
+		Update("is_default", false).Error
+	if err != nil {
+		return fmt.Errorf("failed to reset default template: %w", err)
+	}
+	return nil
+}
+
+type issuedDocumentRepository struct {
+	db *gorm.DB
+}
+
+// NewIssuedDocumentRepository создаёт новый репозиторий выпущенных документов
+func NewIssuedDocumentRepository(db *gorm.DB) interfaces.IssuedDocumentRepository {
+	return &issuedDocumentRepository{db: db}
+}
+
+// Issue нумерует документ: документ по тем же дисциплине, группе и семестру получает номер
+// предыдущего и следующую версию, иначе - следующий порядковый номер вида за учебный год
+func (r *issuedDocumentRepository) Issue(ctx context.Context, doc *models.IssuedDocument, numberPrefix string, fill func(*models.IssuedDocument) error) error {
+	if doc == nil {
+		return errors.New("document cannot be nil")
+	}
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var prev models.IssuedDocument
+		query := tx.Where("kind = ? AND subject_id = ? AND term_id = ? AND is_current = ?", doc.Kind, doc.SubjectID, doc.TermID, true)
+		if doc.GroupID != nil {
+			query = query.Where("group_id = ?", *doc.GroupID)
+		} else {
+			query = query.Where("group_id IS NULL")
+		}
+		err := query.Omit("content").First(&prev).Error
+
+		switch {
+		case err == nil:
+			doc.AcademicYear = prev.AcademicYear
+			doc.Sequence = prev.Sequence
+			doc.Number = prev.Number
+			doc.Version = prev.Version + 1
+			if err := tx.Model(&models.IssuedDocument{}).Where("id = ?", prev.ID).Update("is_current", false).Error; err != nil {
+				return fmt.Errorf("failed to supersede document: %w", err)
+			}
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			var last int
+			if err := tx.Model(&models.IssuedDocument{}).
+				Where("kind = ? AND academic_year = ?", doc.Kind, doc.AcademicYear).
+				Select("COALESCE(MAX(sequence), 0)").Scan(&last).Error; err != nil {
+				return fmt.Errorf("failed to get last document number: %w", err)
+			}
+			doc.Sequence = last + 1
+			doc.Number = fmt.Sprintf("%s%d", numberPrefix, doc.Sequence)
+			doc.Version = 1
+		default:
+			return fmt.Errorf("failed to find previous document: %w", err)
+		}
+		doc.IsCurrent = true
+
+		if err := fill(doc); err != nil {
+			return err
+		}
+		if err := tx.Omit("Subject", "Group", "CreatedBy").Create(doc).Error; err != nil {
+			return fmt.Errorf("failed to create issued document: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetByID возвращает документ вместе с содержимым
+func (r *issuedDocumentRepository) GetByID(ctx context.Context, id uint) (*models.IssuedDocument, error) {
+	if id == 0 {
+		return nil, errors.New("invalid document ID")
+	}
+
+	var doc models.IssuedDocument
+	result := conn(ctx, r.db).
+		Preload("Subject").
+		Preload("Group").
+		Preload("CreatedBy").
+		First(&doc, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("document with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get document: %w", result.Error)
+	}
+	return &doc, nil
+}
+
+// List возвращает реестр документов без содержимого, новые сверху
+func (r *issuedDocumentRepository) List(ctx context.Context, kind models.DocumentKind, subjectID uint, withSuperseded bool) ([]models.IssuedDocument, error) {
+	query := conn(ctx, r.db).Omit("content")
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+	if subjectID != 0 {
+		query = query.Where("subject_id = ?", subjectID)
+	}
+	if !withSuperseded {
+		query = query.Where("is_current = ?", true)
+	}
+
+	var list []models.IssuedDocument
+	result := query.
+		Preload("Subject").
+		Preload("Group").
+		Preload("CreatedBy").
+		Order("academic_year DESC, sequence DESC, version DESC").
+		Find(&list)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list documents: %w", result.Error)
+	}
+	return list, nil
+}
+
+// GetVersions возвращает все версии документа с тем же номером, начиная с последней
+func (r *issuedDocumentRepository) GetVersions(ctx context.Context, id uint) ([]models.IssuedDocument, error) {
+	doc, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []models.IssuedDocument
+	result := conn(ctx, r.db).
+		Omit("content").
+		Preload("CreatedBy").
+		Where("kind = ? AND academic_year = ? AND sequence = ?", doc.Kind, doc.AcademicYear, doc.Sequence).
+		Order("version DESC").
+		Find(&list)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get document versions: %w", result.Error)
+	}
+	return list, nil
+}
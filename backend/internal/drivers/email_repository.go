@@ -0,0 +1,171 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type emailRepository struct {
+	db *gorm.DB
+}
+
+// NewEmailRepository создаёт новый репозиторий почтовых настроек и очереди писем
+func NewEmailRepository(db *gorm.DB) interfaces.EmailRepository {
+	return &emailRepository{db: db}
+}
+
+// GetSettings возвращает настройки пользователя или настройки по умолчанию
+func (r *emailRepository) GetSettings(ctx context.Context, userID uint) (*models.EmailSettings, error) {
+	var list []models.EmailSettings
+	if err := conn(ctx, r.db).Where("user_id = ?", userID).Limit(1).Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("failed to get email settings: %w", err)
+	}
+	if len(list) == 0 {
+		return models.DefaultEmailSettings(userID), nil
+	}
+	return &list[0], nil
+}
+
+// SaveSettings создаёт или обновляет настройки
+func (r *emailRepository) SaveSettings(ctx context.Context, settings *models.EmailSettings) error {
+	if settings == nil || settings.UserID == 0 {
+		return errors.New("invalid email settings")
+	}
+	result := conn(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"locale", "mode", "last_digest_at", "updated_at"}),
+		}).
+		Create(settings)
+	if result.Error != nil {
+		return fmt.Errorf("failed to save email settings: %w", result.Error)
+	}
+	return nil
+}
+
+// ListDigestDue возвращает настройки пользователей на сводке, не получавших её после before
+func (r *emailRepository) ListDigestDue(ctx context.Context, before time.Time, limit int) ([]models.EmailSettings, error) {
+	var list []models.EmailSettings
+	result := conn(ctx, r.db).
+		Where("mode = ? AND (last_digest_at IS NULL OR last_digest_at < ?)", models.EmailDigest, before).
+		Order("user_id").
+		Limit(limit).
+		Find(&list)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list digest recipients: %w", result.Error)
+	}
+	return list, nil
+}
+
+// CompleteDigest сдвигает отметку сводки и ставит письмо в очередь одной транзакцией,
+// чтобы сводка не ушла дважды и не потерялась
+func (r *emailRepository) CompleteDigest(ctx context.Context, userID uint, at time.Time, email *models.OutgoingEmail) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.EmailSettings{}).
+			Where("user_id = ?", userID).
+			Updates(map[string]interface{}{"last_digest_at": at, "updated_at": time.Now()})
+		if result.Error != nil {
+			return fmt.Errorf("failed to update digest time: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("email settings of user %d not found", userID)
+		}
+		if email == nil {
+			return nil
+		}
+		if err := tx.Create(email).Error; err != nil {
+			return fmt.Errorf("failed to enqueue email: %w", err)
+		}
+		return nil
+	})
+}
+
+// Enqueue ставит письмо в очередь
+func (r *emailRepository) Enqueue(ctx context.Context, email *models.OutgoingEmail) error {
+	if email == nil {
+		return errors.New("email cannot be nil")
+	}
+	if email.To == "" {
+		return errors.New("recipient address is required")
+	}
+	if err := conn(ctx, r.db).Create(email).Error; err != nil {
+		return fmt.Errorf("failed to enqueue email: %w", err)
+	}
+	return nil
+}
+
+// ClaimDue забирает письма из очереди условным UPDATE, как ClaimPending у сданных работ:
+// одно письмо не отправят два обработчика, а зависшие в sending возвращаются по staleAfter
+func (r *emailRepository) ClaimDue(ctx context.Context, now time.Time, limit int, staleAfter time.Duration) ([]models.OutgoingEmail, error) {
+	db := conn(ctx, r.db)
+	staleBefore := now.Add(-staleAfter)
+	const due = "(status = ? AND next_attempt_at <= ?) OR (status = ? AND updated_at < ?)"
+
+	var candidates []models.OutgoingEmail
+	result := db.
+		Where(due, models.EmailPending, now, models.EmailSending, staleBefore).
+		Order("id").
+		Limit(limit).
+		Find(&candidates)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get due emails: %w", result.Error)
+	}
+
+	claimed := make([]models.OutgoingEmail, 0, len(candidates))
+	for _, email := range candidates {
+		res := db.Model(&models.OutgoingEmail{}).
+			Where("id = ? AND ("+due+")", email.ID, models.EmailPending, now, models.EmailSending, staleBefore).
+			Updates(map[string]interface{}{
+				"status":     models.EmailSending,
+				"attempts":   gorm.Expr("attempts + 1"),
+				"updated_at": time.Now(),
+			})
+		if res.Error != nil {
+			return nil, fmt.Errorf("failed to claim email: %w", res.Error)
+		}
+		if res.RowsAffected == 1 {
+			email.Status = models.EmailSending
+			email.Attempts++
+			claimed = append(claimed, email)
+		}
+	}
+	return claimed, nil
+}
+
+// MarkSent отмечает письмо отправленным
+func (r *emailRepository) MarkSent(ctx context.Context, id uint, at time.Time) error {
+	result := conn(ctx, r.db).Model(&models.OutgoingEmail{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"status": models.EmailSent, "sent_at": at, "last_error": ""})
+	if result.Error != nil {
+		return fmt.Errorf("failed to mark email as sent: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("email with ID %d not found", id)
+	}
+	return nil
+}
+
+// MarkFailed откладывает письмо до nextAttemptAt; nil - попытки исчерпаны
+func (r *emailRepository) MarkFailed(ctx context.Context, id uint, sendErr string, nextAttemptAt *time.Time) error {
+	updates := map[string]interface{}{"status": models.EmailFailed, "last_error": sendErr}
+	if nextAttemptAt != nil {
+		updates["status"] = models.EmailPending
+		updates["next_attempt_at"] = *nextAttemptAt
+	}
+	result := conn(ctx, r.db).Model(&models.OutgoingEmail{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to mark email as failed: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("email with ID %d not found", id)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"gorm.io/gorm"
+)
+
+type groupSubjectRepository struct {
+	db *gorm.DB
+}
+
+// NewGroupSubjectRepository создаёт новый репозиторий учебного плана групп
+func NewGroupSubjectRepository(db *gorm.DB) interfaces.GroupSubjectRepository {
+	return &groupSubjectRepository{db: db}
+}
+
+// Create добавляет дисциплину в учебный план группы на семестр
+func (r *groupSubjectRepository) Create(ctx context.Context, entry *models.GroupSubject) error {
+	if entry == nil {
+		return errors.New("curriculum entry cannot be nil")
+	}
+	if entry.GroupID == 0 || entry.SubjectID == 0 {
+		return errors.New("group ID and subject ID are required")
+	}
+
+	var count int64
+	if err := conn(ctx, r.db).Model(&models.GroupSubject{}).
+		Where("group_id = ? AND subject_id = ? AND term_id = ?", entry.GroupID, entry.SubjectID, entry.TermID).
+		Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check curriculum entry: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("subject %d is already in the curriculum of group %d", entry.SubjectID, entry.GroupID)
+	}
+
+	result := conn(ctx, r.db).Omit("Group", "Subject", "Term").Create(entry)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create curriculum entry: %w", result.Error)
+	}
+	return nil
+}
+
+// Delete убирает дисциплину из учебного плана группы на семестр
+func (r *groupSubjectRepository) Delete(ctx context.Context, groupID, subjectID, termID uint) error {
+	result := conn(ctx, r.db).
+		Unscoped().
+		Where("group_id = ? AND subject_id = ? AND term_id = ?", groupID, subjectID, termID).
+		Delete(&models.GroupSubject{})
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete curriculum entry: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("subject %d is not in the curriculum of group %d", subjectID, groupID)
+	}
+	return nil
+}
+
+// GetByGroup возвращает учебный план группы; termID == nil - за все семестры
+func (r *groupSubjectRepository) GetByGroup(ctx context.Context, groupID uint, termID *uint) ([]models.GroupSubject, error) {
+	if groupID == 0 {
+		return nil, errors.New("group ID is required")
+	}
+
+	query := conn(ctx, r.db).Where("group_id = ?", groupID)
+	if termID != nil {
+		query = query.Where("term_id = ?", *termID)
+	}
+
+	var list []models.GroupSubject
+	result := query.
+		Preload("Subject").
+		Preload("Term").
+		Order("term_id, subject_id").
+		Find(&list)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get group curriculum: %w", result.Error)
+	}
+	return list, nil
+}
+
+// GetSubjectIDs возвращает ID дисциплин учебного плана группы в семестре
+func (r *groupSubjectRepository) GetSubjectIDs(ctx context.Context, groupID, termID uint) ([]uint, error) {
+	var ids []uint
+	result := conn(ctx, r.db).Model(&models.GroupSubject{}).
+		Where("group_id = ? AND term_id = ?", groupID, termID).
+		Pluck("subject_id", &ids)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get curriculum subjects: %w", result.Error)
+	}
+	return ids, nil
+}
@@ -0,0 +1,80 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"gorm.io/gorm"
+)
+
+type idempotencyRepository struct {
+	db *gorm.DB
+}
+
+// NewIdempotencyRepository создаёт новый репозиторий ключей идемпотентности
+func NewIdempotencyRepository(db *gorm.DB) interfaces.IdempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+// Reserve занимает ключ вставкой: уникальный индекс (user_id, key) не даст двум
+// одновременным запросам с одним ключом выполниться оба
+func (r *idempotencyRepository) Reserve(ctx context.Context, record *models.IdempotencyKey) (bool, error) {
+	if record == nil {
+		return false, errors.New("idempotency key cannot be nil")
+	}
+	if err := conn(ctx, r.db).Create(record).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	return true, nil
+}
+
+// Get возвращает запись ключа пользователя
+func (r *idempotencyRepository) Get(ctx context.Context, userID uint, key string) (*models.IdempotencyKey, error) {
+	var record models.IdempotencyKey
+	err := conn(ctx, r.db).Where("user_id = ? AND key = ?", userID, key).First(&record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("idempotency key %q not found", key)
+		}
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	return &record, nil
+}
+
+// SaveResponse сохраняет ответ на запрос
+func (r *idempotencyRepository) SaveResponse(ctx context.Context, record *models.IdempotencyKey) error {
+	err := conn(ctx, r.db).Model(record).Updates(map[string]interface{}{
+		"status":        record.Status,
+		"response_code": record.ResponseCode,
+		"content_type":  record.ContentType,
+		"response_body": record.ResponseBody,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to save idempotent response: %w", err)
+	}
+	return nil
+}
+
+// Delete освобождает ключ
+func (r *idempotencyRepository) Delete(ctx context.Context, id uint) error {
+	if err := conn(ctx, r.db).Delete(&models.IdempotencyKey{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired удаляет ключи с истёкшим сроком хранения
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := conn(ctx, r.db).Where("expires_at < ?", now).Delete(&models.IdempotencyKey{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,70 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"gorm.io/gorm"
+)
+
+type importJobRepository struct {
+	db *gorm.DB
+}
+
+// NewImportJobRepository создаёт новый репозиторий журнала загрузок
+func NewImportJobRepository(db *gorm.DB) interfaces.ImportJobRepository {
+	return &importJobRepository{db: db}
+}
+
+// Create сохраняет запись о загрузке вместе с ошибками строк
+func (r *importJobRepository) Create(ctx context.Context, job *models.ImportJob) error {
+	if job == nil {
+		return errors.New("import job cannot be nil")
+	}
+
+	result := conn(ctx, r.db).Omit("CreatedBy").Create(job)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create import job: %w", result.Error)
+	}
+	return nil
+}
+
+// GetByID возвращает загрузку с ошибками строк
+func (r *importJobRepository) GetByID(ctx context.Context, id uint) (*models.ImportJob, error) {
+	if id == 0 {
+		return nil, errors.New("invalid import ID")
+	}
+
+	var job models.ImportJob
+	result := conn(ctx, r.db).
+		Preload("CreatedBy").
+		Preload("Errors", func(db *gorm.DB) *gorm.DB { return db.Order("row") }).
+		First(&job, id)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("import with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get import: %w", result.Error)
+	}
+	return &job, nil
+}
+
+// List возвращает последние загрузки без ошибок строк
+func (r *importJobRepository) List(ctx context.Context, limit, offset int) ([]models.ImportJob, error) {
+	var list []models.ImportJob
+	result := conn(ctx, r.db).
+		Preload("CreatedBy").
+		Order("id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&list)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list imports: %w", result.Error)
+	}
+	return list, nil
+}
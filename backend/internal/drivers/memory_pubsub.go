@@ -0,0 +1,106 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+)
+
+// memorySubscription - подписка в памяти процесса
+type memorySubscription struct {
+	ch     chan interfaces.PubSubMessage
+	topics []string
+	closed bool
+}
+
+// memoryPubSub - шина в памяти одного процесса. При нескольких экземплярах сервера
+// её заменяет внешний брокер с тем же интерфейсом.
+type memoryPubSub struct {
+	mu     sync.Mutex
+	buffer int
+	topics map[string]map[*memorySubscription]struct{}
+}
+
+// NewMemoryPubSub создаёт шину в памяти; buffer - сколько сообщений ждут медленного подписчика
+func NewMemoryPubSub(buffer int) interfaces.PubSub {
+	if buffer <= 0 {
+		buffer = 1
+	}
+	return &memoryPubSub{
+		buffer: buffer,
+		topics: make(map[string]map[*memorySubscription]struct{}),
+	}
+}
+
+// Publish раздаёт сообщение подписчикам темы. Publish не ждёт подписчиков: переполненная
+// подписка закрывается, чтобы клиент переподключился и перечитал состояние.
+func (p *memoryPubSub) Publish(ctx context.Context, topic string, payload []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	msg := interfaces.PubSubMessage{Topic: topic, Payload: payload}
+	for sub := range p.topics[topic] {
+		select {
+		case sub.ch <- msg:
+		default:
+			p.unsubscribe(sub)
+		}
+	}
+	return nil
+}
+
+// Subscribe подписывает на темы; отписка вызывается возвращаемой функцией или при отмене ctx
+func (p *memoryPubSub) Subscribe(ctx context.Context, topics ...string) (<-chan interfaces.PubSubMessage, func(), error) {
+	if len(topics) == 0 {
+		return nil, nil, errors.New("at least one topic is required")
+	}
+	sub := &memorySubscription{
+		ch:     make(chan interfaces.PubSubMessage, p.buffer),
+		topics: topics,
+	}
+
+	p.mu.Lock()
+	for _, topic := range topics {
+		if p.topics[topic] == nil {
+			p.topics[topic] = make(map[*memorySubscription]struct{})
+		}
+		p.topics[topic][sub] = struct{}{}
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			close(done)
+			p.mu.Lock()
+			p.unsubscribe(sub)
+			p.mu.Unlock()
+		})
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			cancel()
+		case <-done:
+		}
+	}()
+	return sub.ch, cancel, nil
+}
+
+// unsubscribe снимает подписку со всех тем и закрывает её канал; вызывается под p.mu
+func (p *memoryPubSub) unsubscribe(sub *memorySubscription) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	for _, topic := range sub.topics {
+		delete(p.topics[topic], sub)
+		if len(p.topics[topic]) == 0 {
+			delete(p.topics, topic)
+		}
+	}
+	close(sub.ch)
+}
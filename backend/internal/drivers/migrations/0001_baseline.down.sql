@@ -0,0 +1,17 @@
+DROP TABLE IF EXISTS `teacher_subjects`;
+
+DROP TABLE IF EXISTS `student_groups`;
+
+DROP TABLE IF EXISTS `student_courseworks`;
+
+DROP TABLE IF EXISTS `teacher_profiles`;
+
+DROP TABLE IF EXISTS `student_profiles`;
+
+DROP TABLE IF EXISTS `departments`;
+
+DROP TABLE IF EXISTS `courseworks`;
+
+DROP TABLE IF EXISTS `users`;
+
+DROP TABLE IF EXISTS `subjects`;
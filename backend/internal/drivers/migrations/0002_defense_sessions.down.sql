@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS `defense_slots`;
+DROP TABLE IF EXISTS `defense_committee_members`;
+DROP TABLE IF EXISTS `defense_sessions`;
+DROP TABLE IF EXISTS `defense_rooms`;
@@ -0,0 +1,58 @@
+-- Аудитории, заседания комиссий по защитам и слоты защит
+
+CREATE TABLE `defense_rooms` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` datetime,
+    `name` text NOT NULL,
+    `building` text,
+    `capacity` integer DEFAULT 0
+);
+CREATE UNIQUE INDEX `idx_defense_rooms_name` ON `defense_rooms`(`name`);
+CREATE INDEX `idx_defense_rooms_deleted_at` ON `defense_rooms`(`deleted_at`);
+
+CREATE TABLE `defense_sessions` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` datetime,
+    `title` text NOT NULL,
+    `subject_id` integer NOT NULL,
+    `room_id` integer NOT NULL,
+    `starts_at` datetime NOT NULL,
+    `ends_at` datetime NOT NULL,
+    `slot_minutes` integer NOT NULL DEFAULT 20,
+    CONSTRAINT `fk_defense_sessions_subject` FOREIGN KEY (`subject_id`) REFERENCES `subjects`(`id`),
+    CONSTRAINT `fk_defense_sessions_room` FOREIGN KEY (`room_id`) REFERENCES `defense_rooms`(`id`)
+);
+CREATE INDEX `idx_defense_sessions_starts_at` ON `defense_sessions`(`starts_at`);
+CREATE INDEX `idx_defense_sessions_room_id` ON `defense_sessions`(`room_id`);
+CREATE INDEX `idx_defense_sessions_subject_id` ON `defense_sessions`(`subject_id`);
+CREATE INDEX `idx_defense_sessions_deleted_at` ON `defense_sessions`(`deleted_at`);
+
+CREATE TABLE `defense_committee_members` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `session_id` integer NOT NULL,
+    `teacher_id` integer NOT NULL,
+    `role` varchar(20) DEFAULT "member",
+    CONSTRAINT `fk_defense_sessions_committee` FOREIGN KEY (`session_id`) REFERENCES `defense_sessions`(`id`),
+    CONSTRAINT `fk_defense_committee_members_teacher` FOREIGN KEY (`teacher_id`) REFERENCES `users`(`id`),
+    CONSTRAINT `chk_defense_committee_members_role` CHECK (role IN ('chair','member'))
+);
+CREATE INDEX `idx_defense_committee_members_teacher_id` ON `defense_committee_members`(`teacher_id`);
+CREATE UNIQUE INDEX `idx_committee_session_teacher` ON `defense_committee_members`(`session_id`,`teacher_id`);
+
+CREATE TABLE `defense_slots` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `session_id` integer NOT NULL,
+    `starts_at` datetime NOT NULL,
+    `ends_at` datetime NOT NULL,
+    `student_coursework_id` integer,
+    CONSTRAINT `fk_defense_slots_student_coursework` FOREIGN KEY (`student_coursework_id`) REFERENCES `student_courseworks`(`id`),
+    CONSTRAINT `fk_defense_sessions_slots` FOREIGN KEY (`session_id`) REFERENCES `defense_sessions`(`id`)
+);
+CREATE UNIQUE INDEX `idx_defense_slots_student_coursework_id` ON `defense_slots`(`student_coursework_id`);
+CREATE INDEX `idx_defense_slots_session_id` ON `defense_slots`(`session_id`);
@@ -0,0 +1,25 @@
+-- Предложения тем студентами
+
+CREATE TABLE `topic_proposals` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` datetime,
+    `student_id` integer NOT NULL,
+    `teacher_id` integer NOT NULL,
+    `subject_id` integer NOT NULL,
+    `title` text NOT NULL,
+    `description` text NOT NULL,
+    `status` varchar(20) DEFAULT "pending",
+    `teacher_comment` text,
+    `revision` integer DEFAULT 1,
+    `coursework_id` integer,
+    `decided_at` datetime,
+    CONSTRAINT `fk_topic_proposals_student` FOREIGN KEY (`student_id`) REFERENCES `users`(`id`),
+    CONSTRAINT `fk_topic_proposals_teacher` FOREIGN KEY (`teacher_id`) REFERENCES `users`(`id`),
+    CONSTRAINT `fk_topic_proposals_subject` FOREIGN KEY (`subject_id`) REFERENCES `subjects`(`id`),
+    CONSTRAINT `chk_topic_proposals_status` CHECK (status IN ('pending','changes_requested','accepted','rejected','withdrawn'))
+);
+CREATE INDEX `idx_topic_proposals_teacher_id` ON `topic_proposals`(`teacher_id`);
+CREATE INDEX `idx_topic_proposals_student_id` ON `topic_proposals`(`student_id`);
+CREATE INDEX `idx_topic_proposals_deleted_at` ON `topic_proposals`(`deleted_at`);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS `applicant_rankings`;
+DROP TABLE IF EXISTS `topic_preferences`;
+DROP TABLE IF EXISTS `selection_rounds`;
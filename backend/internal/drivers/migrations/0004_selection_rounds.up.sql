@@ -0,0 +1,42 @@
+-- Раунды выбора тем по предпочтениям
+
+CREATE TABLE `selection_rounds` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` datetime,
+    `title` text NOT NULL,
+    `subject_id` integer NOT NULL,
+    `max_choices` integer NOT NULL DEFAULT 5,
+    `status` varchar(20) DEFAULT "draft",
+    `closes_at` datetime,
+    `committed_at` datetime,
+    CONSTRAINT `fk_selection_rounds_subject` FOREIGN KEY (`subject_id`) REFERENCES `subjects`(`id`),
+    CONSTRAINT `chk_selection_rounds_status` CHECK (status IN ('draft','open','closed','committed'))
+);
+CREATE INDEX `idx_selection_rounds_subject_id` ON `selection_rounds`(`subject_id`);
+CREATE INDEX `idx_selection_rounds_deleted_at` ON `selection_rounds`(`deleted_at`);
+
+CREATE TABLE `topic_preferences` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `round_id` integer NOT NULL,
+    `student_id` integer NOT NULL,
+    `coursework_id` integer NOT NULL,
+    `rank` integer NOT NULL,
+    CONSTRAINT `fk_topic_preferences_student` FOREIGN KEY (`student_id`) REFERENCES `users`(`id`),
+    CONSTRAINT `fk_topic_preferences_coursework` FOREIGN KEY (`coursework_id`) REFERENCES `courseworks`(`id`)
+);
+CREATE INDEX `idx_topic_preferences_coursework_id` ON `topic_preferences`(`coursework_id`);
+CREATE UNIQUE INDEX `idx_pref_round_student_rank` ON `topic_preferences`(`round_id`,`student_id`,`rank`);
+CREATE UNIQUE INDEX `idx_pref_round_student_cw` ON `topic_preferences`(`round_id`,`student_id`,`coursework_id`);
+
+CREATE TABLE `applicant_rankings` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `round_id` integer NOT NULL,
+    `coursework_id` integer NOT NULL,
+    `student_id` integer NOT NULL,
+    `rank` integer NOT NULL
+);
+CREATE UNIQUE INDEX `idx_rank_round_cw_student` ON `applicant_rankings`(`round_id`,`coursework_id`,`student_id`);
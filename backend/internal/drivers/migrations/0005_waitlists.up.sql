@@ -0,0 +1,22 @@
+-- Листы ожидания на заполненные темы
+
+CREATE TABLE `waitlist_entries` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` datetime,
+    `coursework_id` integer NOT NULL,
+    `student_id` integer NOT NULL,
+    `position` integer NOT NULL,
+    `status` varchar(20) DEFAULT "waiting",
+    `offered_at` datetime,
+    `offer_expires_at` datetime,
+    `resolved_at` datetime,
+    CONSTRAINT `fk_waitlist_entries_coursework` FOREIGN KEY (`coursework_id`) REFERENCES `courseworks`(`id`),
+    CONSTRAINT `fk_waitlist_entries_student` FOREIGN KEY (`student_id`) REFERENCES `users`(`id`),
+    CONSTRAINT `chk_waitlist_entries_status` CHECK (status IN ('waiting','offered','accepted','declined','expired','cancelled'))
+);
+CREATE INDEX `idx_waitlist_entries_offer_expires_at` ON `waitlist_entries`(`offer_expires_at`);
+CREATE INDEX `idx_waitlist_entries_student_id` ON `waitlist_entries`(`student_id`);
+CREATE INDEX `idx_waitlist_cw_position` ON `waitlist_entries`(`coursework_id`,`position`);
+CREATE INDEX `idx_waitlist_entries_deleted_at` ON `waitlist_entries`(`deleted_at`);
@@ -0,0 +1,64 @@
+-- Командные курсовые работы
+
+CREATE TABLE `teams` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` datetime,
+    `name` text NOT NULL,
+    `coursework_id` integer NOT NULL,
+    `leader_id` integer NOT NULL,
+    `grading_mode` varchar(20) DEFAULT "team",
+    `submission_url` text,
+    `submission_comment` text,
+    `submitted_by_id` integer,
+    `submitted_at` datetime,
+    CONSTRAINT `fk_teams_coursework` FOREIGN KEY (`coursework_id`) REFERENCES `courseworks`(`id`),
+    CONSTRAINT `fk_teams_leader` FOREIGN KEY (`leader_id`) REFERENCES `users`(`id`),
+    CONSTRAINT `chk_teams_grading_mode` CHECK (grading_mode IN ('team','individual'))
+);
+CREATE INDEX `idx_teams_leader_id` ON `teams`(`leader_id`);
+CREATE UNIQUE INDEX `idx_teams_coursework_id` ON `teams`(`coursework_id`);
+CREATE INDEX `idx_teams_deleted_at` ON `teams`(`deleted_at`);
+
+CREATE TABLE `team_members` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `team_id` integer NOT NULL,
+    `student_id` integer NOT NULL,
+    `contribution_note` text,
+    CONSTRAINT `fk_team_members_student` FOREIGN KEY (`student_id`) REFERENCES `users`(`id`),
+    CONSTRAINT `fk_teams_members` FOREIGN KEY (`team_id`) REFERENCES `teams`(`id`)
+);
+CREATE UNIQUE INDEX `idx_team_members_student_id` ON `team_members`(`student_id`);
+CREATE INDEX `idx_team_members_team_id` ON `team_members`(`team_id`);
+
+CREATE TABLE `team_invitations` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `team_id` integer NOT NULL,
+    `student_id` integer NOT NULL,
+    `invited_by_id` integer NOT NULL,
+    `status` varchar(20) DEFAULT "pending",
+    `responded_at` datetime,
+    CONSTRAINT `fk_team_invitations_student` FOREIGN KEY (`student_id`) REFERENCES `users`(`id`),
+    CONSTRAINT `fk_team_invitations_team` FOREIGN KEY (`team_id`) REFERENCES `teams`(`id`),
+    CONSTRAINT `chk_team_invitations_status` CHECK (status IN ('pending','accepted','declined','revoked'))
+);
+CREATE INDEX `idx_team_invitations_student_id` ON `team_invitations`(`student_id`);
+CREATE INDEX `idx_team_invitations_team_id` ON `team_invitations`(`team_id`);
+
+CREATE TABLE `team_posts` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` datetime,
+    `team_id` integer NOT NULL,
+    `author_id` integer NOT NULL,
+    `body` text NOT NULL,
+    `attachment_url` text,
+    CONSTRAINT `fk_team_posts_author` FOREIGN KEY (`author_id`) REFERENCES `users`(`id`)
+);
+CREATE INDEX `idx_team_posts_team_id` ON `team_posts`(`team_id`);
+CREATE INDEX `idx_team_posts_deleted_at` ON `team_posts`(`deleted_at`);
@@ -0,0 +1,22 @@
+DROP TABLE IF EXISTS `supervision_quotas`;
+
+-- Возврат таблицы без внешних ключей
+CREATE TABLE `teacher_profiles__new` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` datetime,
+    `user_id` integer NOT NULL,
+    `department_id` integer NOT NULL,
+    `position` text,
+    `academic_degree` text
+);
+INSERT INTO `teacher_profiles__new` (`id`, `created_at`, `updated_at`, `deleted_at`, `user_id`, `department_id`, `position`, `academic_degree`)
+SELECT `id`, `created_at`, `updated_at`, `deleted_at`, `user_id`, `department_id`, `position`, `academic_degree` FROM `teacher_profiles`;
+DROP TABLE `teacher_profiles`;
+ALTER TABLE `teacher_profiles__new` RENAME TO `teacher_profiles`;
+CREATE UNIQUE INDEX `idx_teacher_profiles_user_id` ON `teacher_profiles`(`user_id`);
+CREATE INDEX `idx_teacher_profiles_deleted_at` ON `teacher_profiles`(`deleted_at`);
+
+DROP INDEX `idx_departments_head_user_id`;
+ALTER TABLE `departments` DROP COLUMN `head_user_id`;
@@ -0,0 +1,40 @@
+-- Квоты руководства и заведующие кафедрами
+
+CREATE TABLE `supervision_quotas` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` datetime,
+    `teacher_id` integer NOT NULL,
+    `academic_year` text NOT NULL,
+    `max_students` integer NOT NULL,
+    `max_topics` integer NOT NULL,
+    `hours_per_student` real NOT NULL DEFAULT 3,
+    `set_by_id` integer,
+    CONSTRAINT `fk_supervision_quotas_teacher` FOREIGN KEY (`teacher_id`) REFERENCES `users`(`id`)
+);
+CREATE UNIQUE INDEX `idx_quota_teacher_year` ON `supervision_quotas`(`teacher_id`,`academic_year`);
+CREATE INDEX `idx_supervision_quotas_deleted_at` ON `supervision_quotas`(`deleted_at`);
+
+ALTER TABLE `departments` ADD COLUMN `head_user_id` integer;
+CREATE INDEX `idx_departments_head_user_id` ON `departments`(`head_user_id`);
+
+-- SQLite не добавляет внешние ключи к существующей таблице - она пересоздаётся
+CREATE TABLE `teacher_profiles__new` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` datetime,
+    `user_id` integer NOT NULL,
+    `department_id` integer NOT NULL,
+    `position` text,
+    `academic_degree` text,
+    CONSTRAINT `fk_teacher_profiles_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`),
+    CONSTRAINT `fk_teacher_profiles_department` FOREIGN KEY (`department_id`) REFERENCES `departments`(`id`)
+);
+INSERT INTO `teacher_profiles__new` (`id`, `created_at`, `updated_at`, `deleted_at`, `user_id`, `department_id`, `position`, `academic_degree`)
+SELECT `id`, `created_at`, `updated_at`, `deleted_at`, `user_id`, `department_id`, `position`, `academic_degree` FROM `teacher_profiles`;
+DROP TABLE `teacher_profiles`;
+ALTER TABLE `teacher_profiles__new` RENAME TO `teacher_profiles`;
+CREATE UNIQUE INDEX `idx_teacher_profiles_user_id` ON `teacher_profiles`(`user_id`);
+CREATE INDEX `idx_teacher_profiles_deleted_at` ON `teacher_profiles`(`deleted_at`);
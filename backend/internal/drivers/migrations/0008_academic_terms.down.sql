@@ -0,0 +1,21 @@
+DROP TABLE IF EXISTS `terms`;
+DROP TABLE IF EXISTS `academic_years`;
+
+-- Возврат ключа без семестра: закрепления разных семестров сливаются
+CREATE TABLE `teacher_subjects__new` (
+    `user_id` integer,
+    `subject_id` integer,
+    PRIMARY KEY (`user_id`,`subject_id`),
+    CONSTRAINT `fk_teacher_subjects_subject` FOREIGN KEY (`subject_id`) REFERENCES `subjects`(`id`),
+    CONSTRAINT `fk_teacher_subjects_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`)
+);
+INSERT OR IGNORE INTO `teacher_subjects__new` (`user_id`, `subject_id`)
+SELECT `user_id`, `subject_id` FROM `teacher_subjects`;
+DROP TABLE `teacher_subjects`;
+ALTER TABLE `teacher_subjects__new` RENAME TO `teacher_subjects`;
+
+DROP INDEX `idx_student_courseworks_term_id`;
+ALTER TABLE `student_courseworks` DROP COLUMN `term_id`;
+
+DROP INDEX `idx_courseworks_term_id`;
+ALTER TABLE `courseworks` DROP COLUMN `term_id`;
@@ -0,0 +1,53 @@
+-- Учебные годы и семестры
+
+CREATE TABLE `academic_years` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` datetime,
+    `name` text NOT NULL,
+    `starts_on` datetime NOT NULL,
+    `ends_on` datetime NOT NULL
+);
+CREATE UNIQUE INDEX `idx_academic_years_name` ON `academic_years`(`name`);
+CREATE INDEX `idx_academic_years_deleted_at` ON `academic_years`(`deleted_at`);
+
+CREATE TABLE `terms` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` datetime,
+    `academic_year_id` integer NOT NULL,
+    `number` integer NOT NULL,
+    `name` text NOT NULL,
+    `starts_on` datetime NOT NULL,
+    `ends_on` datetime NOT NULL,
+    `is_active` numeric DEFAULT false,
+    CONSTRAINT `fk_academic_years_terms` FOREIGN KEY (`academic_year_id`) REFERENCES `academic_years`(`id`)
+);
+CREATE INDEX `idx_terms_is_active` ON `terms`(`is_active`);
+CREATE UNIQUE INDEX `idx_term_year_number` ON `terms`(`academic_year_id`,`number`);
+CREATE INDEX `idx_terms_deleted_at` ON `terms`(`deleted_at`);
+
+ALTER TABLE `courseworks` ADD COLUMN `term_id` integer;
+CREATE INDEX `idx_courseworks_term_id` ON `courseworks`(`term_id`);
+
+ALTER TABLE `student_courseworks` ADD COLUMN `term_id` integer;
+CREATE INDEX `idx_student_courseworks_term_id` ON `student_courseworks`(`term_id`);
+
+-- Семестр входит в первичный ключ закреплений - таблица пересоздаётся. Записи без семестра
+-- переносятся в семестр при его активации (TermManager.ActivateTerm)
+CREATE TABLE `teacher_subjects__new` (
+    `user_id` integer,
+    `subject_id` integer,
+    `term_id` integer,
+    PRIMARY KEY (`user_id`,`subject_id`,`term_id`),
+    CONSTRAINT `fk_teacher_subjects_subject` FOREIGN KEY (`subject_id`) REFERENCES `subjects`(`id`),
+    CONSTRAINT `fk_teacher_subjects_term` FOREIGN KEY (`term_id`) REFERENCES `terms`(`id`),
+    CONSTRAINT `fk_teacher_subjects_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`),
+    CONSTRAINT `fk_teacher_subjects_teacher` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`)
+);
+INSERT INTO `teacher_subjects__new` (`user_id`, `subject_id`)
+SELECT `user_id`, `subject_id` FROM `teacher_subjects`;
+DROP TABLE `teacher_subjects`;
+ALTER TABLE `teacher_subjects__new` RENAME TO `teacher_subjects`;
@@ -0,0 +1,6 @@
+DROP INDEX `idx_subject_year_lead`;
+DROP INDEX `idx_teacher_subjects_academic_year`;
+ALTER TABLE `teacher_subjects` DROP COLUMN `created_at`;
+ALTER TABLE `teacher_subjects` DROP COLUMN `role`;
+ALTER TABLE `teacher_subjects` DROP COLUMN `is_lead`;
+ALTER TABLE `teacher_subjects` DROP COLUMN `academic_year`;
@@ -0,0 +1,8 @@
+-- Ведущие преподаватели дисциплин по учебным годам
+
+ALTER TABLE `teacher_subjects` ADD COLUMN `academic_year` text;
+ALTER TABLE `teacher_subjects` ADD COLUMN `is_lead` numeric DEFAULT false;
+ALTER TABLE `teacher_subjects` ADD COLUMN `role` text DEFAULT "supervisor";
+ALTER TABLE `teacher_subjects` ADD COLUMN `created_at` datetime;
+CREATE INDEX `idx_teacher_subjects_academic_year` ON `teacher_subjects`(`academic_year`);
+CREATE UNIQUE INDEX `idx_subject_year_lead` ON `teacher_subjects`(`subject_id`,`academic_year`) WHERE is_lead = true;
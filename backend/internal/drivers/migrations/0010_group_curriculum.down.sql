@@ -0,0 +1,37 @@
+DROP TABLE IF EXISTS `group_subjects`;
+
+-- Возврат таблиц без внешних ключей
+CREATE TABLE `student_profiles__new` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` datetime,
+    `user_id` integer NOT NULL,
+    `group_id` integer NOT NULL,
+    `student_number` text
+);
+INSERT INTO `student_profiles__new` (`id`, `created_at`, `updated_at`, `deleted_at`, `user_id`, `group_id`, `student_number`)
+SELECT `id`, `created_at`, `updated_at`, `deleted_at`, `user_id`, `group_id`, `student_number` FROM `student_profiles`;
+DROP TABLE `student_profiles`;
+ALTER TABLE `student_profiles__new` RENAME TO `student_profiles`;
+CREATE UNIQUE INDEX `idx_student_profiles_user_id` ON `student_profiles`(`user_id`);
+CREATE INDEX `idx_student_profiles_deleted_at` ON `student_profiles`(`deleted_at`);
+
+-- То же для групп
+CREATE TABLE `student_groups__new` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` datetime,
+    `group_code` text NOT NULL,
+    `course_year` integer,
+    `specialty` text,
+    `department_id` integer NOT NULL
+);
+INSERT INTO `student_groups__new` (`id`, `created_at`, `updated_at`, `deleted_at`, `group_code`, `course_year`, `specialty`, `department_id`)
+SELECT `id`, `created_at`, `updated_at`, `deleted_at`, `group_code`, `course_year`, `specialty`, `department_id` FROM `student_groups`;
+DROP TABLE `student_groups`;
+ALTER TABLE `student_groups__new` RENAME TO `student_groups`;
+CREATE INDEX `idx_student_groups_department_id` ON `student_groups`(`department_id`);
+CREATE UNIQUE INDEX `idx_student_groups_group_code` ON `student_groups`(`group_code`);
+CREATE INDEX `idx_student_groups_deleted_at` ON `student_groups`(`deleted_at`);
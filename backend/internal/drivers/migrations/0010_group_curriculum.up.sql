@@ -0,0 +1,56 @@
+-- Учебные планы групп
+
+CREATE TABLE `group_subjects` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` datetime,
+    `group_id` integer NOT NULL,
+    `subject_id` integer NOT NULL,
+    `term_id` integer NOT NULL,
+    CONSTRAINT `fk_group_subjects_group` FOREIGN KEY (`group_id`) REFERENCES `student_groups`(`id`),
+    CONSTRAINT `fk_group_subjects_subject` FOREIGN KEY (`subject_id`) REFERENCES `subjects`(`id`),
+    CONSTRAINT `fk_group_subjects_term` FOREIGN KEY (`term_id`) REFERENCES `terms`(`id`)
+);
+CREATE INDEX `idx_group_subjects_subject_id` ON `group_subjects`(`subject_id`);
+CREATE UNIQUE INDEX `idx_group_subject_term` ON `group_subjects`(`group_id`,`subject_id`,`term_id`);
+CREATE INDEX `idx_group_subjects_deleted_at` ON `group_subjects`(`deleted_at`);
+
+-- SQLite не добавляет внешние ключи к существующей таблице - она пересоздаётся
+CREATE TABLE `student_groups__new` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` datetime,
+    `group_code` text NOT NULL,
+    `course_year` integer,
+    `specialty` text,
+    `department_id` integer NOT NULL,
+    CONSTRAINT `fk_student_groups_department` FOREIGN KEY (`department_id`) REFERENCES `departments`(`id`)
+);
+INSERT INTO `student_groups__new` (`id`, `created_at`, `updated_at`, `deleted_at`, `group_code`, `course_year`, `specialty`, `department_id`)
+SELECT `id`, `created_at`, `updated_at`, `deleted_at`, `group_code`, `course_year`, `specialty`, `department_id` FROM `student_groups`;
+DROP TABLE `student_groups`;
+ALTER TABLE `student_groups__new` RENAME TO `student_groups`;
+CREATE INDEX `idx_student_groups_department_id` ON `student_groups`(`department_id`);
+CREATE UNIQUE INDEX `idx_student_groups_group_code` ON `student_groups`(`group_code`);
+CREATE INDEX `idx_student_groups_deleted_at` ON `student_groups`(`deleted_at`);
+
+-- То же для профилей студентов
+CREATE TABLE `student_profiles__new` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` datetime,
+    `user_id` integer NOT NULL,
+    `group_id` integer NOT NULL,
+    `student_number` text,
+    CONSTRAINT `fk_student_profiles_student_group` FOREIGN KEY (`group_id`) REFERENCES `student_groups`(`id`),
+    CONSTRAINT `fk_student_profiles_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`)
+);
+INSERT INTO `student_profiles__new` (`id`, `created_at`, `updated_at`, `deleted_at`, `user_id`, `group_id`, `student_number`)
+SELECT `id`, `created_at`, `updated_at`, `deleted_at`, `user_id`, `group_id`, `student_number` FROM `student_profiles`;
+DROP TABLE `student_profiles`;
+ALTER TABLE `student_profiles__new` RENAME TO `student_profiles`;
+CREATE UNIQUE INDEX `idx_student_profiles_user_id` ON `student_profiles`(`user_id`);
+CREATE INDEX `idx_student_profiles_deleted_at` ON `student_profiles`(`deleted_at`);
@@ -0,0 +1,30 @@
+-- Импорт списков студентов и преподавателей
+
+CREATE TABLE `import_jobs` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` datetime,
+    `kind` text NOT NULL,
+    `file_name` text,
+    `dry_run` numeric,
+    `created_by_id` integer NOT NULL,
+    `total` integer,
+    `created` integer,
+    `updated` integer,
+    `unchanged` integer,
+    `failed` integer,
+    CONSTRAINT `fk_import_jobs_created_by` FOREIGN KEY (`created_by_id`) REFERENCES `users`(`id`)
+);
+CREATE INDEX `idx_import_jobs_created_by_id` ON `import_jobs`(`created_by_id`);
+CREATE INDEX `idx_import_jobs_deleted_at` ON `import_jobs`(`deleted_at`);
+
+CREATE TABLE `import_row_errors` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `import_job_id` integer NOT NULL,
+    `row` integer,
+    `email` text,
+    `message` text,
+    CONSTRAINT `fk_import_jobs_errors` FOREIGN KEY (`import_job_id`) REFERENCES `import_jobs`(`id`)
+);
+CREATE INDEX `idx_import_row_errors_import_job_id` ON `import_row_errors`(`import_job_id`);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `issued_documents`;
+DROP TABLE IF EXISTS `document_templates`;
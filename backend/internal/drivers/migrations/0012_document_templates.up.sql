@@ -0,0 +1,45 @@
+-- Шаблоны документов и приказы
+
+CREATE TABLE `document_templates` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` datetime,
+    `kind` text NOT NULL,
+    `name` text NOT NULL,
+    `number_prefix` text,
+    `header` text,
+    `footer` text,
+    `is_default` numeric DEFAULT false,
+    `updated_by_id` integer
+);
+CREATE INDEX `idx_document_templates_kind` ON `document_templates`(`kind`);
+CREATE INDEX `idx_document_templates_deleted_at` ON `document_templates`(`deleted_at`);
+
+CREATE TABLE `issued_documents` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `kind` text NOT NULL,
+    `academic_year` text,
+    `sequence` integer NOT NULL,
+    `version` integer NOT NULL DEFAULT 1,
+    `number` text NOT NULL,
+    `format` text NOT NULL,
+    `file_name` text,
+    `content` blob,
+    `is_current` numeric DEFAULT true,
+    `template_id` integer,
+    `subject_id` integer NOT NULL,
+    `group_id` integer,
+    `term_id` integer,
+    `created_by_id` integer NOT NULL,
+    CONSTRAINT `fk_issued_documents_created_by` FOREIGN KEY (`created_by_id`) REFERENCES `users`(`id`),
+    CONSTRAINT `fk_issued_documents_subject` FOREIGN KEY (`subject_id`) REFERENCES `subjects`(`id`),
+    CONSTRAINT `fk_issued_documents_group` FOREIGN KEY (`group_id`) REFERENCES `student_groups`(`id`)
+);
+CREATE INDEX `idx_issued_documents_term_id` ON `issued_documents`(`term_id`);
+CREATE INDEX `idx_issued_documents_group_id` ON `issued_documents`(`group_id`);
+CREATE INDEX `idx_issued_documents_subject_id` ON `issued_documents`(`subject_id`);
+CREATE INDEX `idx_issued_documents_is_current` ON `issued_documents`(`is_current`);
+CREATE INDEX `idx_issued_documents_number` ON `issued_documents`(`number`);
+CREATE UNIQUE INDEX `idx_document_number_version` ON `issued_documents`(`kind`,`academic_year`,`sequence`,`version`);
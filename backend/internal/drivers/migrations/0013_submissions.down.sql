@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS `similarity_fragments`;
+DROP TABLE IF EXISTS `similarity_matches`;
+DROP TABLE IF EXISTS `submission_fingerprints`;
+DROP TABLE IF EXISTS `submissions`;
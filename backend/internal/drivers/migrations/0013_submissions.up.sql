@@ -0,0 +1,59 @@
+-- Загрузка работ и проверка на заимствования
+
+CREATE TABLE `submissions` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` datetime,
+    `student_coursework_id` integer NOT NULL,
+    `student_id` integer NOT NULL,
+    `coursework_id` integer NOT NULL,
+    `term_id` integer,
+    `file_name` text NOT NULL,
+    `content_type` text,
+    `size` integer,
+    `sha256` text,
+    `storage_key` text NOT NULL,
+    `check_status` text DEFAULT "pending",
+    `check_error` text,
+    `similarity_score` real,
+    `checked_at` datetime,
+    CONSTRAINT `fk_submissions_coursework` FOREIGN KEY (`coursework_id`) REFERENCES `courseworks`(`id`),
+    CONSTRAINT `fk_submissions_student` FOREIGN KEY (`student_id`) REFERENCES `users`(`id`)
+);
+CREATE INDEX `idx_submissions_check_status` ON `submissions`(`check_status`);
+CREATE INDEX `idx_submissions_sha256` ON `submissions`(`sha256`);
+CREATE INDEX `idx_submissions_term_id` ON `submissions`(`term_id`);
+CREATE INDEX `idx_submissions_coursework_id` ON `submissions`(`coursework_id`);
+CREATE INDEX `idx_submissions_student_id` ON `submissions`(`student_id`);
+CREATE INDEX `idx_submissions_student_coursework_id` ON `submissions`(`student_coursework_id`);
+CREATE INDEX `idx_submissions_deleted_at` ON `submissions`(`deleted_at`);
+
+CREATE TABLE `submission_fingerprints` (
+    `submission_id` integer PRIMARY KEY AUTOINCREMENT,
+    `shingle_count` integer,
+    `signature` blob,
+    `text` text
+);
+
+CREATE TABLE `similarity_matches` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `submission_id` integer NOT NULL,
+    `matched_submission_id` integer NOT NULL,
+    `similarity` real,
+    `jaccard` real,
+    CONSTRAINT `fk_similarity_matches_matched_submission` FOREIGN KEY (`matched_submission_id`) REFERENCES `submissions`(`id`)
+);
+CREATE INDEX `idx_similarity_matches_matched_submission_id` ON `similarity_matches`(`matched_submission_id`);
+CREATE INDEX `idx_similarity_matches_submission_id` ON `similarity_matches`(`submission_id`);
+
+CREATE TABLE `similarity_fragments` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `match_id` integer NOT NULL,
+    `text` text,
+    `words` integer,
+    `position` integer,
+    `matched_position` integer,
+    CONSTRAINT `fk_similarity_matches_fragments` FOREIGN KEY (`match_id`) REFERENCES `similarity_matches`(`id`)
+);
+CREATE INDEX `idx_similarity_fragments_match_id` ON `similarity_fragments`(`match_id`);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS `thread_read_marks`;
+DROP TABLE IF EXISTS `comment_mentions`;
+DROP TABLE IF EXISTS `comment_attachments`;
+DROP TABLE IF EXISTS `comment_revisions`;
+DROP TABLE IF EXISTS `comments`;
+DROP TABLE IF EXISTS `discussion_threads`;
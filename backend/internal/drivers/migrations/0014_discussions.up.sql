@@ -0,0 +1,72 @@
+-- Обсуждения тем и назначений
+
+CREATE TABLE `discussion_threads` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `kind` text NOT NULL,
+    `coursework_id` integer NOT NULL,
+    `student_coursework_id` integer NOT NULL DEFAULT 0,
+    `last_comment_at` datetime,
+    CONSTRAINT `fk_discussion_threads_coursework` FOREIGN KEY (`coursework_id`) REFERENCES `courseworks`(`id`),
+    CONSTRAINT `fk_discussion_threads_student_coursework` FOREIGN KEY (`student_coursework_id`) REFERENCES `student_courseworks`(`id`)
+);
+CREATE UNIQUE INDEX `idx_discussion_thread` ON `discussion_threads`(`kind`,`coursework_id`,`student_coursework_id`);
+
+CREATE TABLE `comments` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` datetime,
+    `thread_id` integer NOT NULL,
+    `parent_id` integer,
+    `author_id` integer NOT NULL,
+    `body` text NOT NULL,
+    `body_html` text,
+    `edited_at` datetime,
+    CONSTRAINT `fk_comments_author` FOREIGN KEY (`author_id`) REFERENCES `users`(`id`)
+);
+CREATE INDEX `idx_comments_author_id` ON `comments`(`author_id`);
+CREATE INDEX `idx_comments_parent_id` ON `comments`(`parent_id`);
+CREATE INDEX `idx_comments_thread_id` ON `comments`(`thread_id`);
+CREATE INDEX `idx_comments_deleted_at` ON `comments`(`deleted_at`);
+
+CREATE TABLE `comment_revisions` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `comment_id` integer NOT NULL,
+    `body` text NOT NULL,
+    `editor_id` integer NOT NULL,
+    CONSTRAINT `fk_comment_revisions_editor` FOREIGN KEY (`editor_id`) REFERENCES `users`(`id`)
+);
+CREATE INDEX `idx_comment_revisions_comment_id` ON `comment_revisions`(`comment_id`);
+
+CREATE TABLE `comment_attachments` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `comment_id` integer NOT NULL,
+    `file_name` text NOT NULL,
+    `content_type` text,
+    `size` integer,
+    `storage_key` text NOT NULL,
+    CONSTRAINT `fk_comments_attachments` FOREIGN KEY (`comment_id`) REFERENCES `comments`(`id`)
+);
+CREATE INDEX `idx_comment_attachments_comment_id` ON `comment_attachments`(`comment_id`);
+
+CREATE TABLE `comment_mentions` (
+    `comment_id` integer,
+    `user_id` integer,
+    PRIMARY KEY (`comment_id`,`user_id`),
+    CONSTRAINT `fk_comment_mentions_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`),
+    CONSTRAINT `fk_comments_mentions` FOREIGN KEY (`comment_id`) REFERENCES `comments`(`id`)
+);
+CREATE INDEX `idx_comment_mentions_user_id` ON `comment_mentions`(`user_id`);
+
+CREATE TABLE `thread_read_marks` (
+    `thread_id` integer,
+    `user_id` integer,
+    `last_read_comment_id` integer,
+    `read_at` datetime,
+    PRIMARY KEY (`thread_id`,`user_id`)
+);
+CREATE INDEX `idx_thread_read_marks_user_id` ON `thread_read_marks`(`user_id`);
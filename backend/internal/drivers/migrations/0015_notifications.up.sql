@@ -0,0 +1,23 @@
+-- Уведомления в приложении
+
+CREATE TABLE `notifications` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `user_id` integer NOT NULL,
+    `event` text NOT NULL,
+    `actor_id` integer,
+    `entity_type` text,
+    `entity_id` integer,
+    `title` text NOT NULL,
+    `message` text,
+    `read_at` datetime
+);
+CREATE INDEX `idx_notification_user_read` ON `notifications`(`user_id`,`read_at`);
+CREATE INDEX `idx_notifications_created_at` ON `notifications`(`created_at`);
+
+CREATE TABLE `notification_preferences` (
+    `user_id` integer,
+    `event` text,
+    `enabled` numeric NOT NULL,
+    PRIMARY KEY (`user_id`,`event`)
+);
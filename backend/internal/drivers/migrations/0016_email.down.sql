@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `outgoing_emails`;
+DROP TABLE IF EXISTS `email_settings`;
@@ -0,0 +1,30 @@
+-- Почтовые уведомления и сводки
+
+CREATE TABLE `email_settings` (
+    `user_id` integer PRIMARY KEY AUTOINCREMENT,
+    `locale` text NOT NULL DEFAULT "ru",
+    `mode` text NOT NULL DEFAULT "immediate",
+    `last_digest_at` datetime,
+    `updated_at` datetime
+);
+CREATE INDEX `idx_email_settings_mode` ON `email_settings`(`mode`);
+
+CREATE TABLE `outgoing_emails` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime,
+    `user_id` integer NOT NULL,
+    `kind` text NOT NULL,
+    `notification_id` integer,
+    `to` text NOT NULL,
+    `subject` text NOT NULL,
+    `text_body` text,
+    `html_body` text,
+    `status` text NOT NULL,
+    `attempts` integer NOT NULL DEFAULT 0,
+    `next_attempt_at` datetime,
+    `last_error` text,
+    `sent_at` datetime
+);
+CREATE INDEX `idx_outgoing_email_due` ON `outgoing_emails`(`status`,`next_attempt_at`);
+CREATE INDEX `idx_outgoing_emails_user_id` ON `outgoing_emails`(`user_id`);
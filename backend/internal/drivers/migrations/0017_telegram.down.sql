@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS `telegram_link_codes`;
+DROP TABLE IF EXISTS `telegram_links`;
+DROP TABLE IF EXISTS `deadline_reminders`;
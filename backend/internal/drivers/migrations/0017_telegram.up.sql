@@ -0,0 +1,27 @@
+-- Бот Telegram и напоминания о сроках
+
+CREATE TABLE `deadline_reminders` (
+    `user_id` integer,
+    `entity_type` text,
+    `entity_id` integer,
+    `sent_at` datetime NOT NULL,
+    PRIMARY KEY (`user_id`,`entity_type`,`entity_id`)
+);
+
+CREATE TABLE `telegram_links` (
+    `user_id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `chat_id` integer NOT NULL,
+    `username` text,
+    CONSTRAINT `fk_telegram_links_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`)
+);
+CREATE UNIQUE INDEX `idx_telegram_links_chat_id` ON `telegram_links`(`chat_id`);
+
+CREATE TABLE `telegram_link_codes` (
+    `code` text,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `user_id` integer NOT NULL,
+    `expires_at` datetime NOT NULL,
+    PRIMARY KEY (`code`)
+);
+CREATE INDEX `idx_telegram_link_codes_user_id` ON `telegram_link_codes`(`user_id`);
@@ -0,0 +1,35 @@
+-- Исходящие вебхуки
+
+CREATE TABLE `webhooks` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `url` text NOT NULL,
+    `description` text,
+    `secret` text NOT NULL,
+    `events` text NOT NULL,
+    `is_active` numeric NOT NULL
+);
+
+CREATE TABLE `webhook_deliveries` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime,
+    `webhook_id` integer NOT NULL,
+    `event_id` text NOT NULL,
+    `event` text NOT NULL,
+    `payload` text NOT NULL,
+    `status` text NOT NULL,
+    `attempts` integer NOT NULL DEFAULT 0,
+    `next_attempt_at` datetime,
+    `response_status` integer,
+    `response_body` text,
+    `last_error` text,
+    `duration_ms` integer,
+    `delivered_at` datetime,
+    `redelivery_of` integer
+);
+CREATE INDEX `idx_webhook_delivery_due` ON `webhook_deliveries`(`status`,`next_attempt_at`);
+CREATE INDEX `idx_webhook_deliveries_event_id` ON `webhook_deliveries`(`event_id`);
+CREATE INDEX `idx_webhook_deliveries_webhook_id` ON `webhook_deliveries`(`webhook_id`);
+CREATE INDEX `idx_webhook_deliveries_created_at` ON `webhook_deliveries`(`created_at`);
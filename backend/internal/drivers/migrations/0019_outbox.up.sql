@@ -0,0 +1,18 @@
+-- Outbox событий предметной области
+
+CREATE TABLE `outbox_events` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime,
+    `type` text NOT NULL,
+    `payload` text NOT NULL,
+    `status` text NOT NULL,
+    `attempts` integer NOT NULL DEFAULT 0,
+    `next_attempt_at` datetime,
+    `handled` text,
+    `last_error` text,
+    `processed_at` datetime
+);
+CREATE INDEX `idx_outbox_due` ON `outbox_events`(`status`,`next_attempt_at`);
+CREATE INDEX `idx_outbox_events_type` ON `outbox_events`(`type`);
+CREATE INDEX `idx_outbox_events_created_at` ON `outbox_events`(`created_at`);
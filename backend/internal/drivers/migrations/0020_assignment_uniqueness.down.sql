@@ -0,0 +1,2 @@
+DROP INDEX `idx_student_term_active`;
+DROP INDEX `idx_student_courseworks_coursework_id`;
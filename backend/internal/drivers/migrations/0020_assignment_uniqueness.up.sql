@@ -0,0 +1,11 @@
+-- Одно активное назначение студента в семестре
+
+CREATE INDEX `idx_student_courseworks_coursework_id` ON `student_courseworks`(`coursework_id`);
+-- назначения без семестра получают семестр темы, а созданные до появления семестров - 0:
+-- NULL в уникальном индексе не совпадает ни с чем, и такие строки обходили бы ограничение.
+-- Если у студента уже несколько активных назначений, создание индекса прервёт миграцию
+UPDATE `student_courseworks` SET `term_id` = COALESCE(
+    (SELECT `courseworks`.`term_id` FROM `courseworks` WHERE `courseworks`.`id` = `student_courseworks`.`coursework_id`), 0)
+WHERE `term_id` IS NULL;
+-- не больше одного активного назначения студента в семестре
+CREATE UNIQUE INDEX `idx_student_term_active` ON `student_courseworks`(`student_id`,`term_id`) WHERE deleted_at IS NULL;
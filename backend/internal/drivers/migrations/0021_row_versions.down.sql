@@ -0,0 +1,2 @@
+ALTER TABLE `courseworks` DROP COLUMN `version`;
+ALTER TABLE `users` DROP COLUMN `version`;
@@ -0,0 +1,4 @@
+-- Версии записей для оптимистичных блокировок
+
+ALTER TABLE `users` ADD COLUMN `version` integer NOT NULL DEFAULT 1;
+ALTER TABLE `courseworks` ADD COLUMN `version` integer NOT NULL DEFAULT 1;
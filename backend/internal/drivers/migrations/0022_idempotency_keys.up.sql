@@ -0,0 +1,17 @@
+-- Ключи идемпотентности
+
+CREATE TABLE `idempotency_keys` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` datetime,
+    `user_id` integer NOT NULL,
+    `key` text NOT NULL,
+    `fingerprint` text NOT NULL,
+    `status` text NOT NULL,
+    `response_code` integer,
+    `content_type` text,
+    `response_body` text,
+    `expires_at` datetime NOT NULL
+);
+CREATE INDEX `idx_idempotency_keys_expires_at` ON `idempotency_keys`(`expires_at`);
+CREATE UNIQUE INDEX `idx_idempotency_user_key` ON `idempotency_keys`(`user_id`,`key`);
@@ -0,0 +1,2 @@
+DROP INDEX `idx_defense_sessions_term_id`;
+ALTER TABLE `defense_sessions` DROP COLUMN `term_id`;
@@ -0,0 +1,4 @@
+-- Семестр сессии защит: распределяются только работы этого семестра
+
+ALTER TABLE `defense_sessions` ADD COLUMN `term_id` integer;
+CREATE INDEX `idx_defense_sessions_term_id` ON `defense_sessions`(`term_id`);
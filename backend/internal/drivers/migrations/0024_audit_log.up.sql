@@ -0,0 +1,13 @@
+-- Журнал аудита событий предметной области
+
+CREATE TABLE `audit_log` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `created_at` datetime DEFAULT CURRENT_TIMESTAMP,
+    `event_id` integer NOT NULL,
+    `event_type` text NOT NULL,
+    `payload` text NOT NULL,
+    `occurred_at` datetime NOT NULL
+);
+CREATE UNIQUE INDEX `idx_audit_log_event_id` ON `audit_log`(`event_id`);
+CREATE INDEX `idx_audit_log_event_type` ON `audit_log`(`event_type`);
+CREATE INDEX `idx_audit_log_occurred_at` ON `audit_log`(`occurred_at`);
@@ -0,0 +1,380 @@
+package drivers
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// migrationFiles - миграции схемы, встроенные в бинарник: NNNN_имя.up.sql и NNNN_имя.down.sql.
+// Новая модель или поле появляется в базе только через новую миграцию
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// baselineVersion - миграция со схемой, которую раньше создавал AutoMigrate
+const baselineVersion = 1
+
+// Migration - версия схемы со скриптами применения и отката
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string // пусто - миграцию нельзя откатить
+}
+
+// MigrationStatus - миграция и время её применения; AppliedAt == nil - ещё не применена
+type MigrationStatus struct {
+	Version   uint
+	Name      string
+	AppliedAt *time.Time
+}
+
+// schemaMigration - запись о применённой миграции
+type schemaMigration struct {
+	Version   uint      `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrator применяет и откатывает миграции по порядку версий. Каждая миграция выполняется
+// в своей транзакции вместе с записью в schema_migrations
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator создаёт Migrator со встроенными миграциями
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	migrations, err := LoadMigrations(migrationFiles)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// LoadMigrations читает миграции из fsys и упорядочивает их по версии
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[uint]*Migration)
+	for _, file := range files {
+		m := migrationFileName.FindStringSubmatch(path.Base(file))
+		if m == nil {
+			return nil, fmt.Errorf("invalid migration file name %s: want NNNN_name.up.sql or NNNN_name.down.sql", file)
+		}
+		version, err := strconv.ParseUint(m[1], 10, 32)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("invalid migration version in %s", file)
+		}
+		script, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[uint(version)]
+		if !ok {
+			migration = &Migration{Version: uint(version), Name: m[2]}
+			byVersion[uint(version)] = migration
+		}
+		if migration.Name != m[2] {
+			return nil, fmt.Errorf("migration %d has different names: %s and %s", version, migration.Name, m[2])
+		}
+		if m[3] == "up" {
+			migration.Up = string(script)
+		} else {
+			migration.Down = string(script)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Up применяет все ещё не применённые миграции и возвращает их число
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	if len(m.migrations) == 0 {
+		return 0, nil
+	}
+	return m.To(ctx, m.migrations[len(m.migrations)-1].Version)
+}
+
+// Down откатывает steps последних применённых миграций
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	if err := m.prepare(ctx); err != nil {
+		return 0, err
+	}
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	done := 0
+	for i := len(m.migrations) - 1; i >= 0 && done < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if err := m.rollback(ctx, migration); err != nil {
+			return done, err
+		}
+		done++
+	}
+	return done, nil
+}
+
+// To приводит схему к версии version: применяет миграции до неё включительно и откатывает
+// более поздние. Версия 0 - откат всех миграций
+func (m *Migrator) To(ctx context.Context, version uint) (int, error) {
+	if version != 0 && m.find(version) == nil {
+		return 0, fmt.Errorf("migration %d not found", version)
+	}
+	if err := m.prepare(ctx); err != nil {
+		return 0, err
+	}
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	done := 0
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok || migration.Version <= version {
+			continue
+		}
+		if err := m.rollback(ctx, migration); err != nil {
+			return done, err
+		}
+		done++
+	}
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok || migration.Version > version {
+			continue
+		}
+		if err := m.apply(ctx, migration); err != nil {
+			return done, err
+		}
+		done++
+	}
+	return done, nil
+}
+
+// Status возвращает все известные миграции с отметкой о применении
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	if err := m.prepare(ctx); err != nil {
+		return nil, err
+	}
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			at := record.AppliedAt
+			status.AppliedAt = &at
+		}
+		statuses = append(statuses, status)
+	}
+	// применённые миграции, которых нет в бинарнике, тоже показываются
+	for version, record := range applied {
+		if m.find(version) == nil {
+			at := record.AppliedAt
+			statuses = append(statuses, MigrationStatus{Version: version, Name: record.Name, AppliedAt: &at})
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// Version возвращает последнюю применённую версию схемы, 0 - миграций нет
+func (m *Migrator) Version(ctx context.Context) (uint, error) {
+	if err := m.prepare(ctx); err != nil {
+		return 0, err
+	}
+	var version uint
+	err := m.db.WithContext(ctx).Model(&schemaMigration{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to get schema version: %w", err)
+	}
+	return version, nil
+}
+
+func (m *Migrator) find(version uint) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+// prepare создаёт schema_migrations и принимает базу, созданную AutoMigrate
+func (m *Migrator) prepare(ctx context.Context) error {
+	db := m.db.WithContext(ctx)
+	if err := db.Exec("CREATE TABLE IF NOT EXISTS `schema_migrations` (" +
+		"`version` integer PRIMARY KEY, `name` text NOT NULL, `applied_at` datetime NOT NULL)").Error; err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	return m.adoptExisting(ctx)
+}
+
+// adoptExisting отмечает базовую миграцию применённой, если schema_migrations пуста, а
+// таблицы уже созданы AutoMigrate. Схема такой базы должна совпадать с базовой: более старую
+// базу нужно сначала обновить прежней версией сервера
+func (m *Migrator) adoptExisting(ctx context.Context) error {
+	db := m.db.WithContext(ctx)
+	var count int64
+	if err := db.Model(&schemaMigration{}).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	baseline := m.find(baselineVersion)
+	if count > 0 || baseline == nil || !db.Migrator().HasTable("users") {
+		return nil
+	}
+
+	missing, err := missingBaselineColumns(ctx, db, baseline.Up)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("existing database schema is older than baseline migration %d_%s, missing: %s",
+			baseline.Version, baseline.Name, strings.Join(missing, ", "))
+	}
+
+	log.Printf("migrations: existing database adopted at baseline %d_%s", baseline.Version, baseline.Name)
+	return db.Create(&schemaMigration{Version: baseline.Version, Name: baseline.Name, AppliedAt: time.Now()}).Error
+}
+
+// missingBaselineColumns сравнивает базу с базовой схемой, развёрнутой во временной базе в
+// памяти, и возвращает таблицы и колонки, которых в базе нет
+func missingBaselineColumns(ctx context.Context, db *gorm.DB, baseline string) ([]string, error) {
+	reference, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		return nil, err
+	}
+	if sqlDB, err := reference.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+	if err := reference.WithContext(ctx).Exec(baseline).Error; err != nil {
+		return nil, fmt.Errorf("failed to load baseline schema: %w", err)
+	}
+
+	tables, err := reference.Migrator().GetTables()
+	if err != nil {
+		return nil, err
+	}
+	var missing []string
+	for _, table := range tables {
+		if table == "sqlite_sequence" {
+			continue
+		}
+		if !db.Migrator().HasTable(table) {
+			missing = append(missing, table)
+			continue
+		}
+		columns, err := reference.Migrator().ColumnTypes(table)
+		if err != nil {
+			return nil, err
+		}
+		for _, column := range columns {
+			if !db.Migrator().HasColumn(table, column.Name()) {
+				missing = append(missing, table+"."+column.Name())
+			}
+		}
+	}
+	return missing, nil
+}
+
+// applied возвращает записи о применённых миграциях по версиям
+func (m *Migrator) applied(ctx context.Context) (map[uint]schemaMigration, error) {
+	var records []schemaMigration
+	if err := m.db.WithContext(ctx).Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	applied := make(map[uint]schemaMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// apply выполняет up-скрипт. Версия перепроверяется внутри транзакции: другой процесс
+// (сервер и сидер при одновременном запуске) мог применить её первым
+func (m *Migrator) apply(ctx context.Context, migration Migration) error {
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&schemaMigration{}).Where("version = ?", migration.Version).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+		if err := tx.Exec(migration.Up).Error; err != nil {
+			return err
+		}
+		return tx.Create(&schemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+	log.Printf("migrations: applied %d_%s", migration.Version, migration.Name)
+	return nil
+}
+
+// rollback выполняет down-скрипт и удаляет запись о миграции
+func (m *Migrator) rollback(ctx context.Context, migration Migration) error {
+	if migration.Down == "" {
+		return fmt.Errorf("migration %d_%s cannot be rolled back: no down script", migration.Version, migration.Name)
+	}
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Down).Error; err != nil {
+			return err
+		}
+		result := tx.Where("version = ?", migration.Version).Delete(&schemaMigration{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("migration was rolled back by another process")
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to roll back migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+	log.Printf("migrations: rolled back %d_%s", migration.Version, migration.Name)
+	return nil
+}
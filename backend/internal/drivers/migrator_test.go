@@ -0,0 +1,158 @@
+package drivers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// preMigrationData - данные, которые должны пережить обновление схемы
+const preMigrationData = "" +
+	"INSERT INTO `departments` (`id`, `department_code`, `department_name`) VALUES (1, 'IT', 'Информационные технологии');" +
+	"INSERT INTO `student_groups` (`id`, `group_code`, `course_year`, `department_id`) VALUES (1, 'IT-31', 3, 1);" +
+	"INSERT INTO `subjects` (`id`, `name`, `code`, `semester`) VALUES (1, 'Базы данных', 'DB', 5);" +
+	"INSERT INTO `users` (`id`, `email`, `password_hash`, `first_name`, `last_name`, `role`) VALUES " +
+	"(1, 't@example.com', 'x', 'Анна', 'Петрова', 'teacher'), (2, 's@example.com', 'x', 'Иван', 'Иванов', 'student');" +
+	"INSERT INTO `teacher_profiles` (`id`, `user_id`, `department_id`, `position`) VALUES (1, 1, 1, 'доцент');" +
+	"INSERT INTO `student_profiles` (`id`, `user_id`, `group_id`, `student_number`) VALUES (1, 2, 1, 'S-1');" +
+	"INSERT INTO `teacher_subjects` (`user_id`, `subject_id`) VALUES (1, 1);" +
+	"INSERT INTO `courseworks` (`id`, `title`, `description`, `subject_id`, `teacher_id`, `difficulty_level`) VALUES (1, 'Тема', 'Описание', 1, 1, 'easy');" +
+	"INSERT INTO `student_courseworks` (`id`, `student_id`, `coursework_id`) VALUES (1, 2, 1);"
+
+func TestMigratorUpgradesAutoMigrateDatabase(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	schema, err := os.ReadFile(filepath.Join("testdata", "automigrate_schema.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := OpenDB(filepath.Join(dir, "old.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Exec(string(schema)).Error; err != nil {
+		t.Fatalf("failed to create pre-migration schema: %v", err)
+	}
+	if err := db.Exec(preMigrationData).Error; err != nil {
+		t.Fatalf("failed to insert data: %v", err)
+	}
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	version, err := migrator.Version(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest := statuses[len(statuses)-1].Version; version != latest {
+		t.Fatalf("schema version = %d, want %d", version, latest)
+	}
+	for _, s := range statuses {
+		if s.AppliedAt == nil {
+			t.Errorf("migration %d_%s is not applied", s.Version, s.Name)
+		}
+	}
+
+	counts := map[string]int64{
+		"users": 2, "teacher_profiles": 1, "student_profiles": 1,
+		"teacher_subjects": 1, "courseworks": 1, "student_courseworks": 1,
+	}
+	for table, want := range counts {
+		var got int64
+		if err := db.Table(table).Count(&got).Error; err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("%s: %d rows after upgrade, want %d", table, got, want)
+		}
+	}
+	// назначение, созданное до появления семестров, попадает под уникальный индекс
+	var termIDs []*uint
+	if err := db.Raw("SELECT `term_id` FROM `student_courseworks` WHERE `id` = 1").Scan(&termIDs).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(termIDs) != 1 || termIDs[0] == nil || *termIDs[0] != 0 {
+		t.Errorf("student_courseworks.term_id = %v, want 0", termIDs)
+	}
+	if err := db.Exec("INSERT INTO `student_courseworks` (`student_id`, `coursework_id`, `term_id`) VALUES (2, 1, 0)").Error; err == nil {
+		t.Error("second active assignment of a legacy student was inserted")
+	}
+
+	var userVersion uint
+	if err := db.Raw("SELECT `version` FROM `users` WHERE `id` = 1").Scan(&userVersion).Error; err != nil {
+		t.Fatal(err)
+	}
+	if userVersion != 1 {
+		t.Errorf("users.version = %d, want 1", userVersion)
+	}
+
+	fresh, err := InitDB(filepath.Join(dir, "fresh.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := schemaOf(t, db), schemaOf(t, fresh); !reflect.DeepEqual(got, want) {
+		t.Errorf("upgraded schema differs from a fresh one:\n got %v\nwant %v", got, want)
+	}
+}
+
+func TestMigratorDownRemovesEverything(t *testing.T) {
+	ctx := context.Background()
+	db, err := InitDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.To(ctx, 0); err != nil {
+		t.Fatalf("To(0): %v", err)
+	}
+	if tables := schemaOf(t, db); len(tables) != 0 {
+		t.Errorf("tables left after full rollback: %v", tables)
+	}
+}
+
+// schemaOf описывает схему без учёта текста CREATE TABLE: колонки, внешние ключи и индексы
+func schemaOf(t *testing.T, db *gorm.DB) map[string][]string {
+	t.Helper()
+	var tables []string
+	if err := db.Raw("SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT IN ('sqlite_sequence', 'schema_migrations')").
+		Scan(&tables).Error; err != nil {
+		t.Fatal(err)
+	}
+	schema := make(map[string][]string, len(tables))
+	for _, table := range tables {
+		var columns []string
+		if err := db.Raw("SELECT name || ' ' || type || ' ' || \"notnull\" || ' ' || ifnull(dflt_value, '') || ' ' || pk "+
+			"FROM pragma_table_info(?) ORDER BY name", table).Scan(&columns).Error; err != nil {
+			t.Fatal(err)
+		}
+		var keys []string
+		if err := db.Raw("SELECT \"from\" || ' -> ' || \"table\" || '.' || \"to\" FROM pragma_foreign_key_list(?) ORDER BY 1", table).
+			Scan(&keys).Error; err != nil {
+			t.Fatal(err)
+		}
+		var indexes []string
+		if err := db.Raw("SELECT name || ' ' || \"unique\" || ' ' || partial FROM pragma_index_list(?) WHERE origin = 'c' ORDER BY name", table).
+			Scan(&indexes).Error; err != nil {
+			t.Fatal(err)
+		}
+		schema[table] = append(append(columns, keys...), indexes...)
+	}
+	return schema
+}
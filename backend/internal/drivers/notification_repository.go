@@ -0,0 +1,142 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository создаёт новый репозиторий уведомлений
+func NewNotificationRepository(db *gorm.DB) interfaces.NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+// Create сохраняет уведомление
+func (r *notificationRepository) Create(ctx context.Context, n *models.Notification) error {
+	if n == nil {
+		return errors.New("notification cannot be nil")
+	}
+	if n.UserID == 0 {
+		return errors.New("recipient is required")
+	}
+	if err := conn(ctx, r.db).Create(n).Error; err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+	return nil
+}
+
+// List возвращает уведомления пользователя, новые сверху
+func (r *notificationRepository) List(ctx context.Context, userID uint, unreadOnly bool, limit, offset int) ([]models.Notification, error) {
+	query := conn(ctx, r.db).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var items []models.Notification
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	return items, nil
+}
+
+// CountUnread возвращает число непрочитанных уведомлений
+func (r *notificationRepository) CountUnread(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	if err := conn(ctx, r.db).
+		Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+	return count, nil
+}
+
+// MarkRead отмечает прочитанными уведомления пользователя; ids == nil - все
+func (r *notificationRepository) MarkRead(ctx context.Context, userID uint, ids []uint, at time.Time) (int64, error) {
+	query := conn(ctx, r.db).
+		Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID)
+	if ids != nil {
+		query = query.Where("id IN ?", ids)
+	}
+
+	result := query.Update("read_at", at)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to mark notifications as read: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// GetPreferences возвращает сохранённые настройки пользователя
+func (r *notificationRepository) GetPreferences(ctx context.Context, userID uint) ([]models.NotificationPreference, error) {
+	var prefs []models.NotificationPreference
+	if err := conn(ctx, r.db).Where("user_id = ?", userID).Find(&prefs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	return prefs, nil
+}
+
+// SavePreferences создаёт или обновляет настройки
+func (r *notificationRepository) SavePreferences(ctx context.Context, prefs []models.NotificationPreference) error {
+	if len(prefs) == 0 {
+		return nil
+	}
+	result := conn(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "event"}},
+			DoUpdates: clause.AssignmentColumns([]string{"enabled"}),
+		}).
+		Create(&prefs)
+	if result.Error != nil {
+		return fmt.Errorf("failed to save notification preferences: %w", result.Error)
+	}
+	return nil
+}
+
+// IsEnabled сообщает, хочет ли пользователь получать события этого вида
+func (r *notificationRepository) IsEnabled(ctx context.Context, userID uint, event models.EventType) (bool, error) {
+	var prefs []models.NotificationPreference
+	if err := conn(ctx, r.db).
+		Where("user_id = ? AND event = ?", userID, event).
+		Limit(1).
+		Find(&prefs).Error; err != nil {
+		return false, fmt.Errorf("failed to get notification preference: %w", err)
+	}
+	if len(prefs) == 0 {
+		return true, nil
+	}
+	return prefs[0].Enabled, nil
+}
+
+// ListUnreadBetween возвращает непрочитанные уведомления, созданные в (since, until], по порядку
+func (r *notificationRepository) ListUnreadBetween(ctx context.Context, userID uint, since, until time.Time) ([]models.Notification, error) {
+	var items []models.Notification
+	if err := conn(ctx, r.db).
+		Where("user_id = ? AND read_at IS NULL AND created_at > ? AND created_at <= ?", userID, since, until).
+		Order("id").
+		Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	return items, nil
+}
+
+// MarkReminded сохраняет отметку о напоминании; false - о сроке уже напоминали
+func (r *notificationRepository) MarkReminded(ctx context.Context, reminder *models.DeadlineReminder) (bool, error) {
+	result := conn(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(reminder)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to save reminder: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -0,0 +1,91 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"gorm.io/gorm"
+)
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository создаёт новый репозиторий outbox
+func NewOutboxRepository(db *gorm.DB) interfaces.OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+// Create записывает событие в outbox
+func (r *outboxRepository) Create(ctx context.Context, event *models.OutboxEvent) error {
+	if event == nil {
+		return errors.New("outbox event cannot be nil")
+	}
+	if err := conn(ctx, r.db).Create(event).Error; err != nil {
+		return fmt.Errorf("failed to write outbox event: %w", err)
+	}
+	return nil
+}
+
+// ClaimDue забирает события условным UPDATE, как ClaimDue у писем: одно событие не возьмут
+// два диспетчера, а зависшие в processing возвращаются по staleAfter
+func (r *outboxRepository) ClaimDue(ctx context.Context, now time.Time, limit int, staleAfter time.Duration) ([]models.OutboxEvent, error) {
+	db := conn(ctx, r.db)
+	staleBefore := now.Add(-staleAfter)
+	const due = "(status = ? AND next_attempt_at <= ?) OR (status = ? AND updated_at < ?)"
+
+	var candidates []models.OutboxEvent
+	result := db.
+		Where(due, models.OutboxPending, now, models.OutboxProcessing, staleBefore).
+		Order("id").
+		Limit(limit).
+		Find(&candidates)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get due outbox events: %w", result.Error)
+	}
+
+	claimed := make([]models.OutboxEvent, 0, len(candidates))
+	for _, event := range candidates {
+		res := db.Model(&models.OutboxEvent{}).
+			Where("id = ? AND ("+due+")", event.ID, models.OutboxPending, now, models.OutboxProcessing, staleBefore).
+			Updates(map[string]interface{}{
+				"status":     models.OutboxProcessing,
+				"attempts":   gorm.Expr("attempts + 1"),
+				"updated_at": time.Now(),
+			})
+		if res.Error != nil {
+			return nil, fmt.Errorf("failed to claim outbox event: %w", res.Error)
+		}
+		if res.RowsAffected == 1 {
+			event.Status = models.OutboxProcessing
+			event.Attempts++
+			claimed = append(claimed, event)
+		}
+	}
+	return claimed, nil
+}
+
+// SaveResult сохраняет итог доставки события
+func (r *outboxRepository) SaveResult(ctx context.Context, event *models.OutboxEvent) error {
+	result := conn(ctx, r.db).Model(&models.OutboxEvent{}).
+		Where("id = ?", event.ID).
+		Updates(map[string]interface{}{
+			"status":          event.Status,
+			"next_attempt_at": event.NextAttemptAt,
+			"handled":         event.Handled,
+			"last_error":      event.LastError,
+			"processed_at":    event.ProcessedAt,
+			"updated_at":      time.Now(),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to save outbox event: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("outbox event with ID %d not found", event.ID)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"gorm.io/gorm"
+)
+
+type selectionPreferenceRepository struct {
+	db *gorm.DB
+}
+
+// NewSelectionPreferenceRepository создаёт новый репозиторий предпочтений и рейтингов
+func NewSelectionPreferenceRepository(db *gorm.DB) interfaces.SelectionPreferenceRepository {
+	return &selectionPreferenceRepository{db: db}
+}
+
+// ReplaceStudentPreferences полностью заменяет список предпочтений студента в раунде
+func (r *selectionPreferenceRepository) ReplaceStudentPreferences(ctx context.Context, roundID, studentID uint, prefs []models.TopicPreference) error {
+	if roundID == 0 || studentID == 0 {
+		return errors.New("round ID and student ID are required")
+	}
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("round_id = ? AND student_id = ?", roundID, studentID).
+			Delete(&models.TopicPreference{}).Error; err != nil {
+			return fmt.Errorf("failed to clear preferences: %w", err)
+		}
+		if len(prefs) == 0 {
+			return nil
+		}
+		for i := range prefs {
+			prefs[i].ID = 0
+			prefs[i].RoundID = roundID
+			prefs[i].StudentID = studentID
+		}
+		if err := tx.Omit("Student", "Coursework").Create(&prefs).Error; err != nil {
+			return fmt.Errorf("failed to save preferences: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetStudentPreferences возвращает предпочтения студента по порядку
+func (r *selectionPreferenceRepository) GetStudentPreferences(ctx context.Context, roundID, studentID uint) ([]models.TopicPreference, error) {
+	var list []models.TopicPreference
+	result := conn(ctx, r.db).
+		Preload("Coursework.Teacher").
+		Where("round_id = ? AND student_id = ?", roundID, studentID).
+		Order("rank").
+		Find(&list)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get student preferences: %w", result.Error)
+	}
+	return list, nil
+}
+
+// GetRoundPreferences возвращает все предпочтения раунда
+func (r *selectionPreferenceRepository) GetRoundPreferences(ctx context.Context, roundID uint) ([]models.TopicPreference, error) {
+	var list []models.TopicPreference
+	result := conn(ctx, r.db).
+		Preload("Student").
+		Where("round_id = ?", roundID).
+		Order("student_id, rank").
+		Find(&list)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get round preferences: %w", result.Error)
+	}
+	return list, nil
+}
+
+// GetCourseworkApplicants возвращает студентов, выбравших тему
+func (r *selectionPreferenceRepository) GetCourseworkApplicants(ctx context.Context, roundID, courseworkID uint) ([]models.TopicPreference, error) {
+	var list []models.TopicPreference
+	result := conn(ctx, r.db).
+		Preload("Student").
+		Where("round_id = ? AND coursework_id = ?", roundID, courseworkID).
+		Order("rank, student_id").
+		Find(&list)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get coursework applicants: %w", result.Error)
+	}
+	return list, nil
+}
+
+// ReplaceApplicantRankings полностью заменяет рейтинг претендентов на тему
+func (r *selectionPreferenceRepository) ReplaceApplicantRankings(ctx context.Context, roundID, courseworkID uint, rankings []models.ApplicantRanking) error {
+	if roundID == 0 || courseworkID == 0 {
+		return errors.New("round ID and coursework ID are required")
+	}
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("round_id = ? AND coursework_id = ?", roundID, courseworkID).
+			Delete(&models.ApplicantRanking{}).Error; err != nil {
+			return fmt.Errorf("failed to clear rankings: %w", err)
+		}
+		if len(rankings) == 0 {
+			return nil
+		}
+		for i := range rankings {
+			rankings[i].ID = 0
+			rankings[i].RoundID = roundID
+			rankings[i].CourseworkID = courseworkID
+		}
+		if err := tx.Create(&rankings).Error; err != nil {
+			return fmt.Errorf("failed to save rankings: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetRoundRankings возвращает все рейтинги руководителей в раунде
+func (r *selectionPreferenceRepository) GetRoundRankings(ctx context.Context, roundID uint) ([]models.ApplicantRanking, error) {
+	var list []models.ApplicantRanking
+	result := conn(ctx, r.db).
+		Where("round_id = ?", roundID).
+		Order("coursework_id, rank").
+		Find(&list)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get round rankings: %w", result.Error)
+	}
+	return list, nil
+}
@@ -0,0 +1,102 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"gorm.io/gorm"
+)
+
+type selectionRoundRepository struct {
+	db *gorm.DB
+}
+
+// NewSelectionRoundRepository создаёт новый репозиторий раундов выбора тем
+func NewSelectionRoundRepository(db *gorm.DB) interfaces.SelectionRoundRepository {
+	return &selectionRoundRepository{db: db}
+}
+
+// Create создаёт новый раунд
+func (r *selectionRoundRepository) Create(ctx context.Context, round *models.SelectionRound) error {
+	if round == nil {
+		return errors.New("round cannot be nil")
+	}
+	if round.SubjectID == 0 {
+		return errors.New("subject ID is required")
+	}
+	if round.MaxChoices < 1 {
+		return errors.New("max_choices must be at least 1")
+	}
+
+	result := conn(ctx, r.db).Create(round)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create selection round: %w", result.Error)
+	}
+	return nil
+}
+
+// GetByID возвращает раунд по ID вместе с дисциплиной
+func (r *selectionRoundRepository) GetByID(ctx context.Context, id uint) (*models.SelectionRound, error) {
+	if id == 0 {
+		return nil, errors.New("invalid round ID")
+	}
+
+	var round models.SelectionRound
+	result := conn(ctx, r.db).Preload("Subject").First(&round, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("selection round with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get selection round by ID: %w", result.Error)
+	}
+	return &round, nil
+}
+
+// Update сохраняет изменения раунда
+func (r *selectionRoundRepository) Update(ctx context.Context, round *models.SelectionRound) error {
+	if round == nil || round.ID == 0 {
+		return errors.New("invalid round")
+	}
+
+	result := conn(ctx, r.db).Omit("Subject").Save(round)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update selection round: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("selection round with ID %d not found", round.ID)
+	}
+	return nil
+}
+
+// List возвращает все раунды, начиная с последних
+func (r *selectionRoundRepository) List(ctx context.Context) ([]models.SelectionRound, error) {
+	var rounds []models.SelectionRound
+	result := conn(ctx, r.db).Preload("Subject").Order("created_at DESC").Find(&rounds)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list selection rounds: %w", result.Error)
+	}
+	return rounds, nil
+}
+
+// GetActiveBySubject возвращает открытый или закрытый, но не зафиксированный раунд дисциплины
+func (r *selectionRoundRepository) GetActiveBySubject(ctx context.Context, subjectID uint) (*models.SelectionRound, error) {
+	if subjectID == 0 {
+		return nil, errors.New("invalid subject ID")
+	}
+
+	var round models.SelectionRound
+	result := conn(ctx, r.db).
+		Where("subject_id = ? AND status IN ?", subjectID, []models.RoundStatus{models.RoundOpen, models.RoundClosed}).
+		First(&round)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("no active selection round for subject %d", subjectID)
+		}
+		return nil, fmt.Errorf("failed to get active selection round: %w", result.Error)
+	}
+	return &round, nil
+}
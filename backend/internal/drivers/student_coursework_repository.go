@@ -28,22 +28,98 @@ func (r *studentCourseworkRepository) Create(ctx context.Context, assignment *mo
 	if assignment.StudentID == 0 || assignment.CourseworkID == 0 {
 		return errors.New("student ID and coursework ID are required")
 	}
+	// назначение относится к семестру темы
+	if assignment.TermID == 0 {
+		var termIDs []uint
+		if err := conn(ctx, r.db).Model(&models.Coursework{}).
+			Where("id = ?", assignment.CourseworkID).Pluck("COALESCE(term_id, 0)", &termIDs).Error; err != nil {
+			return fmt.Errorf("failed to get coursework term: %w", err)
+		}
+		if len(termIDs) > 0 {
+			assignment.TermID = termIDs[0]
+		}
+	}
 
-	result := r.db.WithContext(ctx).Create(assignment)
+	result := conn(ctx, r.db).Create(assignment)
 	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return errStudentAlreadyAssigned
+		}
 		return fmt.Errorf("failed to create student coursework: %w", result.Error)
 	}
 	return nil
 }
 
+// errStudentAlreadyAssigned - нарушение уникальности действующего назначения студента в семестре
+var errStudentAlreadyAssigned = errors.New("student already has an assigned coursework")
+
+// CreateIfSlotAvailable вставляет назначение, если у темы остались места. Семестр берётся
+// из темы в том же запросе (тема без семестра - 0, чтобы действовал уникальный индекс),
+// ID нового назначения возвращает RETURNING.
+func (r *studentCourseworkRepository) CreateIfSlotAvailable(ctx context.Context, assignment *models.StudentCoursework, reserved int) (bool, error) {
+	if assignment == nil {
+		return false, errors.New("assignment cannot be nil")
+	}
+	if assignment.StudentID == 0 || assignment.CourseworkID == 0 {
+		return false, errors.New("student ID and coursework ID are required")
+	}
+	now := time.Now()
+	if assignment.CreatedAt.IsZero() {
+		assignment.CreatedAt = now
+	}
+	if assignment.UpdatedAt.IsZero() {
+		assignment.UpdatedAt = now
+	}
+	if assignment.Status == "" {
+		assignment.Status = models.StatusAssigned
+	}
+
+	var rows []struct {
+		ID     uint
+		TermID uint
+	}
+	err := conn(ctx, r.db).Raw(`
+		INSERT INTO student_courseworks (created_at, updated_at, student_id, coursework_id, term_id, status)
+		SELECT ?, ?, ?, c.id, COALESCE(c.term_id, 0), ?
+		FROM courseworks c
+		WHERE c.id = ? AND c.deleted_at IS NULL
+		  AND (SELECT COUNT(*) FROM student_courseworks sc
+		       WHERE sc.coursework_id = c.id AND sc.deleted_at IS NULL) + ? < c.max_students
+		RETURNING id, term_id`,
+		assignment.CreatedAt, assignment.UpdatedAt, assignment.StudentID, assignment.Status,
+		assignment.CourseworkID, reserved,
+	).Scan(&rows).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return false, errStudentAlreadyAssigned
+		}
+		return false, fmt.Errorf("failed to create student coursework: %w", err)
+	}
+	if len(rows) == 0 {
+		return false, nil
+	}
+	assignment.ID = rows[0].ID
+	assignment.TermID = rows[0].TermID
+	return true, nil
+}
+
 // GetByID возвращает назначение по его ID
 func (r *studentCourseworkRepository) GetByID(ctx context.Context, id uint) (*models.StudentCoursework, error) {
+	return r.getByID(conn(ctx, r.db), id)
+}
+
+// GetByIDWithDeleted возвращает назначение по его ID, в том числе отменённое
+func (r *studentCourseworkRepository) GetByIDWithDeleted(ctx context.Context, id uint) (*models.StudentCoursework, error) {
+	return r.getByID(conn(ctx, r.db).Unscoped(), id)
+}
+
+func (r *studentCourseworkRepository) getByID(db *gorm.DB, id uint) (*models.StudentCoursework, error) {
 	if id == 0 {
 		return nil, errors.New("invalid ID")
 	}
 
 	var sc models.StudentCoursework
-	result := r.db.WithContext(ctx).
+	result := db.
 		Preload("Student").
 		Preload("Coursework").
 		First(&sc, id)
@@ -57,17 +133,25 @@ func (r *studentCourseworkRepository) GetByID(ctx context.Context, id uint) (*mo
 	return &sc, nil
 }
 
-// GetByStudent возвращает единственное назначение по студенту
+// GetByStudent возвращает назначение студента в текущем семестре
 func (r *studentCourseworkRepository) GetByStudent(ctx context.Context, studentID uint) (*models.StudentCoursework, error) {
 	if studentID == 0 {
 		return nil, errors.New("invalid student ID")
 	}
 
-	var sc models.StudentCoursework
-	result := r.db.WithContext(ctx).
+	termID, err := activeTermID(conn(ctx, r.db))
+	if err != nil {
+		return nil, err
+	}
+	query := conn(ctx, r.db).
 		Preload("Coursework").
-		Where("student_id = ?", studentID).
-		First(&sc)
+		Where("student_id = ?", studentID)
+	if termID != 0 {
+		query = query.Where("term_id = ?", termID)
+	}
+
+	var sc models.StudentCoursework
+	result := query.Order("id DESC").First(&sc)
 
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
@@ -85,7 +169,7 @@ func (r *studentCourseworkRepository) GetByCoursework(ctx context.Context, cours
 	}
 
 	var list []models.StudentCoursework
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Preload("Student").
 		Where("coursework_id = ?", courseworkID).
 		Find(&list)
@@ -102,7 +186,7 @@ func (r *studentCourseworkRepository) Update(ctx context.Context, assignment *mo
 		return errors.New("invalid assignment")
 	}
 
-	result := r.db.WithContext(ctx).Save(assignment)
+	result := conn(ctx, r.db).Save(assignment)
 	if result.Error != nil {
 		return fmt.Errorf("failed to update student coursework: %w", result.Error)
 	}
@@ -118,7 +202,7 @@ func (r *studentCourseworkRepository) Delete(ctx context.Context, id uint) error
 		return errors.New("invalid ID")
 	}
 
-	result := r.db.WithContext(ctx).Delete(&models.StudentCoursework{}, id)
+	result := conn(ctx, r.db).Delete(&models.StudentCoursework{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete student coursework: %w", result.Error)
 	}
@@ -140,7 +224,7 @@ func (r *studentCourseworkRepository) UpdateStatus(ctx context.Context, id uint,
 		return fmt.Errorf("invalid status: %s", status)
 	}
 
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Model(&models.StudentCoursework{}).
 		Where("id = ?", id).
 		Update("status", status)
@@ -162,7 +246,7 @@ func (r *studentCourseworkRepository) SetGrade(ctx context.Context, id uint, gra
 		return fmt.Errorf("invalid grade: %d", grade)
 	}
 
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Model(&models.StudentCoursework{}).
 		Where("id = ?", id).
 		Updates(map[string]interface{}{
@@ -184,7 +268,7 @@ func (r *studentCourseworkRepository) SetSubmitted(ctx context.Context, id uint,
 		return errors.New("invalid ID")
 	}
 
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Model(&models.StudentCoursework{}).
 		Where("id = ?", id).
 		Updates(map[string]interface{}{
@@ -206,7 +290,7 @@ func (r *studentCourseworkRepository) SetCompleted(ctx context.Context, id uint,
 		return errors.New("invalid ID")
 	}
 
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Model(&models.StudentCoursework{}).
 		Where("id = ?", id).
 		Updates(map[string]interface{}{
@@ -229,7 +313,7 @@ func (r *studentCourseworkRepository) GetByTeacher(ctx context.Context, teacherI
 	}
 
 	var list []models.StudentCoursework
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Joins("JOIN courseworks ON courseworks.id = student_courseworks.coursework_id").
 		Where("courseworks.teacher_id = ?", teacherID).
 		Preload("Student").
@@ -241,3 +325,105 @@ func (r *studentCourseworkRepository) GetByTeacher(ctx context.Context, teacherI
 	}
 	return list, nil
 }
+
+// GetBySubjectAndStatus возвращает назначения по дисциплине и семестру с указанными статусами
+func (r *studentCourseworkRepository) GetBySubjectAndStatus(ctx context.Context, subjectID, termID uint, statuses ...models.CourseworkStatus) ([]models.StudentCoursework, error) {
+	if subjectID == 0 {
+		return nil, errors.New("invalid subject ID")
+	}
+
+	query := conn(ctx, r.db).
+		Joins("JOIN courseworks ON courseworks.id = student_courseworks.coursework_id").
+		Where("courseworks.subject_id = ?", subjectID)
+	if termID != 0 {
+		query = query.Where("student_courseworks.term_id = ?", termID)
+	}
+	if len(statuses) > 0 {
+		query = query.Where("student_courseworks.status IN ?", statuses)
+	}
+
+	var list []models.StudentCoursework
+	result := query.
+		Preload("Student").
+		Preload("Coursework").
+		Order("student_courseworks.submitted_at, student_courseworks.id").
+		Find(&list)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get by subject and status: %w", result.Error)
+	}
+	return list, nil
+}
+
+// CountByTeacher считает студентов под руководством преподавателя в семестрах учебного года
+func (r *studentCourseworkRepository) CountByTeacher(ctx context.Context, teacherID, academicYearID uint) (int, error) {
+	if teacherID == 0 {
+		return 0, errors.New("invalid teacher ID")
+	}
+
+	var count int64
+	result := conn(ctx, r.db).
+		Model(&models.StudentCoursework{}).
+		Joins("JOIN courseworks ON courseworks.id = student_courseworks.coursework_id").
+		Where("courseworks.teacher_id = ? AND student_courseworks.term_id IN (?)", teacherID,
+			conn(ctx, r.db).Model(&models.Term{}).Select("id").Where("academic_year_id = ?", academicYearID)).
+		Count(&count)
+
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to count supervised students: %w", result.Error)
+	}
+	return int(count), nil
+}
+
+// GetHistoryByStudent возвращает назначения студента за все семестры или за указанный
+func (r *studentCourseworkRepository) GetHistoryByStudent(ctx context.Context, studentID uint, termID *uint) ([]models.StudentCoursework, error) {
+	if studentID == 0 {
+		return nil, errors.New("invalid student ID")
+	}
+
+	query := conn(ctx, r.db).Where("student_id = ?", studentID)
+	if termID != nil {
+		query = query.Where("term_id = ?", *termID)
+	}
+
+	var list []models.StudentCoursework
+	result := query.
+		Preload("Coursework").
+		Preload("Coursework.Subject").
+		Preload("Coursework.Teacher").
+		Preload("Student").
+		Order("term_id DESC, id DESC").
+		Find(&list)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get student history: %w", result.Error)
+	}
+	return list, nil
+}
+
+// GetGradebook возвращает назначения по дисциплине в семестре (termID = 0 - без учёта семестра)
+// вместе с руководителями тем
+func (r *studentCourseworkRepository) GetGradebook(ctx context.Context, subjectID, termID uint) ([]models.StudentCoursework, error) {
+	if subjectID == 0 {
+		return nil, errors.New("invalid subject ID")
+	}
+
+	query := conn(ctx, r.db).
+		Joins("JOIN courseworks ON courseworks.id = student_courseworks.coursework_id").
+		Where("courseworks.subject_id = ?", subjectID)
+	if termID != 0 {
+		query = query.Where("student_courseworks.term_id = ?", termID)
+	}
+
+	var list []models.StudentCoursework
+	result := query.
+		Preload("Student").
+		Preload("Coursework.Teacher").
+		Order("student_courseworks.id").
+		Find(&list)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get gradebook: %w", result.Error)
+	}
+	return list, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/Foxpunk/courseforge/internal/interfaces"
 	"github.com/Foxpunk/courseforge/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // studentGroupRepository — реализация interfaces.StudentGroupRepository на GORM
@@ -29,7 +30,7 @@ func (r *studentGroupRepository) Create(ctx context.Context, group *models.Stude
 		return errors.New("group code cannot be empty")
 	}
 
-	result := r.db.WithContext(ctx).Create(group)
+	result := conn(ctx, r.db).Create(group)
 	if result.Error != nil {
 		return fmt.Errorf("failed to create student group: %w", result.Error)
 	}
@@ -43,7 +44,7 @@ func (r *studentGroupRepository) GetByID(ctx context.Context, id uint) (*models.
 	}
 
 	var group models.StudentGroup
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Preload("Department").
 		First(&group, id)
 
@@ -63,14 +64,14 @@ func (r *studentGroupRepository) GetByCode(ctx context.Context, code string) (*m
 	}
 
 	var group models.StudentGroup
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Preload("Department").
 		Where("group_code = ?", code).
 		First(&group)
 
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("student group with code %q not found", code)
+			return nil, fmt.Errorf("student group with code %q %w", code, interfaces.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get student group by code: %w", result.Error)
 	}
@@ -86,7 +87,7 @@ func (r *studentGroupRepository) Update(ctx context.Context, group *models.Stude
 		return errors.New("group ID cannot be zero")
 	}
 
-	result := r.db.WithContext(ctx).Save(group)
+	result := conn(ctx, r.db).Omit(clause.Associations).Save(group)
 	if result.Error != nil {
 		return fmt.Errorf("failed to update student group: %w", result.Error)
 	}
@@ -102,7 +103,7 @@ func (r *studentGroupRepository) Delete(ctx context.Context, id uint) error {
 		return errors.New("invalid group ID")
 	}
 
-	result := r.db.WithContext(ctx).Delete(&models.StudentGroup{}, id)
+	result := conn(ctx, r.db).Delete(&models.StudentGroup{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete student group: %w", result.Error)
 	}
@@ -115,7 +116,7 @@ func (r *studentGroupRepository) Delete(ctx context.Context, id uint) error {
 // List возвращает все группы (без пагинации)
 func (r *studentGroupRepository) List(ctx context.Context) ([]models.StudentGroup, error) {
 	var groups []models.StudentGroup
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Preload("Department").
 		Find(&groups)
 
@@ -132,7 +133,7 @@ func (r *studentGroupRepository) GetByDepartment(ctx context.Context, department
 	}
 
 	var groups []models.StudentGroup
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Preload("Department").
 		Where("department_id = ?", departmentID).
 		Find(&groups)
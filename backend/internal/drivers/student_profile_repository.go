@@ -8,6 +8,7 @@ import (
 	"github.com/Foxpunk/courseforge/internal/interfaces"
 	"github.com/Foxpunk/courseforge/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // studentProfileRepository — реализация interfaces.StudentProfileRepository на GORM
@@ -34,7 +35,7 @@ func (r *studentProfileRepository) Create(ctx context.Context, profile *models.S
 		return errors.New("group ID is required")
 	}
 
-	result := r.db.WithContext(ctx).Create(profile)
+	result := conn(ctx, r.db).Create(profile)
 	if result.Error != nil {
 		return fmt.Errorf("failed to create student profile: %w", result.Error)
 	}
@@ -48,15 +49,15 @@ func (r *studentProfileRepository) GetByUserID(ctx context.Context, userID uint)
 	}
 
 	var profile models.StudentProfile
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Preload("User").
-		Preload("StudentGroup").
+		Preload("StudentGroup.Department").
 		Where("user_id = ?", userID).
 		First(&profile)
 
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("student profile for user %d not found", userID)
+			return nil, fmt.Errorf("student profile for user %d %w", userID, interfaces.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get student profile by user ID: %w", result.Error)
 	}
@@ -70,9 +71,9 @@ func (r *studentProfileRepository) GetByID(ctx context.Context, id uint) (*model
 	}
 
 	var profile models.StudentProfile
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Preload("User").
-		Preload("StudentGroup").
+		Preload("StudentGroup.Department").
 		First(&profile, id)
 
 	if result.Error != nil {
@@ -93,7 +94,7 @@ func (r *studentProfileRepository) Update(ctx context.Context, profile *models.S
 		return errors.New("profile ID is required")
 	}
 
-	result := r.db.WithContext(ctx).Save(profile)
+	result := conn(ctx, r.db).Omit(clause.Associations).Save(profile)
 	if result.Error != nil {
 		return fmt.Errorf("failed to update student profile: %w", result.Error)
 	}
@@ -103,13 +104,14 @@ func (r *studentProfileRepository) Update(ctx context.Context, profile *models.S
 	return nil
 }
 
-// Delete выполняет мягкое удаление профиля по ID
+// Delete удаляет профиль студента по ID
 func (r *studentProfileRepository) Delete(ctx context.Context, id uint) error {
 	if id == 0 {
 		return errors.New("invalid profile ID")
 	}
 
-	result := r.db.WithContext(ctx).Delete(&models.StudentProfile{}, id)
+	// удаляем безвозвратно: user_id уникален, и профиль должен заводиться заново
+	result := conn(ctx, r.db).Unscoped().Delete(&models.StudentProfile{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete student profile: %w", result.Error)
 	}
@@ -126,9 +128,9 @@ func (r *studentProfileRepository) GetByGroup(ctx context.Context, groupID uint)
 	}
 
 	var profiles []models.StudentProfile
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Preload("User").
-		Preload("StudentGroup").
+		Preload("StudentGroup.Department").
 		Where("group_id = ?", groupID).
 		Find(&profiles)
 
@@ -137,3 +139,22 @@ func (r *studentProfileRepository) GetByGroup(ctx context.Context, groupID uint)
 	}
 	return profiles, nil
 }
+
+// GetByUserIDs возвращает профили указанных студентов вместе с группами
+func (r *studentProfileRepository) GetByUserIDs(ctx context.Context, userIDs []uint) ([]models.StudentProfile, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	var profiles []models.StudentProfile
+	result := conn(ctx, r.db).
+		Preload("User").
+		Preload("StudentGroup.Department").
+		Where("user_id IN ?", userIDs).
+		Find(&profiles)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get student profiles by users: %w", result.Error)
+	}
+	return profiles, nil
+}
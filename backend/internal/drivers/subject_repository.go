@@ -31,7 +31,7 @@ func (r *subjectRepository) Create(ctx context.Context, subject *models.Subject)
 		return errors.New("semester must be >= 1")
 	}
 
-	result := r.db.WithContext(ctx).Create(subject)
+	result := conn(ctx, r.db).Create(subject)
 	if result.Error != nil {
 		return fmt.Errorf("failed to create subject: %w", result.Error)
 	}
@@ -45,7 +45,7 @@ func (r *subjectRepository) GetByID(ctx context.Context, id uint) (*models.Subje
 	}
 
 	var subj models.Subject
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Preload("Teachers").
 		Preload("Courseworks").
 		First(&subj, id)
@@ -68,7 +68,7 @@ func (r *subjectRepository) Update(ctx context.Context, subject *models.Subject)
 		return errors.New("subject ID cannot be zero")
 	}
 
-	result := r.db.WithContext(ctx).Save(subject)
+	result := conn(ctx, r.db).Save(subject)
 	if result.Error != nil {
 		return fmt.Errorf("failed to update subject: %w", result.Error)
 	}
@@ -84,7 +84,7 @@ func (r *subjectRepository) Delete(ctx context.Context, id uint) error {
 		return errors.New("invalid subject ID")
 	}
 
-	result := r.db.WithContext(ctx).Delete(&models.Subject{}, id)
+	result := conn(ctx, r.db).Delete(&models.Subject{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete subject: %w", result.Error)
 	}
@@ -97,7 +97,7 @@ func (r *subjectRepository) Delete(ctx context.Context, id uint) error {
 // List возвращает все дисциплины (без фильтрации)
 func (r *subjectRepository) List(ctx context.Context) ([]models.Subject, error) {
 	var subjects []models.Subject
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Preload("Teachers").
 		Preload("Courseworks").
 		Find(&subjects)
@@ -115,7 +115,7 @@ func (r *subjectRepository) GetByDepartment(ctx context.Context, departmentID ui
 	}
 
 	var subjects []models.Subject
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Where("department_id = ?", departmentID).
 		Preload("Teachers").
 		Preload("Courseworks").
@@ -134,7 +134,7 @@ func (r *subjectRepository) GetBySemester(ctx context.Context, semester int) ([]
 	}
 
 	var subjects []models.Subject
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Where("semester = ?", semester).
 		Preload("Teachers").
 		Preload("Courseworks").
@@ -152,7 +152,7 @@ func (r *subjectRepository) SetActive(ctx context.Context, subjectID uint, activ
 		return errors.New("invalid subject ID")
 	}
 
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Model(&models.Subject{}).
 		Where("id = ?", subjectID).
 		Update("is_active", active)
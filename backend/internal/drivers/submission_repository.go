@@ -0,0 +1,225 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type submissionRepository struct {
+	db *gorm.DB
+}
+
+// NewSubmissionRepository создаёт новый репозиторий сданных работ
+func NewSubmissionRepository(db *gorm.DB) interfaces.SubmissionRepository {
+	return &submissionRepository{db: db}
+}
+
+// Create сохраняет сданную работу
+func (r *submissionRepository) Create(ctx context.Context, sub *models.Submission) error {
+	if sub == nil {
+		return errors.New("submission cannot be nil")
+	}
+	if sub.StudentCourseworkID == 0 || sub.StorageKey == "" {
+		return errors.New("assignment and storage key are required")
+	}
+
+	result := conn(ctx, r.db).Omit(clause.Associations).Create(sub)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create submission: %w", result.Error)
+	}
+	return nil
+}
+
+// GetByID возвращает работу со студентом и темой
+func (r *submissionRepository) GetByID(ctx context.Context, id uint) (*models.Submission, error) {
+	if id == 0 {
+		return nil, errors.New("invalid submission ID")
+	}
+
+	var sub models.Submission
+	result := conn(ctx, r.db).
+		Preload("Student").
+		Preload("Coursework").
+		First(&sub, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("submission with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get submission: %w", result.Error)
+	}
+	return &sub, nil
+}
+
+// ListByStudent возвращает работы студента за все семестры, новые сверху
+func (r *submissionRepository) ListByStudent(ctx context.Context, studentID uint) ([]models.Submission, error) {
+	var list []models.Submission
+	result := conn(ctx, r.db).
+		Preload("Coursework").
+		Where("student_id = ?", studentID).
+		Order("id DESC").
+		Find(&list)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list student submissions: %w", result.Error)
+	}
+	return list, nil
+}
+
+// ListByCoursework возвращает работы по теме, новые сверху
+func (r *submissionRepository) ListByCoursework(ctx context.Context, courseworkID uint) ([]models.Submission, error) {
+	var list []models.Submission
+	result := conn(ctx, r.db).
+		Preload("Student").
+		Where("coursework_id = ?", courseworkID).
+		Order("id DESC").
+		Find(&list)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list coursework submissions: %w", result.Error)
+	}
+	return list, nil
+}
+
+// ClaimPending забирает работы из очереди. Статус меняется условным UPDATE, поэтому
+// одну работу не возьмут два обработчика; зависшие в running после падения сервера
+// возвращаются в обработку по staleAfter.
+func (r *submissionRepository) ClaimPending(ctx context.Context, limit int, staleAfter time.Duration) ([]models.Submission, error) {
+	db := conn(ctx, r.db)
+	staleBefore := time.Now().Add(-staleAfter)
+
+	var candidates []models.Submission
+	result := db.
+		Where("check_status = ? OR (check_status = ? AND updated_at < ?)", models.CheckPending, models.CheckRunning, staleBefore).
+		Order("id").
+		Limit(limit).
+		Find(&candidates)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get pending submissions: %w", result.Error)
+	}
+
+	claimed := make([]models.Submission, 0, len(candidates))
+	for _, sub := range candidates {
+		res := db.Model(&models.Submission{}).
+			Where("id = ? AND (check_status = ? OR (check_status = ? AND updated_at < ?))", sub.ID, models.CheckPending, models.CheckRunning, staleBefore).
+			Updates(map[string]interface{}{"check_status": models.CheckRunning, "updated_at": time.Now()})
+		if res.Error != nil {
+			return nil, fmt.Errorf("failed to claim submission: %w", res.Error)
+		}
+		if res.RowsAffected == 1 {
+			sub.CheckStatus = models.CheckRunning
+			claimed = append(claimed, sub)
+		}
+	}
+	return claimed, nil
+}
+
+// SetCheckStatus меняет состояние проверки
+func (r *submissionRepository) SetCheckStatus(ctx context.Context, id uint, status models.SimilarityCheckStatus, checkErr string) error {
+	result := conn(ctx, r.db).Model(&models.Submission{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"check_status": status, "check_error": checkErr})
+	if result.Error != nil {
+		return fmt.Errorf("failed to set check status: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("submission with ID %d not found", id)
+	}
+	return nil
+}
+
+// SaveFingerprint сохраняет или заменяет отпечаток работы
+func (r *submissionRepository) SaveFingerprint(ctx context.Context, fp *models.SubmissionFingerprint) error {
+	if fp == nil || fp.SubmissionID == 0 {
+		return errors.New("invalid fingerprint")
+	}
+	if err := conn(ctx, r.db).Save(fp).Error; err != nil {
+		return fmt.Errorf("failed to save fingerprint: %w", err)
+	}
+	return nil
+}
+
+// GetFingerprint возвращает отпечаток вместе с текстом работы
+func (r *submissionRepository) GetFingerprint(ctx context.Context, submissionID uint) (*models.SubmissionFingerprint, error) {
+	var fp models.SubmissionFingerprint
+	result := conn(ctx, r.db).First(&fp, "submission_id = ?", submissionID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("fingerprint of submission %d not found", submissionID)
+		}
+		return nil, fmt.Errorf("failed to get fingerprint: %w", result.Error)
+	}
+	return &fp, nil
+}
+
+// ListSignatures возвращает сигнатуры более ранних работ архива за все годы
+func (r *submissionRepository) ListSignatures(ctx context.Context, beforeSubmissionID, exceptStudentCourseworkID uint) ([]models.SubmissionFingerprint, error) {
+	var list []models.SubmissionFingerprint
+	result := conn(ctx, r.db).
+		Select("submission_fingerprints.submission_id, submission_fingerprints.shingle_count, submission_fingerprints.signature").
+		Joins("JOIN submissions ON submissions.id = submission_fingerprints.submission_id AND submissions.deleted_at IS NULL").
+		Where("submissions.id < ? AND submissions.student_coursework_id <> ?", beforeSubmissionID, exceptStudentCourseworkID).
+		Where("submission_fingerprints.shingle_count > 0").
+		Find(&list)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list signatures: %w", result.Error)
+	}
+	return list, nil
+}
+
+// SaveReport заменяет совпадения работы новыми и отмечает проверку завершённой
+func (r *submissionRepository) SaveReport(ctx context.Context, submissionID uint, score float64, matches []models.SimilarityMatch) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var oldIDs []uint
+		if err := tx.Model(&models.SimilarityMatch{}).Where("submission_id = ?", submissionID).Pluck("id", &oldIDs).Error; err != nil {
+			return fmt.Errorf("failed to get old matches: %w", err)
+		}
+		if len(oldIDs) > 0 {
+			if err := tx.Where("match_id IN ?", oldIDs).Delete(&models.SimilarityFragment{}).Error; err != nil {
+				return fmt.Errorf("failed to delete old fragments: %w", err)
+			}
+			if err := tx.Where("id IN ?", oldIDs).Delete(&models.SimilarityMatch{}).Error; err != nil {
+				return fmt.Errorf("failed to delete old matches: %w", err)
+			}
+		}
+
+		for i := range matches {
+			matches[i].SubmissionID = submissionID
+			if err := tx.Omit("MatchedSubmission").Create(&matches[i]).Error; err != nil {
+				return fmt.Errorf("failed to save match: %w", err)
+			}
+		}
+
+		now := time.Now()
+		err := tx.Model(&models.Submission{}).Where("id = ?", submissionID).Updates(map[string]interface{}{
+			"check_status":     models.CheckDone,
+			"check_error":      "",
+			"similarity_score": score,
+			"checked_at":       &now,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("failed to finish check: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetMatches возвращает совпадения работы с фрагментами, самые сильные первыми
+func (r *submissionRepository) GetMatches(ctx context.Context, submissionID uint) ([]models.SimilarityMatch, error) {
+	var list []models.SimilarityMatch
+	result := conn(ctx, r.db).
+		Preload("MatchedSubmission.Student").
+		Preload("MatchedSubmission.Coursework").
+		Preload("Fragments", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		Where("submission_id = ?", submissionID).
+		Order("similarity DESC").
+		Find(&list)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get similarity matches: %w", result.Error)
+	}
+	return list, nil
+}
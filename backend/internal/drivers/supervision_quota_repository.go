@@ -0,0 +1,91 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type supervisionQuotaRepository struct {
+	db *gorm.DB
+}
+
+// NewSupervisionQuotaRepository создаёт новый репозиторий квот руководства
+func NewSupervisionQuotaRepository(db *gorm.DB) interfaces.SupervisionQuotaRepository {
+	return &supervisionQuotaRepository{db: db}
+}
+
+// Upsert создаёт или обновляет квоту преподавателя на учебный год
+func (r *supervisionQuotaRepository) Upsert(ctx context.Context, quota *models.SupervisionQuota) error {
+	if quota == nil {
+		return errors.New("quota cannot be nil")
+	}
+	if quota.TeacherID == 0 || quota.AcademicYear == "" {
+		return errors.New("teacher ID and academic year are required")
+	}
+
+	result := conn(ctx, r.db).
+		Omit("Teacher").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "teacher_id"}, {Name: "academic_year"}},
+			DoUpdates: clause.AssignmentColumns([]string{"max_students", "max_topics", "hours_per_student", "set_by_id", "updated_at", "deleted_at"}),
+		}).
+		Create(quota)
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to save supervision quota: %w", result.Error)
+	}
+	return nil
+}
+
+// GetByTeacherAndYear возвращает квоту преподавателя на учебный год
+func (r *supervisionQuotaRepository) GetByTeacherAndYear(ctx context.Context, teacherID uint, academicYear string) (*models.SupervisionQuota, error) {
+	var quota models.SupervisionQuota
+	result := conn(ctx, r.db).
+		Where("teacher_id = ? AND academic_year = ?", teacherID, academicYear).
+		First(&quota)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("no supervision quota for teacher %d in %s", teacherID, academicYear)
+		}
+		return nil, fmt.Errorf("failed to get supervision quota: %w", result.Error)
+	}
+	return &quota, nil
+}
+
+// ListByYear возвращает все квоты учебного года
+func (r *supervisionQuotaRepository) ListByYear(ctx context.Context, academicYear string) ([]models.SupervisionQuota, error) {
+	var list []models.SupervisionQuota
+	result := conn(ctx, r.db).
+		Preload("Teacher").
+		Where("academic_year = ?", academicYear).
+		Order("teacher_id").
+		Find(&list)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list supervision quotas: %w", result.Error)
+	}
+	return list, nil
+}
+
+// Delete удаляет квоту, после чего действует лимит по умолчанию
+func (r *supervisionQuotaRepository) Delete(ctx context.Context, teacherID uint, academicYear string) error {
+	result := conn(ctx, r.db).
+		Unscoped().
+		Where("teacher_id = ? AND academic_year = ?", teacherID, academicYear).
+		Delete(&models.SupervisionQuota{})
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete supervision quota: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("no supervision quota for teacher %d in %s", teacherID, academicYear)
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/Foxpunk/courseforge/internal/interfaces"
 	"github.com/Foxpunk/courseforge/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type teacherProfileRepository struct {
@@ -28,7 +29,7 @@ func (r *teacherProfileRepository) Create(ctx context.Context, profile *models.T
 		return errors.New("user ID and department ID are required")
 	}
 
-	result := r.db.WithContext(ctx).Create(profile)
+	result := conn(ctx, r.db).Create(profile)
 	if result.Error != nil {
 		return fmt.Errorf("failed to create teacher profile: %w", result.Error)
 	}
@@ -42,7 +43,7 @@ func (r *teacherProfileRepository) GetByUserID(ctx context.Context, userID uint)
 	}
 
 	var profile models.TeacherProfile
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Preload("User").
 		Preload("Department").
 		Where("user_id = ?", userID).
@@ -50,7 +51,7 @@ func (r *teacherProfileRepository) GetByUserID(ctx context.Context, userID uint)
 
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("teacher profile for user %d not found", userID)
+			return nil, fmt.Errorf("teacher profile for user %d %w", userID, interfaces.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get teacher profile by user ID: %w", result.Error)
 	}
@@ -64,7 +65,7 @@ func (r *teacherProfileRepository) GetByID(ctx context.Context, id uint) (*model
 	}
 
 	var profile models.TeacherProfile
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Preload("User").
 		Preload("Department").
 		First(&profile, id)
@@ -84,7 +85,7 @@ func (r *teacherProfileRepository) Update(ctx context.Context, profile *models.T
 		return errors.New("invalid profile data")
 	}
 
-	result := r.db.WithContext(ctx).Save(profile)
+	result := conn(ctx, r.db).Omit(clause.Associations).Save(profile)
 	if result.Error != nil {
 		return fmt.Errorf("failed to update teacher profile: %w", result.Error)
 	}
@@ -94,13 +95,14 @@ func (r *teacherProfileRepository) Update(ctx context.Context, profile *models.T
 	return nil
 }
 
-// Delete удаляет профиль преподавателя по ID (мягкое удаление)
+// Delete удаляет профиль преподавателя по ID
 func (r *teacherProfileRepository) Delete(ctx context.Context, id uint) error {
 	if id == 0 {
 		return errors.New("invalid ID")
 	}
 
-	result := r.db.WithContext(ctx).Delete(&models.TeacherProfile{}, id)
+	// удаляем безвозвратно: user_id уникален, и профиль должен заводиться заново
+	result := conn(ctx, r.db).Unscoped().Delete(&models.TeacherProfile{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete teacher profile: %w", result.Error)
 	}
@@ -117,7 +119,7 @@ func (r *teacherProfileRepository) GetByDepartment(ctx context.Context, departme
 	}
 
 	var profiles []models.TeacherProfile
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Preload("User").
 		Preload("Department").
 		Where("department_id = ?", departmentID).
@@ -128,3 +130,18 @@ func (r *teacherProfileRepository) GetByDepartment(ctx context.Context, departme
 	}
 	return profiles, nil
 }
+
+// List возвращает профили всех преподавателей
+func (r *teacherProfileRepository) List(ctx context.Context) ([]models.TeacherProfile, error) {
+	var profiles []models.TeacherProfile
+	result := conn(ctx, r.db).
+		Preload("User").
+		Preload("Department").
+		Order("department_id, id").
+		Find(&profiles)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list teacher profiles: %w", result.Error)
+	}
+	return profiles, nil
+}
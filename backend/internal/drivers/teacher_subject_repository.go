@@ -30,14 +30,22 @@ func (r *teacherSubjectRepository) Create(ctx context.Context, assignment *model
 
 	log.Printf("Repository Create: UserID=%d, SubjectID=%d", assignment.UserID, assignment.SubjectID)
 
-	result := r.db.WithContext(ctx).Create(assignment)
-	if result.Error != nil {
-		log.Printf("GORM Create error: %v", result.Error)
-		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// новый ведущий снимает флаг с предыдущего в том же учебном году
+		if assignment.IsLead {
+			if err := clearLead(tx, assignment.SubjectID, assignment.AcademicYear); err != nil {
+				return err
+			}
+		}
+		return tx.Create(assignment).Error
+	})
+	if err != nil {
+		log.Printf("GORM Create error: %v", err)
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
 			return fmt.Errorf("assignment already exists for teacher %d, subject %d",
 				assignment.UserID, assignment.SubjectID)
 		}
-		return fmt.Errorf("failed to create teacher assignment: %w", result.Error)
+		return fmt.Errorf("failed to create teacher assignment: %w", err)
 	}
 
 	log.Printf("Repository Create: success")
@@ -54,10 +62,10 @@ func (r *teacherSubjectRepository) Delete(ctx context.Context, id uint) error {
 	return errors.New("Delete by ID not supported for composite primary key table")
 }
 
-// DeleteByTeacherAndSubject удаляет назначение по teacherID и subjectID
-func (r *teacherSubjectRepository) DeleteByTeacherAndSubject(ctx context.Context, teacherID, subjectID uint) error {
-	result := r.db.WithContext(ctx).
-		Where("user_id = ? AND subject_id = ?", teacherID, subjectID).
+// DeleteByTeacherAndSubject удаляет назначение по teacherID и subjectID в семестре
+func (r *teacherSubjectRepository) DeleteByTeacherAndSubject(ctx context.Context, teacherID, subjectID, termID uint) error {
+	result := conn(ctx, r.db).
+		Where("user_id = ? AND subject_id = ? AND term_id = ?", teacherID, subjectID, termID).
 		Delete(&models.TeacherSubject{})
 
 	if result.Error != nil {
@@ -69,16 +77,17 @@ func (r *teacherSubjectRepository) DeleteByTeacherAndSubject(ctx context.Context
 	return nil
 }
 
-// GetByTeacher возвращает назначения по преподавателю (игнорируем year)
+// GetByTeacher возвращает назначения по преподавателю за учебный год (пустой год - за все годы)
 func (r *teacherSubjectRepository) GetByTeacher(ctx context.Context, teacherID uint, year string) ([]models.TeacherSubject, error) {
 	if teacherID == 0 {
 		return nil, errors.New("teacher ID is required")
 	}
 
 	var list []models.TeacherSubject
-	result := r.db.WithContext(ctx).
+	result := r.inYear(ctx, year).
 		Preload("Subject").
-		Where("user_id = ?", teacherID).
+		Preload("Term").
+		Where("teacher_subjects.user_id = ?", teacherID).
 		Find(&list)
 
 	if result.Error != nil {
@@ -87,16 +96,17 @@ func (r *teacherSubjectRepository) GetByTeacher(ctx context.Context, teacherID u
 	return list, nil
 }
 
-// GetBySubject возвращает назначения по дисциплине (игнорируем year)
+// GetBySubject возвращает назначения по дисциплине за учебный год (пустой год - за все годы)
 func (r *teacherSubjectRepository) GetBySubject(ctx context.Context, subjectID uint, year string) ([]models.TeacherSubject, error) {
 	if subjectID == 0 {
 		return nil, errors.New("subject ID is required")
 	}
 
 	var list []models.TeacherSubject
-	result := r.db.WithContext(ctx).
+	result := r.inYear(ctx, year).
 		Preload("Teacher").
-		Where("subject_id = ?", subjectID).
+		Preload("Term").
+		Where("teacher_subjects.subject_id = ?", subjectID).
 		Find(&list)
 
 	if result.Error != nil {
@@ -105,12 +115,91 @@ func (r *teacherSubjectRepository) GetBySubject(ctx context.Context, subjectID u
 	return list, nil
 }
 
-// GetLeadTeacher не поддерживается простой таблицей
+// GetLeadTeacher возвращает назначение ведущего преподавателя дисциплины в учебном году
 func (r *teacherSubjectRepository) GetLeadTeacher(ctx context.Context, subjectID uint, year string) (*models.TeacherSubject, error) {
-	return nil, errors.New("lead teacher not supported with simple teacher_subjects table")
+	if subjectID == 0 {
+		return nil, errors.New("subject ID is required")
+	}
+
+	var lead models.TeacherSubject
+	result := conn(ctx, r.db).
+		Preload("Teacher").
+		Preload("Subject").
+		Preload("Term").
+		Where("subject_id = ? AND academic_year = ? AND is_lead = ?", subjectID, year, true).
+		First(&lead)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("lead teacher for subject %d in %q not found", subjectID, year)
+		}
+		return nil, fmt.Errorf("failed to get lead teacher: %w", result.Error)
+	}
+	return &lead, nil
 }
 
-// SetLead не поддерживается простой таблицей
-func (r *teacherSubjectRepository) SetLead(ctx context.Context, assignmentID uint, isLead bool) error {
-	return errors.New("lead teacher not supported with simple teacher_subjects table")
+// SetLead назначает или снимает ведущего преподавателя дисциплины в учебном году.
+// Снятие флага с предыдущего ведущего и установка нового выполняются в одной транзакции.
+func (r *teacherSubjectRepository) SetLead(ctx context.Context, teacherID, subjectID uint, year string, isLead bool) error {
+	if teacherID == 0 || subjectID == 0 {
+		return errors.New("teacher ID and subject ID are required")
+	}
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.TeacherSubject{}).
+			Where("user_id = ? AND subject_id = ? AND academic_year = ?", teacherID, subjectID, year).
+			Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to check assignment: %w", err)
+		}
+		if count == 0 {
+			return fmt.Errorf("teacher %d is not assigned to subject %d in %q", teacherID, subjectID, year)
+		}
+
+		if isLead {
+			if err := clearLead(tx, subjectID, year); err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(&models.TeacherSubject{}).
+			Where("user_id = ? AND subject_id = ? AND academic_year = ?", teacherID, subjectID, year).
+			Update("is_lead", isLead).Error; err != nil {
+			return fmt.Errorf("failed to set lead teacher: %w", err)
+		}
+		return nil
+	})
+}
+
+// IsLead проверяет, является ли преподаватель ведущим по дисциплине в учебном году
+func (r *teacherSubjectRepository) IsLead(ctx context.Context, teacherID, subjectID uint, year string) (bool, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&models.TeacherSubject{}).
+		Where("user_id = ? AND subject_id = ? AND academic_year = ? AND is_lead = ?", teacherID, subjectID, year, true).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check lead teacher: %w", err)
+	}
+	return count > 0, nil
+}
+
+// clearLead снимает флаг ведущего со всех преподавателей дисциплины в учебном году
+func clearLead(tx *gorm.DB, subjectID uint, year string) error {
+	if err := tx.Model(&models.TeacherSubject{}).
+		Where("subject_id = ? AND academic_year = ? AND is_lead = ?", subjectID, year, true).
+		Update("is_lead", false).Error; err != nil {
+		return fmt.Errorf("failed to reset lead teacher: %w", err)
+	}
+	return nil
+}
+
+// inYear ограничивает выборку назначениями семестров указанного учебного года
+func (r *teacherSubjectRepository) inYear(ctx context.Context, year string) *gorm.DB {
+	query := conn(ctx, r.db).Model(&models.TeacherSubject{})
+	if year == "" {
+		return query
+	}
+	return query.
+		Joins("JOIN terms ON terms.id = teacher_subjects.term_id AND terms.deleted_at IS NULL").
+		Joins("JOIN academic_years ON academic_years.id = terms.academic_year_id").
+		Where("academic_years.name = ?", year)
 }
@@ -0,0 +1,116 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"gorm.io/gorm"
+)
+
+type teamInvitationRepository struct {
+	db *gorm.DB
+}
+
+// NewTeamInvitationRepository создаёт новый репозиторий приглашений в команды
+func NewTeamInvitationRepository(db *gorm.DB) interfaces.TeamInvitationRepository {
+	return &teamInvitationRepository{db: db}
+}
+
+// Create создаёт приглашение
+func (r *teamInvitationRepository) Create(ctx context.Context, inv *models.TeamInvitation) error {
+	if inv == nil {
+		return errors.New("invitation cannot be nil")
+	}
+	if inv.TeamID == 0 || inv.StudentID == 0 {
+		return errors.New("team ID and student ID are required")
+	}
+
+	if err := conn(ctx, r.db).Omit("Team", "Student").Create(inv).Error; err != nil {
+		return fmt.Errorf("failed to create team invitation: %w", err)
+	}
+	return nil
+}
+
+// GetByID возвращает приглашение по ID
+func (r *teamInvitationRepository) GetByID(ctx context.Context, id uint) (*models.TeamInvitation, error) {
+	if id == 0 {
+		return nil, errors.New("invalid invitation ID")
+	}
+
+	var inv models.TeamInvitation
+	result := conn(ctx, r.db).Preload("Team.Coursework").Preload("Student").First(&inv, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("team invitation with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get team invitation by ID: %w", result.Error)
+	}
+	return &inv, nil
+}
+
+// Update сохраняет изменения приглашения
+func (r *teamInvitationRepository) Update(ctx context.Context, inv *models.TeamInvitation) error {
+	if inv == nil || inv.ID == 0 {
+		return errors.New("invalid invitation")
+	}
+
+	result := conn(ctx, r.db).Omit("Team", "Student").Save(inv)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update team invitation: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("team invitation with ID %d not found", inv.ID)
+	}
+	return nil
+}
+
+// GetPendingByStudent возвращает входящие приглашения студента
+func (r *teamInvitationRepository) GetPendingByStudent(ctx context.Context, studentID uint) ([]models.TeamInvitation, error) {
+	var list []models.TeamInvitation
+	result := conn(ctx, r.db).
+		Preload("Team.Coursework").
+		Preload("Team.Leader").
+		Where("student_id = ? AND status = ?", studentID, models.InvitationPending).
+		Order("created_at DESC").
+		Find(&list)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get student invitations: %w", result.Error)
+	}
+	return list, nil
+}
+
+// GetPendingByTeam возвращает неотвеченные приглашения команды
+func (r *teamInvitationRepository) GetPendingByTeam(ctx context.Context, teamID uint) ([]models.TeamInvitation, error) {
+	var list []models.TeamInvitation
+	result := conn(ctx, r.db).
+		Preload("Student").
+		Where("team_id = ? AND status = ?", teamID, models.InvitationPending).
+		Order("created_at").
+		Find(&list)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get team invitations: %w", result.Error)
+	}
+	return list, nil
+}
+
+// RevokePendingByStudent отзывает все неотвеченные приглашения студента
+func (r *teamInvitationRepository) RevokePendingByStudent(ctx context.Context, studentID uint) error {
+	result := conn(ctx, r.db).
+		Model(&models.TeamInvitation{}).
+		Where("student_id = ? AND status = ?", studentID, models.InvitationPending).
+		Updates(map[string]interface{}{
+			"status":       models.InvitationRevoked,
+			"responded_at": time.Now(),
+		})
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to revoke team invitations: %w", result.Error)
+	}
+	return nil
+}
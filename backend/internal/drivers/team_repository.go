@@ -0,0 +1,201 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"gorm.io/gorm"
+)
+
+type teamRepository struct {
+	db *gorm.DB
+}
+
+// NewTeamRepository создаёт новый репозиторий команд
+func NewTeamRepository(db *gorm.DB) interfaces.TeamRepository {
+	return &teamRepository{db: db}
+}
+
+// Create создаёт команду вместе с лидером в качестве первого участника
+func (r *teamRepository) Create(ctx context.Context, team *models.Team) error {
+	if team == nil {
+		return errors.New("team cannot be nil")
+	}
+	if team.CourseworkID == 0 || team.LeaderID == 0 {
+		return errors.New("coursework ID and leader ID are required")
+	}
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Coursework", "Leader", "Members").Create(team).Error; err != nil {
+			return fmt.Errorf("failed to create team: %w", err)
+		}
+		leader := &models.TeamMember{TeamID: team.ID, StudentID: team.LeaderID}
+		if err := tx.Omit("Student").Create(leader).Error; err != nil {
+			return fmt.Errorf("failed to add team leader: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetByID возвращает команду с участниками
+func (r *teamRepository) GetByID(ctx context.Context, id uint) (*models.Team, error) {
+	if id == 0 {
+		return nil, errors.New("invalid team ID")
+	}
+
+	var team models.Team
+	result := r.preload(ctx).First(&team, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("team with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get team by ID: %w", result.Error)
+	}
+	return &team, nil
+}
+
+// GetByCoursework возвращает команду, выполняющую курсовую работу
+func (r *teamRepository) GetByCoursework(ctx context.Context, courseworkID uint) (*models.Team, error) {
+	var team models.Team
+	result := r.preload(ctx).Where("coursework_id = ?", courseworkID).First(&team)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("team for coursework %d not found", courseworkID)
+		}
+		return nil, fmt.Errorf("failed to get team by coursework: %w", result.Error)
+	}
+	return &team, nil
+}
+
+// GetByStudent возвращает команду, в которой состоит студент
+func (r *teamRepository) GetByStudent(ctx context.Context, studentID uint) (*models.Team, error) {
+	var team models.Team
+	result := r.preload(ctx).
+		Where("id = (?)", r.db.Model(&models.TeamMember{}).Select("team_id").Where("student_id = ?", studentID)).
+		First(&team)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("team for student %d not found", studentID)
+		}
+		return nil, fmt.Errorf("failed to get team by student: %w", result.Error)
+	}
+	return &team, nil
+}
+
+// Update сохраняет изменения команды
+func (r *teamRepository) Update(ctx context.Context, team *models.Team) error {
+	if team == nil || team.ID == 0 {
+		return errors.New("invalid team")
+	}
+
+	result := conn(ctx, r.db).Omit("Coursework", "Leader", "Members").Save(team)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update team: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("team with ID %d not found", team.ID)
+	}
+	return nil
+}
+
+// Delete удаляет команду вместе с участниками, приглашениями и записями
+func (r *teamRepository) Delete(ctx context.Context, id uint) error {
+	if id == 0 {
+		return errors.New("invalid team ID")
+	}
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{&models.TeamMember{}, &models.TeamInvitation{}, &models.TeamPost{}} {
+			if err := tx.Unscoped().Where("team_id = ?", id).Delete(model).Error; err != nil {
+				return fmt.Errorf("failed to delete team data: %w", err)
+			}
+		}
+		result := tx.Unscoped().Delete(&models.Team{}, id)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete team: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("team with ID %d not found", id)
+		}
+		return nil
+	})
+}
+
+// AddMember добавляет участника в команду
+func (r *teamRepository) AddMember(ctx context.Context, member *models.TeamMember) error {
+	if member == nil || member.TeamID == 0 || member.StudentID == 0 {
+		return errors.New("invalid team member")
+	}
+
+	if err := conn(ctx, r.db).Omit("Student").Create(member).Error; err != nil {
+		return fmt.Errorf("failed to add team member: %w", err)
+	}
+	return nil
+}
+
+// UpdateMember сохраняет изменения участника
+func (r *teamRepository) UpdateMember(ctx context.Context, member *models.TeamMember) error {
+	if member == nil || member.ID == 0 {
+		return errors.New("invalid team member")
+	}
+
+	result := conn(ctx, r.db).Omit("Student").Save(member)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update team member: %w", result.Error)
+	}
+	return nil
+}
+
+// RemoveMember исключает студента из команды
+func (r *teamRepository) RemoveMember(ctx context.Context, teamID, studentID uint) error {
+	result := conn(ctx, r.db).
+		Where("team_id = ? AND student_id = ?", teamID, studentID).
+		Delete(&models.TeamMember{})
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to remove team member: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("student %d is not a member of team %d", studentID, teamID)
+	}
+	return nil
+}
+
+// CreatePost добавляет запись в рабочее пространство команды
+func (r *teamRepository) CreatePost(ctx context.Context, post *models.TeamPost) error {
+	if post == nil || post.TeamID == 0 || post.AuthorID == 0 {
+		return errors.New("invalid team post")
+	}
+
+	if err := conn(ctx, r.db).Omit("Author").Create(post).Error; err != nil {
+		return fmt.Errorf("failed to create team post: %w", err)
+	}
+	return nil
+}
+
+// GetPosts возвращает записи рабочего пространства, начиная с новых
+func (r *teamRepository) GetPosts(ctx context.Context, teamID uint) ([]models.TeamPost, error) {
+	var posts []models.TeamPost
+	result := conn(ctx, r.db).
+		Preload("Author").
+		Where("team_id = ?", teamID).
+		Order("created_at DESC, id DESC").
+		Find(&posts)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get team posts: %w", result.Error)
+	}
+	return posts, nil
+}
+
+func (r *teamRepository) preload(ctx context.Context) *gorm.DB {
+	return conn(ctx, r.db).
+		Preload("Coursework").
+		Preload("Leader").
+		Preload("Members", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Preload("Members.Student")
+}
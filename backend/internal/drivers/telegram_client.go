@@ -0,0 +1,138 @@
+package drivers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/config"
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+)
+
+// telegramRequestTimeout - запас времени на запрос к Bot API сверх ожидания long polling
+const telegramRequestTimeout = 15 * time.Second
+
+// telegramClient обращается к Bot API по HTTP. Адрес API задаётся настройкой,
+// поэтому бота можно проверить на локальном сервере, отвечающем как Bot API.
+type telegramClient struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+// NewTelegramClient создаёт клиента Bot API; без токена бот выключен и возвращается nil
+func NewTelegramClient(cfg config.TelegramConfig) interfaces.TelegramClient {
+	if cfg.BotToken == "" {
+		return nil
+	}
+	return &telegramClient{
+		baseURL: strings.TrimRight(cfg.APIURL, "/"),
+		token:   cfg.BotToken,
+		http:    &http.Client{},
+	}
+}
+
+// telegramResponse - общий ответ Bot API
+type telegramResponse struct {
+	OK          bool            `json:"ok"`
+	Result      json.RawMessage `json:"result"`
+	ErrorCode   int             `json:"error_code"`
+	Description string          `json:"description"`
+}
+
+type telegramUpdate struct {
+	UpdateID int64 `json:"update_id"`
+	Message  *struct {
+		Text string `json:"text"`
+		Chat struct {
+			ID int64 `json:"id"`
+		} `json:"chat"`
+		From *struct {
+			Username string `json:"username"`
+		} `json:"from"`
+	} `json:"message"`
+}
+
+// GetUpdates ждёт новые сообщения боту не дольше timeout
+func (c *telegramClient) GetUpdates(ctx context.Context, offset int64, timeout time.Duration) ([]interfaces.TelegramUpdate, error) {
+	params := map[string]interface{}{
+		"offset":          offset,
+		"timeout":         int(timeout.Seconds()),
+		"allowed_updates": []string{"message"},
+	}
+	var raw []telegramUpdate
+	if err := c.call(ctx, "getUpdates", params, timeout+telegramRequestTimeout, &raw); err != nil {
+		return nil, err
+	}
+
+	updates := make([]interfaces.TelegramUpdate, 0, len(raw))
+	for _, u := range raw {
+		update := interfaces.TelegramUpdate{UpdateID: u.UpdateID}
+		if u.Message != nil {
+			update.ChatID = u.Message.Chat.ID
+			update.Text = u.Message.Text
+			if u.Message.From != nil {
+				update.Username = u.Message.From.Username
+			}
+		}
+		updates = append(updates, update)
+	}
+	return updates, nil
+}
+
+// SendMessage отправляет сообщение в чат простым текстом
+func (c *telegramClient) SendMessage(ctx context.Context, chatID int64, text string) error {
+	params := map[string]interface{}{
+		"chat_id":                  chatID,
+		"text":                     text,
+		"disable_web_page_preview": true,
+	}
+	return c.call(ctx, "sendMessage", params, telegramRequestTimeout, nil)
+}
+
+// call вызывает метод Bot API. Адрес запроса содержит токен, поэтому в ошибки он не попадает.
+func (c *telegramClient) call(ctx context.Context, method string, params interface{}, timeout time.Duration, result interface{}) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s request: %w", method, err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/bot"+c.token+"/"+method, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build %s request", method)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("telegram %s failed: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	var tr telegramResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		return fmt.Errorf("telegram %s: invalid response (HTTP %d): %w", method, resp.StatusCode, err)
+	}
+	if !tr.OK {
+		return fmt.Errorf("telegram %s failed: %d %s", method, tr.ErrorCode, tr.Description)
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(tr.Result, result); err != nil {
+		return fmt.Errorf("telegram %s: invalid result: %w", method, err)
+	}
+	return nil
+}
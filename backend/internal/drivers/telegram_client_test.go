@@ -0,0 +1,61 @@
+package drivers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/config"
+)
+
+func TestTelegramClientAgainstLocalServer(t *testing.T) {
+	const token = "123:secret-token"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/bot" + token + "/getUpdates":
+			// второе обновление - без сообщения, например правка старого
+			w.Write([]byte(`{"ok":true,"result":[
+				{"update_id":7,"message":{"text":"/help","chat":{"id":42},"from":{"username":"alice"}}},
+				{"update_id":8}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewTelegramClient(config.TelegramConfig{BotToken: token, APIURL: server.URL + "/"})
+	updates, err := client.GetUpdates(context.Background(), 7, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) != 2 {
+		t.Fatalf("updates = %+v, want 2", updates)
+	}
+	if u := updates[0]; u.UpdateID != 7 || u.ChatID != 42 || u.Username != "alice" || u.Text != "/help" {
+		t.Errorf("first update = %+v", u)
+	}
+	if u := updates[1]; u.UpdateID != 8 || u.ChatID != 0 {
+		t.Errorf("update without a message = %+v", u)
+	}
+
+	// ошибка Bot API передаётся описанием, а токен из адреса в неё не попадает
+	err = client.SendMessage(context.Background(), 1, "hi")
+	if err == nil || !strings.Contains(err.Error(), "chat not found") {
+		t.Errorf("SendMessage error = %v, want Bot API description", err)
+	}
+	if err != nil && strings.Contains(err.Error(), token) {
+		t.Errorf("error leaks the bot token: %v", err)
+	}
+}
+
+func TestTelegramClientDisabledWithoutToken(t *testing.T) {
+	if client := NewTelegramClient(config.TelegramConfig{APIURL: "http://127.0.0.1"}); client != nil {
+		t.Errorf("client without a token = %v, want nil", client)
+	}
+}
@@ -0,0 +1,104 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"gorm.io/gorm"
+)
+
+type telegramRepository struct {
+	db *gorm.DB
+}
+
+// NewTelegramRepository создаёт новый репозиторий привязок Telegram
+func NewTelegramRepository(db *gorm.DB) interfaces.TelegramRepository {
+	return &telegramRepository{db: db}
+}
+
+// CreateLinkCode сохраняет код привязки, прежние коды пользователя удаляются
+func (r *telegramRepository) CreateLinkCode(ctx context.Context, code *models.TelegramLinkCode) error {
+	if code == nil || code.Code == "" || code.UserID == 0 {
+		return errors.New("invalid link code")
+	}
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", code.UserID).Delete(&models.TelegramLinkCode{}).Error; err != nil {
+			return fmt.Errorf("failed to delete old link codes: %w", err)
+		}
+		if err := tx.Create(code).Error; err != nil {
+			return fmt.Errorf("failed to create link code: %w", err)
+		}
+		return nil
+	})
+}
+
+// LinkChat погашает код и привязывает чат к его владельцу. Код удаляется условным
+// DELETE, поэтому одним кодом нельзя привязать два чата.
+func (r *telegramRepository) LinkChat(ctx context.Context, code string, now time.Time, link *models.TelegramLink) error {
+	if link == nil || link.ChatID == 0 {
+		return errors.New("invalid telegram link")
+	}
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var linkCode models.TelegramLinkCode
+		result := tx.Where("code = ? AND expires_at > ?", code, now).Limit(1).Find(&linkCode)
+		if result.Error != nil {
+			return fmt.Errorf("failed to get link code: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("link code is invalid or expired")
+		}
+		res := tx.Where("code = ?", code).Delete(&models.TelegramLinkCode{})
+		if res.Error != nil {
+			return fmt.Errorf("failed to use link code: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return errors.New("link code is invalid or expired")
+		}
+
+		link.UserID = linkCode.UserID
+		if err := tx.Where("user_id = ? OR chat_id = ?", link.UserID, link.ChatID).Delete(&models.TelegramLink{}).Error; err != nil {
+			return fmt.Errorf("failed to delete old telegram link: %w", err)
+		}
+		if err := tx.Omit("User").Create(link).Error; err != nil {
+			return fmt.Errorf("failed to link telegram chat: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetLinkByUser возвращает привязку пользователя или nil
+func (r *telegramRepository) GetLinkByUser(ctx context.Context, userID uint) (*models.TelegramLink, error) {
+	return r.findLink(ctx, "user_id = ?", userID)
+}
+
+// GetLinkByChat возвращает привязку чата вместе с пользователем или nil
+func (r *telegramRepository) GetLinkByChat(ctx context.Context, chatID int64) (*models.TelegramLink, error) {
+	return r.findLink(ctx, "chat_id = ?", chatID)
+}
+
+func (r *telegramRepository) findLink(ctx context.Context, query string, arg interface{}) (*models.TelegramLink, error) {
+	var links []models.TelegramLink
+	if err := conn(ctx, r.db).Preload("User").Where(query, arg).Limit(1).Find(&links).Error; err != nil {
+		return nil, fmt.Errorf("failed to get telegram link: %w", err)
+	}
+	if len(links) == 0 {
+		return nil, nil
+	}
+	return &links[0], nil
+}
+
+// DeleteLink отвязывает чат пользователя
+func (r *telegramRepository) DeleteLink(ctx context.Context, userID uint) error {
+	result := conn(ctx, r.db).Where("user_id = ?", userID).Delete(&models.TelegramLink{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete telegram link: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("telegram chat is not linked")
+	}
+	return nil
+}
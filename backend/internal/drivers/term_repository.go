@@ -0,0 +1,171 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"gorm.io/gorm"
+)
+
+type termRepository struct {
+	db *gorm.DB
+}
+
+// NewTermRepository создаёт новый репозиторий учебных годов и семестров
+func NewTermRepository(db *gorm.DB) interfaces.TermRepository {
+	return &termRepository{db: db}
+}
+
+// CreateYear создаёт учебный год вместе с его семестрами
+func (r *termRepository) CreateYear(ctx context.Context, year *models.AcademicYear) error {
+	if year == nil {
+		return errors.New("academic year cannot be nil")
+	}
+	if year.Name == "" {
+		return errors.New("academic year name is required")
+	}
+
+	result := conn(ctx, r.db).Create(year)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create academic year: %w", result.Error)
+	}
+	return nil
+}
+
+// GetYearByName возвращает учебный год по названию вида "2024-2025"
+func (r *termRepository) GetYearByName(ctx context.Context, name string) (*models.AcademicYear, error) {
+	var year models.AcademicYear
+	result := conn(ctx, r.db).
+		Preload("Terms", func(db *gorm.DB) *gorm.DB { return db.Order("number") }).
+		Where("name = ?", name).
+		First(&year)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("academic year %s not found", name)
+		}
+		return nil, fmt.Errorf("failed to get academic year: %w", result.Error)
+	}
+	return &year, nil
+}
+
+// ListYears возвращает учебные годы с семестрами, новые первыми
+func (r *termRepository) ListYears(ctx context.Context) ([]models.AcademicYear, error) {
+	var years []models.AcademicYear
+	result := conn(ctx, r.db).
+		Preload("Terms", func(db *gorm.DB) *gorm.DB { return db.Order("number") }).
+		Order("starts_on DESC").
+		Find(&years)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list academic years: %w", result.Error)
+	}
+	return years, nil
+}
+
+// GetByID возвращает семестр по ID
+func (r *termRepository) GetByID(ctx context.Context, id uint) (*models.Term, error) {
+	if id == 0 {
+		return nil, errors.New("invalid term ID")
+	}
+
+	var term models.Term
+	result := conn(ctx, r.db).Preload("AcademicYear").First(&term, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("term with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get term: %w", result.Error)
+	}
+	return &term, nil
+}
+
+// GetActive возвращает текущий активный семестр
+func (r *termRepository) GetActive(ctx context.Context) (*models.Term, error) {
+	var term models.Term
+	result := conn(ctx, r.db).
+		Preload("AcademicYear").
+		Where("is_active = ?", true).
+		First(&term)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("active term %w", interfaces.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get active term: %w", result.Error)
+	}
+	return &term, nil
+}
+
+// GetByYearAndNumber возвращает семестр учебного года по номеру (1 - осенний, 2 - весенний)
+func (r *termRepository) GetByYearAndNumber(ctx context.Context, yearName string, number int) (*models.Term, error) {
+	var term models.Term
+	result := conn(ctx, r.db).
+		Preload("AcademicYear").
+		Joins("JOIN academic_years ON academic_years.id = terms.academic_year_id AND academic_years.deleted_at IS NULL").
+		Where("academic_years.name = ? AND terms.number = ?", yearName, number).
+		First(&term)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("term %d of academic year %s not found", number, yearName)
+		}
+		return nil, fmt.Errorf("failed to get term: %w", result.Error)
+	}
+	return &term, nil
+}
+
+// SetActive делает семестр активным и снимает признак с остальных.
+// Данные, созданные до появления семестров (term_id = 0), переносятся в активируемый семестр.
+func (r *termRepository) SetActive(ctx context.Context, termID uint) error {
+	if termID == 0 {
+		return errors.New("invalid term ID")
+	}
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var term models.Term
+		if err := tx.First(&term, termID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("term with ID %d not found", termID)
+			}
+			return fmt.Errorf("failed to get term: %w", err)
+		}
+		if err := tx.Model(&models.Term{}).Where("is_active = ? AND id <> ?", true, termID).
+			Update("is_active", false).Error; err != nil {
+			return fmt.Errorf("failed to deactivate terms: %w", err)
+		}
+		if err := tx.Model(&term).Update("is_active", true).Error; err != nil {
+			return fmt.Errorf("failed to activate term: %w", err)
+		}
+
+		for _, m := range []interface{}{&models.Coursework{}, &models.StudentCoursework{}, &models.TeacherSubject{}} {
+			if err := tx.Model(m).Where("term_id = 0 OR term_id IS NULL").Update("term_id", termID).Error; err != nil {
+				return fmt.Errorf("failed to backfill term: %w", err)
+			}
+		}
+		// учебный год назначений нужен для ограничения на ведущего преподавателя
+		if err := tx.Model(&models.TeacherSubject{}).
+			Where("academic_year = '' OR academic_year IS NULL").
+			Update("academic_year", gorm.Expr(`(SELECT academic_years.name FROM terms
+				JOIN academic_years ON academic_years.id = terms.academic_year_id
+				WHERE terms.id = teacher_subjects.term_id)`)).Error; err != nil {
+			return fmt.Errorf("failed to backfill academic year: %w", err)
+		}
+		return nil
+	})
+}
+
+// activeTermID возвращает ID активного семестра или 0, если семестры ещё не заведены
+func activeTermID(db *gorm.DB) (uint, error) {
+	var ids []uint
+	if err := db.Model(&models.Term{}).Where("is_active = ?", true).Limit(1).Pluck("id", &ids).Error; err != nil {
+		return 0, fmt.Errorf("failed to get active term: %w", err)
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	return ids[0], nil
+}
@@ -0,0 +1,24 @@
+CREATE TABLE `subjects` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime DEFAULT CURRENT_TIMESTAMP,`updated_at` datetime DEFAULT CURRENT_TIMESTAMP,`deleted_at` datetime,`name` text NOT NULL,`code` text NOT NULL,`description` text,`semester` integer NOT NULL,`is_active` numeric DEFAULT true);
+CREATE UNIQUE INDEX `idx_subjects_code` ON `subjects`(`code`);
+CREATE INDEX `idx_subjects_deleted_at` ON `subjects`(`deleted_at`);
+CREATE TABLE `users` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime DEFAULT CURRENT_TIMESTAMP,`updated_at` datetime DEFAULT CURRENT_TIMESTAMP,`deleted_at` datetime,`email` text NOT NULL,`password_hash` text NOT NULL,`first_name` text NOT NULL,`last_name` text NOT NULL,`role` text NOT NULL DEFAULT "student",`is_active` numeric DEFAULT true,CONSTRAINT `chk_users_role` CHECK (role IN ('admin','teacher','student')));
+CREATE UNIQUE INDEX `idx_users_email` ON `users`(`email`);
+CREATE INDEX `idx_users_deleted_at` ON `users`(`deleted_at`);
+CREATE TABLE `courseworks` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime DEFAULT CURRENT_TIMESTAMP,`updated_at` datetime DEFAULT CURRENT_TIMESTAMP,`deleted_at` datetime,`title` text NOT NULL,`description` text NOT NULL,`requirements` text,`subject_id` integer NOT NULL,`teacher_id` integer NOT NULL,`max_students` integer DEFAULT 1,`difficulty_level` varchar(20),`is_available` numeric DEFAULT true,CONSTRAINT `fk_subjects_courseworks` FOREIGN KEY (`subject_id`) REFERENCES `subjects`(`id`),CONSTRAINT `fk_courseworks_teacher` FOREIGN KEY (`teacher_id`) REFERENCES `users`(`id`),CONSTRAINT `chk_courseworks_difficulty_level` CHECK (difficulty_level IN ('easy','medium','hard')));
+CREATE INDEX `idx_courseworks_deleted_at` ON `courseworks`(`deleted_at`);
+CREATE TABLE `departments` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime DEFAULT CURRENT_TIMESTAMP,`updated_at` datetime DEFAULT CURRENT_TIMESTAMP,`deleted_at` datetime,`department_code` text NOT NULL,`department_name` text NOT NULL,`description` text);
+CREATE UNIQUE INDEX `idx_departments_department_code` ON `departments`(`department_code`);
+CREATE INDEX `idx_departments_deleted_at` ON `departments`(`deleted_at`);
+CREATE TABLE `student_profiles` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime DEFAULT CURRENT_TIMESTAMP,`updated_at` datetime DEFAULT CURRENT_TIMESTAMP,`deleted_at` datetime,`user_id` integer NOT NULL,`group_id` integer NOT NULL,`student_number` text);
+CREATE UNIQUE INDEX `idx_student_profiles_user_id` ON `student_profiles`(`user_id`);
+CREATE INDEX `idx_student_profiles_deleted_at` ON `student_profiles`(`deleted_at`);
+CREATE TABLE `teacher_profiles` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime DEFAULT CURRENT_TIMESTAMP,`updated_at` datetime DEFAULT CURRENT_TIMESTAMP,`deleted_at` datetime,`user_id` integer NOT NULL,`department_id` integer NOT NULL,`position` text,`academic_degree` text);
+CREATE UNIQUE INDEX `idx_teacher_profiles_user_id` ON `teacher_profiles`(`user_id`);
+CREATE INDEX `idx_teacher_profiles_deleted_at` ON `teacher_profiles`(`deleted_at`);
+CREATE TABLE `student_courseworks` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime DEFAULT CURRENT_TIMESTAMP,`updated_at` datetime,`deleted_at` datetime,`student_id` integer NOT NULL,`coursework_id` integer NOT NULL,`status` varchar(20) DEFAULT "assigned",`submitted_at` datetime,`completed_at` datetime,`grade` integer,`feedback` text,CONSTRAINT `fk_student_courseworks_student` FOREIGN KEY (`student_id`) REFERENCES `users`(`id`),CONSTRAINT `fk_student_courseworks_coursework` FOREIGN KEY (`coursework_id`) REFERENCES `courseworks`(`id`));
+CREATE INDEX `idx_student_courseworks_deleted_at` ON `student_courseworks`(`deleted_at`);
+CREATE TABLE `student_groups` (`id` integer PRIMARY KEY AUTOINCREMENT,`created_at` datetime DEFAULT CURRENT_TIMESTAMP,`updated_at` datetime DEFAULT CURRENT_TIMESTAMP,`deleted_at` datetime,`group_code` text NOT NULL,`course_year` integer,`specialty` text,`department_id` integer NOT NULL);
+CREATE INDEX `idx_student_groups_department_id` ON `student_groups`(`department_id`);
+CREATE UNIQUE INDEX `idx_student_groups_group_code` ON `student_groups`(`group_code`);
+CREATE INDEX `idx_student_groups_deleted_at` ON `student_groups`(`deleted_at`);
+CREATE TABLE `teacher_subjects` (`user_id` integer,`subject_id` integer,PRIMARY KEY (`user_id`,`subject_id`),CONSTRAINT `fk_teacher_subjects_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`),CONSTRAINT `fk_teacher_subjects_subject` FOREIGN KEY (`subject_id`) REFERENCES `subjects`(`id`));
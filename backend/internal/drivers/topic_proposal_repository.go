@@ -0,0 +1,139 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"gorm.io/gorm"
+)
+
+type topicProposalRepository struct {
+	db *gorm.DB
+}
+
+// NewTopicProposalRepository создаёт новый репозиторий предложенных тем
+func NewTopicProposalRepository(db *gorm.DB) interfaces.TopicProposalRepository {
+	return &topicProposalRepository{db: db}
+}
+
+// Create сохраняет новое предложение темы
+func (r *topicProposalRepository) Create(ctx context.Context, proposal *models.TopicProposal) error {
+	if proposal == nil {
+		return errors.New("proposal cannot be nil")
+	}
+	if proposal.StudentID == 0 || proposal.TeacherID == 0 || proposal.SubjectID == 0 {
+		return errors.New("student, teacher and subject IDs are required")
+	}
+
+	result := conn(ctx, r.db).Create(proposal)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create topic proposal: %w", result.Error)
+	}
+	return nil
+}
+
+// GetByID возвращает предложение по ID вместе со студентом, руководителем и дисциплиной
+func (r *topicProposalRepository) GetByID(ctx context.Context, id uint) (*models.TopicProposal, error) {
+	if id == 0 {
+		return nil, errors.New("invalid proposal ID")
+	}
+
+	var proposal models.TopicProposal
+	result := conn(ctx, r.db).
+		Preload("Student").
+		Preload("Teacher").
+		Preload("Subject").
+		First(&proposal, id)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("topic proposal with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get topic proposal by ID: %w", result.Error)
+	}
+	return &proposal, nil
+}
+
+// Update сохраняет изменения в предложении
+func (r *topicProposalRepository) Update(ctx context.Context, proposal *models.TopicProposal) error {
+	if proposal == nil || proposal.ID == 0 {
+		return errors.New("invalid proposal")
+	}
+
+	result := conn(ctx, r.db).Omit("Student", "Teacher", "Subject").Save(proposal)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update topic proposal: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("topic proposal with ID %d not found", proposal.ID)
+	}
+	return nil
+}
+
+// GetByStudent возвращает все предложения студента, начиная с последних
+func (r *topicProposalRepository) GetByStudent(ctx context.Context, studentID uint) ([]models.TopicProposal, error) {
+	if studentID == 0 {
+		return nil, errors.New("invalid student ID")
+	}
+
+	var list []models.TopicProposal
+	result := conn(ctx, r.db).
+		Preload("Teacher").
+		Preload("Subject").
+		Where("student_id = ?", studentID).
+		Order("created_at DESC").
+		Find(&list)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get proposals by student: %w", result.Error)
+	}
+	return list, nil
+}
+
+// GetByTeacher возвращает предложения, адресованные руководителю, с фильтром по статусам
+func (r *topicProposalRepository) GetByTeacher(ctx context.Context, teacherID uint, statuses ...models.ProposalStatus) ([]models.TopicProposal, error) {
+	if teacherID == 0 {
+		return nil, errors.New("invalid teacher ID")
+	}
+
+	query := conn(ctx, r.db).Where("teacher_id = ?", teacherID)
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+
+	var list []models.TopicProposal
+	result := query.
+		Preload("Student").
+		Preload("Subject").
+		Order("created_at DESC").
+		Find(&list)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get proposals by teacher: %w", result.Error)
+	}
+	return list, nil
+}
+
+// GetOpenByStudent возвращает незакрытое предложение студента, если оно есть
+func (r *topicProposalRepository) GetOpenByStudent(ctx context.Context, studentID uint) (*models.TopicProposal, error) {
+	if studentID == 0 {
+		return nil, errors.New("invalid student ID")
+	}
+
+	var proposal models.TopicProposal
+	result := conn(ctx, r.db).
+		Where("student_id = ? AND status IN ?", studentID,
+			[]models.ProposalStatus{models.ProposalPending, models.ProposalChangesRequested}).
+		First(&proposal)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("no open proposal for student %d", studentID)
+		}
+		return nil, fmt.Errorf("failed to get open proposal: %w", result.Error)
+	}
+	return &proposal, nil
+}
@@ -0,0 +1,39 @@
+package drivers
+
+import (
+	"context"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"gorm.io/gorm"
+)
+
+// txKey - ключ транзакции единицы работы в контексте
+type txKey struct{}
+
+type unitOfWork struct {
+	db *gorm.DB
+}
+
+// NewUnitOfWork создаёт единицу работы поверх соединения с базой
+func NewUnitOfWork(db *gorm.DB) interfaces.UnitOfWork {
+	return &unitOfWork{db: db}
+}
+
+// Do выполняет fn в одной транзакции: репозитории, вызванные с переданным в fn контекстом,
+// работают внутри неё. Вложенный вызов присоединяется к уже открытой транзакции.
+func (u *unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// conn возвращает транзакцию единицы работы из контекста, а вне её - обычное соединение
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/Foxpunk/courseforge/internal/interfaces"
 	"github.com/Foxpunk/courseforge/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // userRepository - реализация интерфейса UserRepository с использованием GORM
@@ -28,7 +29,7 @@ func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 		return errors.New("user cannot be nil")
 	}
 
-	result := r.db.WithContext(ctx).Create(user)
+	result := conn(ctx, r.db).Create(user)
 	if result.Error != nil {
 		return fmt.Errorf("failed to create user: %w", result.Error)
 	}
@@ -43,7 +44,7 @@ func (r *userRepository) GetByID(ctx context.Context, id uint) (*models.User, er
 	}
 
 	var user models.User
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Preload("TeacherSubjects").
 		First(&user, id)
 
@@ -64,14 +65,14 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.
 	}
 
 	var user models.User
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Preload("TeacherSubjects").
 		Where("email = ?", email).
 		First(&user)
 
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("user with email %s not found", email)
+			return nil, fmt.Errorf("user with email %s %w", email, interfaces.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get user by email: %w", result.Error)
 	}
@@ -89,36 +90,63 @@ func (r *userRepository) Update(ctx context.Context, user *models.User) error {
 		return errors.New("user ID cannot be zero")
 	}
 
-	result := r.db.WithContext(ctx).Save(user)
+	// запись меняется, только если её версия совпадает с прочитанной
+	expected := user.Version
+	user.Version = expected + 1
+	result := conn(ctx, r.db).
+		Model(user).
+		Where("version = ?", expected).
+		Select("*").
+		Omit("id", "created_at", clause.Associations).
+		Updates(user)
 	if result.Error != nil {
+		user.Version = expected
 		return fmt.Errorf("failed to update user: %w", result.Error)
 	}
 
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("user with ID %d not found", user.ID)
+		user.Version = expected
+		return r.versionMismatch(ctx, user.ID)
 	}
 
 	return nil
 }
 
-// Delete удаляет пользователя (мягкое удаление)
-func (r *userRepository) Delete(ctx context.Context, id uint) error {
+// Delete удаляет пользователя (мягкое удаление); при version != 0 - только этой версии
+func (r *userRepository) Delete(ctx context.Context, id uint, version uint) error {
 	if id == 0 {
 		return errors.New("invalid user ID")
 	}
 
-	result := r.db.WithContext(ctx).Delete(&models.User{}, id)
+	query := conn(ctx, r.db)
+	if version != 0 {
+		query = query.Where("version = ?", version)
+	}
+	result := query.Delete(&models.User{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete user: %w", result.Error)
 	}
 
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("user with ID %d not found", id)
+		return r.versionMismatch(ctx, id)
 	}
 
 	return nil
 }
 
+// versionMismatch объясняет, почему условное изменение не затронуло строк:
+// пользователя нет либо его версия уже другая
+func (r *userRepository) versionMismatch(ctx context.Context, id uint) error {
+	var count int64
+	if err := conn(ctx, r.db).Model(&models.User{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check user version: %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("user with ID %d not found", id)
+	}
+	return interfaces.ErrVersionConflict
+}
+
 // List получает список пользователей с пагинацией
 func (r *userRepository) List(ctx context.Context, limit, offset int) ([]models.User, error) {
 	if limit <= 0 {
@@ -129,7 +157,7 @@ func (r *userRepository) List(ctx context.Context, limit, offset int) ([]models.
 	}
 
 	var users []models.User
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Preload("TeacherSubjects").
 		Limit(limit).
 		Offset(offset).
@@ -149,7 +177,7 @@ func (r *userRepository) GetByRole(ctx context.Context, role models.UserRole) ([
 	}
 
 	var users []models.User
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Preload("TeacherSubjects").
 		Where("role = ?", role).
 		Find(&users)
@@ -185,10 +213,10 @@ func (r *userRepository) UpdateRole(ctx context.Context, userID uint, role model
 		return fmt.Errorf("invalid role: %s", role)
 	}
 
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Model(&models.User{}).
 		Where("id = ?", userID).
-		Update("role", role)
+		Updates(map[string]interface{}{"role": role, "version": gorm.Expr("version + 1")})
 
 	if result.Error != nil {
 		return fmt.Errorf("failed to update user role: %w", result.Error)
@@ -207,10 +235,10 @@ func (r *userRepository) SetActive(ctx context.Context, userID uint, active bool
 		return errors.New("invalid user ID")
 	}
 
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Model(&models.User{}).
 		Where("id = ?", userID).
-		Update("is_active", active)
+		Updates(map[string]interface{}{"is_active": active, "version": gorm.Expr("version + 1")})
 
 	if result.Error != nil {
 		return fmt.Errorf("failed to set user active status: %w", result.Error)
@@ -228,7 +256,7 @@ func (r *userRepository) SetActive(ctx context.Context, userID uint, active bool
 // GetActiveUsers получает только активных пользователей
 func (r *userRepository) GetActiveUsers(ctx context.Context) ([]models.User, error) {
 	var users []models.User
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Preload("TeacherSubjects").
 		Where("is_active = ?", true).
 		Find(&users)
@@ -243,7 +271,7 @@ func (r *userRepository) GetActiveUsers(ctx context.Context) ([]models.User, err
 // GetUserCount получает общее количество пользователей
 func (r *userRepository) GetUserCount(ctx context.Context) (int64, error) {
 	var count int64
-	result := r.db.WithContext(ctx).Model(&models.User{}).Count(&count)
+	result := conn(ctx, r.db).Model(&models.User{}).Count(&count)
 	if result.Error != nil {
 		return 0, fmt.Errorf("failed to get user count: %w", result.Error)
 	}
@@ -253,7 +281,7 @@ func (r *userRepository) GetUserCount(ctx context.Context) (int64, error) {
 // GetUserCountByRole получает количество пользователей по роли
 func (r *userRepository) GetUserCountByRole(ctx context.Context, role models.UserRole) (int64, error) {
 	var count int64
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Model(&models.User{}).
 		Where("role = ?", role).
 		Count(&count)
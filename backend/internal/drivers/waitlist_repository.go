@@ -0,0 +1,169 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"gorm.io/gorm"
+)
+
+type waitlistRepository struct {
+	db *gorm.DB
+}
+
+// NewWaitlistRepository создаёт новый репозиторий листов ожидания
+func NewWaitlistRepository(db *gorm.DB) interfaces.WaitlistRepository {
+	return &waitlistRepository{db: db}
+}
+
+// Create ставит студента в конец очереди на курсовую работу
+func (r *waitlistRepository) Create(ctx context.Context, entry *models.WaitlistEntry) error {
+	if entry == nil {
+		return errors.New("waitlist entry cannot be nil")
+	}
+	if entry.CourseworkID == 0 || entry.StudentID == 0 {
+		return errors.New("coursework ID and student ID are required")
+	}
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var last int
+		if err := tx.Model(&models.WaitlistEntry{}).
+			Where("coursework_id = ?", entry.CourseworkID).
+			Select("COALESCE(MAX(position), 0)").
+			Scan(&last).Error; err != nil {
+			return fmt.Errorf("failed to get waitlist position: %w", err)
+		}
+		entry.Position = last + 1
+		if err := tx.Omit("Coursework", "Student").Create(entry).Error; err != nil {
+			return fmt.Errorf("failed to create waitlist entry: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetByID возвращает запись очереди по ID
+func (r *waitlistRepository) GetByID(ctx context.Context, id uint) (*models.WaitlistEntry, error) {
+	if id == 0 {
+		return nil, errors.New("invalid waitlist entry ID")
+	}
+
+	var entry models.WaitlistEntry
+	result := conn(ctx, r.db).Preload("Coursework").Preload("Student").First(&entry, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("waitlist entry with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get waitlist entry by ID: %w", result.Error)
+	}
+	return &entry, nil
+}
+
+// Update сохраняет изменения записи очереди
+func (r *waitlistRepository) Update(ctx context.Context, entry *models.WaitlistEntry) error {
+	if entry == nil || entry.ID == 0 {
+		return errors.New("invalid waitlist entry")
+	}
+
+	result := conn(ctx, r.db).Omit("Coursework", "Student").Save(entry)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update waitlist entry: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("waitlist entry with ID %d not found", entry.ID)
+	}
+	return nil
+}
+
+// GetActiveByStudentAndCoursework возвращает активную запись студента в очереди на тему
+func (r *waitlistRepository) GetActiveByStudentAndCoursework(ctx context.Context, studentID, courseworkID uint) (*models.WaitlistEntry, error) {
+	var entry models.WaitlistEntry
+	result := conn(ctx, r.db).
+		Where("student_id = ? AND coursework_id = ? AND status IN ?", studentID, courseworkID,
+			[]models.WaitlistStatus{models.WaitlistWaiting, models.WaitlistOffered}).
+		First(&entry)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("no active waitlist entry for student %d", studentID)
+		}
+		return nil, fmt.Errorf("failed to get waitlist entry: %w", result.Error)
+	}
+	return &entry, nil
+}
+
+// GetByCoursework возвращает очередь на тему по порядку, опционально фильтруя по статусам
+func (r *waitlistRepository) GetByCoursework(ctx context.Context, courseworkID uint, statuses ...models.WaitlistStatus) ([]models.WaitlistEntry, error) {
+	query := conn(ctx, r.db).Preload("Student").Where("coursework_id = ?", courseworkID)
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+
+	var list []models.WaitlistEntry
+	if err := query.Order("position").Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("failed to get coursework waitlist: %w", err)
+	}
+	return list, nil
+}
+
+// GetByStudent возвращает все записи студента в очередях
+func (r *waitlistRepository) GetByStudent(ctx context.Context, studentID uint) ([]models.WaitlistEntry, error) {
+	var list []models.WaitlistEntry
+	result := conn(ctx, r.db).
+		Preload("Coursework").
+		Where("student_id = ?", studentID).
+		Order("created_at DESC").
+		Find(&list)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get student waitlist entries: %w", result.Error)
+	}
+	return list, nil
+}
+
+// CountOffered считает места темы, зарезервированные предложениями из очереди
+func (r *waitlistRepository) CountOffered(ctx context.Context, courseworkID, exceptStudentID uint) (int, error) {
+	var count int64
+	result := conn(ctx, r.db).
+		Model(&models.WaitlistEntry{}).
+		Where("coursework_id = ? AND status = ? AND student_id <> ?", courseworkID, models.WaitlistOffered, exceptStudentID).
+		Count(&count)
+
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to count waitlist offers: %w", result.Error)
+	}
+	return int(count), nil
+}
+
+// GetExpiredOffers возвращает предложения, окно подтверждения которых истекло
+func (r *waitlistRepository) GetExpiredOffers(ctx context.Context, now time.Time) ([]models.WaitlistEntry, error) {
+	var list []models.WaitlistEntry
+	result := conn(ctx, r.db).
+		Preload("Coursework").
+		Where("status = ? AND offer_expires_at < ?", models.WaitlistOffered, now).
+		Order("offer_expires_at").
+		Find(&list)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get expired waitlist offers: %w", result.Error)
+	}
+	return list, nil
+}
+
+// GetOffersExpiringBetween возвращает действующие предложения, срок которых истекает в [from, to)
+func (r *waitlistRepository) GetOffersExpiringBetween(ctx context.Context, from, to time.Time) ([]models.WaitlistEntry, error) {
+	var list []models.WaitlistEntry
+	result := conn(ctx, r.db).
+		Preload("Coursework").
+		Where("status = ? AND offer_expires_at >= ? AND offer_expires_at < ?", models.WaitlistOffered, from, to).
+		Order("offer_expires_at").
+		Find(&list)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get expiring waitlist offers: %w", result.Error)
+	}
+	return list, nil
+}
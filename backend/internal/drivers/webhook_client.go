@@ -0,0 +1,65 @@
+package drivers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+)
+
+// maxWebhookResponse - сколько байт ответа получателя читается в журнал доставок
+const maxWebhookResponse = 4 << 10
+
+// webhookClient отправляет вебхуки по HTTP
+type webhookClient struct {
+	http *http.Client
+}
+
+// NewWebhookClient создаёт клиента вебхуков; переадресации не выполняются,
+// чтобы подписанное тело не ушло на другой адрес
+func NewWebhookClient(timeout time.Duration) interfaces.WebhookClient {
+	return &webhookClient{
+		http: &http.Client{
+			Timeout: timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Post отправляет JSON и возвращает код и начало тела ответа
+func (c *webhookClient) Post(ctx context.Context, target string, headers map[string]string, body []byte) (*interfaces.WebhookHTTPResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "CourseForge-Webhook/1.0")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		// адрес уже есть в журнале доставок, в тексте ошибки он не нужен
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponse))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook response: %w", err)
+	}
+	return &interfaces.WebhookHTTPResult{StatusCode: resp.StatusCode, Body: respBody}, nil
+}
@@ -0,0 +1,190 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"gorm.io/gorm"
+)
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository создаёт новый репозиторий вебхуков и их доставок
+func NewWebhookRepository(db *gorm.DB) interfaces.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+// Create сохраняет вебхук
+func (r *webhookRepository) Create(ctx context.Context, webhook *models.Webhook) error {
+	if webhook == nil {
+		return errors.New("webhook cannot be nil")
+	}
+	if err := conn(ctx, r.db).Create(webhook).Error; err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return nil
+}
+
+// GetByID возвращает вебхук по ID
+func (r *webhookRepository) GetByID(ctx context.Context, id uint) (*models.Webhook, error) {
+	var webhook models.Webhook
+	if err := conn(ctx, r.db).First(&webhook, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("webhook with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+	return &webhook, nil
+}
+
+// List возвращает все вебхуки
+func (r *webhookRepository) List(ctx context.Context) ([]models.Webhook, error) {
+	var list []models.Webhook
+	if err := conn(ctx, r.db).Order("id").Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	return list, nil
+}
+
+// ListActive возвращает включённые вебхуки
+func (r *webhookRepository) ListActive(ctx context.Context) ([]models.Webhook, error) {
+	var list []models.Webhook
+	if err := conn(ctx, r.db).Where("is_active = ?", true).Order("id").Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("failed to list active webhooks: %w", err)
+	}
+	return list, nil
+}
+
+// Update сохраняет изменения вебхука
+func (r *webhookRepository) Update(ctx context.Context, webhook *models.Webhook) error {
+	if webhook == nil || webhook.ID == 0 {
+		return errors.New("invalid webhook")
+	}
+	if err := conn(ctx, r.db).Save(webhook).Error; err != nil {
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+	return nil
+}
+
+// Delete удаляет вебхук вместе с журналом доставок
+func (r *webhookRepository) Delete(ctx context.Context, id uint) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", id).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return fmt.Errorf("failed to delete webhook deliveries: %w", err)
+		}
+		result := tx.Delete(&models.Webhook{}, id)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete webhook: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("webhook with ID %d not found", id)
+		}
+		return nil
+	})
+}
+
+// CreateDeliveries ставит доставки в очередь одной вставкой
+func (r *webhookRepository) CreateDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if err := conn(ctx, r.db).Create(&deliveries).Error; err != nil {
+		return fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// GetDelivery возвращает доставку по ID
+func (r *webhookRepository) GetDelivery(ctx context.Context, id uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := conn(ctx, r.db).First(&delivery, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("webhook delivery with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	return &delivery, nil
+}
+
+// ListDeliveries возвращает журнал доставок вебхука, новые сверху
+func (r *webhookRepository) ListDeliveries(ctx context.Context, webhookID uint, limit, offset int) ([]models.WebhookDelivery, int64, error) {
+	query := conn(ctx, r.db).Model(&models.WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+	var list []models.WebhookDelivery
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&list).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	return list, total, nil
+}
+
+// ClaimDue забирает доставки из очереди условным UPDATE, как ClaimDue у писем: одну доставку
+// не отправят два обработчика, а зависшие в sending возвращаются по staleAfter
+func (r *webhookRepository) ClaimDue(ctx context.Context, now time.Time, limit int, staleAfter time.Duration) ([]models.WebhookDelivery, error) {
+	db := conn(ctx, r.db)
+	staleBefore := now.Add(-staleAfter)
+	const due = "(status = ? AND next_attempt_at <= ?) OR (status = ? AND updated_at < ?)"
+
+	var candidates []models.WebhookDelivery
+	result := db.
+		Where(due, models.DeliveryPending, now, models.DeliverySending, staleBefore).
+		Order("id").
+		Limit(limit).
+		Find(&candidates)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get due webhook deliveries: %w", result.Error)
+	}
+
+	claimed := make([]models.WebhookDelivery, 0, len(candidates))
+	for _, delivery := range candidates {
+		res := db.Model(&models.WebhookDelivery{}).
+			Where("id = ? AND ("+due+")", delivery.ID, models.DeliveryPending, now, models.DeliverySending, staleBefore).
+			Updates(map[string]interface{}{
+				"status":     models.DeliverySending,
+				"attempts":   gorm.Expr("attempts + 1"),
+				"updated_at": time.Now(),
+			})
+		if res.Error != nil {
+			return nil, fmt.Errorf("failed to claim webhook delivery: %w", res.Error)
+		}
+		if res.RowsAffected == 1 {
+			delivery.Status = models.DeliverySending
+			delivery.Attempts++
+			claimed = append(claimed, delivery)
+		}
+	}
+	return claimed, nil
+}
+
+// SaveAttempt сохраняет итог попытки доставки
+func (r *webhookRepository) SaveAttempt(ctx context.Context, delivery *models.WebhookDelivery) error {
+	result := conn(ctx, r.db).Model(&models.WebhookDelivery{}).
+		Where("id = ?", delivery.ID).
+		Updates(map[string]interface{}{
+			"status":          delivery.Status,
+			"attempts":        delivery.Attempts,
+			"next_attempt_at": delivery.NextAttemptAt,
+			"response_status": delivery.ResponseStatus,
+			"response_body":   delivery.ResponseBody,
+			"last_error":      delivery.LastError,
+			"duration_ms":     delivery.DurationMS,
+			"delivered_at":    delivery.DeliveredAt,
+			"updated_at":      time.Now(),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to save webhook delivery attempt: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("webhook delivery with ID %d not found", delivery.ID)
+	}
+	return nil
+}
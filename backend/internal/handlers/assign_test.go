@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/Foxpunk/courseforge/internal/config"
+	"github.com/Foxpunk/courseforge/internal/drivers"
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/managers"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"github.com/Foxpunk/courseforge/internal/testutil"
+)
+
+// newAssignRouter собирает настоящий NewRouter с менеджерами записи на тему, чтобы запросы
+// проходили через авторизацию, проверку роли и idempotent; остальные менеджеры маршрутами
+// теста не используются. Гонку назначений на уровне менеджера проверяет
+// TestAssignStudentToCourseworkConcurrent, здесь - только ответы HTTP и повторы по ключу
+func newAssignRouter(t *testing.T, db *gorm.DB) (*gin.Engine, interfaces.AuthManager) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	// gin.Logger берёт writer при сборке роутера
+	prevWriter := gin.DefaultWriter
+	gin.DefaultWriter = io.Discard
+	t.Cleanup(func() { gin.DefaultWriter = prevWriter })
+
+	userRepo := drivers.NewUserRepository(db)
+	scRepo := drivers.NewStudentCourseworkRepository(db)
+	cwRepo := drivers.NewCourseworkRepository(db)
+	termRepo := drivers.NewTermRepository(db)
+	uow := drivers.NewUnitOfWork(db)
+
+	authManager := managers.NewAuthManager(userRepo, testutil.NopWebhooks{}, config.JWTConfig{
+		SecretKey:           "test-secret",
+		AccessTokenDuration: time.Hour,
+		Issuer:              "courseforge-test",
+	})
+	workload := managers.NewWorkloadManager(drivers.NewSupervisionQuotaRepository(db), drivers.NewTeacherProfileRepository(db),
+		drivers.NewDepartmentRepository(db), userRepo, cwRepo, scRepo, termRepo, config.WorkloadConfig{})
+	events := managers.NewEventBus(drivers.NewOutboxRepository(db), config.OutboxConfig{BatchSize: 50, MaxAttempts: 1})
+	waitlist := managers.NewWaitlistManager(drivers.NewWaitlistRepository(db), cwRepo, scRepo, workload, uow, events, time.Hour)
+	scManager := managers.NewStudentCourseworkManager(scRepo, cwRepo, drivers.NewSelectionRoundRepository(db), termRepo,
+		waitlist, workload, uow, events)
+	im := managers.NewIdempotencyManager(drivers.NewIdempotencyRepository(db), config.IdempotencyConfig{TTL: time.Hour})
+
+	r := NewRouter(authManager, nil, nil, nil, scManager, nil, nil, nil, waitlist, nil, workload, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, im, 0, time.Second, false, "test-secret")
+	return r, authManager
+}
+
+func TestAssignStudentEndpointConcurrent(t *testing.T) {
+	const (
+		slots    = 3
+		students = 20
+		repeat   = 3
+	)
+	db := testutil.NewDB(t)
+	r, authManager := newAssignRouter(t, db)
+	term := testutil.CreateActiveTerm(t, db)
+	teacher := testutil.CreateUser(t, db, models.RoleTeacher, "teacher@example.com")
+	cw := testutil.CreateCoursework(t, db, teacher, term, slots)
+
+	type student struct {
+		id    uint
+		token string
+	}
+	list := make([]student, students)
+	for i := range list {
+		u := testutil.CreateUser(t, db, models.RoleStudent, fmt.Sprintf("s%d@example.com", i))
+		token, err := authManager.GenerateToken(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		list[i] = student{id: u.ID, token: token}
+	}
+
+	path := fmt.Sprintf("/api/v1/courseworks/%d/assign", cw.ID)
+	assign := func(s student) *httptest.ResponseRecorder {
+		body := fmt.Sprintf(`{"student_id":%d,"coursework_id":%d}`, s.id, cw.ID)
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+s.token)
+		// клиент повторяет один и тот же запрос с тем же ключом
+		req.Header.Set(idempotencyKeyHeader, fmt.Sprintf("assign-%d", s.id))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// каждый студент отправляет несколько запросов, и все они стартуют одновременно
+	type result struct {
+		studentID uint
+		code      int
+		replayed  bool
+		body      string
+	}
+	start := make(chan struct{})
+	results := make(chan result, students*repeat)
+	var wg sync.WaitGroup
+	for _, s := range list {
+		for j := 0; j < repeat; j++ {
+			wg.Add(1)
+			go func(s student) {
+				defer wg.Done()
+				<-start
+				w := assign(s)
+				results <- result{
+					studentID: s.id,
+					code:      w.Code,
+					replayed:  w.Header().Get("Idempotent-Replayed") == "true",
+					body:      w.Body.String(),
+				}
+			}(s)
+		}
+	}
+	close(start)
+	wg.Wait()
+	close(results)
+
+	// первый ответ по каждому ключу: выполненный запрос, а не повтор и не 409
+	executed := make(map[uint]int)
+	created := make(map[uint]bool)
+	for res := range results {
+		switch {
+		case res.code == http.StatusConflict:
+			// ключ ещё занят параллельным запросом того же студента
+		case res.replayed:
+			// повтор отдаёт сохранённый ответ и проверяется ниже
+		case res.code == http.StatusCreated:
+			executed[res.studentID]++
+			created[res.studentID] = true
+		case res.code == http.StatusBadRequest:
+			executed[res.studentID]++
+			if !strings.Contains(res.body, "no slots available") {
+				t.Errorf("student %d: unexpected rejection: %s", res.studentID, res.body)
+			}
+		default:
+			t.Errorf("student %d: status %d: %s", res.studentID, res.code, res.body)
+		}
+	}
+	for _, s := range list {
+		if executed[s.id] != 1 {
+			t.Errorf("student %d: handler ran %d times for one idempotency key, want 1", s.id, executed[s.id])
+		}
+	}
+	if len(created) != slots {
+		t.Errorf("%d students assigned, want %d", len(created), slots)
+	}
+
+	// повтор после завершения отдаёт сохранённый ответ и не назначает студента повторно
+	for _, s := range list {
+		w := assign(s)
+		if w.Header().Get("Idempotent-Replayed") != "true" {
+			t.Errorf("student %d: retry was not replayed, status %d: %s", s.id, w.Code, w.Body.String())
+		}
+		want := http.StatusBadRequest
+		if created[s.id] {
+			want = http.StatusCreated
+		}
+		if w.Code != want {
+			t.Errorf("student %d: retry status = %d, want %d", s.id, w.Code, want)
+		}
+	}
+}
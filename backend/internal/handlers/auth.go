@@ -13,16 +13,18 @@ import (
 )
 
 type AuthHandler struct {
-	authManager interfaces.AuthManager
-	userManager interfaces.UserManager
-	jwtSecret   string
+	authManager    interfaces.AuthManager
+	userManager    interfaces.UserManager
+	profileManager interfaces.ProfileManager
+	jwtSecret      string
 }
 
-func NewAuthHandler(authManager interfaces.AuthManager, userManager interfaces.UserManager, jwtSecret string) *AuthHandler {
+func NewAuthHandler(authManager interfaces.AuthManager, userManager interfaces.UserManager, profileManager interfaces.ProfileManager, jwtSecret string) *AuthHandler {
 	return &AuthHandler{
-		authManager: authManager,
-		userManager: userManager,
-		jwtSecret:   jwtSecret,
+		authManager:    authManager,
+		userManager:    userManager,
+		profileManager: profileManager,
+		jwtSecret:      jwtSecret,
 	}
 }
 
@@ -175,7 +177,7 @@ func (h *AuthHandler) ResetPassword(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Инструкции по сбросу пароля отправлены на email"})
 }
 
-// GetProfile - получение профиля текущего пользователя
+// GetProfile - получение текущего пользователя вместе с профилем студента или преподавателя
 func (h *AuthHandler) GetProfile(c *gin.Context) {
 	user, exists := c.Get("user")
 	if !exists {
@@ -191,7 +193,20 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, userProfile)
+	resp := interfaces.ProfileResponse{UserResponse: buildUserResponse(userProfile)}
+	switch userProfile.Role {
+	case models.RoleStudent:
+		if p, err := h.profileManager.GetStudentProfile(ctx, userID); err == nil {
+			sp := buildStudentProfileResponse(p)
+			resp.StudentProfile = &sp
+		}
+	case models.RoleTeacher:
+		if p, err := h.profileManager.GetTeacherProfile(ctx, userID); err == nil {
+			tp := buildTeacherProfileResponse(p)
+			resp.TeacherProfile = &tp
+		}
+	}
+	c.JSON(http.StatusOK, resp)
 }
 
 // Logout - выход из системы (в простой реализации просто подтверждение)
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// CurriculumHandler управляет учебным планом групп
+type CurriculumHandler struct {
+	curriculumManager interfaces.CurriculumManager
+	validator         *validator.Validate
+}
+
+// NewCurriculumHandler создаёт новый CurriculumHandler
+func NewCurriculumHandler(cm interfaces.CurriculumManager) *CurriculumHandler {
+	return &CurriculumHandler{
+		curriculumManager: cm,
+		validator:         validator.New(),
+	}
+}
+
+// GetGroupSubjects - учебный план группы (?term_id=N|all, по умолчанию - текущий семестр)
+func (h *CurriculumHandler) GetGroupSubjects(c *gin.Context) {
+	groupID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid group id"})
+		return
+	}
+	termID, allTerms, ok := parseTermQuery(c)
+	if !ok {
+		return
+	}
+
+	list, err := h.curriculumManager.GetGroupSubjects(c.Request.Context(), uint(groupID), termID, allTerms)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]interfaces.GroupSubjectResponse, len(list))
+	for i := range list {
+		resp[i] = buildGroupSubjectResponse(&list[i])
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// AddGroupSubject - включить дисциплину в учебный план группы (admin)
+func (h *CurriculumHandler) AddGroupSubject(c *gin.Context) {
+	groupID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid group id"})
+		return
+	}
+	var req interfaces.AddGroupSubjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.curriculumManager.AddGroupSubject(c.Request.Context(), uint(groupID), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"id": entry.ID, "group_id": entry.GroupID, "subject_id": entry.SubjectID, "term_id": entry.TermID})
+}
+
+// RemoveGroupSubject - исключить дисциплину из учебного плана группы (admin, ?term_id=N)
+func (h *CurriculumHandler) RemoveGroupSubject(c *gin.Context) {
+	groupID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid group id"})
+		return
+	}
+	subjectID, err := strconv.ParseUint(c.Param("subjectId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid subject id"})
+		return
+	}
+	var termID uint64
+	if raw := c.Query("term_id"); raw != "" {
+		if termID, err = strconv.ParseUint(raw, 10, 32); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid term_id"})
+			return
+		}
+	}
+
+	if err := h.curriculumManager.RemoveGroupSubject(c.Request.Context(), uint(groupID), uint(subjectID), uint(termID)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func buildGroupSubjectResponse(gs *models.GroupSubject) interfaces.GroupSubjectResponse {
+	return interfaces.GroupSubjectResponse{
+		ID:      gs.ID,
+		GroupID: gs.GroupID,
+		TermID:  gs.TermID,
+		Term:    gs.Term.Name,
+		Subject: interfaces.SubjectResponse{
+			ID:          gs.Subject.ID,
+			Name:        gs.Subject.Name,
+			Code:        gs.Subject.Code,
+			Description: gs.Subject.Description,
+			Semester:    gs.Subject.Semester,
+			IsActive:    gs.Subject.IsActive,
+			Teachers:    []interfaces.UserResponse{},
+			CreatedAt:   gs.Subject.CreatedAt.Format(time.RFC3339),
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+)
+
+// DeadlineHandler - ближайшие сроки текущего пользователя
+type DeadlineHandler struct {
+	deadlineManager interfaces.DeadlineManager
+}
+
+// NewDeadlineHandler создаёт новый DeadlineHandler
+func NewDeadlineHandler(dm interfaces.DeadlineManager) *DeadlineHandler {
+	return &DeadlineHandler{deadlineManager: dm}
+}
+
+// ListDeadlines - защиты, сроки предложений из листов ожидания и раундов выбора тем
+func (h *DeadlineHandler) ListDeadlines(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	deadlines, err := h.deadlineManager.ListDeadlines(c.Request.Context(), user.ID, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, deadlines)
+}
@@ -0,0 +1,370 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// DefenseHandler управляет аудиториями, сессиями защит и расписанием
+type DefenseHandler struct {
+	defenseManager interfaces.DefenseManager
+	validator      *validator.Validate
+}
+
+// NewDefenseHandler создаёт новый DefenseHandler
+func NewDefenseHandler(dm interfaces.DefenseManager) *DefenseHandler {
+	return &DefenseHandler{
+		defenseManager: dm,
+		validator:      validator.New(),
+	}
+}
+
+// CreateRoom - добавить аудиторию (admin)
+func (h *DefenseHandler) CreateRoom(c *gin.Context) {
+	var req interfaces.CreateDefenseRoomRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	room, err := h.defenseManager.CreateRoom(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, buildDefenseRoomResponse(room))
+}
+
+// ListRooms - список аудиторий
+func (h *DefenseHandler) ListRooms(c *gin.Context) {
+	rooms, err := h.defenseManager.ListRooms(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]interfaces.DefenseRoomResponse, len(rooms))
+	for i := range rooms {
+		resp[i] = buildDefenseRoomResponse(&rooms[i])
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteRoom - удалить аудиторию (admin)
+func (h *DefenseHandler) DeleteRoom(c *gin.Context) {
+	roomID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid room id"})
+		return
+	}
+
+	if err := h.defenseManager.DeleteRoom(c.Request.Context(), uint(roomID)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateSession - создать сессию защит (admin)
+func (h *DefenseHandler) CreateSession(c *gin.Context) {
+	var req interfaces.CreateDefenseSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := h.defenseManager.CreateSession(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, buildDefenseSessionResponse(session))
+}
+
+// ListSessions - список сессий защит
+func (h *DefenseHandler) ListSessions(c *gin.Context) {
+	sessions, err := h.defenseManager.ListSessions(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]interfaces.DefenseSessionResponse, len(sessions))
+	for i := range sessions {
+		resp[i] = buildDefenseSessionResponse(&sessions[i])
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetSession - сессия защит со слотами
+func (h *DefenseHandler) GetSession(c *gin.Context) {
+	sessionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid session id"})
+		return
+	}
+
+	session, err := h.defenseManager.GetSession(c.Request.Context(), uint(sessionID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "session not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, buildDefenseSessionResponse(session))
+}
+
+// DeleteSession - удалить сессию защит (admin)
+func (h *DefenseHandler) DeleteSession(c *gin.Context) {
+	sessionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid session id"})
+		return
+	}
+
+	if err := h.defenseManager.DeleteSession(c.Request.Context(), uint(sessionID)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SetCommittee - задать состав комиссии (admin)
+func (h *DefenseHandler) SetCommittee(c *gin.Context) {
+	sessionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid session id"})
+		return
+	}
+
+	var req interfaces.SetCommitteeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.defenseManager.SetCommittee(c.Request.Context(), uint(sessionID), req.Committee); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// DistributeStudents - автоматически распределить студентов по слотам (admin)
+func (h *DefenseHandler) DistributeStudents(c *gin.Context) {
+	sessionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid session id"})
+		return
+	}
+
+	result, err := h.defenseManager.DistributeStudents(c.Request.Context(), uint(sessionID))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// AssignSlot - вручную поставить студента в слот (admin)
+func (h *DefenseHandler) AssignSlot(c *gin.Context) {
+	slotID, err := strconv.ParseUint(c.Param("slotId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid slot id"})
+		return
+	}
+
+	var req interfaces.AssignDefenseSlotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.defenseManager.AssignSlot(c.Request.Context(), uint(slotID), req.AssignmentID); err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ReleaseSlot - освободить слот (admin)
+func (h *DefenseHandler) ReleaseSlot(c *gin.Context) {
+	slotID, err := strconv.ParseUint(c.Param("slotId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid slot id"})
+		return
+	}
+
+	if err := h.defenseManager.ReleaseSlot(c.Request.Context(), uint(slotID)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetMySchedule - расписание защит текущего пользователя
+func (h *DefenseHandler) GetMySchedule(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	entries, err := h.defenseManager.GetUserSchedule(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// GetCalendarLink - ссылка на iCalendar-ленту для подписки в календаре
+func (h *DefenseHandler) GetCalendarLink(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	token := h.defenseManager.CalendarToken(user.ID)
+	c.JSON(http.StatusOK, gin.H{
+		"url": "/api/v1/defense/calendar/" + strconv.FormatUint(uint64(user.ID), 10) + ".ics?token=" + token,
+	})
+}
+
+// GetCalendarFeed - iCalendar-лента пользователя (доступ по подписанной ссылке)
+func (h *DefenseHandler) GetCalendarFeed(c *gin.Context) {
+	param := c.Param("feed")
+	if len(param) < 5 || param[len(param)-4:] != ".ics" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "calendar not found"})
+		return
+	}
+	userID, err := strconv.ParseUint(param[:len(param)-4], 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+	if !h.defenseManager.VerifyCalendarToken(uint(userID), c.Query("token")) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "invalid calendar token"})
+		return
+	}
+
+	data, err := h.defenseManager.BuildCalendar(c.Request.Context(), uint(userID), time.Now())
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="defense.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", data)
+}
+
+// Вспомогательные функции
+
+// currentUser извлекает текущего пользователя из контекста
+func currentUser(c *gin.Context) *models.User {
+	raw, exists := c.Get("user")
+	if !exists {
+		return nil
+	}
+	user, ok := raw.(*models.User)
+	if !ok {
+		return nil
+	}
+	return user
+}
+
+// buildUserResponse создаёт ответ для пользователя
+func buildUserResponse(u *models.User) interfaces.UserResponse {
+	return interfaces.UserResponse{
+		ID:        u.ID,
+		Email:     u.Email,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		Role:      u.Role,
+		IsActive:  u.IsActive,
+		CreatedAt: u.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func buildDefenseRoomResponse(room *models.DefenseRoom) interfaces.DefenseRoomResponse {
+	return interfaces.DefenseRoomResponse{
+		ID:       room.ID,
+		Name:     room.Name,
+		Building: room.Building,
+		Capacity: room.Capacity,
+	}
+}
+
+func buildDefenseSessionResponse(s *models.DefenseSession) interfaces.DefenseSessionResponse {
+	resp := interfaces.DefenseSessionResponse{
+		ID:    s.ID,
+		Title: s.Title,
+		Subject: interfaces.SubjectResponse{
+			ID:          s.Subject.ID,
+			Name:        s.Subject.Name,
+			Code:        s.Subject.Code,
+			Description: s.Subject.Description,
+			Semester:    s.Subject.Semester,
+			IsActive:    s.Subject.IsActive,
+			CreatedAt:   s.Subject.CreatedAt.Format(time.RFC3339),
+		},
+		Room:        buildDefenseRoomResponse(&s.Room),
+		StartsAt:    s.StartsAt,
+		EndsAt:      s.EndsAt,
+		SlotMinutes: s.SlotMinutes,
+		Committee:   make([]interfaces.CommitteeMemberResponse, len(s.Committee)),
+	}
+	for i, member := range s.Committee {
+		resp.Committee[i] = interfaces.CommitteeMemberResponse{
+			Teacher: buildUserResponse(&member.Teacher),
+			Role:    member.Role,
+		}
+	}
+	for _, slot := range s.Slots {
+		item := interfaces.DefenseSlotResponse{
+			ID:           slot.ID,
+			StartsAt:     slot.StartsAt,
+			EndsAt:       slot.EndsAt,
+			AssignmentID: slot.StudentCourseworkID,
+		}
+		if slot.StudentCoursework != nil {
+			student := buildUserResponse(&slot.StudentCoursework.Student)
+			item.Student = &student
+			item.CourseworkTitle = slot.StudentCoursework.Coursework.Title
+		}
+		resp.Slots = append(resp.Slots, item)
+	}
+	return resp
+}
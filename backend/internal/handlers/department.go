@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// DepartmentHandler управляет кафедрами
+type DepartmentHandler struct {
+	departmentManager interfaces.DepartmentManager
+	groupManager      interfaces.GroupManager
+	validator         *validator.Validate
+}
+
+// NewDepartmentHandler создаёт новый DepartmentHandler
+func NewDepartmentHandler(dm interfaces.DepartmentManager, gm interfaces.GroupManager) *DepartmentHandler {
+	return &DepartmentHandler{
+		departmentManager: dm,
+		groupManager:      gm,
+		validator:         validator.New(),
+	}
+}
+
+// ListDepartments - список кафедр
+func (h *DepartmentHandler) ListDepartments(c *gin.Context) {
+	list, err := h.departmentManager.ListDepartments(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]interfaces.DepartmentResponse, len(list))
+	for i := range list {
+		resp[i] = buildDepartmentResponse(&list[i])
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetDepartment - кафедра по ID
+func (h *DepartmentHandler) GetDepartment(c *gin.Context) {
+	deptID, ok := parseIDParam(c, "id", "department")
+	if !ok {
+		return
+	}
+
+	dept, err := h.departmentManager.GetDepartment(c.Request.Context(), deptID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "department not found"})
+		return
+	}
+	c.JSON(http.StatusOK, buildDepartmentResponse(dept))
+}
+
+// CreateDepartment - создание кафедры (admin)
+func (h *DepartmentHandler) CreateDepartment(c *gin.Context) {
+	var req interfaces.CreateDepartmentRequest
+	if !bindAndValidate(c, h.validator, &req) {
+		return
+	}
+
+	dept, err := h.departmentManager.CreateDepartment(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, buildDepartmentResponse(dept))
+}
+
+// UpdateDepartment - изменение кафедры (admin)
+func (h *DepartmentHandler) UpdateDepartment(c *gin.Context) {
+	deptID, ok := parseIDParam(c, "id", "department")
+	if !ok {
+		return
+	}
+	var req interfaces.UpdateDepartmentRequest
+	if !bindAndValidate(c, h.validator, &req) {
+		return
+	}
+
+	dept, err := h.departmentManager.UpdateDepartment(c.Request.Context(), deptID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, buildDepartmentResponse(dept))
+}
+
+// DeleteDepartment - удаление пустой кафедры (admin)
+func (h *DepartmentHandler) DeleteDepartment(c *gin.Context) {
+	deptID, ok := parseIDParam(c, "id", "department")
+	if !ok {
+		return
+	}
+
+	if err := h.departmentManager.DeleteDepartment(c.Request.Context(), deptID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetDepartmentGroups - группы кафедры
+func (h *DepartmentHandler) GetDepartmentGroups(c *gin.Context) {
+	deptID, ok := parseIDParam(c, "id", "department")
+	if !ok {
+		return
+	}
+
+	groups, err := h.groupManager.GetGroupsByDepartment(c.Request.Context(), deptID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]interfaces.StudentGroupResponse, len(groups))
+	for i := range groups {
+		resp[i] = buildGroupResponse(&groups[i])
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetDepartmentTeachers - профили преподавателей кафедры
+func (h *DepartmentHandler) GetDepartmentTeachers(c *gin.Context) {
+	deptID, ok := parseIDParam(c, "id", "department")
+	if !ok {
+		return
+	}
+
+	profiles, err := h.departmentManager.GetDepartmentTeachers(c.Request.Context(), deptID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]interfaces.TeacherProfileResponse, len(profiles))
+	for i := range profiles {
+		resp[i] = buildTeacherProfileResponse(&profiles[i])
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Вспомогательные функции
+
+// parseIDParam разбирает числовой параметр пути, при ошибке сам пишет ответ
+func parseIDParam(c *gin.Context, param, what string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(param), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + what + " id"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// bindAndValidate читает JSON тела запроса и проверяет его валидатором
+func bindAndValidate(c *gin.Context, v *validator.Validate, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+	if err := v.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+	return true
+}
+
+func buildDepartmentResponse(d *models.Department) interfaces.DepartmentResponse {
+	return interfaces.DepartmentResponse{
+		ID:             d.ID,
+		DepartmentCode: d.DepartmentCode,
+		DepartmentName: d.DepartmentName,
+		Description:    d.Description,
+		HeadUserID:     d.HeadUserID,
+		CreatedAt:      d.CreatedAt.Format(time.RFC3339),
+	}
+}
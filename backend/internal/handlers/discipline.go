@@ -106,8 +106,7 @@ func (h *DisciplineHandler) AssignTeacher(c *gin.Context) {
 		c.Request.Context(),
 		req.TeacherID,
 		uint(subjID),
-		req.AcademicYear,
-		req.IsLead,
+		req,
 	)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -225,12 +224,8 @@ func (h *DisciplineHandler) RemoveTeacher(c *gin.Context) {
 		return
 	}
 
-	// Академический год из query параметра
+	// Академический год из query параметра, по умолчанию - текущий
 	academicYear := c.Query("academic_year")
-	if academicYear == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "academic_year is required"})
-		return
-	}
 
 	err = h.subjectManager.RemoveTeacherFromSubject(
 		c.Request.Context(),
@@ -275,7 +270,42 @@ func (h *DisciplineHandler) SetLeadTeacher(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// GetLeadTeacher - ведущий преподаватель дисциплины в учебном году (по умолчанию - текущем)
+func (h *DisciplineHandler) GetLeadTeacher(c *gin.Context) {
+	idParam := c.Param("id")
+	subjID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid subject id"})
+		return
+	}
+
+	lead, err := h.subjectManager.GetLeadTeacher(c.Request.Context(), uint(subjID), c.Query("academic_year"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, interfaces.TeacherSubjectResponse{
+		TermID:  lead.TermID,
+		Teacher: buildUserResponse(&lead.Teacher),
+		Subject: interfaces.SubjectResponse{
+			ID:          lead.Subject.ID,
+			Name:        lead.Subject.Name,
+			Code:        lead.Subject.Code,
+			Description: lead.Subject.Description,
+			Semester:    lead.Subject.Semester,
+			IsActive:    lead.Subject.IsActive,
+			Teachers:    []interfaces.UserResponse{},
+			CreatedAt:   lead.Subject.CreatedAt.Format(time.RFC3339),
+		},
+		AcademicYear: lead.AcademicYear,
+		IsLead:       lead.IsLead,
+		Role:         string(lead.Role),
+		CreatedAt:    lead.CreatedAt.Format(time.RFC3339),
+	})
+}
+
 type SetLeadTeacherRequest struct {
 	TeacherID    uint   `json:"teacher_id" validate:"required"`
-	AcademicYear string `json:"academic_year" validate:"required"`
+	AcademicYear string `json:"academic_year"` // по умолчанию - текущий учебный год
 }
@@ -0,0 +1,432 @@
+package handlers
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// DiscussionHandler - обсуждения тем и переписка по назначениям
+type DiscussionHandler struct {
+	discussionManager interfaces.DiscussionManager
+	validator         *validator.Validate
+	maxUploadSize     int64
+}
+
+// NewDiscussionHandler создаёт новый DiscussionHandler
+func NewDiscussionHandler(dm interfaces.DiscussionManager, maxUploadSize int64) *DiscussionHandler {
+	return &DiscussionHandler{
+		discussionManager: dm,
+		validator:         validator.New(),
+		maxUploadSize:     maxUploadSize,
+	}
+}
+
+// GetCourseworkThread - открытое обсуждение темы
+func (h *DiscussionHandler) GetCourseworkThread(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "coursework")
+	if !ok {
+		return
+	}
+	thread, err := h.discussionManager.GetCourseworkThread(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "project not found"})
+		return
+	}
+	h.respondThread(c, thread)
+}
+
+// GetAssignmentThread - переписка студента с руководителем по назначению
+func (h *DiscussionHandler) GetAssignmentThread(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "assignment")
+	if !ok {
+		return
+	}
+	thread, err := h.discussionManager.GetAssignmentThread(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "assignment not found"})
+		return
+	}
+	h.respondThread(c, thread)
+}
+
+// GetThread - обсуждение по ID
+func (h *DiscussionHandler) GetThread(c *gin.Context) {
+	thread, _, ok := h.loadThread(c)
+	if !ok {
+		return
+	}
+	h.respondThread(c, thread)
+}
+
+// GetUnreadThreads - обсуждения текущего пользователя с непрочитанными сообщениями
+func (h *DiscussionHandler) GetUnreadThreads(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	threads, err := h.discussionManager.ListUnreadThreads(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	resp := make([]interfaces.ThreadResponse, len(threads))
+	for i := range threads {
+		resp[i] = buildThreadResponse(&threads[i].Thread)
+		resp[i].UnreadCount = threads[i].Unread
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateComment - новое сообщение или ответ (parent_id)
+func (h *DiscussionHandler) CreateComment(c *gin.Context) {
+	thread, user, ok := h.loadThread(c)
+	if !ok {
+		return
+	}
+	var req interfaces.CreateCommentRequest
+	if !bindAndValidate(c, h.validator, &req) {
+		return
+	}
+
+	comment, err := h.discussionManager.AddComment(c.Request.Context(), thread.ID, user.ID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, buildCommentResponse(comment))
+}
+
+// MarkThreadRead - отметить обсуждение прочитанным (целиком или до comment_id)
+func (h *DiscussionHandler) MarkThreadRead(c *gin.Context) {
+	thread, user, ok := h.loadThread(c)
+	if !ok {
+		return
+	}
+	var req interfaces.MarkThreadReadRequest
+	if c.Request.ContentLength != 0 && !bindAndValidate(c, h.validator, &req) {
+		return
+	}
+
+	if err := h.discussionManager.MarkRead(c.Request.Context(), thread.ID, user.ID, req.CommentID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateComment - правка сообщения автором
+func (h *DiscussionHandler) UpdateComment(c *gin.Context) {
+	comment, _, user, ok := h.loadComment(c)
+	if !ok {
+		return
+	}
+	if comment.AuthorID != user.ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return
+	}
+	var req interfaces.UpdateCommentRequest
+	if !bindAndValidate(c, h.validator, &req) {
+		return
+	}
+
+	updated, err := h.discussionManager.EditComment(c.Request.Context(), comment.ID, user.ID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, buildCommentResponse(updated))
+}
+
+// DeleteComment - удаление сообщения автором или руководителем темы
+func (h *DiscussionHandler) DeleteComment(c *gin.Context) {
+	comment, thread, user, ok := h.loadComment(c)
+	if !ok {
+		return
+	}
+	if comment.AuthorID != user.ID && !h.canModerate(c, user, thread) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return
+	}
+
+	if err := h.discussionManager.DeleteComment(c.Request.Context(), comment.ID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetCommentHistory - прежние версии сообщения
+func (h *DiscussionHandler) GetCommentHistory(c *gin.Context) {
+	comment, _, _, ok := h.loadComment(c)
+	if !ok {
+		return
+	}
+
+	revisions, err := h.discussionManager.GetCommentHistory(c.Request.Context(), comment.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	resp := make([]interfaces.CommentRevisionResponse, len(revisions))
+	for i := range revisions {
+		resp[i] = interfaces.CommentRevisionResponse{
+			Body:      revisions[i].Body,
+			Editor:    buildUserResponse(&revisions[i].Editor),
+			CreatedAt: revisions[i].CreatedAt,
+		}
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// UploadAttachment - приложить файл к своему сообщению (multipart: file)
+func (h *DiscussionHandler) UploadAttachment(c *gin.Context) {
+	comment, _, user, ok := h.loadComment(c)
+	if !ok {
+		return
+	}
+	if comment.AuthorID != user.ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadSize+1<<20)
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	if header.Size > h.maxUploadSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file is too large"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.discussionManager.AddAttachment(c.Request.Context(), comment.ID, header.Filename, header.Header.Get("Content-Type"), file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, buildCommentAttachmentResponse(attachment))
+}
+
+// DownloadAttachment - скачивание приложенного файла
+func (h *DiscussionHandler) DownloadAttachment(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	id, ok := parseIDParam(c, "id", "attachment")
+	if !ok {
+		return
+	}
+
+	attachment, err := h.discussionManager.GetAttachment(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "attachment not found"})
+		return
+	}
+	comment, err := h.discussionManager.GetComment(c.Request.Context(), attachment.CommentID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "attachment not found"})
+		return
+	}
+	if _, ok := h.checkThreadAccess(c, user, comment.ThreadID); !ok {
+		return
+	}
+
+	file, err := h.discussionManager.OpenAttachment(c.Request.Context(), attachment)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "file not found"})
+		return
+	}
+	defer file.Close()
+
+	contentType := attachment.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.DataFromReader(http.StatusOK, attachment.Size, contentType, file, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}),
+	})
+}
+
+// respondThread проверяет доступ и отдаёт обсуждение с сообщениями и отметками прочтения
+func (h *DiscussionHandler) respondThread(c *gin.Context, thread *models.DiscussionThread) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	canView, err := h.discussionManager.CanViewThread(c.Request.Context(), user.ID, thread)
+	if err != nil || !canView {
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return
+	}
+
+	comments, err := h.discussionManager.ListComments(c.Request.Context(), thread.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	lastRead, err := h.discussionManager.GetReadMark(c.Request.Context(), thread.ID, user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := buildThreadResponse(thread)
+	resp.LastReadCommentID = lastRead
+	resp.Comments = make([]interfaces.CommentResponse, 0, len(comments))
+	for _, comment := range visibleComments(comments) {
+		item := buildCommentResponse(comment)
+		if !item.IsDeleted && comment.AuthorID != user.ID && comment.ID > lastRead {
+			item.IsUnread = true
+			resp.UnreadCount++
+		}
+		resp.Comments = append(resp.Comments, item)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// loadThread загружает обсуждение по :id и проверяет доступ, при ошибке сам пишет ответ
+func (h *DiscussionHandler) loadThread(c *gin.Context) (*models.DiscussionThread, *models.User, bool) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return nil, nil, false
+	}
+	id, ok := parseIDParam(c, "id", "thread")
+	if !ok {
+		return nil, nil, false
+	}
+	thread, ok := h.checkThreadAccess(c, user, id)
+	return thread, user, ok
+}
+
+// loadComment загружает сообщение по :id вместе с обсуждением и проверяет доступ
+func (h *DiscussionHandler) loadComment(c *gin.Context) (*models.Comment, *models.DiscussionThread, *models.User, bool) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return nil, nil, nil, false
+	}
+	id, ok := parseIDParam(c, "id", "comment")
+	if !ok {
+		return nil, nil, nil, false
+	}
+
+	comment, err := h.discussionManager.GetComment(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "comment not found"})
+		return nil, nil, nil, false
+	}
+	thread, ok := h.checkThreadAccess(c, user, comment.ThreadID)
+	if !ok {
+		return nil, nil, nil, false
+	}
+	return comment, thread, user, true
+}
+
+func (h *DiscussionHandler) checkThreadAccess(c *gin.Context, user *models.User, threadID uint) (*models.DiscussionThread, bool) {
+	thread, err := h.discussionManager.GetThread(c.Request.Context(), threadID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "thread not found"})
+		return nil, false
+	}
+	canView, err := h.discussionManager.CanViewThread(c.Request.Context(), user.ID, thread)
+	if err != nil || !canView {
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return nil, false
+	}
+	return thread, true
+}
+
+func (h *DiscussionHandler) canModerate(c *gin.Context, user *models.User, thread *models.DiscussionThread) bool {
+	ok, err := h.discussionManager.CanModerateThread(c.Request.Context(), user.ID, thread)
+	return err == nil && ok
+}
+
+// visibleComments убирает удалённые сообщения, кроме тех, на которые остались ответы:
+// иначе ветка развалится. Ответы всегда новее своего сообщения, поэтому хватает одного
+// прохода с конца.
+func visibleComments(comments []models.Comment) []*models.Comment {
+	keep := make([]bool, len(comments))
+	hasReplies := make(map[uint]bool)
+	for i := len(comments) - 1; i >= 0; i-- {
+		c := &comments[i]
+		keep[i] = !c.DeletedAt.Valid || hasReplies[c.ID]
+		if keep[i] && c.ParentID != nil {
+			hasReplies[*c.ParentID] = true
+		}
+	}
+
+	result := make([]*models.Comment, 0, len(comments))
+	for i := range comments {
+		if keep[i] {
+			result = append(result, &comments[i])
+		}
+	}
+	return result
+}
+
+func buildThreadResponse(t *models.DiscussionThread) interfaces.ThreadResponse {
+	return interfaces.ThreadResponse{
+		ID:                  t.ID,
+		Kind:                t.Kind,
+		CourseworkID:        t.CourseworkID,
+		CourseworkTitle:     t.Coursework.Title,
+		StudentCourseworkID: t.StudentCourseworkID,
+		LastCommentAt:       t.LastCommentAt,
+	}
+}
+
+// buildCommentResponse создаёт ответ для сообщения; от удалённого остаются только место и связи
+func buildCommentResponse(cm *models.Comment) interfaces.CommentResponse {
+	resp := interfaces.CommentResponse{
+		ID:        cm.ID,
+		ThreadID:  cm.ThreadID,
+		ParentID:  cm.ParentID,
+		CreatedAt: cm.CreatedAt,
+	}
+	if cm.DeletedAt.Valid {
+		resp.IsDeleted = true
+		return resp
+	}
+
+	author := buildUserResponse(&cm.Author)
+	resp.Author = &author
+	resp.Body = cm.Body
+	resp.BodyHTML = cm.BodyHTML
+	resp.EditedAt = cm.EditedAt
+	for i := range cm.Mentions {
+		resp.Mentions = append(resp.Mentions, buildUserResponse(&cm.Mentions[i].User))
+	}
+	for i := range cm.Attachments {
+		resp.Attachments = append(resp.Attachments, buildCommentAttachmentResponse(&cm.Attachments[i]))
+	}
+	return resp
+}
+
+func buildCommentAttachmentResponse(a *models.CommentAttachment) interfaces.CommentAttachmentResponse {
+	return interfaces.CommentAttachmentResponse{
+		ID:          a.ID,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		CreatedAt:   a.CreatedAt,
+	}
+}
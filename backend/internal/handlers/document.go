@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// DocumentHandler управляет шаблонами и выпуском официальных документов
+type DocumentHandler struct {
+	documentManager interfaces.DocumentManager
+	validator       *validator.Validate
+}
+
+// NewDocumentHandler создаёт новый DocumentHandler
+func NewDocumentHandler(dm interfaces.DocumentManager) *DocumentHandler {
+	return &DocumentHandler{
+		documentManager: dm,
+		validator:       validator.New(),
+	}
+}
+
+// ListTemplates - шаблоны документов (?kind=topic_order)
+func (h *DocumentHandler) ListTemplates(c *gin.Context) {
+	list, err := h.documentManager.ListTemplates(c.Request.Context(), models.DocumentKind(c.Query("kind")))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]interfaces.DocumentTemplateResponse, len(list))
+	for i := range list {
+		resp[i] = buildDocumentTemplateResponse(&list[i])
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetTemplate - шаблон по ID
+func (h *DocumentHandler) GetTemplate(c *gin.Context) {
+	tplID, ok := parseIDParam(c, "id", "template")
+	if !ok {
+		return
+	}
+
+	tpl, err := h.documentManager.GetTemplate(c.Request.Context(), tplID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "template not found"})
+		return
+	}
+	c.JSON(http.StatusOK, buildDocumentTemplateResponse(tpl))
+}
+
+// CreateTemplate - новый шаблон документа
+func (h *DocumentHandler) CreateTemplate(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	var req interfaces.CreateDocumentTemplateRequest
+	if !bindAndValidate(c, h.validator, &req) {
+		return
+	}
+
+	tpl, err := h.documentManager.CreateTemplate(c.Request.Context(), user.ID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, buildDocumentTemplateResponse(tpl))
+}
+
+// UpdateTemplate - изменение шаблона
+func (h *DocumentHandler) UpdateTemplate(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	tplID, ok := parseIDParam(c, "id", "template")
+	if !ok {
+		return
+	}
+	var req interfaces.UpdateDocumentTemplateRequest
+	if !bindAndValidate(c, h.validator, &req) {
+		return
+	}
+
+	tpl, err := h.documentManager.UpdateTemplate(c.Request.Context(), user.ID, tplID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, buildDocumentTemplateResponse(tpl))
+}
+
+// DeleteTemplate - удаление шаблона
+func (h *DocumentHandler) DeleteTemplate(c *gin.Context) {
+	tplID, ok := parseIDParam(c, "id", "template")
+	if !ok {
+		return
+	}
+
+	if err := h.documentManager.DeleteTemplate(c.Request.Context(), tplID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// IssueTopicOrder - выпуск приказа о закреплении тем курсовых работ
+func (h *DocumentHandler) IssueTopicOrder(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	var req interfaces.IssueTopicOrderRequest
+	if !bindAndValidate(c, h.validator, &req) {
+		return
+	}
+
+	doc, err := h.documentManager.IssueTopicOrder(c.Request.Context(), user.ID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, buildIssuedDocumentResponse(doc))
+}
+
+// ListDocuments - реестр выпущенных документов (?kind=&subject_id=&all=true - вместе с заменёнными редакциями)
+func (h *DocumentHandler) ListDocuments(c *gin.Context) {
+	var subjectID uint
+	if raw := c.Query("subject_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid subject id"})
+			return
+		}
+		subjectID = uint(id)
+	}
+	withSuperseded, _ := strconv.ParseBool(c.Query("all"))
+
+	list, err := h.documentManager.ListDocuments(c.Request.Context(), models.DocumentKind(c.Query("kind")), subjectID, withSuperseded)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, buildIssuedDocumentList(list))
+}
+
+// GetDocument - сведения о выпущенном документе
+func (h *DocumentHandler) GetDocument(c *gin.Context) {
+	docID, ok := parseIDParam(c, "id", "document")
+	if !ok {
+		return
+	}
+
+	doc, err := h.documentManager.GetDocument(c.Request.Context(), docID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "document not found"})
+		return
+	}
+	c.JSON(http.StatusOK, buildIssuedDocumentResponse(doc))
+}
+
+// GetDocumentVersions - все редакции документа
+func (h *DocumentHandler) GetDocumentVersions(c *gin.Context) {
+	docID, ok := parseIDParam(c, "id", "document")
+	if !ok {
+		return
+	}
+
+	list, err := h.documentManager.GetDocumentVersions(c.Request.Context(), docID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "document not found"})
+		return
+	}
+	c.JSON(http.StatusOK, buildIssuedDocumentList(list))
+}
+
+// DownloadDocument - файл документа в том виде, в каком он был выпущен
+func (h *DocumentHandler) DownloadDocument(c *gin.Context) {
+	docID, ok := parseIDParam(c, "id", "document")
+	if !ok {
+		return
+	}
+
+	doc, err := h.documentManager.GetDocument(c.Request.Context(), docID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "document not found"})
+		return
+	}
+
+	contentType := "application/pdf"
+	if doc.Format == models.DocumentFormatDOCX {
+		contentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	}
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": doc.FileName}))
+	c.Data(http.StatusOK, contentType, doc.Content)
+}
+
+func buildDocumentTemplateResponse(t *models.DocumentTemplate) interfaces.DocumentTemplateResponse {
+	return interfaces.DocumentTemplateResponse{
+		ID:           t.ID,
+		Kind:         t.Kind,
+		Name:         t.Name,
+		NumberPrefix: t.NumberPrefix,
+		Header:       t.Header,
+		Footer:       t.Footer,
+		IsDefault:    t.IsDefault,
+		UpdatedAt:    t.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func buildIssuedDocumentResponse(d *models.IssuedDocument) interfaces.IssuedDocumentResponse {
+	resp := interfaces.IssuedDocumentResponse{
+		ID:           d.ID,
+		Kind:         d.Kind,
+		Number:       d.Number,
+		Version:      d.Version,
+		IsCurrent:    d.IsCurrent,
+		AcademicYear: d.AcademicYear,
+		Format:       d.Format,
+		FileName:     d.FileName,
+		SubjectID:    d.SubjectID,
+		SubjectName:  d.Subject.Name,
+		GroupID:      d.GroupID,
+		TermID:       d.TermID,
+		TemplateID:   d.TemplateID,
+		CreatedBy:    buildUserResponse(&d.CreatedBy),
+		CreatedAt:    d.CreatedAt.Format(time.RFC3339),
+	}
+	if d.Group != nil {
+		resp.GroupCode = d.Group.GroupCode
+	}
+	return resp
+}
+
+func buildIssuedDocumentList(list []models.IssuedDocument) []interfaces.IssuedDocumentResponse {
+	resp := make([]interfaces.IssuedDocumentResponse, len(list))
+	for i := range list {
+		resp[i] = buildIssuedDocumentResponse(&list[i])
+	}
+	return resp
+}
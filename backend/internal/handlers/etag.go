@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+)
+
+// etag строит сильный ETag из версии записи
+func etag(version uint) string {
+	return `"` + strconv.FormatUint(uint64(version), 10) + `"`
+}
+
+// setETag отдаёт версию записи в заголовке ETag
+func setETag(c *gin.Context, version uint) {
+	c.Header("ETag", etag(version))
+}
+
+// etagMatches сообщает, есть ли в списке тегов из If-Match/If-None-Match текущая версия
+// записи или "*". weak - слабое сравнение (RFC 7232 §2.3.2) для If-None-Match: префикс W/
+// отбрасывается. If-Match сравнивается строго, и слабый тег с ним не совпадает никогда
+func etagMatches(header string, version uint, weak bool) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if weak {
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+		if tag == "*" || tag == etag(version) {
+			return true
+		}
+	}
+	return false
+}
+
+// notModified отвечает 304, если версия у клиента (If-None-Match) совпадает с текущей
+func notModified(c *gin.Context, version uint) bool {
+	match := c.GetHeader("If-None-Match")
+	if match == "" || !etagMatches(match, version, true) {
+		return false
+	}
+	setETag(c, version)
+	c.Status(http.StatusNotModified)
+	return true
+}
+
+// ifMatchVersion сверяет If-Match с текущей версией записи и возвращает версию, которую
+// должно проверить обновление: 0 - заголовка нет или указан "*". Если ни один тег из
+// списка не совпал строго (слабые W/ не совпадают), отвечает 412
+func ifMatchVersion(c *gin.Context, current uint) (uint, bool) {
+	match := strings.TrimSpace(c.GetHeader("If-Match"))
+	if match == "" {
+		return 0, true
+	}
+	if !etagMatches(match, current, false) {
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": "If-Match does not match the current version"})
+		return 0, false
+	}
+	for _, tag := range strings.Split(match, ",") {
+		if strings.TrimSpace(tag) == "*" {
+			return 0, true
+		}
+	}
+	return current, true
+}
+
+// versionConflict отвечает 412, если запись изменили после того, как клиент её прочитал
+func versionConflict(c *gin.Context, err error) bool {
+	if !errors.Is(err, interfaces.ErrVersionConflict) {
+		return false
+	}
+	c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+	return true
+}
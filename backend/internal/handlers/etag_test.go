@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestIfMatchVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const current = 3
+
+	tests := []struct {
+		name    string
+		header  string
+		version uint
+		ok      bool
+	}{
+		{name: "no header", header: "", version: 0, ok: true},
+		{name: "current tag", header: `"3"`, version: current, ok: true},
+		{name: "stale tag", header: `"2"`, ok: false},
+		// If-Match требует сильного сравнения: слабый тег текущей версии не проходит
+		{name: "weak tag", header: `W/"3"`, ok: false},
+		{name: "list with current", header: `"1", "2" ,"3"`, version: current, ok: true},
+		{name: "list with weak current", header: `"1", W/"3"`, ok: false},
+		{name: "list without current", header: `"1", "2"`, ok: false},
+		{name: "any", header: "*", version: 0, ok: true},
+		{name: "any in list", header: `"1", *`, version: 0, ok: true},
+		{name: "garbage", header: "abc", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPut, "/", nil)
+			if tt.header != "" {
+				c.Request.Header.Set("If-Match", tt.header)
+			}
+
+			version, ok := ifMatchVersion(c, current)
+			if ok != tt.ok || version != tt.version {
+				t.Errorf("ifMatchVersion(%q) = %d, %v; want %d, %v", tt.header, version, ok, tt.version, tt.ok)
+			}
+			if !tt.ok && w.Code != http.StatusPreconditionFailed {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusPreconditionFailed)
+			}
+		})
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{header: "", want: false},
+		{header: `"3"`, want: true},
+		{header: `"1", W/"3"`, want: true},
+		{header: `W/"3"`, want: true},
+		{header: `"1", "2"`, want: false},
+		{header: "*", want: true},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		if tt.header != "" {
+			c.Request.Header.Set("If-None-Match", tt.header)
+		}
+		if got := notModified(c, 3); got != tt.want {
+			t.Errorf("notModified(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
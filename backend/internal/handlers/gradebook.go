@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+)
+
+// GradebookHandler отдаёт ведомости оценок по курсовым работам
+type GradebookHandler struct {
+	gradebookManager interfaces.GradebookManager
+	validator        *validator.Validate
+}
+
+// NewGradebookHandler создаёт новый GradebookHandler
+func NewGradebookHandler(gm interfaces.GradebookManager) *GradebookHandler {
+	return &GradebookHandler{
+		gradebookManager: gm,
+		validator:        validator.New(),
+	}
+}
+
+// GetGradebook - ведомость по дисциплине (?subject_id=&group_id=&term_id=)
+func (h *GradebookHandler) GetGradebook(c *gin.Context) {
+	req, ok := h.bindGradebookRequest(c)
+	if !ok {
+		return
+	}
+
+	book, err := h.gradebookManager.GetGradebook(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, book)
+}
+
+// ExportGradebook - выгрузка ведомости (?format=csv|xlsx|pdf, остальные параметры как у GetGradebook)
+func (h *GradebookHandler) ExportGradebook(c *gin.Context) {
+	format := interfaces.GradebookFormat(c.DefaultQuery("format", string(interfaces.GradebookFormatPDF)))
+	switch format {
+	case interfaces.GradebookFormatCSV, interfaces.GradebookFormatXLSX, interfaces.GradebookFormatPDF:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be one of csv, xlsx, pdf"})
+		return
+	}
+
+	req, ok := h.bindGradebookRequest(c)
+	if !ok {
+		return
+	}
+
+	file, err := h.gradebookManager.ExportGradebook(c.Request.Context(), req, format)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// FormatMediaType сам кодирует кириллицу в имени файла (filename*=utf-8'')
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.FileName}))
+	c.Data(http.StatusOK, file.ContentType, file.Data)
+}
+
+// bindGradebookRequest читает параметры ведомости и проверяет доступ:
+// преподавателю доступны только ведомости дисциплин, которые он ведёт
+func (h *GradebookHandler) bindGradebookRequest(c *gin.Context) (interfaces.GradebookRequest, bool) {
+	var req interfaces.GradebookRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return req, false
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return req, false
+	}
+
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return req, false
+	}
+	if !user.IsAdmin() {
+		allowed, err := h.gradebookManager.CanViewGradebook(c.Request.Context(), user.ID, req)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return req, false
+		}
+		if !allowed {
+			c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+			return req, false
+		}
+	}
+	return req, true
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// GroupHandler управляет студенческими группами
+type GroupHandler struct {
+	groupManager interfaces.GroupManager
+	validator    *validator.Validate
+}
+
+// NewGroupHandler создаёт новый GroupHandler
+func NewGroupHandler(gm interfaces.GroupManager) *GroupHandler {
+	return &GroupHandler{
+		groupManager: gm,
+		validator:    validator.New(),
+	}
+}
+
+// ListGroups - список групп (?department_id=N - только группы кафедры)
+func (h *GroupHandler) ListGroups(c *gin.Context) {
+	var (
+		groups []models.StudentGroup
+		err    error
+	)
+	if raw := c.Query("department_id"); raw != "" {
+		deptID, perr := strconv.ParseUint(raw, 10, 32)
+		if perr != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid department_id"})
+			return
+		}
+		groups, err = h.groupManager.GetGroupsByDepartment(c.Request.Context(), uint(deptID))
+	} else {
+		groups, err = h.groupManager.ListGroups(c.Request.Context())
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]interfaces.StudentGroupResponse, len(groups))
+	for i := range groups {
+		resp[i] = buildGroupResponse(&groups[i])
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetGroup - группа по ID
+func (h *GroupHandler) GetGroup(c *gin.Context) {
+	groupID, ok := parseIDParam(c, "id", "group")
+	if !ok {
+		return
+	}
+
+	group, err := h.groupManager.GetGroup(c.Request.Context(), groupID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "group not found"})
+		return
+	}
+	c.JSON(http.StatusOK, buildGroupResponse(group))
+}
+
+// CreateGroup - создание группы (admin)
+func (h *GroupHandler) CreateGroup(c *gin.Context) {
+	var req interfaces.CreateGroupRequest
+	if !bindAndValidate(c, h.validator, &req) {
+		return
+	}
+
+	group, err := h.groupManager.CreateGroup(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.respondGroup(c, http.StatusCreated, group.ID)
+}
+
+// UpdateGroup - изменение группы (admin)
+func (h *GroupHandler) UpdateGroup(c *gin.Context) {
+	groupID, ok := parseIDParam(c, "id", "group")
+	if !ok {
+		return
+	}
+	var req interfaces.UpdateGroupRequest
+	if !bindAndValidate(c, h.validator, &req) {
+		return
+	}
+
+	if _, err := h.groupManager.UpdateGroup(c.Request.Context(), groupID, req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.respondGroup(c, http.StatusOK, groupID)
+}
+
+// DeleteGroup - удаление группы без студентов (admin)
+func (h *GroupHandler) DeleteGroup(c *gin.Context) {
+	groupID, ok := parseIDParam(c, "id", "group")
+	if !ok {
+		return
+	}
+
+	if err := h.groupManager.DeleteGroup(c.Request.Context(), groupID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetGroupStudents - профили студентов группы (teacher/admin)
+func (h *GroupHandler) GetGroupStudents(c *gin.Context) {
+	groupID, ok := parseIDParam(c, "id", "group")
+	if !ok {
+		return
+	}
+
+	profiles, err := h.groupManager.GetGroupStudents(c.Request.Context(), groupID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]interfaces.StudentProfileResponse, len(profiles))
+	for i := range profiles {
+		resp[i] = buildStudentProfileResponse(&profiles[i])
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// respondGroup перечитывает группу вместе с кафедрой и отдаёт её клиенту
+func (h *GroupHandler) respondGroup(c *gin.Context, status int, groupID uint) {
+	group, err := h.groupManager.GetGroup(c.Request.Context(), groupID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(status, buildGroupResponse(group))
+}
+
+func buildGroupResponse(g *models.StudentGroup) interfaces.StudentGroupResponse {
+	return interfaces.StudentGroupResponse{
+		ID:         g.ID,
+		GroupCode:  g.GroupCode,
+		CourseYear: g.CourseYear,
+		Specialty:  g.Specialty,
+		Department: buildDepartmentResponse(&g.Department),
+		CreatedAt:  g.CreatedAt.Format(time.RFC3339),
+	}
+}
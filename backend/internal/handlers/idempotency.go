@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+)
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+	maxIdempotencyKeyLen = 255
+)
+
+// responseRecorder копирует тело ответа, чтобы сохранить его для повторов
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// idempotent выполняет запрос с заголовком Idempotency-Key один раз: повтор с тем же ключом
+// и телом получает сохранённый ответ, тот же ключ с другим запросом - 422, а пока первый
+// запрос не завершён - 409. Ответ 5xx и паника обработчика (её перехватывает gin.Recovery
+// выше по цепочке) освобождают ключ, и повтор выполняется заново.
+// Запросы без заголовка проходят как обычно.
+// Ставится после AuthMiddleware: ключи у каждого пользователя свои
+func idempotent(im interfaces.IdempotencyManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(idempotencyKeyHeader)
+		user := currentUser(c)
+		if key == "" || user == nil {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLen {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key is too long"})
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		// отпечаток различает запросы на разные адреса и с разными телами
+		hash := sha256.New()
+		hash.Write([]byte(c.Request.Method + " " + c.Request.URL.Path + "\n"))
+		hash.Write(body)
+		fingerprint := hex.EncodeToString(hash.Sum(nil))
+
+		record, replay, err := im.Begin(c.Request.Context(), user.ID, key, fingerprint)
+		switch {
+		case errors.Is(err, interfaces.ErrIdempotencyKeyReused):
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, interfaces.ErrIdempotencyInProgress):
+			c.Header("Retry-After", "1")
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		case err != nil:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if replay {
+			c.Header("Idempotent-Replayed", "true")
+			c.Data(record.ResponseCode, record.ContentType, []byte(record.ResponseBody))
+			c.Abort()
+			return
+		}
+
+		// клиент с плохой связью мог уже отключиться, а ответ должен сохраниться для его повтора
+		ctx := context.WithoutCancel(c.Request.Context())
+		completed := false
+		// при панике обработчика Complete не вызывается - иначе ключ висел бы в pending
+		// до idempotencyLockTimeout и все повторы получали бы 409
+		defer func() {
+			if completed {
+				return
+			}
+			if err := im.Release(ctx, record); err != nil {
+				log.Printf("failed to release idempotency key %q: %v", key, err)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		if err := im.Complete(ctx, record, recorder.Status(), recorder.Header().Get("Content-Type"), recorder.body.Bytes()); err != nil {
+			log.Printf("failed to save response for idempotency key %q: %v", key, err)
+			return
+		}
+		completed = true
+	}
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Foxpunk/courseforge/internal/config"
+	"github.com/Foxpunk/courseforge/internal/drivers"
+	"github.com/Foxpunk/courseforge/internal/managers"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"github.com/Foxpunk/courseforge/internal/testutil"
+)
+
+// newIdempotentRouter собирает цепочку как в NewRouter: Recovery, пользователь, idempotent
+func newIdempotentRouter(t *testing.T, handler gin.HandlerFunc) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	db := testutil.NewDB(t)
+	im := managers.NewIdempotencyManager(drivers.NewIdempotencyRepository(db), config.IdempotencyConfig{TTL: time.Hour})
+
+	r := gin.New()
+	r.Use(gin.RecoveryWithWriter(io.Discard))
+	r.Use(func(c *gin.Context) {
+		c.Set("user", &models.User{ID: 1, Role: models.RoleStudent})
+	})
+	r.POST("/items", idempotent(im), handler)
+	return r
+}
+
+func postWithKey(r *gin.Engine, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`{"name":"item"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(idempotencyKeyHeader, key)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotentRetryAfterFailedHandler(t *testing.T) {
+	tests := []struct {
+		name string
+		fail func(c *gin.Context)
+	}{
+		{name: "panic", fail: func(c *gin.Context) { panic("handler failed") }},
+		{name: "server error", fail: func(c *gin.Context) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "handler failed"})
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			r := newIdempotentRouter(t, func(c *gin.Context) {
+				calls++
+				if calls == 1 {
+					tt.fail(c)
+					return
+				}
+				c.JSON(http.StatusCreated, gin.H{"call": calls})
+			})
+
+			if w := postWithKey(r, "key-1"); w.Code != http.StatusInternalServerError {
+				t.Fatalf("first request status = %d, want %d", w.Code, http.StatusInternalServerError)
+			}
+
+			// ключ освобождён: повтор выполняется заново, а не получает 409
+			w := postWithKey(r, "key-1")
+			if w.Code != http.StatusCreated {
+				t.Fatalf("retry status = %d, want %d: %s", w.Code, http.StatusCreated, w.Body.String())
+			}
+			if calls != 2 {
+				t.Errorf("handler calls after retry = %d, want 2", calls)
+			}
+
+			// успешный ответ сохранён и отдаётся повторно без вызова обработчика
+			w = postWithKey(r, "key-1")
+			if w.Code != http.StatusCreated || w.Header().Get("Idempotent-Replayed") != "true" {
+				t.Errorf("replay status = %d, replayed = %q", w.Code, w.Header().Get("Idempotent-Replayed"))
+			}
+			if calls != 2 {
+				t.Errorf("handler calls after replay = %d, want 2", calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// MaxImportFileSize - предельный размер загружаемого списка
+const MaxImportFileSize = 5 << 20
+
+// ImportHandler загружает списки студентов и преподавателей
+type ImportHandler struct {
+	importManager interfaces.ImportManager
+	validator     *validator.Validate
+}
+
+// NewImportHandler создаёт новый ImportHandler
+func NewImportHandler(im interfaces.ImportManager) *ImportHandler {
+	return &ImportHandler{
+		importManager: im,
+		validator:     validator.New(),
+	}
+}
+
+// ImportRoster - загрузка CSV/XLSX (multipart: file, kind, dry_run, department_id, course_year)
+func (h *ImportHandler) ImportRoster(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxImportFileSize+1<<20)
+	var req interfaces.ImportRosterRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	if header.Size > MaxImportFileSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file is too large"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	report, err := h.importManager.ImportRoster(c.Request.Context(), user.ID, header.Filename, file, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// ListImports - журнал загрузок
+func (h *ImportHandler) ListImports(c *gin.Context) {
+	limit := DefaultPageSize
+	if v, err := strconv.Atoi(c.Query("limit")); err == nil && v > 0 && v <= MaxPageSize {
+		limit = v
+	}
+	offset := 0
+	if v, err := strconv.Atoi(c.Query("offset")); err == nil && v > 0 {
+		offset = v
+	}
+
+	jobs, err := h.importManager.ListImports(c.Request.Context(), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]interfaces.ImportJobResponse, len(jobs))
+	for i := range jobs {
+		resp[i] = buildImportJobResponse(&jobs[i])
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetImport - загрузка с ошибками строк
+func (h *ImportHandler) GetImport(c *gin.Context) {
+	jobID, ok := parseIDParam(c, "id", "import")
+	if !ok {
+		return
+	}
+
+	job, err := h.importManager.GetImport(c.Request.Context(), jobID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "import not found"})
+		return
+	}
+	c.JSON(http.StatusOK, buildImportJobResponse(job))
+}
+
+// GetImportErrors - отчёт об ошибках загрузки в CSV, чтобы исправить строки и загрузить их снова
+func (h *ImportHandler) GetImportErrors(c *gin.Context) {
+	jobID, ok := parseIDParam(c, "id", "import")
+	if !ok {
+		return
+	}
+
+	job, err := h.importManager.GetImport(c.Request.Context(), jobID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "import not found"})
+		return
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff") // BOM, чтобы Excel открыл кириллицу в UTF-8
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{"row", "email", "error"})
+	for _, e := range job.Errors {
+		_ = w.Write([]string{strconv.Itoa(e.Row), e.Email, e.Message})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="import-%d-errors.csv"`, job.ID))
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
+
+func buildImportJobResponse(j *models.ImportJob) interfaces.ImportJobResponse {
+	resp := interfaces.ImportJobResponse{
+		ID:        j.ID,
+		Kind:      j.Kind,
+		FileName:  j.FileName,
+		DryRun:    j.DryRun,
+		CreatedBy: buildUserResponse(&j.CreatedBy),
+		Total:     j.Total,
+		Created:   j.Created,
+		Updated:   j.Updated,
+		Unchanged: j.Unchanged,
+		Failed:    j.Failed,
+		CreatedAt: j.CreatedAt.Format(time.RFC3339),
+	}
+	for _, e := range j.Errors {
+		resp.Errors = append(resp.Errors, interfaces.ImportRowResult{
+			Row:     e.Row,
+			Email:   e.Email,
+			Action:  interfaces.ImportRowError,
+			Message: e.Message,
+		})
+	}
+	return resp
+}
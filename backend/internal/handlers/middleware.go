@@ -91,6 +91,18 @@ func (m *Middleware) TeacherOrAdminRequired() gin.HandlerFunc {
 	}
 }
 
+// IfMatchRequired отклоняет изменение записи без If-Match с 428, чтобы клиент не
+// перезаписал чужие правки вслепую; при required=false заголовок необязателен
+func (m *Middleware) IfMatchRequired(required bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if required && c.GetHeader("If-Match") == "" {
+			c.AbortWithStatusJSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match header required"})
+			return
+		}
+		c.Next()
+	}
+}
+
 /*
 // CORS если нужен фронт
 
@@ -119,7 +131,8 @@ func (m *Middleware) CORS() gin.HandlerFunc {
 		}
 
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization, X-Requested-With")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization, X-Requested-With, If-Match, If-None-Match, Idempotency-Key")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "ETag")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 		c.Writer.Header().Set("Access-Control-Max-Age", "86400")
 
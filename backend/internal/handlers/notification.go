@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// NotificationHandler - центр уведомлений текущего пользователя
+type NotificationHandler struct {
+	notificationManager interfaces.NotificationManager
+	emailManager        interfaces.EmailManager
+	validator           *validator.Validate
+}
+
+// NewNotificationHandler создаёт новый NotificationHandler
+func NewNotificationHandler(nm interfaces.NotificationManager, em interfaces.EmailManager) *NotificationHandler {
+	return &NotificationHandler{
+		notificationManager: nm,
+		emailManager:        em,
+		validator:           validator.New(),
+	}
+}
+
+// ListNotifications - уведомления, новые сверху (?unread=true&limit=&offset=)
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	limit := DefaultPageSize
+	if v, err := strconv.Atoi(c.Query("limit")); err == nil && v > 0 && v <= MaxPageSize {
+		limit = v
+	}
+	offset := 0
+	if v, err := strconv.Atoi(c.Query("offset")); err == nil && v > 0 {
+		offset = v
+	}
+	unreadOnly := c.Query("unread") == "true"
+
+	items, err := h.notificationManager.ListNotifications(c.Request.Context(), user.ID, unreadOnly, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	unread, err := h.notificationManager.CountUnread(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := interfaces.NotificationListResponse{
+		Notifications: make([]interfaces.NotificationResponse, len(items)),
+		UnreadCount:   unread,
+	}
+	for i := range items {
+		resp.Notifications[i] = buildNotificationResponse(&items[i])
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetUnreadCount - число непрочитанных уведомлений (для значка в шапке)
+func (h *NotificationHandler) GetUnreadCount(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	unread, err := h.notificationManager.CountUnread(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"unread_count": unread})
+}
+
+// MarkRead - отметить прочитанными выбранные уведомления
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	var req interfaces.MarkNotificationsReadRequest
+	if !bindAndValidate(c, h.validator, &req) {
+		return
+	}
+
+	updated, err := h.notificationManager.MarkRead(c.Request.Context(), user.ID, req.IDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"updated": updated})
+}
+
+// MarkAllRead - отметить прочитанными все уведомления
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	updated, err := h.notificationManager.MarkAllRead(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"updated": updated})
+}
+
+// GetPreferences - какие события пользователь получает
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	prefs, err := h.notificationManager.GetPreferences(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, prefs)
+}
+
+// UpdatePreferences - включить или отключить виды событий
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	var req interfaces.UpdateNotificationPreferencesRequest
+	if !bindAndValidate(c, h.validator, &req) {
+		return
+	}
+
+	prefs, err := h.notificationManager.UpdatePreferences(c.Request.Context(), user.ID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, prefs)
+}
+
+// GetEmailSettings - язык писем и способ получения уведомлений по почте
+func (h *NotificationHandler) GetEmailSettings(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	settings, err := h.emailManager.GetSettings(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateEmailSettings - выбрать язык писем и режим: каждое уведомление, сводка раз в день или без писем
+func (h *NotificationHandler) UpdateEmailSettings(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	var req interfaces.UpdateEmailSettingsRequest
+	if !bindAndValidate(c, h.validator, &req) {
+		return
+	}
+
+	settings, err := h.emailManager.UpdateSettings(c.Request.Context(), user.ID, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, settings)
+}
+
+func buildNotificationResponse(n *models.Notification) interfaces.NotificationResponse {
+	return interfaces.NotificationResponse{
+		ID:         n.ID,
+		Event:      n.Event,
+		Title:      n.Title,
+		Message:    n.Message,
+		ActorID:    n.ActorID,
+		EntityType: n.EntityType,
+		EntityID:   n.EntityID,
+		IsRead:     n.ReadAt != nil,
+		ReadAt:     n.ReadAt,
+		CreatedAt:  n.CreatedAt,
+	}
+}
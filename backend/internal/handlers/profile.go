@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// ProfileHandler управляет профилями студентов и преподавателей (адресуются по ID пользователя)
+type ProfileHandler struct {
+	profileManager interfaces.ProfileManager
+	validator      *validator.Validate
+}
+
+// NewProfileHandler создаёт новый ProfileHandler
+func NewProfileHandler(pm interfaces.ProfileManager) *ProfileHandler {
+	return &ProfileHandler{
+		profileManager: pm,
+		validator:      validator.New(),
+	}
+}
+
+// GetStudentProfile - профиль студента (сам студент, преподаватель или админ)
+func (h *ProfileHandler) GetStudentProfile(c *gin.Context) {
+	userID, ok := parseIDParam(c, "userId", "user")
+	if !ok {
+		return
+	}
+	user := currentUser(c)
+	if user.IsStudent() && user.ID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return
+	}
+
+	profile, err := h.profileManager.GetStudentProfile(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "student profile not found"})
+		return
+	}
+	c.JSON(http.StatusOK, buildStudentProfileResponse(profile))
+}
+
+// CreateStudentProfile - зачисление студента в группу (admin)
+func (h *ProfileHandler) CreateStudentProfile(c *gin.Context) {
+	var req interfaces.CreateStudentProfileRequest
+	if !bindAndValidate(c, h.validator, &req) {
+		return
+	}
+
+	profile, err := h.profileManager.CreateStudentProfile(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, buildStudentProfileResponse(profile))
+}
+
+// UpdateStudentProfile - перевод в другую группу, смена номера зачётки (admin)
+func (h *ProfileHandler) UpdateStudentProfile(c *gin.Context) {
+	userID, ok := parseIDParam(c, "userId", "user")
+	if !ok {
+		return
+	}
+	var req interfaces.UpdateStudentProfileRequest
+	if !bindAndValidate(c, h.validator, &req) {
+		return
+	}
+
+	profile, err := h.profileManager.UpdateStudentProfile(c.Request.Context(), userID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, buildStudentProfileResponse(profile))
+}
+
+// DeleteStudentProfile - удаление профиля студента (admin)
+func (h *ProfileHandler) DeleteStudentProfile(c *gin.Context) {
+	userID, ok := parseIDParam(c, "userId", "user")
+	if !ok {
+		return
+	}
+
+	if err := h.profileManager.DeleteStudentProfile(c.Request.Context(), userID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListTeacherProfiles - профили преподавателей (?department_id=N - только кафедры)
+func (h *ProfileHandler) ListTeacherProfiles(c *gin.Context) {
+	var deptID uint64
+	if raw := c.Query("department_id"); raw != "" {
+		var err error
+		if deptID, err = strconv.ParseUint(raw, 10, 32); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid department_id"})
+			return
+		}
+	}
+
+	profiles, err := h.profileManager.ListTeacherProfiles(c.Request.Context(), uint(deptID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]interfaces.TeacherProfileResponse, len(profiles))
+	for i := range profiles {
+		resp[i] = buildTeacherProfileResponse(&profiles[i])
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetTeacherProfile - профиль преподавателя
+func (h *ProfileHandler) GetTeacherProfile(c *gin.Context) {
+	userID, ok := parseIDParam(c, "userId", "user")
+	if !ok {
+		return
+	}
+
+	profile, err := h.profileManager.GetTeacherProfile(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "teacher profile not found"})
+		return
+	}
+	c.JSON(http.StatusOK, buildTeacherProfileResponse(profile))
+}
+
+// CreateTeacherProfile - привязка преподавателя к кафедре (admin)
+func (h *ProfileHandler) CreateTeacherProfile(c *gin.Context) {
+	var req interfaces.CreateTeacherProfileRequest
+	if !bindAndValidate(c, h.validator, &req) {
+		return
+	}
+
+	profile, err := h.profileManager.CreateTeacherProfile(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, buildTeacherProfileResponse(profile))
+}
+
+// UpdateTeacherProfile - смена кафедры, должности, степени (admin)
+func (h *ProfileHandler) UpdateTeacherProfile(c *gin.Context) {
+	userID, ok := parseIDParam(c, "userId", "user")
+	if !ok {
+		return
+	}
+	var req interfaces.UpdateTeacherProfileRequest
+	if !bindAndValidate(c, h.validator, &req) {
+		return
+	}
+
+	profile, err := h.profileManager.UpdateTeacherProfile(c.Request.Context(), userID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, buildTeacherProfileResponse(profile))
+}
+
+// DeleteTeacherProfile - удаление профиля преподавателя (admin)
+func (h *ProfileHandler) DeleteTeacherProfile(c *gin.Context) {
+	userID, ok := parseIDParam(c, "userId", "user")
+	if !ok {
+		return
+	}
+
+	if err := h.profileManager.DeleteTeacherProfile(c.Request.Context(), userID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func buildStudentProfileResponse(p *models.StudentProfile) interfaces.StudentProfileResponse {
+	return interfaces.StudentProfileResponse{
+		ID:            p.ID,
+		User:          buildUserResponse(&p.User),
+		StudentGroup:  buildGroupResponse(&p.StudentGroup),
+		StudentNumber: p.StudentNumber,
+		CreatedAt:     p.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func buildTeacherProfileResponse(p *models.TeacherProfile) interfaces.TeacherProfileResponse {
+	return interfaces.TeacherProfileResponse{
+		ID:             p.ID,
+		User:           buildUserResponse(&p.User),
+		Department:     buildDepartmentResponse(&p.Department),
+		Position:       p.Position,
+		AcademicDegree: p.AcademicDegree,
+		CreatedAt:      p.CreatedAt.Format(time.RFC3339),
+	}
+}
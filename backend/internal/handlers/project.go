@@ -74,7 +74,13 @@ func (h *ProjectHandler) GetProjects(c *gin.Context) {
 	// Парсим параметры запроса
 	var req interfaces.ListCourseworksRequest
 
-	// Параметры фильтрации
+	// Параметры фильтрации; без term_id - текущий семестр, term_id=all - все семестры
+	termID, allTerms, ok := parseTermQuery(c)
+	if !ok {
+		return
+	}
+	req.TermID, req.AllTerms = termID, allTerms
+
 	if subjectID := c.Query("subject_id"); subjectID != "" {
 		if id, err := strconv.ParseUint(subjectID, 10, 32); err == nil {
 			subjID := uint(id)
@@ -156,11 +162,15 @@ func (h *ProjectHandler) GetProject(c *gin.Context) {
 		return
 	}
 
+	if notModified(c, coursework.Version) {
+		return
+	}
+	setETag(c, coursework.Version)
 	response := h.buildCourseworkResponse(coursework)
 	c.JSON(http.StatusOK, response)
 }
 
-// UpdateProject обновляет проект (только владелец или админ)
+// UpdateProject обновляет проект (владелец, ведущий преподаватель дисциплины или админ)
 func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 	idParam := c.Param("id")
 	cwID, err := strconv.ParseUint(idParam, 10, 32)
@@ -195,23 +205,30 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 		return
 	}
 
-	// Проверяем права (только владелец или админ может редактировать)
-	if !user.IsAdmin() && currentCw.TeacherID != user.ID {
+	// Проверяем права (владелец, ведущий преподаватель дисциплины или админ может редактировать)
+	if !canModerateCoursework(c, h.courseworkManager, user, currentCw) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
 		return
 	}
+	version, ok := ifMatchVersion(c, currentCw.Version)
+	if !ok {
+		return
+	}
 
-	coursework, err := h.courseworkManager.UpdateCoursework(c.Request.Context(), uint(cwID), req)
+	coursework, err := h.courseworkManager.UpdateCoursework(c.Request.Context(), uint(cwID), req, version)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if !versionConflict(c, err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
+	setETag(c, coursework.Version)
 	response := h.buildCourseworkResponse(coursework)
 	c.JSON(http.StatusOK, response)
 }
 
-// DeleteProject удаляет проект (только владелец или админ)
+// DeleteProject удаляет проект (владелец, ведущий преподаватель дисциплины или админ)
 func (h *ProjectHandler) DeleteProject(c *gin.Context) {
 	idParam := c.Param("id")
 	cwID, err := strconv.ParseUint(idParam, 10, 32)
@@ -234,15 +251,21 @@ func (h *ProjectHandler) DeleteProject(c *gin.Context) {
 		return
 	}
 
-	// Проверяем права (только владелец или админ может удалять)
-	if !user.IsAdmin() && currentCw.TeacherID != user.ID {
+	// Проверяем права (владелец, ведущий преподаватель дисциплины или админ может удалять)
+	if !canModerateCoursework(c, h.courseworkManager, user, currentCw) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
 		return
 	}
+	version, ok := ifMatchVersion(c, currentCw.Version)
+	if !ok {
+		return
+	}
 
-	err = h.courseworkManager.DeleteCoursework(c.Request.Context(), uint(cwID))
+	err = h.courseworkManager.DeleteCoursework(c.Request.Context(), uint(cwID), version)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if !versionConflict(c, err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
@@ -299,6 +322,74 @@ func (h *ProjectHandler) AssignStudent(c *gin.Context) {
 	c.JSON(http.StatusCreated, response)
 }
 
+// UnassignStudent снимает студента с проекта (владелец, ведущий преподаватель дисциплины или админ)
+func (h *ProjectHandler) UnassignStudent(c *gin.Context) {
+	cwID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid project id"})
+		return
+	}
+	studentID, err := strconv.ParseUint(c.Param("studentId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid student id"})
+		return
+	}
+
+	user := h.getCurrentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	currentCw, err := h.courseworkManager.GetCoursework(c.Request.Context(), uint(cwID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "project not found"})
+		return
+	}
+	if !canModerateCoursework(c, h.courseworkManager, user, currentCw) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return
+	}
+
+	assignment, err := h.studentCourseworkManager.GetStudentCoursework(c.Request.Context(), uint(studentID))
+	if err != nil || assignment.CourseworkID != currentCw.ID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "student is not assigned to this project"})
+		return
+	}
+
+	if err := h.studentCourseworkManager.UnassignStudentFromCoursework(c.Request.Context(), uint(studentID)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetMyCourseworks возвращает назначения текущего студента; по умолчанию - текущий семестр
+func (h *ProjectHandler) GetMyCourseworks(c *gin.Context) {
+	user := h.getCurrentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	termID, allTerms, ok := parseTermQuery(c)
+	if !ok {
+		return
+	}
+
+	list, err := h.studentCourseworkManager.GetStudentHistory(c.Request.Context(), user.ID, termID, allTerms)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := make([]interfaces.StudentCourseworkResponse, len(list))
+	for i := range list {
+		response[i] = h.buildStudentCourseworkResponse(&list[i])
+	}
+	c.JSON(http.StatusOK, response)
+}
+
 // GetAvailableProjects возвращает доступные проекты для студента
 func (h *ProjectHandler) GetAvailableProjects(c *gin.Context) {
 	user := h.getCurrentUser(c)
@@ -321,7 +412,7 @@ func (h *ProjectHandler) GetAvailableProjects(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
-// SetProjectAvailability изменяет доступность проекта (только владелец или админ)
+// SetProjectAvailability изменяет доступность проекта (владелец, ведущий преподаватель дисциплины или админ)
 func (h *ProjectHandler) SetProjectAvailability(c *gin.Context) {
 	idParam := c.Param("id")
 	cwID, err := strconv.ParseUint(idParam, 10, 32)
@@ -352,8 +443,8 @@ func (h *ProjectHandler) SetProjectAvailability(c *gin.Context) {
 		return
 	}
 
-	// Проверяем права (только владелец или админ может изменять доступность)
-	if !user.IsAdmin() && currentCw.TeacherID != user.ID {
+	// Проверяем права (владелец, ведущий преподаватель дисциплины или админ может изменять доступность)
+	if !canModerateCoursework(c, h.courseworkManager, user, currentCw) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
 		return
 	}
@@ -382,6 +473,16 @@ func (h *ProjectHandler) getCurrentUser(c *gin.Context) *models.User {
 	return user
 }
 
+// canModerateCoursework - курсовой работой управляют админ, её руководитель
+// и ведущий преподаватель дисциплины
+func canModerateCoursework(c *gin.Context, cm interfaces.CourseworkManager, user *models.User, cw *models.Coursework) bool {
+	if user.IsAdmin() || cw.TeacherID == user.ID {
+		return true
+	}
+	isLead, err := cm.IsLeadTeacher(c.Request.Context(), user.ID, cw)
+	return err == nil && isLead
+}
+
 // buildCourseworkResponse создаёт ответ для курсовой работы
 func (h *ProjectHandler) buildCourseworkResponse(cw *models.Coursework) interfaces.CourseworkResponse {
 	return interfaces.CourseworkResponse{
@@ -392,6 +493,8 @@ func (h *ProjectHandler) buildCourseworkResponse(cw *models.Coursework) interfac
 		MaxStudents:     cw.MaxStudents,
 		DifficultyLevel: cw.DifficultyLevel,
 		IsAvailable:     cw.IsAvailable,
+		TermID:          cw.TermID,
+		Version:         cw.Version,
 		Subject: interfaces.SubjectResponse{
 			ID:          cw.Subject.ID,
 			Name:        cw.Subject.Name,
@@ -439,3 +542,21 @@ func (h *ProjectHandler) buildStudentCourseworkResponse(sc *models.StudentCourse
 		UpdatedAt:   sc.UpdatedAt,
 	}
 }
+
+// parseTermQuery разбирает параметр term_id: число - конкретный семестр, all - все семестры
+func parseTermQuery(c *gin.Context) (*uint, bool, bool) {
+	raw := c.Query("term_id")
+	switch raw {
+	case "":
+		return nil, false, true
+	case "all":
+		return nil, true, true
+	}
+	id, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid term id"})
+		return nil, false, false
+	}
+	termID := uint(id)
+	return &termID, false, true
+}
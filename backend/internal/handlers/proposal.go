@@ -0,0 +1,311 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// ProposalHandler управляет темами, предложенными студентами
+type ProposalHandler struct {
+	proposalManager interfaces.TopicProposalManager
+	validator       *validator.Validate
+}
+
+// NewProposalHandler создаёт новый ProposalHandler
+func NewProposalHandler(pm interfaces.TopicProposalManager) *ProposalHandler {
+	return &ProposalHandler{
+		proposalManager: pm,
+		validator:       validator.New(),
+	}
+}
+
+// CreateProposal - студент предлагает свою тему руководителю
+func (h *ProposalHandler) CreateProposal(c *gin.Context) {
+	var req interfaces.CreateTopicProposalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	proposal, err := h.proposalManager.CreateProposal(c.Request.Context(), user.ID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, buildTopicProposalResponse(proposal))
+}
+
+// GetMyProposals - предложения текущего студента
+func (h *ProposalHandler) GetMyProposals(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	list, err := h.proposalManager.GetStudentProposals(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, buildTopicProposalList(list))
+}
+
+// GetIncomingProposals - предложения, адресованные текущему преподавателю
+func (h *ProposalHandler) GetIncomingProposals(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	onlyOpen := true
+	if v := c.Query("open"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			onlyOpen = b
+		}
+	}
+
+	list, err := h.proposalManager.GetTeacherProposals(c.Request.Context(), user.ID, onlyOpen)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, buildTopicProposalList(list))
+}
+
+// GetProposal - детали предложения (автор, адресат или админ)
+func (h *ProposalHandler) GetProposal(c *gin.Context) {
+	proposal, ok := h.loadProposal(c)
+	if !ok {
+		return
+	}
+	user := currentUser(c)
+	if !user.IsAdmin() && proposal.StudentID != user.ID && proposal.TeacherID != user.ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return
+	}
+
+	c.JSON(http.StatusOK, buildTopicProposalResponse(proposal))
+}
+
+// UpdateProposal - студент дорабатывает своё предложение
+func (h *ProposalHandler) UpdateProposal(c *gin.Context) {
+	proposal, ok := h.loadProposal(c)
+	if !ok {
+		return
+	}
+	if proposal.StudentID != currentUser(c).ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return
+	}
+
+	var req interfaces.UpdateTopicProposalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updated, err := h.proposalManager.UpdateProposal(c.Request.Context(), proposal.ID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, buildTopicProposalResponse(updated))
+}
+
+// WithdrawProposal - студент отзывает своё предложение
+func (h *ProposalHandler) WithdrawProposal(c *gin.Context) {
+	proposal, ok := h.loadProposal(c)
+	if !ok {
+		return
+	}
+	if proposal.StudentID != currentUser(c).ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return
+	}
+
+	if err := h.proposalManager.WithdrawProposal(c.Request.Context(), proposal.ID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AcceptProposal - руководитель принимает тему, студент назначается автоматически
+func (h *ProposalHandler) AcceptProposal(c *gin.Context) {
+	proposal, ok := h.loadDecidableProposal(c)
+	if !ok {
+		return
+	}
+
+	var req interfaces.AcceptTopicProposalRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, err := h.proposalManager.AcceptProposal(c.Request.Context(), proposal.ID, req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	accepted, err := h.proposalManager.GetProposal(c.Request.Context(), proposal.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, buildTopicProposalResponse(accepted))
+}
+
+// RejectProposal - руководитель отклоняет тему
+func (h *ProposalHandler) RejectProposal(c *gin.Context) {
+	proposal, ok := h.loadDecidableProposal(c)
+	if !ok {
+		return
+	}
+	req, ok := h.bindDecision(c)
+	if !ok {
+		return
+	}
+
+	if err := h.proposalManager.RejectProposal(c.Request.Context(), proposal.ID, req.Comment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RequestChanges - руководитель возвращает тему на доработку
+func (h *ProposalHandler) RequestChanges(c *gin.Context) {
+	proposal, ok := h.loadDecidableProposal(c)
+	if !ok {
+		return
+	}
+	req, ok := h.bindDecision(c)
+	if !ok {
+		return
+	}
+
+	if err := h.proposalManager.RequestChanges(c.Request.Context(), proposal.ID, req.Comment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Вспомогательные методы
+
+// loadProposal загружает предложение по :id, при ошибке сам пишет ответ
+func (h *ProposalHandler) loadProposal(c *gin.Context) (*models.TopicProposal, bool) {
+	if currentUser(c) == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return nil, false
+	}
+	proposalID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid proposal id"})
+		return nil, false
+	}
+	proposal, err := h.proposalManager.GetProposal(c.Request.Context(), uint(proposalID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "proposal not found"})
+		return nil, false
+	}
+	return proposal, true
+}
+
+// loadDecidableProposal загружает предложение, решение по которому может принять текущий пользователь
+func (h *ProposalHandler) loadDecidableProposal(c *gin.Context) (*models.TopicProposal, bool) {
+	proposal, ok := h.loadProposal(c)
+	if !ok {
+		return nil, false
+	}
+	user := currentUser(c)
+	if !user.IsAdmin() && proposal.TeacherID != user.ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only the addressed supervisor can decide"})
+		return nil, false
+	}
+	return proposal, true
+}
+
+func (h *ProposalHandler) bindDecision(c *gin.Context) (*interfaces.ProposalDecisionRequest, bool) {
+	var req interfaces.ProposalDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return &req, true
+}
+
+func buildTopicProposalList(list []models.TopicProposal) []interfaces.TopicProposalResponse {
+	resp := make([]interfaces.TopicProposalResponse, len(list))
+	for i := range list {
+		resp[i] = buildTopicProposalResponse(&list[i])
+	}
+	return resp
+}
+
+func buildTopicProposalResponse(p *models.TopicProposal) interfaces.TopicProposalResponse {
+	return interfaces.TopicProposalResponse{
+		ID:             p.ID,
+		Title:          p.Title,
+		Description:    p.Description,
+		Status:         p.Status,
+		TeacherComment: p.TeacherComment,
+		Revision:       p.Revision,
+		Student:        buildUserResponse(&p.Student),
+		Teacher:        buildUserResponse(&p.Teacher),
+		Subject: interfaces.SubjectResponse{
+			ID:          p.Subject.ID,
+			Name:        p.Subject.Name,
+			Code:        p.Subject.Code,
+			Description: p.Subject.Description,
+			Semester:    p.Subject.Semester,
+			IsActive:    p.Subject.IsActive,
+			CreatedAt:   p.Subject.CreatedAt.Format(time.RFC3339),
+		},
+		CourseworkID: p.CourseworkID,
+		DecidedAt:    p.DecidedAt,
+		CreatedAt:    p.CreatedAt,
+		UpdatedAt:    p.UpdatedAt,
+	}
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+)
+
+// realtimeRetry - через сколько миллисекунд EventSource переподключается после обрыва
+const realtimeRetry = 3000
+
+// RealtimeHandler - обновления в реальном времени через Server-Sent Events
+type RealtimeHandler struct {
+	realtimeManager interfaces.RealtimeManager
+	heartbeat       time.Duration
+}
+
+// NewRealtimeHandler создаёт новый RealtimeHandler
+func NewRealtimeHandler(rm interfaces.RealtimeManager, heartbeat time.Duration) *RealtimeHandler {
+	return &RealtimeHandler{realtimeManager: rm, heartbeat: heartbeat}
+}
+
+// IssueTicket выдаёт одноразовый билет для подключения к потоку
+func (h *RealtimeHandler) IssueTicket(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	ticket, err := h.realtimeManager.IssueStreamTicket(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, ticket)
+}
+
+// Stream держит поток событий SSE. Клиент подключается по билету из параметра ticket,
+// полученному через POST /realtime/tickets. Параметр streams (через запятую) выбирает потоки:
+// slots, assignments, notifications; по умолчанию - все. Обновления, пришедшие до
+// подключения, не повторяются: после события ready клиенту нужно перечитать состояние.
+func (h *RealtimeHandler) Stream(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := h.realtimeManager.RedeemStreamTicket(ctx, c.Query("ticket"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired stream ticket"})
+		return
+	}
+	streams, ok := parseRealtimeStreams(c.Query("streams"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid streams, expected slots, assignments or notifications"})
+		return
+	}
+
+	events, cancel, err := h.realtimeManager.Subscribe(ctx, userID, streams)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer cancel()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // nginx не должен копить поток в буфере
+	c.Status(http.StatusOK)
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", realtimeRetry)
+	c.SSEvent("ready", gin.H{"streams": streams})
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := io.WriteString(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				// подписка закрыта (клиент не успевал читать) - EventSource переподключится
+				return
+			}
+			c.SSEvent(string(event.Stream), event.Data)
+		}
+		c.Writer.Flush()
+	}
+}
+
+// parseRealtimeStreams разбирает список потоков; пустой список - все потоки
+func parseRealtimeStreams(raw string) ([]interfaces.RealtimeStream, bool) {
+	if raw == "" {
+		return interfaces.RealtimeStreams, true
+	}
+	var streams []interfaces.RealtimeStream
+	for _, part := range strings.Split(raw, ",") {
+		stream := interfaces.RealtimeStream(strings.TrimSpace(part))
+		known := false
+		for _, s := range interfaces.RealtimeStreams {
+			known = known || s == stream
+		}
+		if !known {
+			return nil, false
+		}
+		streams = append(streams, stream)
+	}
+	return streams, true
+}
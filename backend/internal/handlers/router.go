@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"log"
+	"time"
 
 	"github.com/Foxpunk/courseforge/internal/interfaces"
 	"github.com/gin-gonic/gin"
@@ -14,6 +15,32 @@ func NewRouter(
 	subjectManager interfaces.SubjectManager,
 	courseworkManager interfaces.CourseworkManager,
 	studentCourseworkManager interfaces.StudentCourseworkManager,
+	defenseManager interfaces.DefenseManager,
+	proposalManager interfaces.TopicProposalManager,
+	selectionManager interfaces.SelectionManager,
+	waitlistManager interfaces.WaitlistManager,
+	teamManager interfaces.TeamManager,
+	workloadManager interfaces.WorkloadManager,
+	termManager interfaces.TermManager,
+	curriculumManager interfaces.CurriculumManager,
+	departmentManager interfaces.DepartmentManager,
+	groupManager interfaces.GroupManager,
+	profileManager interfaces.ProfileManager,
+	importManager interfaces.ImportManager,
+	gradebookManager interfaces.GradebookManager,
+	documentManager interfaces.DocumentManager,
+	submissionManager interfaces.SubmissionManager,
+	discussionManager interfaces.DiscussionManager,
+	notificationManager interfaces.NotificationManager,
+	emailManager interfaces.EmailManager,
+	deadlineManager interfaces.DeadlineManager,
+	telegramManager interfaces.TelegramManager,
+	realtimeManager interfaces.RealtimeManager,
+	webhookManager interfaces.WebhookManager,
+	idempotencyManager interfaces.IdempotencyManager,
+	maxUploadSize int64,
+	realtimeHeartbeat time.Duration,
+	requireIfMatch bool,
 	jwtSecret string,
 ) *gin.Engine {
 	// создаём gin
@@ -22,10 +49,33 @@ func NewRouter(
 
 	// Инициализируем middleware и хендлеры
 	mw := NewMiddleware(authManager)
-	authH := NewAuthHandler(authManager, userManager, jwtSecret)
+	ifMatch := mw.IfMatchRequired(requireIfMatch)
+	idem := idempotent(idempotencyManager)
+	authH := NewAuthHandler(authManager, userManager, profileManager, jwtSecret)
 	userH := NewUserHandler(userManager)
 	discH := NewDisciplineHandler(subjectManager)
 	projH := NewProjectHandler(courseworkManager, studentCourseworkManager)
+	defH := NewDefenseHandler(defenseManager)
+	propH := NewProposalHandler(proposalManager)
+	selH := NewSelectionHandler(selectionManager, courseworkManager)
+	waitH := NewWaitlistHandler(waitlistManager, courseworkManager)
+	teamH := NewTeamHandler(teamManager)
+	workH := NewWorkloadHandler(workloadManager)
+	termH := NewTermHandler(termManager)
+	currH := NewCurriculumHandler(curriculumManager)
+	deptH := NewDepartmentHandler(departmentManager, groupManager)
+	groupH := NewGroupHandler(groupManager)
+	profH := NewProfileHandler(profileManager)
+	impH := NewImportHandler(importManager)
+	gradeH := NewGradebookHandler(gradebookManager)
+	docH := NewDocumentHandler(documentManager)
+	subH := NewSubmissionHandler(submissionManager, courseworkManager, maxUploadSize)
+	threadH := NewDiscussionHandler(discussionManager, maxUploadSize)
+	notifH := NewNotificationHandler(notificationManager, emailManager)
+	deadH := NewDeadlineHandler(deadlineManager)
+	tgH := NewTelegramHandler(telegramManager)
+	rtH := NewRealtimeHandler(realtimeManager, realtimeHeartbeat)
+	hookH := NewWebhookHandler(webhookManager)
 
 	// При необходимости включить CORS
 	r.Use(mw.CORS())
@@ -70,8 +120,8 @@ func NewRouter(
 		users.POST("", userH.CreateUser)
 		users.GET("", userH.ListUsers)
 		users.GET("/:id", userH.GetUser)
-		users.PUT("/:id", userH.UpdateUser)
-		users.DELETE("/:id", userH.DeleteUser)
+		users.PUT("/:id", ifMatch, userH.UpdateUser)
+		users.DELETE("/:id", ifMatch, userH.DeleteUser)
 	}
 
 	// SUBJECTS / DISCIPLINE
@@ -80,6 +130,7 @@ func NewRouter(
 		// общий доступ по авторизации
 		subj.GET("", mw.AuthMiddleware(), discH.GetDisciplines)
 		subj.GET("/:id", mw.AuthMiddleware(), discH.GetDiscipline)
+		subj.GET("/:id/lead-teacher", mw.AuthMiddleware(), discH.GetLeadTeacher)
 
 		// admin only
 		adminSubj := subj.Group("", mw.AuthMiddleware(), mw.AdminRequired())
@@ -99,22 +150,329 @@ func NewRouter(
 		// авторизованные
 		cw.GET("", mw.AuthMiddleware(), projH.GetProjects)
 		cw.GET("/available", mw.AuthMiddleware(), projH.GetAvailableProjects)
+		cw.GET("/my", mw.AuthMiddleware(), mw.StudentRequired(), projH.GetMyCourseworks)
 		cw.GET("/:id", mw.AuthMiddleware(), projH.GetProject)
 
 		// teacher or admin
 		tAdmin := cw.Group("", mw.AuthMiddleware(), mw.TeacherOrAdminRequired())
 		{
-			tAdmin.POST("", projH.CreateProject)
-			tAdmin.PUT("/:id", projH.UpdateProject)
-			tAdmin.DELETE("/:id", projH.DeleteProject)
+			tAdmin.POST("", idem, projH.CreateProject)
+			tAdmin.PUT("/:id", ifMatch, projH.UpdateProject)
+			tAdmin.DELETE("/:id", ifMatch, projH.DeleteProject)
 			tAdmin.PUT("/:id/availability", projH.SetProjectAvailability)
+			tAdmin.DELETE("/:id/students/:studentId", projH.UnassignStudent)
+			tAdmin.GET("/:id/waitlist", waitH.GetCourseworkWaitlist)
+			tAdmin.GET("/:id/submissions", subH.GetCourseworkSubmissions)
 		}
 
 		// student only
 		stud := cw.Group("/:id/assign", mw.AuthMiddleware(), mw.StudentRequired())
 		{
-			stud.POST("", projH.AssignStudent)
+			stud.POST("", idem, projH.AssignStudent)
 		}
+		cw.POST("/:id/waitlist", mw.AuthMiddleware(), mw.StudentRequired(), waitH.JoinWaitlist)
+	}
+
+	// WAITLIST (очереди текущего студента)
+	wait := api.Group("/waitlist", mw.AuthMiddleware(), mw.StudentRequired())
+	{
+		wait.GET("/my", waitH.GetMyWaitlist)
+		wait.POST("/:id/accept", waitH.AcceptOffer)
+		wait.POST("/:id/decline", waitH.DeclineOffer)
+		wait.DELETE("/:id", waitH.LeaveWaitlist)
+	}
+
+	// DEFENSE SCHEDULING
+	def := api.Group("/defense")
+	{
+		// публичная лента по подписанной ссылке (календарные клиенты не передают JWT)
+		def.GET("/calendar/:feed", defH.GetCalendarFeed)
+
+		authDef := def.Group("", mw.AuthMiddleware())
+		{
+			authDef.GET("/calendar", defH.GetCalendarLink)
+			authDef.GET("/schedule", defH.GetMySchedule)
+			authDef.GET("/rooms", defH.ListRooms)
+			authDef.GET("/sessions", defH.ListSessions)
+			authDef.GET("/sessions/:id", defH.GetSession)
+		}
+
+		// admin only
+		adminDef := def.Group("", mw.AuthMiddleware(), mw.AdminRequired())
+		{
+			adminDef.POST("/rooms", defH.CreateRoom)
+			adminDef.DELETE("/rooms/:id", defH.DeleteRoom)
+			adminDef.POST("/sessions", defH.CreateSession)
+			adminDef.DELETE("/sessions/:id", defH.DeleteSession)
+			adminDef.PUT("/sessions/:id/committee", defH.SetCommittee)
+			adminDef.POST("/sessions/:id/distribute", defH.DistributeStudents)
+			adminDef.PUT("/slots/:slotId", defH.AssignSlot)
+			adminDef.DELETE("/slots/:slotId", defH.ReleaseSlot)
+		}
+	}
+
+	// TOPIC PROPOSALS (темы, предложенные студентами)
+	prop := api.Group("/proposals", mw.AuthMiddleware())
+	{
+		prop.GET("/:id", propH.GetProposal)
+
+		stud := prop.Group("", mw.StudentRequired())
+		{
+			stud.POST("", propH.CreateProposal)
+			stud.GET("/my", propH.GetMyProposals)
+			stud.PUT("/:id", propH.UpdateProposal)
+			stud.DELETE("/:id", propH.WithdrawProposal)
+		}
+
+		tAdmin := prop.Group("", mw.TeacherOrAdminRequired())
+		{
+			tAdmin.GET("/incoming", propH.GetIncomingProposals)
+			tAdmin.POST("/:id/accept", propH.AcceptProposal)
+			tAdmin.POST("/:id/reject", propH.RejectProposal)
+			tAdmin.POST("/:id/request-changes", propH.RequestChanges)
+		}
+	}
+
+	// TEAMS (командные курсовые)
+	team := api.Group("/teams", mw.AuthMiddleware())
+	{
+		team.GET("/:id", teamH.GetTeam)
+		team.GET("/:id/posts", teamH.GetPosts)
+		team.POST("/:id/posts", teamH.CreatePost)
+
+		stud := team.Group("", mw.StudentRequired())
+		{
+			stud.POST("", teamH.CreateTeam)
+			stud.GET("/my", teamH.GetMyTeam)
+			stud.GET("/invitations", teamH.GetMyInvitations)
+			stud.POST("/invitations/:invId/accept", teamH.AcceptInvitation)
+			stud.POST("/invitations/:invId/decline", teamH.DeclineInvitation)
+
+			stud.POST("/:id/invitations", teamH.InviteMember)
+			stud.GET("/:id/invitations", teamH.GetTeamInvitations)
+			stud.DELETE("/:id/invitations/:invId", teamH.RevokeInvitation)
+			stud.DELETE("/:id/members/:studentId", teamH.RemoveMember)
+			stud.PUT("/:id/leader", teamH.TransferLeadership)
+			stud.PUT("/:id/contribution", teamH.UpdateContribution)
+			stud.POST("/:id/submit", teamH.SubmitWork)
+		}
+
+		team.DELETE("/:id", teamH.DisbandTeam)
+		team.POST("/:id/grade", mw.TeacherOrAdminRequired(), teamH.GradeTeam)
+	}
+
+	// SELECTION ROUNDS (распределение тем по предпочтениям)
+	sel := api.Group("/selection-rounds", mw.AuthMiddleware())
+	{
+		sel.GET("", selH.ListRounds)
+		sel.GET("/:id", selH.GetRound)
+
+		stud := sel.Group("", mw.StudentRequired())
+		{
+			stud.GET("/:id/preferences", selH.GetMyPreferences)
+			stud.PUT("/:id/preferences", selH.SubmitPreferences)
+		}
+
+		tAdmin := sel.Group("", mw.TeacherOrAdminRequired())
+		{
+			tAdmin.GET("/:id/applicants/:courseworkId", selH.GetApplicants)
+			tAdmin.PUT("/:id/rankings/:courseworkId", selH.RankApplicants)
+		}
+
+		adminSel := sel.Group("", mw.AdminRequired())
+		{
+			adminSel.POST("", selH.CreateRound)
+			adminSel.PUT("/:id/status", selH.SetRoundStatus)
+			adminSel.POST("/:id/allocation/preview", selH.PreviewAllocation)
+			adminSel.POST("/:id/allocation/commit", selH.CommitAllocation)
+		}
+	}
+
+	// TERMS (учебные годы и семестры)
+	terms := api.Group("/terms", mw.AuthMiddleware())
+	{
+		terms.GET("", termH.ListAcademicYears)
+		terms.GET("/current", termH.GetCurrentTerm)
+		terms.GET("/:id", termH.GetTerm)
+
+		adminTerms := terms.Group("", mw.AdminRequired())
+		{
+			adminTerms.POST("/years", termH.CreateAcademicYear)
+			adminTerms.POST("/:id/activate", termH.ActivateTerm)
+		}
+	}
+
+	// WORKLOAD (квоты руководства и нагрузка преподавателей)
+	work := api.Group("/workload", mw.AuthMiddleware(), mw.TeacherOrAdminRequired())
+	{
+		work.GET("/me", workH.GetMyWorkload)
+		work.GET("/report", workH.GetReport)
+		work.GET("/quotas", workH.ListQuotas)
+		work.PUT("/quotas/:teacherId", workH.SetQuota)
+		work.DELETE("/quotas/:teacherId", workH.DeleteQuota)
+	}
+
+	// DEPARTMENTS (кафедры)
+	dept := api.Group("/departments", mw.AuthMiddleware())
+	{
+		dept.GET("", deptH.ListDepartments)
+		dept.GET("/:id", deptH.GetDepartment)
+		dept.GET("/:id/groups", deptH.GetDepartmentGroups)
+		dept.GET("/:id/teachers", deptH.GetDepartmentTeachers)
+
+		adminDept := dept.Group("", mw.AdminRequired())
+		{
+			adminDept.POST("", deptH.CreateDepartment)
+			adminDept.PUT("/:id", deptH.UpdateDepartment)
+			adminDept.DELETE("/:id", deptH.DeleteDepartment)
+			adminDept.PUT("/:id/head", workH.SetDepartmentHead)
+		}
+	}
+
+	// GROUPS (студенческие группы и их учебный план)
+	groups := api.Group("/groups", mw.AuthMiddleware())
+	{
+		groups.GET("", groupH.ListGroups)
+		groups.GET("/:id", groupH.GetGroup)
+		groups.GET("/:id/students", mw.TeacherOrAdminRequired(), groupH.GetGroupStudents)
+		groups.GET("/:id/subjects", currH.GetGroupSubjects)
+
+		adminGroups := groups.Group("", mw.AdminRequired())
+		{
+			adminGroups.POST("", groupH.CreateGroup)
+			adminGroups.PUT("/:id", groupH.UpdateGroup)
+			adminGroups.DELETE("/:id", groupH.DeleteGroup)
+			adminGroups.POST("/:id/subjects", currH.AddGroupSubject)
+			adminGroups.DELETE("/:id/subjects/:subjectId", currH.RemoveGroupSubject)
+		}
+	}
+
+	// PROFILES (профили студентов и преподавателей по ID пользователя)
+	profiles := api.Group("/profiles", mw.AuthMiddleware())
+	{
+		profiles.GET("/students/:userId", profH.GetStudentProfile)
+		profiles.GET("/teachers", profH.ListTeacherProfiles)
+		profiles.GET("/teachers/:userId", profH.GetTeacherProfile)
+
+		adminProfiles := profiles.Group("", mw.AdminRequired())
+		{
+			adminProfiles.POST("/students", profH.CreateStudentProfile)
+			adminProfiles.PUT("/students/:userId", profH.UpdateStudentProfile)
+			adminProfiles.DELETE("/students/:userId", profH.DeleteStudentProfile)
+			adminProfiles.POST("/teachers", profH.CreateTeacherProfile)
+			adminProfiles.PUT("/teachers/:userId", profH.UpdateTeacherProfile)
+			adminProfiles.DELETE("/teachers/:userId", profH.DeleteTeacherProfile)
+		}
+	}
+
+	// IMPORTS (загрузка списков студентов и преподавателей, admin only)
+	imports := api.Group("/imports", mw.AuthMiddleware(), mw.AdminRequired())
+	{
+		imports.POST("", impH.ImportRoster)
+		imports.GET("", impH.ListImports)
+		imports.GET("/:id", impH.GetImport)
+		imports.GET("/:id/errors", impH.GetImportErrors)
+	}
+
+	// GRADEBOOK (ведомости оценок по дисциплине)
+	grades := api.Group("/gradebook", mw.AuthMiddleware(), mw.TeacherOrAdminRequired())
+	{
+		grades.GET("", gradeH.GetGradebook)
+		grades.GET("/export", gradeH.ExportGradebook)
+	}
+
+	// DOCUMENTS (приказы и другие официальные документы)
+	docs := api.Group("/documents", mw.AuthMiddleware(), mw.TeacherOrAdminRequired())
+	{
+		docs.GET("", docH.ListDocuments)
+		docs.GET("/:id", docH.GetDocument)
+		docs.GET("/:id/versions", docH.GetDocumentVersions)
+		docs.GET("/:id/download", docH.DownloadDocument)
+
+		adminDocs := docs.Group("", mw.AdminRequired())
+		{
+			adminDocs.POST("/topic-orders", docH.IssueTopicOrder)
+			adminDocs.GET("/templates", docH.ListTemplates)
+			adminDocs.GET("/templates/:id", docH.GetTemplate)
+			adminDocs.POST("/templates", docH.CreateTemplate)
+			adminDocs.PUT("/templates/:id", docH.UpdateTemplate)
+			adminDocs.DELETE("/templates/:id", docH.DeleteTemplate)
+		}
+	}
+
+	// SUBMISSIONS (сданные файлы работ и проверка на заимствования)
+	subs := api.Group("/submissions", mw.AuthMiddleware())
+	{
+		subs.POST("", mw.StudentRequired(), subH.SubmitFile)
+		subs.GET("/my", mw.StudentRequired(), subH.GetMySubmissions)
+		subs.GET("/:id", subH.GetSubmission)
+		subs.GET("/:id/file", subH.DownloadFile)
+
+		tAdmin := subs.Group("", mw.TeacherOrAdminRequired())
+		{
+			tAdmin.GET("/:id/similarity", subH.GetSimilarityReport)
+			tAdmin.POST("/:id/recheck", subH.RecheckSubmission)
+		}
+	}
+
+	// DISCUSSIONS (вопросы по темам и переписка студента с руководителем)
+	disc := api.Group("/discussions", mw.AuthMiddleware())
+	{
+		disc.GET("/unread", threadH.GetUnreadThreads)
+		disc.GET("/coursework/:id", threadH.GetCourseworkThread)
+		disc.GET("/assignment/:id", threadH.GetAssignmentThread)
+
+		disc.GET("/threads/:id", threadH.GetThread)
+		disc.POST("/threads/:id/comments", threadH.CreateComment)
+		disc.POST("/threads/:id/read", threadH.MarkThreadRead)
+
+		disc.PUT("/comments/:id", threadH.UpdateComment)
+		disc.DELETE("/comments/:id", threadH.DeleteComment)
+		disc.GET("/comments/:id/history", threadH.GetCommentHistory)
+		disc.POST("/comments/:id/attachments", threadH.UploadAttachment)
+		disc.GET("/attachments/:id", threadH.DownloadAttachment)
+	}
+
+	// NOTIFICATIONS (центр уведомлений текущего пользователя)
+	notif := api.Group("/notifications", mw.AuthMiddleware())
+	{
+		notif.GET("", notifH.ListNotifications)
+		notif.GET("/unread-count", notifH.GetUnreadCount)
+		notif.POST("/read", notifH.MarkRead)
+		notif.POST("/read-all", notifH.MarkAllRead)
+		notif.GET("/preferences", notifH.GetPreferences)
+		notif.PUT("/preferences", notifH.UpdatePreferences)
+		notif.GET("/email-settings", notifH.GetEmailSettings)
+		notif.PUT("/email-settings", notifH.UpdateEmailSettings)
+	}
+
+	// DEADLINES (ближайшие сроки текущего пользователя)
+	api.GET("/deadlines", mw.AuthMiddleware(), deadH.ListDeadlines)
+
+	// TELEGRAM (привязка чата с ботом)
+	tg := api.Group("/telegram", mw.AuthMiddleware())
+	{
+		tg.GET("/link", tgH.GetLink)
+		tg.POST("/link", tgH.CreateLinkCode)
+		tg.DELETE("/link", tgH.Unlink)
+	}
+
+	// REALTIME (поток обновлений SSE; подключение по одноразовому билету, а не по токену в адресе)
+	api.POST("/realtime/tickets", mw.AuthMiddleware(), rtH.IssueTicket)
+	api.GET("/realtime/stream", rtH.Stream)
+
+	// WEBHOOKS (исходящие события для внешних систем, admin only)
+	hooks := api.Group("/webhooks", mw.AuthMiddleware(), mw.AdminRequired())
+	{
+		hooks.POST("", hookH.CreateWebhook)
+		hooks.GET("", hookH.ListWebhooks)
+		hooks.GET("/:id", hookH.GetWebhook)
+		hooks.PUT("/:id", hookH.UpdateWebhook)
+		hooks.DELETE("/:id", hookH.DeleteWebhook)
+		hooks.POST("/:id/ping", hookH.Ping)
+		hooks.GET("/:id/deliveries", hookH.ListDeliveries)
+		hooks.GET("/:id/deliveries/:deliveryId", hookH.GetDelivery)
+		hooks.POST("/:id/deliveries/:deliveryId/redeliver", hookH.Redeliver)
 	}
 
 	return r
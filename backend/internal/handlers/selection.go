@@ -0,0 +1,310 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// SelectionHandler управляет раундами выбора тем по предпочтениям
+type SelectionHandler struct {
+	selectionManager  interfaces.SelectionManager
+	courseworkManager interfaces.CourseworkManager
+	validator         *validator.Validate
+}
+
+// NewSelectionHandler создаёт новый SelectionHandler
+func NewSelectionHandler(sm interfaces.SelectionManager, cm interfaces.CourseworkManager) *SelectionHandler {
+	return &SelectionHandler{
+		selectionManager:  sm,
+		courseworkManager: cm,
+		validator:         validator.New(),
+	}
+}
+
+// CreateRound - создание раунда выбора тем (admin)
+func (h *SelectionHandler) CreateRound(c *gin.Context) {
+	var req interfaces.CreateSelectionRoundRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	round, err := h.selectionManager.CreateRound(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, buildSelectionRoundResponse(round))
+}
+
+// ListRounds - список раундов
+func (h *SelectionHandler) ListRounds(c *gin.Context) {
+	rounds, err := h.selectionManager.ListRounds(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]interfaces.SelectionRoundResponse, len(rounds))
+	for i := range rounds {
+		resp[i] = buildSelectionRoundResponse(&rounds[i])
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetRound - детали раунда
+func (h *SelectionHandler) GetRound(c *gin.Context) {
+	roundID, ok := parseRoundID(c)
+	if !ok {
+		return
+	}
+
+	round, err := h.selectionManager.GetRound(c.Request.Context(), roundID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "selection round not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, buildSelectionRoundResponse(round))
+}
+
+// SetRoundStatus - открытие, закрытие или возврат раунда в черновик (admin)
+func (h *SelectionHandler) SetRoundStatus(c *gin.Context) {
+	roundID, ok := parseRoundID(c)
+	if !ok {
+		return
+	}
+
+	var req interfaces.SetSelectionRoundStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.selectionManager.SetRoundStatus(c.Request.Context(), roundID, req.Status); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SubmitPreferences - студент сохраняет список тем по убыванию желательности
+func (h *SelectionHandler) SubmitPreferences(c *gin.Context) {
+	roundID, ok := parseRoundID(c)
+	if !ok {
+		return
+	}
+
+	var req interfaces.SubmitPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	prefs, err := h.selectionManager.SubmitPreferences(c.Request.Context(), roundID, user.ID, req.CourseworkIDs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, buildTopicPreferenceList(prefs))
+}
+
+// GetMyPreferences - текущий список тем студента
+func (h *SelectionHandler) GetMyPreferences(c *gin.Context) {
+	roundID, ok := parseRoundID(c)
+	if !ok {
+		return
+	}
+
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	prefs, err := h.selectionManager.GetStudentPreferences(c.Request.Context(), roundID, user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, buildTopicPreferenceList(prefs))
+}
+
+// GetApplicants - претенденты на тему руководителя
+func (h *SelectionHandler) GetApplicants(c *gin.Context) {
+	roundID, ok := parseRoundID(c)
+	if !ok {
+		return
+	}
+	cw, ok := h.loadOwnCoursework(c)
+	if !ok {
+		return
+	}
+
+	applicants, err := h.selectionManager.GetApplicants(c.Request.Context(), roundID, cw.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, applicants)
+}
+
+// RankApplicants - руководитель упорядочивает претендентов на свою тему
+func (h *SelectionHandler) RankApplicants(c *gin.Context) {
+	roundID, ok := parseRoundID(c)
+	if !ok {
+		return
+	}
+	cw, ok := h.loadOwnCoursework(c)
+	if !ok {
+		return
+	}
+
+	var req interfaces.RankApplicantsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.selectionManager.RankApplicants(c.Request.Context(), roundID, cw.ID, req.StudentIDs); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// PreviewAllocation - пробный расчёт распределения без сохранения (admin)
+func (h *SelectionHandler) PreviewAllocation(c *gin.Context) {
+	roundID, ok := parseRoundID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.selectionManager.PreviewAllocation(c.Request.Context(), roundID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// CommitAllocation - фиксация распределения и создание назначений (admin)
+func (h *SelectionHandler) CommitAllocation(c *gin.Context) {
+	roundID, ok := parseRoundID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.selectionManager.CommitAllocation(c.Request.Context(), roundID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Вспомогательные методы
+
+func parseRoundID(c *gin.Context) (uint, bool) {
+	roundID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid round id"})
+		return 0, false
+	}
+	return uint(roundID), true
+}
+
+// loadOwnCoursework загружает тему из :courseworkId и проверяет, что её ведёт текущий преподаватель
+func (h *SelectionHandler) loadOwnCoursework(c *gin.Context) (*models.Coursework, bool) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return nil, false
+	}
+	cwID, err := strconv.ParseUint(c.Param("courseworkId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid coursework id"})
+		return nil, false
+	}
+	cw, err := h.courseworkManager.GetCoursework(c.Request.Context(), uint(cwID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "coursework not found"})
+		return nil, false
+	}
+	if !canModerateCoursework(c, h.courseworkManager, user, cw) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return nil, false
+	}
+	return cw, true
+}
+
+func buildSelectionRoundResponse(r *models.SelectionRound) interfaces.SelectionRoundResponse {
+	return interfaces.SelectionRoundResponse{
+		ID:          r.ID,
+		Title:       r.Title,
+		MaxChoices:  r.MaxChoices,
+		Status:      r.Status,
+		ClosesAt:    r.ClosesAt,
+		CommittedAt: r.CommittedAt,
+		Subject: interfaces.SubjectResponse{
+			ID:          r.Subject.ID,
+			Name:        r.Subject.Name,
+			Code:        r.Subject.Code,
+			Description: r.Subject.Description,
+			Semester:    r.Subject.Semester,
+			IsActive:    r.Subject.IsActive,
+			CreatedAt:   r.Subject.CreatedAt.Format(time.RFC3339),
+		},
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func buildTopicPreferenceList(prefs []models.TopicPreference) []interfaces.TopicPreferenceResponse {
+	resp := make([]interfaces.TopicPreferenceResponse, len(prefs))
+	for i, p := range prefs {
+		resp[i] = interfaces.TopicPreferenceResponse{
+			Rank:            p.Rank,
+			CourseworkID:    p.CourseworkID,
+			CourseworkTitle: p.Coursework.Title,
+			TeacherName:     p.Coursework.Teacher.GetFullName(),
+		}
+	}
+	return resp
+}
@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// SubmissionHandler принимает файлы курсовых работ и отдаёт отчёты о заимствованиях
+type SubmissionHandler struct {
+	submissionManager interfaces.SubmissionManager
+	courseworkManager interfaces.CourseworkManager
+	maxUploadSize     int64
+}
+
+// NewSubmissionHandler создаёт новый SubmissionHandler
+func NewSubmissionHandler(sm interfaces.SubmissionManager, cm interfaces.CourseworkManager, maxUploadSize int64) *SubmissionHandler {
+	return &SubmissionHandler{
+		submissionManager: sm,
+		courseworkManager: cm,
+		maxUploadSize:     maxUploadSize,
+	}
+}
+
+// SubmitFile - сдача файла работы студентом (multipart: file)
+func (h *SubmissionHandler) SubmitFile(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadSize+1<<20)
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	if header.Size > h.maxUploadSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file is too large"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	sub, err := h.submissionManager.Submit(c.Request.Context(), user.ID, header.Filename, header.Header.Get("Content-Type"), file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, buildSubmissionResponse(sub, false))
+}
+
+// GetMySubmissions - все сданные файлы текущего студента
+func (h *SubmissionHandler) GetMySubmissions(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	subs, err := h.submissionManager.ListStudentSubmissions(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	resp := make([]interfaces.SubmissionResponse, len(subs))
+	for i := range subs {
+		resp[i] = buildSubmissionResponse(&subs[i], false)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetSubmission - сданный файл (автор, руководитель темы или админ)
+func (h *SubmissionHandler) GetSubmission(c *gin.Context) {
+	sub, user, ok := h.loadSubmission(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, buildSubmissionResponse(sub, !user.IsStudent()))
+}
+
+// DownloadFile - скачивание сданного файла
+func (h *SubmissionHandler) DownloadFile(c *gin.Context) {
+	sub, _, ok := h.loadSubmission(c)
+	if !ok {
+		return
+	}
+
+	file, err := h.submissionManager.OpenFile(c.Request.Context(), sub)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "file not found"})
+		return
+	}
+	defer file.Close()
+
+	contentType := sub.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.DataFromReader(http.StatusOK, sub.Size, contentType, file, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": sub.FileName}),
+	})
+}
+
+// GetSimilarityReport - отчёт о заимствованиях (руководитель темы или админ)
+func (h *SubmissionHandler) GetSimilarityReport(c *gin.Context) {
+	sub, ok := h.loadModeratedSubmission(c)
+	if !ok {
+		return
+	}
+
+	matches, err := h.submissionManager.GetSimilarityReport(c.Request.Context(), sub.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	resp := interfaces.SimilarityReportResponse{
+		Submission: buildSubmissionResponse(sub, true),
+		Matches:    make([]interfaces.SimilarityMatchResponse, len(matches)),
+	}
+	for i := range matches {
+		resp.Matches[i] = buildSimilarityMatchResponse(&matches[i])
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// RecheckSubmission - повторная проверка на заимствования
+func (h *SubmissionHandler) RecheckSubmission(c *gin.Context) {
+	sub, ok := h.loadModeratedSubmission(c)
+	if !ok {
+		return
+	}
+
+	if err := h.submissionManager.Recheck(c.Request.Context(), sub.ID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"message": "submission queued for checking"})
+}
+
+// GetCourseworkSubmissions - файлы, сданные по теме (руководитель темы или админ)
+func (h *SubmissionHandler) GetCourseworkSubmissions(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	cwID, ok := parseIDParam(c, "id", "coursework")
+	if !ok {
+		return
+	}
+
+	cw, err := h.courseworkManager.GetCoursework(c.Request.Context(), cwID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "project not found"})
+		return
+	}
+	if !canModerateCoursework(c, h.courseworkManager, user, cw) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return
+	}
+
+	subs, err := h.submissionManager.ListCourseworkSubmissions(c.Request.Context(), cwID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	resp := make([]interfaces.SubmissionResponse, len(subs))
+	for i := range subs {
+		resp[i] = buildSubmissionResponse(&subs[i], true)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// loadSubmission загружает работу и проверяет, что её может видеть текущий пользователь
+func (h *SubmissionHandler) loadSubmission(c *gin.Context) (*models.Submission, *models.User, bool) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return nil, nil, false
+	}
+	id, ok := parseIDParam(c, "id", "submission")
+	if !ok {
+		return nil, nil, false
+	}
+
+	sub, err := h.submissionManager.GetSubmission(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "submission not found"})
+		return nil, nil, false
+	}
+	if sub.StudentID != user.ID && (user.IsStudent() || !canModerateCoursework(c, h.courseworkManager, user, &sub.Coursework)) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return nil, nil, false
+	}
+	return sub, user, true
+}
+
+// loadModeratedSubmission загружает работу, доступную только руководителю темы или админу
+func (h *SubmissionHandler) loadModeratedSubmission(c *gin.Context) (*models.Submission, bool) {
+	sub, user, ok := h.loadSubmission(c)
+	if !ok {
+		return nil, false
+	}
+	if user.IsStudent() {
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return nil, false
+	}
+	return sub, true
+}
+
+// buildSubmissionResponse создаёт ответ для сданного файла; withScore - показывать ли процент заимствований
+func buildSubmissionResponse(sub *models.Submission, withScore bool) interfaces.SubmissionResponse {
+	resp := interfaces.SubmissionResponse{
+		ID:                  sub.ID,
+		StudentCourseworkID: sub.StudentCourseworkID,
+		CourseworkID:        sub.CourseworkID,
+		CourseworkTitle:     sub.Coursework.Title,
+		TermID:              sub.TermID,
+		FileName:            sub.FileName,
+		ContentType:         sub.ContentType,
+		Size:                sub.Size,
+		SHA256:              sub.SHA256,
+		CheckStatus:         sub.CheckStatus,
+		CheckError:          sub.CheckError,
+		CheckedAt:           sub.CheckedAt,
+		CreatedAt:           sub.CreatedAt,
+	}
+	if sub.Student.ID != 0 {
+		student := buildUserResponse(&sub.Student)
+		resp.Student = &student
+	}
+	if withScore && sub.CheckStatus == models.CheckDone {
+		score := sub.SimilarityScore
+		resp.SimilarityScore = &score
+	}
+	return resp
+}
+
+func buildSimilarityMatchResponse(m *models.SimilarityMatch) interfaces.SimilarityMatchResponse {
+	resp := interfaces.SimilarityMatchResponse{
+		MatchedSubmission: buildSubmissionResponse(&m.MatchedSubmission, true),
+		Similarity:        m.Similarity,
+		Jaccard:           m.Jaccard,
+		Fragments:         make([]interfaces.SimilarityFragmentResponse, len(m.Fragments)),
+	}
+	for i, f := range m.Fragments {
+		resp.Fragments[i] = interfaces.SimilarityFragmentResponse{
+			Text:            f.Text,
+			Words:           f.Words,
+			Position:        f.Position,
+			MatchedPosition: f.MatchedPosition,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,482 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// TeamHandler управляет командами студентов
+type TeamHandler struct {
+	teamManager interfaces.TeamManager
+	validator   *validator.Validate
+}
+
+// NewTeamHandler создаёт новый TeamHandler
+func NewTeamHandler(tm interfaces.TeamManager) *TeamHandler {
+	return &TeamHandler{
+		teamManager: tm,
+		validator:   validator.New(),
+	}
+}
+
+// CreateTeam - студент создаёт команду на свою курсовую и становится лидером
+func (h *TeamHandler) CreateTeam(c *gin.Context) {
+	var req interfaces.CreateTeamRequest
+	if !h.bind(c, &req) {
+		return
+	}
+
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	team, err := h.teamManager.CreateTeam(c.Request.Context(), user.ID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, buildTeamResponse(team))
+}
+
+// GetMyTeam - команда текущего студента
+func (h *TeamHandler) GetMyTeam(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	team, err := h.teamManager.GetStudentTeam(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "team not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, buildTeamResponse(team))
+}
+
+// GetTeam - детали команды (участники, руководитель курсовой, админ)
+func (h *TeamHandler) GetTeam(c *gin.Context) {
+	team, ok := h.loadVisibleTeam(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, buildTeamResponse(team))
+}
+
+// DisbandTeam - лидер или админ распускает команду
+func (h *TeamHandler) DisbandTeam(c *gin.Context) {
+	team, ok := h.loadTeam(c)
+	if !ok {
+		return
+	}
+	user := currentUser(c)
+	if !user.IsAdmin() && team.LeaderID != user.ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only the team leader can disband the team"})
+		return
+	}
+
+	if err := h.teamManager.DisbandTeam(c.Request.Context(), team.ID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// InviteMember - лидер приглашает студента
+func (h *TeamHandler) InviteMember(c *gin.Context) {
+	team, ok := h.loadLedTeam(c)
+	if !ok {
+		return
+	}
+	var req interfaces.InviteTeamMemberRequest
+	if !h.bind(c, &req) {
+		return
+	}
+
+	inv, err := h.teamManager.InviteMember(c.Request.Context(), team.ID, currentUser(c).ID, req.StudentID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, buildTeamInvitationResponse(inv))
+}
+
+// GetTeamInvitations - неотвеченные приглашения команды (лидер)
+func (h *TeamHandler) GetTeamInvitations(c *gin.Context) {
+	team, ok := h.loadLedTeam(c)
+	if !ok {
+		return
+	}
+
+	list, err := h.teamManager.GetTeamInvitations(c.Request.Context(), team.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, buildTeamInvitationList(list))
+}
+
+// RevokeInvitation - лидер отзывает приглашение
+func (h *TeamHandler) RevokeInvitation(c *gin.Context) {
+	team, ok := h.loadLedTeam(c)
+	if !ok {
+		return
+	}
+	invID, err := strconv.ParseUint(c.Param("invId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid invitation id"})
+		return
+	}
+	inv, err := h.teamManager.GetInvitation(c.Request.Context(), uint(invID))
+	if err != nil || inv.TeamID != team.ID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "invitation not found"})
+		return
+	}
+
+	if err := h.teamManager.RevokeInvitation(c.Request.Context(), inv.ID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetMyInvitations - входящие приглашения текущего студента
+func (h *TeamHandler) GetMyInvitations(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	list, err := h.teamManager.GetStudentInvitations(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, buildTeamInvitationList(list))
+}
+
+// AcceptInvitation - студент принимает приглашение
+func (h *TeamHandler) AcceptInvitation(c *gin.Context) {
+	h.respondInvitation(c, true)
+}
+
+// DeclineInvitation - студент отклоняет приглашение
+func (h *TeamHandler) DeclineInvitation(c *gin.Context) {
+	h.respondInvitation(c, false)
+}
+
+// RemoveMember - лидер исключает участника или участник покидает команду сам
+func (h *TeamHandler) RemoveMember(c *gin.Context) {
+	team, ok := h.loadTeam(c)
+	if !ok {
+		return
+	}
+	studentID, err := strconv.ParseUint(c.Param("studentId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid student id"})
+		return
+	}
+	user := currentUser(c)
+	if team.LeaderID != user.ID && uint(studentID) != user.ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return
+	}
+
+	if err := h.teamManager.RemoveMember(c.Request.Context(), team.ID, uint(studentID)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// TransferLeadership - лидер передаёт роль другому участнику
+func (h *TeamHandler) TransferLeadership(c *gin.Context) {
+	team, ok := h.loadLedTeam(c)
+	if !ok {
+		return
+	}
+	var req interfaces.TransferTeamLeadershipRequest
+	if !h.bind(c, &req) {
+		return
+	}
+
+	if err := h.teamManager.TransferLeadership(c.Request.Context(), team.ID, req.StudentID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateContribution - участник описывает свой вклад в работу
+func (h *TeamHandler) UpdateContribution(c *gin.Context) {
+	team, ok := h.loadTeam(c)
+	if !ok {
+		return
+	}
+	user := currentUser(c)
+	if !team.HasMember(user.ID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return
+	}
+	var req interfaces.UpdateContributionRequest
+	if !h.bind(c, &req) {
+		return
+	}
+
+	if err := h.teamManager.UpdateContribution(c.Request.Context(), team.ID, user.ID, req.Note); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetPosts - рабочее пространство команды
+func (h *TeamHandler) GetPosts(c *gin.Context) {
+	team, ok := h.loadVisibleTeam(c)
+	if !ok {
+		return
+	}
+
+	posts, err := h.teamManager.GetPosts(c.Request.Context(), team.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]interfaces.TeamPostResponse, len(posts))
+	for i := range posts {
+		resp[i] = buildTeamPostResponse(&posts[i])
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreatePost - запись в рабочем пространстве (участники и руководитель)
+func (h *TeamHandler) CreatePost(c *gin.Context) {
+	team, ok := h.loadVisibleTeam(c)
+	if !ok {
+		return
+	}
+	var req interfaces.CreateTeamPostRequest
+	if !h.bind(c, &req) {
+		return
+	}
+
+	post, err := h.teamManager.AddPost(c.Request.Context(), team.ID, currentUser(c).ID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, buildTeamPostResponse(post))
+}
+
+// SubmitWork - лидер сдаёт общую работу команды
+func (h *TeamHandler) SubmitWork(c *gin.Context) {
+	team, ok := h.loadLedTeam(c)
+	if !ok {
+		return
+	}
+	var req interfaces.SubmitTeamWorkRequest
+	if !h.bind(c, &req) {
+		return
+	}
+
+	if err := h.teamManager.SubmitWork(c.Request.Context(), team.ID, currentUser(c).ID, req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GradeTeam - руководитель курсовой оценивает команду целиком или каждого участника
+func (h *TeamHandler) GradeTeam(c *gin.Context) {
+	team, ok := h.loadTeam(c)
+	if !ok {
+		return
+	}
+	user := currentUser(c)
+	if !user.IsAdmin() && team.Coursework.TeacherID != user.ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only the coursework supervisor can grade the team"})
+		return
+	}
+	var req interfaces.GradeTeamRequest
+	if !h.bind(c, &req) {
+		return
+	}
+
+	if err := h.teamManager.GradeTeam(c.Request.Context(), team.ID, req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Вспомогательные методы
+
+func (h *TeamHandler) bind(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+	return true
+}
+
+// loadTeam загружает команду по :id, при ошибке сам пишет ответ
+func (h *TeamHandler) loadTeam(c *gin.Context) (*models.Team, bool) {
+	if currentUser(c) == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return nil, false
+	}
+	teamID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team id"})
+		return nil, false
+	}
+	team, err := h.teamManager.GetTeam(c.Request.Context(), uint(teamID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "team not found"})
+		return nil, false
+	}
+	return team, true
+}
+
+// loadVisibleTeam загружает команду, доступную участникам, руководителю курсовой и админу
+func (h *TeamHandler) loadVisibleTeam(c *gin.Context) (*models.Team, bool) {
+	team, ok := h.loadTeam(c)
+	if !ok {
+		return nil, false
+	}
+	user := currentUser(c)
+	if !user.IsAdmin() && team.Coursework.TeacherID != user.ID && !team.HasMember(user.ID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return nil, false
+	}
+	return team, true
+}
+
+// loadLedTeam загружает команду, лидером которой является текущий пользователь
+func (h *TeamHandler) loadLedTeam(c *gin.Context) (*models.Team, bool) {
+	team, ok := h.loadTeam(c)
+	if !ok {
+		return nil, false
+	}
+	if team.LeaderID != currentUser(c).ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only the team leader can do this"})
+		return nil, false
+	}
+	return team, true
+}
+
+func (h *TeamHandler) respondInvitation(c *gin.Context, accept bool) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	invID, err := strconv.ParseUint(c.Param("invId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid invitation id"})
+		return
+	}
+	inv, err := h.teamManager.GetInvitation(c.Request.Context(), uint(invID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "invitation not found"})
+		return
+	}
+	if inv.StudentID != user.ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return
+	}
+
+	team, err := h.teamManager.RespondInvitation(c.Request.Context(), inv.ID, accept)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if team == nil {
+		c.Status(http.StatusNoContent)
+		return
+	}
+	c.JSON(http.StatusOK, buildTeamResponse(team))
+}
+
+func buildTeamResponse(t *models.Team) interfaces.TeamResponse {
+	members := make([]interfaces.TeamMemberResponse, len(t.Members))
+	for i := range t.Members {
+		members[i] = interfaces.TeamMemberResponse{
+			Student:          buildUserResponse(&t.Members[i].Student),
+			IsLeader:         t.Members[i].StudentID == t.LeaderID,
+			ContributionNote: t.Members[i].ContributionNote,
+			JoinedAt:         t.Members[i].CreatedAt,
+		}
+	}
+	return interfaces.TeamResponse{
+		ID:                t.ID,
+		Name:              t.Name,
+		CourseworkID:      t.CourseworkID,
+		CourseworkTitle:   t.Coursework.Title,
+		MaxMembers:        t.Coursework.MaxStudents,
+		Leader:            buildUserResponse(&t.Leader),
+		GradingMode:       t.GradingMode,
+		Members:           members,
+		SubmissionURL:     t.SubmissionURL,
+		SubmissionComment: t.SubmissionComment,
+		SubmittedAt:       t.SubmittedAt,
+		CreatedAt:         t.CreatedAt,
+	}
+}
+
+func buildTeamInvitationList(list []models.TeamInvitation) []interfaces.TeamInvitationResponse {
+	resp := make([]interfaces.TeamInvitationResponse, len(list))
+	for i := range list {
+		resp[i] = buildTeamInvitationResponse(&list[i])
+	}
+	return resp
+}
+
+func buildTeamInvitationResponse(inv *models.TeamInvitation) interfaces.TeamInvitationResponse {
+	return interfaces.TeamInvitationResponse{
+		ID:              inv.ID,
+		TeamID:          inv.TeamID,
+		TeamName:        inv.Team.Name,
+		CourseworkTitle: inv.Team.Coursework.Title,
+		Student:         buildUserResponse(&inv.Student),
+		Status:          inv.Status,
+		CreatedAt:       inv.CreatedAt,
+	}
+}
+
+func buildTeamPostResponse(p *models.TeamPost) interfaces.TeamPostResponse {
+	return interfaces.TeamPostResponse{
+		ID:            p.ID,
+		Author:        buildUserResponse(&p.Author),
+		Body:          p.Body,
+		AttachmentURL: p.AttachmentURL,
+		CreatedAt:     p.CreatedAt,
+	}
+}
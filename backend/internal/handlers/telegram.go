@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+)
+
+// TelegramHandler - привязка чата Telegram к аккаунту
+type TelegramHandler struct {
+	telegramManager interfaces.TelegramManager
+}
+
+// NewTelegramHandler создаёт новый TelegramHandler
+func NewTelegramHandler(tm interfaces.TelegramManager) *TelegramHandler {
+	return &TelegramHandler{telegramManager: tm}
+}
+
+// GetLink - привязан ли чат текущего пользователя
+func (h *TelegramHandler) GetLink(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	link, err := h.telegramManager.GetLink(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, link)
+}
+
+// CreateLinkCode - одноразовый код, который нужно отправить боту командой /start
+func (h *TelegramHandler) CreateLinkCode(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	code, err := h.telegramManager.CreateLinkCode(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, code)
+}
+
+// Unlink - отвязать чат
+func (h *TelegramHandler) Unlink(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.telegramManager.Unlink(c.Request.Context(), user.ID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
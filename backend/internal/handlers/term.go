@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// TermHandler управляет учебными годами и семестрами
+type TermHandler struct {
+	termManager interfaces.TermManager
+	validator   *validator.Validate
+}
+
+// NewTermHandler создаёт новый TermHandler
+func NewTermHandler(tm interfaces.TermManager) *TermHandler {
+	return &TermHandler{
+		termManager: tm,
+		validator:   validator.New(),
+	}
+}
+
+// ListAcademicYears - учебные годы с семестрами, включая прошедшие
+func (h *TermHandler) ListAcademicYears(c *gin.Context) {
+	years, err := h.termManager.ListAcademicYears(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]interfaces.AcademicYearResponse, len(years))
+	for i := range years {
+		resp[i] = buildAcademicYearResponse(&years[i])
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetCurrentTerm - текущий активный семестр
+func (h *TermHandler) GetCurrentTerm(c *gin.Context) {
+	term, err := h.termManager.GetActiveTerm(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, buildTermResponse(term, term.AcademicYear.Name))
+}
+
+// GetTerm - семестр по ID
+func (h *TermHandler) GetTerm(c *gin.Context) {
+	termID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid term id"})
+		return
+	}
+
+	term, err := h.termManager.GetTerm(c.Request.Context(), uint(termID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "term not found"})
+		return
+	}
+	c.JSON(http.StatusOK, buildTermResponse(term, term.AcademicYear.Name))
+}
+
+// CreateAcademicYear - админ заводит учебный год; осенний и весенний семестры создаются автоматически
+func (h *TermHandler) CreateAcademicYear(c *gin.Context) {
+	var req interfaces.CreateAcademicYearRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	year, err := h.termManager.CreateAcademicYear(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, buildAcademicYearResponse(year))
+}
+
+// ActivateTerm - админ переключает текущий семестр
+func (h *TermHandler) ActivateTerm(c *gin.Context) {
+	termID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid term id"})
+		return
+	}
+
+	term, err := h.termManager.ActivateTerm(c.Request.Context(), uint(termID))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, buildTermResponse(term, term.AcademicYear.Name))
+}
+
+func buildAcademicYearResponse(y *models.AcademicYear) interfaces.AcademicYearResponse {
+	resp := interfaces.AcademicYearResponse{
+		ID:       y.ID,
+		Name:     y.Name,
+		StartsOn: y.StartsOn,
+		EndsOn:   y.EndsOn,
+		Terms:    make([]interfaces.TermResponse, len(y.Terms)),
+	}
+	for i := range y.Terms {
+		resp.Terms[i] = buildTermResponse(&y.Terms[i], y.Name)
+	}
+	return resp
+}
+
+func buildTermResponse(t *models.Term, yearName string) interfaces.TermResponse {
+	return interfaces.TermResponse{
+		ID:             t.ID,
+		AcademicYearID: t.AcademicYearID,
+		AcademicYear:   yearName,
+		Number:         t.Number,
+		Name:           t.Name,
+		StartsOn:       t.StartsOn,
+		EndsOn:         t.EndsOn,
+		IsActive:       t.IsActive,
+	}
+}
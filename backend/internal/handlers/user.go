@@ -47,6 +47,7 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 		LastName:  user.LastName,
 		Role:      user.Role,
 		IsActive:  user.IsActive,
+		Version:   user.Version,
 		CreatedAt: user.CreatedAt.Format(time.RFC3339),
 	})
 }
@@ -105,6 +106,7 @@ func (h *UserHandler) ListUsers(c *gin.Context) {
 			LastName:  u.LastName,
 			Role:      u.Role,
 			IsActive:  u.IsActive,
+			Version:   u.Version,
 			CreatedAt: u.CreatedAt.Format(time.RFC3339),
 		}
 	}
@@ -127,6 +129,10 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 		return
 	}
 
+	if notModified(c, user.Version) {
+		return
+	}
+	setETag(c, user.Version)
 	c.JSON(http.StatusOK, interfaces.UserResponse{
 		ID:        user.ID,
 		Email:     user.Email,
@@ -134,6 +140,7 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 		LastName:  user.LastName,
 		Role:      user.Role,
 		IsActive:  user.IsActive,
+		Version:   user.Version,
 		CreatedAt: user.CreatedAt.Format(time.RFC3339),
 	})
 }
@@ -146,6 +153,15 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
 		return
 	}
+	current, err := h.userManager.GetUser(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	version, ok := ifMatchVersion(c, current.Version)
+	if !ok {
+		return
+	}
 
 	var req interfaces.UpdateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -153,12 +169,15 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
-	user, err := h.userManager.UpdateUser(c.Request.Context(), uint(id), req)
+	user, err := h.userManager.UpdateUser(c.Request.Context(), uint(id), req, version)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if !versionConflict(c, err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
+	setETag(c, user.Version)
 	c.JSON(http.StatusOK, interfaces.UserResponse{
 		ID:        user.ID,
 		Email:     user.Email,
@@ -166,6 +185,7 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		LastName:  user.LastName,
 		Role:      user.Role,
 		IsActive:  user.IsActive,
+		Version:   user.Version,
 		CreatedAt: user.CreatedAt.Format(time.RFC3339),
 	})
 }
@@ -178,10 +198,21 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
 		return
 	}
+	current, err := h.userManager.GetUser(c.Request.Context(), uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	version, ok := ifMatchVersion(c, current.Version)
+	if !ok {
+		return
+	}
 
-	err = h.userManager.DeleteUser(c.Request.Context(), uint(id))
+	err = h.userManager.DeleteUser(c.Request.Context(), uint(id), version)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if !versionConflict(c, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// WaitlistHandler управляет листами ожидания на заполненные курсовые
+type WaitlistHandler struct {
+	waitlistManager   interfaces.WaitlistManager
+	courseworkManager interfaces.CourseworkManager
+}
+
+// NewWaitlistHandler создаёт новый WaitlistHandler
+func NewWaitlistHandler(wm interfaces.WaitlistManager, cm interfaces.CourseworkManager) *WaitlistHandler {
+	return &WaitlistHandler{
+		waitlistManager:   wm,
+		courseworkManager: cm,
+	}
+}
+
+// JoinWaitlist - студент встаёт в очередь на заполненную курсовую
+func (h *WaitlistHandler) JoinWaitlist(c *gin.Context) {
+	cwID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid project id"})
+		return
+	}
+
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	entry, err := h.waitlistManager.JoinWaitlist(c.Request.Context(), user.ID, uint(cwID))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, buildWaitlistEntryResponse(entry, false))
+}
+
+// GetCourseworkWaitlist - очередь на курсовую (владелец, ведущий преподаватель или админ)
+func (h *WaitlistHandler) GetCourseworkWaitlist(c *gin.Context) {
+	cwID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid project id"})
+		return
+	}
+
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	cw, err := h.courseworkManager.GetCoursework(c.Request.Context(), uint(cwID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "project not found"})
+		return
+	}
+	if !canModerateCoursework(c, h.courseworkManager, user, cw) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return
+	}
+
+	list, err := h.waitlistManager.GetCourseworkWaitlist(c.Request.Context(), cw.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]interfaces.WaitlistEntryResponse, len(list))
+	for i := range list {
+		list[i].Coursework = *cw
+		resp[i] = buildWaitlistEntryResponse(&list[i], true)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetMyWaitlist - очереди текущего студента
+func (h *WaitlistHandler) GetMyWaitlist(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	list, err := h.waitlistManager.GetStudentEntries(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]interfaces.WaitlistEntryResponse, len(list))
+	for i := range list {
+		resp[i] = buildWaitlistEntryResponse(&list[i], false)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// AcceptOffer - студент подтверждает предложенное место
+func (h *WaitlistHandler) AcceptOffer(c *gin.Context) {
+	entry, ok := h.loadOwnEntry(c)
+	if !ok {
+		return
+	}
+
+	assignment, err := h.waitlistManager.AcceptOffer(c.Request.Context(), entry.ID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"assignment_id": assignment.ID,
+		"coursework_id": assignment.CourseworkID,
+		"status":        assignment.Status,
+	})
+}
+
+// DeclineOffer - студент отказывается от предложенного места
+func (h *WaitlistHandler) DeclineOffer(c *gin.Context) {
+	entry, ok := h.loadOwnEntry(c)
+	if !ok {
+		return
+	}
+
+	if err := h.waitlistManager.DeclineOffer(c.Request.Context(), entry.ID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// LeaveWaitlist - студент покидает очередь
+func (h *WaitlistHandler) LeaveWaitlist(c *gin.Context) {
+	entry, ok := h.loadOwnEntry(c)
+	if !ok {
+		return
+	}
+
+	if err := h.waitlistManager.LeaveWaitlist(c.Request.Context(), entry.ID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Вспомогательные методы
+
+// loadOwnEntry загружает запись очереди по :id и проверяет, что она принадлежит текущему студенту
+func (h *WaitlistHandler) loadOwnEntry(c *gin.Context) (*models.WaitlistEntry, bool) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return nil, false
+	}
+	entryID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid waitlist entry id"})
+		return nil, false
+	}
+	entry, err := h.waitlistManager.GetEntry(c.Request.Context(), uint(entryID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "waitlist entry not found"})
+		return nil, false
+	}
+	if entry.StudentID != user.ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return nil, false
+	}
+	return entry, true
+}
+
+func buildWaitlistEntryResponse(e *models.WaitlistEntry, withStudent bool) interfaces.WaitlistEntryResponse {
+	resp := interfaces.WaitlistEntryResponse{
+		ID:              e.ID,
+		CourseworkID:    e.CourseworkID,
+		CourseworkTitle: e.Coursework.Title,
+		Position:        e.Position,
+		Status:          e.Status,
+		OfferedAt:       e.OfferedAt,
+		OfferExpiresAt:  e.OfferExpiresAt,
+		ResolvedAt:      e.ResolvedAt,
+		CreatedAt:       e.CreatedAt,
+	}
+	if withStudent {
+		student := buildUserResponse(&e.Student)
+		resp.Student = &student
+	}
+	return resp
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+)
+
+// WebhookHandler управляет исходящими вебхуками (admin)
+type WebhookHandler struct {
+	webhookManager interfaces.WebhookManager
+	validator      *validator.Validate
+}
+
+// NewWebhookHandler создаёт новый WebhookHandler
+func NewWebhookHandler(wm interfaces.WebhookManager) *WebhookHandler {
+	return &WebhookHandler{
+		webhookManager: wm,
+		validator:      validator.New(),
+	}
+}
+
+// CreateWebhook - регистрация вебхука; ключ подписи возвращается только здесь и при смене
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var req interfaces.CreateWebhookRequest
+	if !bindAndValidate(c, h.validator, &req) {
+		return
+	}
+
+	hook, err := h.webhookManager.CreateWebhook(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, hook)
+}
+
+// ListWebhooks - все вебхуки
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	hooks, err := h.webhookManager.ListWebhooks(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, hooks)
+}
+
+// GetWebhook - вебхук по ID
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	hookID, ok := parseIDParam(c, "id", "webhook")
+	if !ok {
+		return
+	}
+
+	hook, err := h.webhookManager.GetWebhook(c.Request.Context(), hookID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "webhook not found"})
+		return
+	}
+	c.JSON(http.StatusOK, hook)
+}
+
+// UpdateWebhook - изменение адреса, событий, включение и смена ключа
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	hookID, ok := parseIDParam(c, "id", "webhook")
+	if !ok {
+		return
+	}
+	var req interfaces.UpdateWebhookRequest
+	if !bindAndValidate(c, h.validator, &req) {
+		return
+	}
+
+	hook, err := h.webhookManager.UpdateWebhook(c.Request.Context(), hookID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, hook)
+}
+
+// DeleteWebhook - удаление вебхука вместе с журналом доставок
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	hookID, ok := parseIDParam(c, "id", "webhook")
+	if !ok {
+		return
+	}
+
+	if err := h.webhookManager.DeleteWebhook(c.Request.Context(), hookID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListDeliveries - журнал доставок вебхука, новые сверху
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	hookID, ok := parseIDParam(c, "id", "webhook")
+	if !ok {
+		return
+	}
+	limit := DefaultPageSize
+	if v, err := strconv.Atoi(c.Query("limit")); err == nil && v > 0 && v <= MaxPageSize {
+		limit = v
+	}
+	offset := 0
+	if v, err := strconv.Atoi(c.Query("offset")); err == nil && v > 0 {
+		offset = v
+	}
+
+	list, err := h.webhookManager.ListDeliveries(c.Request.Context(), hookID, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// GetDelivery - доставка с телом запроса и ответом получателя
+func (h *WebhookHandler) GetDelivery(c *gin.Context) {
+	hookID, ok := parseIDParam(c, "id", "webhook")
+	if !ok {
+		return
+	}
+	deliveryID, ok := parseIDParam(c, "deliveryId", "delivery")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.webhookManager.GetDelivery(c.Request.Context(), hookID, deliveryID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "delivery not found"})
+		return
+	}
+	c.JSON(http.StatusOK, delivery)
+}
+
+// Redeliver - повторная отправка события вручную
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	hookID, ok := parseIDParam(c, "id", "webhook")
+	if !ok {
+		return
+	}
+	deliveryID, ok := parseIDParam(c, "deliveryId", "delivery")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.webhookManager.Redeliver(c.Request.Context(), hookID, deliveryID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, delivery)
+}
+
+// Ping - проверочное событие для проверки адреса и подписи
+func (h *WebhookHandler) Ping(c *gin.Context) {
+	hookID, ok := parseIDParam(c, "id", "webhook")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.webhookManager.Ping(c.Request.Context(), hookID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, delivery)
+}
@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// WorkloadHandler управляет квотами руководства и отчётами о нагрузке
+type WorkloadHandler struct {
+	workloadManager interfaces.WorkloadManager
+	validator       *validator.Validate
+}
+
+// NewWorkloadHandler создаёт новый WorkloadHandler
+func NewWorkloadHandler(wm interfaces.WorkloadManager) *WorkloadHandler {
+	return &WorkloadHandler{
+		workloadManager: wm,
+		validator:       validator.New(),
+	}
+}
+
+// GetMyWorkload - нагрузка текущего преподавателя
+func (h *WorkloadHandler) GetMyWorkload(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	w, err := h.workloadManager.GetTeacherWorkload(c.Request.Context(), user.ID, c.Query("year"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, w)
+}
+
+// GetReport - отчёт о нагрузке; заведующий видит только свою кафедру
+func (h *WorkloadHandler) GetReport(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var departmentID uint
+	if raw := c.Query("department_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid department id"})
+			return
+		}
+		departmentID = uint(id)
+	}
+
+	if !user.IsAdmin() {
+		departments, err := h.workloadManager.GetHeadedDepartments(c.Request.Context(), user.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if departmentID == 0 && len(departments) == 1 {
+			departmentID = departments[0].ID
+		}
+		if !headsDepartment(departments, departmentID) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+			return
+		}
+	}
+
+	report, err := h.workloadManager.GetWorkloadReport(c.Request.Context(), c.Query("year"), departmentID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// ListQuotas - квоты учебного года; заведующему - только преподаватели его кафедры
+func (h *WorkloadHandler) ListQuotas(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	quotas, err := h.workloadManager.GetQuotas(c.Request.Context(), c.Query("year"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := make([]interfaces.SupervisionQuotaResponse, 0, len(quotas))
+	for i := range quotas {
+		if !user.IsAdmin() {
+			ok, err := h.workloadManager.CanManageTeacher(c.Request.Context(), user.ID, quotas[i].TeacherID)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			if !ok {
+				continue
+			}
+		}
+		resp = append(resp, buildQuotaResponse(&quotas[i]))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// SetQuota - админ или заведующий кафедрой задаёт квоту преподавателю
+func (h *WorkloadHandler) SetQuota(c *gin.Context) {
+	user, teacherID, ok := h.authorizeTeacher(c)
+	if !ok {
+		return
+	}
+
+	var req interfaces.SetSupervisionQuotaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	quota, err := h.workloadManager.SetQuota(c.Request.Context(), teacherID, user.ID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, buildQuotaResponse(quota))
+}
+
+// DeleteQuota - снимает индивидуальную квоту преподавателя
+func (h *WorkloadHandler) DeleteQuota(c *gin.Context) {
+	_, teacherID, ok := h.authorizeTeacher(c)
+	if !ok {
+		return
+	}
+
+	if err := h.workloadManager.DeleteQuota(c.Request.Context(), teacherID, c.Query("year")); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// SetDepartmentHead - админ назначает или снимает заведующего кафедрой
+func (h *WorkloadHandler) SetDepartmentHead(c *gin.Context) {
+	deptID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid department id"})
+		return
+	}
+
+	var req interfaces.SetDepartmentHeadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.workloadManager.SetDepartmentHead(c.Request.Context(), uint(deptID), req.UserID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Вспомогательные методы
+
+// authorizeTeacher разбирает :teacherId и проверяет право управлять квотой преподавателя
+func (h *WorkloadHandler) authorizeTeacher(c *gin.Context) (*models.User, uint, bool) {
+	user := currentUser(c)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return nil, 0, false
+	}
+	teacherID, err := strconv.ParseUint(c.Param("teacherId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid teacher id"})
+		return nil, 0, false
+	}
+	if !user.IsAdmin() {
+		ok, err := h.workloadManager.CanManageTeacher(c.Request.Context(), user.ID, uint(teacherID))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return nil, 0, false
+		}
+		if !ok {
+			c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+			return nil, 0, false
+		}
+	}
+	return user, uint(teacherID), true
+}
+
+func headsDepartment(departments []models.Department, departmentID uint) bool {
+	for _, d := range departments {
+		if d.ID == departmentID {
+			return true
+		}
+	}
+	return false
+}
+
+func buildQuotaResponse(q *models.SupervisionQuota) interfaces.SupervisionQuotaResponse {
+	return interfaces.SupervisionQuotaResponse{
+		TeacherID:       q.TeacherID,
+		Teacher:         buildUserResponse(&q.Teacher),
+		AcademicYear:    q.AcademicYear,
+		MaxStudents:     q.MaxStudents,
+		MaxTopics:       q.MaxTopics,
+		HoursPerStudent: q.HoursPerStudent,
+		SetByID:         q.SetByID,
+		UpdatedAt:       q.UpdatedAt,
+	}
+}
@@ -1,6 +1,7 @@
 package interfaces
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/Foxpunk/courseforge/internal/models"
@@ -91,6 +92,7 @@ type UserResponse struct {
 	LastName  string          `json:"last_name"`
 	Role      models.UserRole `json:"role"`
 	IsActive  bool            `json:"is_active"`
+	Version   uint            `json:"version,omitempty"` // для If-Match при изменении и удалении
 	CreatedAt string          `json:"created_at"`
 }
 
@@ -120,6 +122,7 @@ type DepartmentResponse struct {
 	DepartmentCode string `json:"department_code"`
 	DepartmentName string `json:"department_name"`
 	Description    string `json:"description"`
+	HeadUserID     *uint  `json:"head_user_id,omitempty"`
 	CreatedAt      string `json:"created_at"`
 }
 
@@ -199,6 +202,13 @@ type TeacherProfileResponse struct {
 	CreatedAt      string             `json:"created_at"`
 }
 
+// ProfileResponse - текущий пользователь вместе с профилем студента или преподавателя
+type ProfileResponse struct {
+	UserResponse
+	StudentProfile *StudentProfileResponse `json:"student_profile,omitempty"`
+	TeacherProfile *TeacherProfileResponse `json:"teacher_profile,omitempty"`
+}
+
 // ============================================================================
 // SUBJECT DTOs
 // ============================================================================
@@ -235,8 +245,9 @@ type SubjectResponse struct {
 type CreateTeacherSubjectRequest struct {
 	TeacherID    uint   `json:"teacher_id" validate:"required"`
 	SubjectID    uint   `json:"subject_id" validate:"required"`
-	AcademicYear string `json:"academic_year" validate:"required,min=9,max=9"`
+	AcademicYear string `json:"academic_year" validate:"omitempty,len=9"` // по умолчанию - текущий учебный год
 	IsLead       bool   `json:"is_lead"`
+	Role         string `json:"role" validate:"omitempty,oneof=lecturer assistant supervisor"` // по умолчанию - supervisor
 }
 
 type UpdateTeacherSubjectRequest struct {
@@ -244,11 +255,12 @@ type UpdateTeacherSubjectRequest struct {
 }
 
 type TeacherSubjectResponse struct {
-	ID           uint            `json:"id"`
+	TermID       uint            `json:"term_id"`
 	Teacher      UserResponse    `json:"teacher"`
 	Subject      SubjectResponse `json:"subject"`
 	AcademicYear string          `json:"academic_year"`
 	IsLead       bool            `json:"is_lead"`
+	Role         string          `json:"role"`
 	CreatedAt    string          `json:"created_at"`
 }
 
@@ -269,6 +281,7 @@ type CreateCourseworkRequest struct {
 	TeacherID       uint                   `json:"teacher_id" validate:"required"`
 	MaxStudents     int                    `json:"max_students" validate:"min=1,max=10"`
 	DifficultyLevel models.DifficultyLevel `json:"difficulty_level" validate:"required,oneof=easy medium hard"`
+	TermID          uint                   `json:"term_id,omitempty"` // по умолчанию - текущий семестр
 }
 
 type UpdateCourseworkRequest struct {
@@ -282,11 +295,15 @@ type UpdateCourseworkRequest struct {
 	IsAvailable     *bool                   `json:"is_available,omitempty"`
 }
 
+// ListCourseworksRequest - без TermID берётся текущий семестр, AllTerms снимает фильтр; Limit 0 - без пагинации
 type ListCourseworksRequest struct {
+	TermID     *uint                   `json:"term_id,omitempty"`
+	AllTerms   bool                    `json:"all_terms,omitempty"`
 	SubjectID  *uint                   `json:"subject_id,omitempty"`
 	TeacherID  *uint                   `json:"teacher_id,omitempty"`
 	Available  *bool                   `json:"available,omitempty"`
 	Difficulty *models.DifficultyLevel `json:"difficulty_level,omitempty"`
+	SubjectIDs []uint                  `json:"-"` // ограничение учебным планом студента; nil - без ограничения
 	Limit      int                     `json:"limit" validate:"min=1,max=100"`
 	Offset     int                     `json:"offset" validate:"min=0"`
 }
@@ -299,6 +316,8 @@ type CourseworkResponse struct {
 	MaxStudents     int                    `json:"max_students"`
 	DifficultyLevel models.DifficultyLevel `json:"difficulty_level"`
 	IsAvailable     bool                   `json:"is_available"`
+	TermID          uint                   `json:"term_id"`
+	Version         uint                   `json:"version"` // для If-Match при изменении и удалении
 	Subject         SubjectResponse        `json:"subject"`
 	Teacher         UserResponse           `json:"teacher"`
 	CreatedAt       time.Time              `json:"created_at"`
@@ -378,3 +397,1039 @@ type StudentCourseworkSummary struct {
 	CompletedAt *time.Time              `json:"completed_at,omitempty"`
 	Grade       *int                    `json:"grade,omitempty"`
 }
+
+// ============================================================================
+// DEFENSE SCHEDULING DTOs
+// ============================================================================
+
+type CreateDefenseRoomRequest struct {
+	Name     string `json:"name" validate:"required,min=1,max=50"`
+	Building string `json:"building" validate:"max=100"`
+	Capacity int    `json:"capacity" validate:"min=0"`
+}
+
+type DefenseRoomResponse struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	Building string `json:"building"`
+	Capacity int    `json:"capacity"`
+}
+
+type CommitteeMemberRequest struct {
+	TeacherID uint                 `json:"teacher_id" validate:"required"`
+	Role      models.CommitteeRole `json:"role" validate:"omitempty,oneof=chair member"`
+}
+
+type CreateDefenseSessionRequest struct {
+	Title       string                   `json:"title" validate:"required,min=3,max=300"`
+	SubjectID   uint                     `json:"subject_id" validate:"required"`
+	RoomID      uint                     `json:"room_id" validate:"required"`
+	StartsAt    time.Time                `json:"starts_at" validate:"required"`
+	EndsAt      time.Time                `json:"ends_at" validate:"required,gtfield=StartsAt"`
+	SlotMinutes int                      `json:"slot_minutes" validate:"required,min=5,max=240"`
+	Committee   []CommitteeMemberRequest `json:"committee" validate:"dive"`
+	TermID      uint                     `json:"term_id,omitempty"` // по умолчанию - текущий семестр
+}
+
+type SetCommitteeRequest struct {
+	Committee []CommitteeMemberRequest `json:"committee" validate:"required,min=1,dive"`
+}
+
+type AssignDefenseSlotRequest struct {
+	AssignmentID uint `json:"assignment_id" validate:"required"`
+}
+
+type CommitteeMemberResponse struct {
+	Teacher UserResponse         `json:"teacher"`
+	Role    models.CommitteeRole `json:"role"`
+}
+
+type DefenseSlotResponse struct {
+	ID              uint          `json:"id"`
+	StartsAt        time.Time     `json:"starts_at"`
+	EndsAt          time.Time     `json:"ends_at"`
+	AssignmentID    *uint         `json:"assignment_id,omitempty"`
+	Student         *UserResponse `json:"student,omitempty"`
+	CourseworkTitle string        `json:"coursework_title,omitempty"`
+}
+
+type DefenseSessionResponse struct {
+	ID          uint                      `json:"id"`
+	Title       string                    `json:"title"`
+	Subject     SubjectResponse           `json:"subject"`
+	Room        DefenseRoomResponse       `json:"room"`
+	StartsAt    time.Time                 `json:"starts_at"`
+	EndsAt      time.Time                 `json:"ends_at"`
+	SlotMinutes int                       `json:"slot_minutes"`
+	Committee   []CommitteeMemberResponse `json:"committee"`
+	Slots       []DefenseSlotResponse     `json:"slots,omitempty"`
+}
+
+// DefenseSlotAssignment - результат распределения одного назначения по слотам
+type DefenseSlotAssignment struct {
+	AssignmentID uint       `json:"assignment_id"`
+	StudentID    uint       `json:"student_id"`
+	StudentName  string     `json:"student_name"`
+	SlotID       *uint      `json:"slot_id,omitempty"`
+	StartsAt     *time.Time `json:"starts_at,omitempty"`
+	Reason       string     `json:"reason,omitempty"`
+}
+
+type DefenseDistributionResult struct {
+	SessionID   uint                    `json:"session_id"`
+	Scheduled   []DefenseSlotAssignment `json:"scheduled"`
+	Unscheduled []DefenseSlotAssignment `json:"unscheduled"`
+}
+
+// DefenseScheduleEntry - событие в расписании защит пользователя
+type DefenseScheduleEntry struct {
+	SessionID uint      `json:"session_id"`
+	SlotID    *uint     `json:"slot_id,omitempty"`
+	Kind      string    `json:"kind"` // defense | committee | supervision
+	Title     string    `json:"title"`
+	Location  string    `json:"location"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+}
+
+// ============================================================================
+// TOPIC PROPOSAL DTOs
+// ============================================================================
+
+type CreateTopicProposalRequest struct {
+	Title       string `json:"title" validate:"required,min=5,max=300"`
+	Description string `json:"description" validate:"required,min=20"`
+	SubjectID   uint   `json:"subject_id" validate:"required"`
+	TeacherID   uint   `json:"teacher_id" validate:"required"`
+}
+
+type UpdateTopicProposalRequest struct {
+	Title       *string `json:"title,omitempty" validate:"omitempty,min=5,max=300"`
+	Description *string `json:"description,omitempty" validate:"omitempty,min=20"`
+}
+
+type AcceptTopicProposalRequest struct {
+	Requirements    string                 `json:"requirements"`
+	DifficultyLevel models.DifficultyLevel `json:"difficulty_level" validate:"omitempty,oneof=easy medium hard"`
+}
+
+type ProposalDecisionRequest struct {
+	Comment string `json:"comment" validate:"required,min=3"`
+}
+
+type TopicProposalResponse struct {
+	ID             uint                  `json:"id"`
+	Title          string                `json:"title"`
+	Description    string                `json:"description"`
+	Status         models.ProposalStatus `json:"status"`
+	TeacherComment string                `json:"teacher_comment,omitempty"`
+	Revision       int                   `json:"revision"`
+	Student        UserResponse          `json:"student"`
+	Teacher        UserResponse          `json:"teacher"`
+	Subject        SubjectResponse       `json:"subject"`
+	CourseworkID   *uint                 `json:"coursework_id,omitempty"`
+	DecidedAt      *time.Time            `json:"decided_at,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+// ============================================================================
+// SELECTION ROUND DTOs
+// ============================================================================
+
+type CreateSelectionRoundRequest struct {
+	Title      string     `json:"title" validate:"required,min=3,max=300"`
+	SubjectID  uint       `json:"subject_id" validate:"required"`
+	MaxChoices int        `json:"max_choices" validate:"required,min=1,max=20"`
+	ClosesAt   *time.Time `json:"closes_at,omitempty"`
+}
+
+type SetSelectionRoundStatusRequest struct {
+	Status models.RoundStatus `json:"status" validate:"required,oneof=draft open closed"`
+}
+
+// SubmitPreferencesRequest - темы в порядке убывания желательности
+type SubmitPreferencesRequest struct {
+	CourseworkIDs []uint `json:"coursework_ids" validate:"required,min=1,dive,required"`
+}
+
+// RankApplicantsRequest - студенты в порядке приоритета руководителя
+type RankApplicantsRequest struct {
+	StudentIDs []uint `json:"student_ids" validate:"dive,required"`
+}
+
+type SelectionRoundResponse struct {
+	ID          uint               `json:"id"`
+	Title       string             `json:"title"`
+	MaxChoices  int                `json:"max_choices"`
+	Status      models.RoundStatus `json:"status"`
+	ClosesAt    *time.Time         `json:"closes_at,omitempty"`
+	CommittedAt *time.Time         `json:"committed_at,omitempty"`
+	Subject     SubjectResponse    `json:"subject"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
+type TopicPreferenceResponse struct {
+	Rank            int    `json:"rank"`
+	CourseworkID    uint   `json:"coursework_id"`
+	CourseworkTitle string `json:"coursework_title"`
+	TeacherName     string `json:"teacher_name"`
+}
+
+// SelectionApplicant - студент, выбравший тему, с его приоритетом и оценкой руководителя
+type SelectionApplicant struct {
+	StudentID      uint   `json:"student_id"`
+	StudentName    string `json:"student_name"`
+	PreferenceRank int    `json:"preference_rank"`
+	TeacherRank    *int   `json:"teacher_rank,omitempty"`
+}
+
+// AllocationEntry - результат распределения одного студента
+type AllocationEntry struct {
+	StudentID       uint   `json:"student_id"`
+	StudentName     string `json:"student_name"`
+	CourseworkID    uint   `json:"coursework_id,omitempty"`
+	CourseworkTitle string `json:"coursework_title,omitempty"`
+	PreferenceRank  int    `json:"preference_rank,omitempty"`
+	Reason          string `json:"reason,omitempty"`
+}
+
+// AllocationStats - показатели справедливости распределения
+type AllocationStats struct {
+	Participants      int         `json:"participants"`
+	Matched           int         `json:"matched"`
+	Unmatched         int         `json:"unmatched"`
+	FirstChoice       int         `json:"first_choice"`
+	FirstChoiceShare  float64     `json:"first_choice_share"`
+	AverageRank       float64     `json:"average_rank"`
+	WorstRank         int         `json:"worst_rank"`
+	RankDistribution  map[int]int `json:"rank_distribution"`
+	TopicsFilled      int         `json:"topics_filled"`
+	FreeSlotsRemained int         `json:"free_slots_remained"`
+}
+
+type AllocationResult struct {
+	RoundID     uint              `json:"round_id"`
+	Committed   bool              `json:"committed"`
+	Assignments []AllocationEntry `json:"assignments"`
+	Unmatched   []AllocationEntry `json:"unmatched"`
+	Stats       AllocationStats   `json:"stats"`
+}
+
+// ============================================================================
+// WAITLIST DTOs
+// ============================================================================
+
+type WaitlistEntryResponse struct {
+	ID              uint                  `json:"id"`
+	CourseworkID    uint                  `json:"coursework_id"`
+	CourseworkTitle string                `json:"coursework_title,omitempty"`
+	Student         *UserResponse         `json:"student,omitempty"`
+	Position        int                   `json:"position"`
+	Status          models.WaitlistStatus `json:"status"`
+	OfferedAt       *time.Time            `json:"offered_at,omitempty"`
+	OfferExpiresAt  *time.Time            `json:"offer_expires_at,omitempty"`
+	ResolvedAt      *time.Time            `json:"resolved_at,omitempty"`
+	CreatedAt       time.Time             `json:"created_at"`
+}
+
+// ============================================================================
+// TEAM DTOs
+// ============================================================================
+
+type CreateTeamRequest struct {
+	Name string `json:"name" validate:"required,min=2,max=100"`
+}
+
+type InviteTeamMemberRequest struct {
+	StudentID uint `json:"student_id" validate:"required"`
+}
+
+type TransferTeamLeadershipRequest struct {
+	StudentID uint `json:"student_id" validate:"required"`
+}
+
+type UpdateContributionRequest struct {
+	Note string `json:"note" validate:"max=5000"`
+}
+
+type CreateTeamPostRequest struct {
+	Body          string `json:"body" validate:"required,min=1,max=10000"`
+	AttachmentURL string `json:"attachment_url,omitempty" validate:"omitempty,url,max=500"`
+}
+
+type SubmitTeamWorkRequest struct {
+	SubmissionURL string `json:"submission_url" validate:"required,url,max=500"`
+	Comment       string `json:"comment,omitempty"`
+}
+
+// GradeTeamRequest - в режиме team используется Grade, в режиме individual - Members
+type GradeTeamRequest struct {
+	Mode     models.TeamGradingMode `json:"mode" validate:"required,oneof=team individual"`
+	Grade    *int                   `json:"grade,omitempty" validate:"omitempty,min=2,max=5"`
+	Feedback string                 `json:"feedback,omitempty"`
+	Members  []MemberGradeRequest   `json:"members,omitempty" validate:"dive"`
+}
+
+type MemberGradeRequest struct {
+	StudentID uint   `json:"student_id" validate:"required"`
+	Grade     int    `json:"grade" validate:"required,min=2,max=5"`
+	Feedback  string `json:"feedback,omitempty"`
+}
+
+type TeamMemberResponse struct {
+	Student          UserResponse `json:"student"`
+	IsLeader         bool         `json:"is_leader"`
+	ContributionNote string       `json:"contribution_note,omitempty"`
+	JoinedAt         time.Time    `json:"joined_at"`
+}
+
+type TeamResponse struct {
+	ID                uint                   `json:"id"`
+	Name              string                 `json:"name"`
+	CourseworkID      uint                   `json:"coursework_id"`
+	CourseworkTitle   string                 `json:"coursework_title"`
+	MaxMembers        int                    `json:"max_members"`
+	Leader            UserResponse           `json:"leader"`
+	GradingMode       models.TeamGradingMode `json:"grading_mode"`
+	Members           []TeamMemberResponse   `json:"members"`
+	SubmissionURL     string                 `json:"submission_url,omitempty"`
+	SubmissionComment string                 `json:"submission_comment,omitempty"`
+	SubmittedAt       *time.Time             `json:"submitted_at,omitempty"`
+	CreatedAt         time.Time              `json:"created_at"`
+}
+
+type TeamInvitationResponse struct {
+	ID              uint                    `json:"id"`
+	TeamID          uint                    `json:"team_id"`
+	TeamName        string                  `json:"team_name,omitempty"`
+	CourseworkTitle string                  `json:"coursework_title,omitempty"`
+	Student         UserResponse            `json:"student"`
+	Status          models.InvitationStatus `json:"status"`
+	CreatedAt       time.Time               `json:"created_at"`
+}
+
+type TeamPostResponse struct {
+	ID            uint         `json:"id"`
+	Author        UserResponse `json:"author"`
+	Body          string       `json:"body"`
+	AttachmentURL string       `json:"attachment_url,omitempty"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+// ============================================================================
+// WORKLOAD DTOs
+// ============================================================================
+
+// SetSupervisionQuotaRequest - пустой AcademicYear означает текущий учебный год
+type SetSupervisionQuotaRequest struct {
+	AcademicYear    string   `json:"academic_year,omitempty" validate:"omitempty,len=9"`
+	MaxStudents     int      `json:"max_students" validate:"min=0,max=200"`
+	MaxTopics       int      `json:"max_topics" validate:"min=0,max=200"`
+	HoursPerStudent *float64 `json:"hours_per_student,omitempty" validate:"omitempty,gt=0,max=100"`
+}
+
+// SetDepartmentHeadRequest - nil снимает заведующего
+type SetDepartmentHeadRequest struct {
+	UserID *uint `json:"user_id"`
+}
+
+type SupervisionQuotaResponse struct {
+	TeacherID       uint         `json:"teacher_id"`
+	Teacher         UserResponse `json:"teacher"`
+	AcademicYear    string       `json:"academic_year"`
+	MaxStudents     int          `json:"max_students"`
+	MaxTopics       int          `json:"max_topics"`
+	HoursPerStudent float64      `json:"hours_per_student"`
+	SetByID         uint         `json:"set_by_id"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+}
+
+// WorkloadStatus - оценка загрузки руководителя относительно квоты
+type WorkloadStatus string
+
+const (
+	WorkloadUnlimited WorkloadStatus = "unlimited" // квота не задана
+	WorkloadUnder     WorkloadStatus = "under"     // занято меньше половины квоты
+	WorkloadBalanced  WorkloadStatus = "balanced"
+	WorkloadFull      WorkloadStatus = "full"
+	WorkloadOver      WorkloadStatus = "over" // квоту уменьшили после распределения
+)
+
+type TeacherWorkload struct {
+	TeacherID          uint           `json:"teacher_id"`
+	TeacherName        string         `json:"teacher_name"`
+	DepartmentID       uint           `json:"department_id"`
+	DepartmentName     string         `json:"department_name,omitempty"`
+	Position           string         `json:"position,omitempty"`
+	Topics             int            `json:"topics"`
+	MaxTopics          int            `json:"max_topics"` // 0 - без ограничения
+	SupervisedStudents int            `json:"supervised_students"`
+	MaxStudents        int            `json:"max_students"` // 0 - без ограничения
+	HoursPerStudent    float64        `json:"hours_per_student"`
+	Hours              float64        `json:"hours"`
+	PlannedHours       float64        `json:"planned_hours"`
+	Utilization        float64        `json:"utilization"` // доля занятых мест квоты, 0 при отсутствии квоты
+	Status             WorkloadStatus `json:"status"`
+	QuotaSet           bool           `json:"quota_set"`
+}
+
+type WorkloadReport struct {
+	AcademicYear string            `json:"academic_year"`
+	DepartmentID uint              `json:"department_id,omitempty"`
+	Teachers     []TeacherWorkload `json:"teachers"`
+	Totals       WorkloadTotals    `json:"totals"`
+}
+
+type WorkloadTotals struct {
+	Teachers           int     `json:"teachers"`
+	SupervisedStudents int     `json:"supervised_students"`
+	Capacity           int     `json:"capacity"` // сумма квот руководителей с заданным лимитом
+	Hours              float64 `json:"hours"`
+	Overloaded         int     `json:"overloaded"`
+	Underloaded        int     `json:"underloaded"`
+}
+
+// ============================================================================
+// ACADEMIC TERM DTOs
+// ============================================================================
+
+// CreateAcademicYearRequest - семестры создаются автоматически; границы можно переопределить
+type CreateAcademicYearRequest struct {
+	Name           string     `json:"name" validate:"required,len=9"`
+	AutumnStartsOn *time.Time `json:"autumn_starts_on,omitempty"`
+	SpringStartsOn *time.Time `json:"spring_starts_on,omitempty"`
+	ActivateAutumn bool       `json:"activate_autumn,omitempty"`
+}
+
+type TermResponse struct {
+	ID             uint      `json:"id"`
+	AcademicYearID uint      `json:"academic_year_id"`
+	AcademicYear   string    `json:"academic_year"`
+	Number         int       `json:"number"`
+	Name           string    `json:"name"`
+	StartsOn       time.Time `json:"starts_on"`
+	EndsOn         time.Time `json:"ends_on"`
+	IsActive       bool      `json:"is_active"`
+}
+
+type AcademicYearResponse struct {
+	ID       uint           `json:"id"`
+	Name     string         `json:"name"`
+	StartsOn time.Time      `json:"starts_on"`
+	EndsOn   time.Time      `json:"ends_on"`
+	Terms    []TermResponse `json:"terms"`
+}
+
+// ============================================================================
+// CURRICULUM DTOs
+// ============================================================================
+
+// AddGroupSubjectRequest - без term_id дисциплина попадает в семестр текущего учебного года по её номеру семестра
+type AddGroupSubjectRequest struct {
+	SubjectID uint `json:"subject_id" validate:"required"`
+	TermID    uint `json:"term_id,omitempty"`
+}
+
+type GroupSubjectResponse struct {
+	ID      uint            `json:"id"`
+	GroupID uint            `json:"group_id"`
+	TermID  uint            `json:"term_id"`
+	Term    string          `json:"term,omitempty"`
+	Subject SubjectResponse `json:"subject"`
+}
+
+// ============================================================================
+// ROSTER IMPORT DTOs
+// ============================================================================
+
+// ImportRosterRequest - поля multipart-формы рядом с файлом списка
+type ImportRosterRequest struct {
+	Kind         models.ImportKind `form:"kind" json:"kind" validate:"required,oneof=students teachers"`
+	DryRun       bool              `form:"dry_run" json:"dry_run"`
+	DepartmentID uint              `form:"department_id" json:"department_id,omitempty"`                              // кафедра новых групп и преподавателей без столбца кафедры
+	CourseYear   int               `form:"course_year" json:"course_year,omitempty" validate:"omitempty,min=1,max=6"` // курс новых групп без столбца курса
+}
+
+// ImportRowAction - что произошло (или произойдёт при dry_run) со строкой списка
+type ImportRowAction string
+
+const (
+	ImportRowCreate    ImportRowAction = "create"
+	ImportRowUpdate    ImportRowAction = "update"
+	ImportRowUnchanged ImportRowAction = "unchanged"
+	ImportRowError     ImportRowAction = "error"
+)
+
+type ImportRowResult struct {
+	Row     int             `json:"row"`
+	Email   string          `json:"email"`
+	Action  ImportRowAction `json:"action"`
+	Message string          `json:"message,omitempty"`
+}
+
+// ImportCredential - начальный пароль созданного пользователя, показывается один раз
+type ImportCredential struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type ImportReport struct {
+	ImportID      uint               `json:"import_id"`
+	Kind          models.ImportKind  `json:"kind"`
+	FileName      string             `json:"file_name"`
+	DryRun        bool               `json:"dry_run"`
+	Total         int                `json:"total"`
+	Created       int                `json:"created"`
+	Updated       int                `json:"updated"`
+	Unchanged     int                `json:"unchanged"`
+	Failed        int                `json:"failed"`
+	GroupsCreated []string           `json:"groups_created,omitempty"`
+	Rows          []ImportRowResult  `json:"rows"`
+	Credentials   []ImportCredential `json:"credentials,omitempty"`
+}
+
+type ImportJobResponse struct {
+	ID        uint              `json:"id"`
+	Kind      models.ImportKind `json:"kind"`
+	FileName  string            `json:"file_name"`
+	DryRun    bool              `json:"dry_run"`
+	CreatedBy UserResponse      `json:"created_by"`
+	Total     int               `json:"total"`
+	Created   int               `json:"created"`
+	Updated   int               `json:"updated"`
+	Unchanged int               `json:"unchanged"`
+	Failed    int               `json:"failed"`
+	Errors    []ImportRowResult `json:"errors,omitempty"`
+	CreatedAt string            `json:"created_at"`
+}
+
+// ============================================================================
+// GRADEBOOK DTOs
+// ============================================================================
+
+// GradebookFormat - формат выгрузки ведомости
+type GradebookFormat string
+
+const (
+	GradebookFormatCSV  GradebookFormat = "csv"
+	GradebookFormatXLSX GradebookFormat = "xlsx"
+	GradebookFormatPDF  GradebookFormat = "pdf"
+)
+
+// GradebookRequest - ведомость по дисциплине; без term_id берётся семестр дисциплины
+// в текущем учебном году, без group_id - все студенты с темами по дисциплине
+type GradebookRequest struct {
+	SubjectID uint `form:"subject_id" json:"subject_id" validate:"required"`
+	GroupID   uint `form:"group_id" json:"group_id,omitempty"`
+	TermID    uint `form:"term_id" json:"term_id,omitempty"`
+}
+
+// GradebookRow - строка ведомости; студент группы без темы попадает с пустыми полями работы
+type GradebookRow struct {
+	No            int                     `json:"no"`
+	StudentID     uint                    `json:"student_id"`
+	StudentName   string                  `json:"student_name"`
+	StudentNumber string                  `json:"student_number,omitempty"`
+	GroupCode     string                  `json:"group_code,omitempty"`
+	CourseworkID  uint                    `json:"coursework_id,omitempty"`
+	Topic         string                  `json:"topic,omitempty"`
+	Supervisor    string                  `json:"supervisor,omitempty"`
+	Status        models.CourseworkStatus `json:"status,omitempty"`
+	Grade         *int                    `json:"grade,omitempty"`
+	GradeText     string                  `json:"grade_text,omitempty"`
+}
+
+type GradebookResponse struct {
+	SubjectID   uint           `json:"subject_id"`
+	SubjectCode string         `json:"subject_code"`
+	SubjectName string         `json:"subject_name"`
+	TermID      uint           `json:"term_id,omitempty"`
+	Term        string         `json:"term,omitempty"`
+	GroupID     uint           `json:"group_id,omitempty"`
+	GroupCode   string         `json:"group_code,omitempty"`
+	Department  string         `json:"department,omitempty"`
+	Rows        []GradebookRow `json:"rows"`
+	GeneratedAt time.Time      `json:"generated_at"`
+}
+
+// GradebookFile - готовый файл ведомости
+type GradebookFile struct {
+	FileName    string
+	ContentType string
+	Data        []byte
+}
+
+// ============================================================================
+// DOCUMENT DTOs
+// ============================================================================
+
+// CreateDocumentTemplateRequest - шаблон документа. Header и Footer - text/template
+// с полями .Number, .Version, .Date, .AcademicYear, .Term, .Subject, .SubjectCode, .Group,
+// .Department, .DepartmentHead, .Count и .Rows; строка, начинающаяся с "# ", - заголовок по центру
+type CreateDocumentTemplateRequest struct {
+	Kind         models.DocumentKind `json:"kind" validate:"required,oneof=topic_order"`
+	Name         string              `json:"name" validate:"required,min=3,max=200"`
+	NumberPrefix string              `json:"number_prefix" validate:"max=20"`
+	Header       string              `json:"header" validate:"required"`
+	Footer       string              `json:"footer"`
+	IsDefault    bool                `json:"is_default"`
+}
+
+type UpdateDocumentTemplateRequest struct {
+	Name         *string `json:"name,omitempty" validate:"omitempty,min=3,max=200"`
+	NumberPrefix *string `json:"number_prefix,omitempty" validate:"omitempty,max=20"`
+	Header       *string `json:"header,omitempty" validate:"omitempty,min=1"`
+	Footer       *string `json:"footer,omitempty"`
+	IsDefault    *bool   `json:"is_default,omitempty"`
+}
+
+type DocumentTemplateResponse struct {
+	ID           uint                `json:"id"`
+	Kind         models.DocumentKind `json:"kind"`
+	Name         string              `json:"name"`
+	NumberPrefix string              `json:"number_prefix"`
+	Header       string              `json:"header"`
+	Footer       string              `json:"footer"`
+	IsDefault    bool                `json:"is_default"`
+	UpdatedAt    string              `json:"updated_at"`
+}
+
+// IssueTopicOrderRequest - приказ о закреплении тем; без template_id берётся шаблон по умолчанию
+type IssueTopicOrderRequest struct {
+	SubjectID  uint                  `json:"subject_id" validate:"required"`
+	GroupID    uint                  `json:"group_id,omitempty"`
+	TermID     uint                  `json:"term_id,omitempty"`
+	TemplateID uint                  `json:"template_id,omitempty"`
+	Format     models.DocumentFormat `json:"format" validate:"omitempty,oneof=docx pdf"` // по умолчанию docx
+}
+
+type IssuedDocumentResponse struct {
+	ID           uint                  `json:"id"`
+	Kind         models.DocumentKind   `json:"kind"`
+	Number       string                `json:"number"`
+	Version      int                   `json:"version"`
+	IsCurrent    bool                  `json:"is_current"`
+	AcademicYear string                `json:"academic_year"`
+	Format       models.DocumentFormat `json:"format"`
+	FileName     string                `json:"file_name"`
+	SubjectID    uint                  `json:"subject_id"`
+	SubjectName  string                `json:"subject_name,omitempty"`
+	GroupID      *uint                 `json:"group_id,omitempty"`
+	GroupCode    string                `json:"group_code,omitempty"`
+	TermID       uint                  `json:"term_id,omitempty"`
+	TemplateID   uint                  `json:"template_id,omitempty"`
+	CreatedBy    UserResponse          `json:"created_by"`
+	CreatedAt    string                `json:"created_at"`
+}
+
+// ============================================================================
+// SUBMISSION DTOs
+// ============================================================================
+
+// SubmissionResponse - сданный файл; оценка заимствований видна только руководителю
+type SubmissionResponse struct {
+	ID                  uint                         `json:"id"`
+	StudentCourseworkID uint                         `json:"student_coursework_id"`
+	CourseworkID        uint                         `json:"coursework_id"`
+	CourseworkTitle     string                       `json:"coursework_title,omitempty"`
+	TermID              uint                         `json:"term_id,omitempty"`
+	Student             *UserResponse                `json:"student,omitempty"`
+	FileName            string                       `json:"file_name"`
+	ContentType         string                       `json:"content_type,omitempty"`
+	Size                int64                        `json:"size"`
+	SHA256              string                       `json:"sha256"`
+	CheckStatus         models.SimilarityCheckStatus `json:"check_status"`
+	CheckError          string                       `json:"check_error,omitempty"`
+	SimilarityScore     *float64                     `json:"similarity_score,omitempty"`
+	CheckedAt           *time.Time                   `json:"checked_at,omitempty"`
+	CreatedAt           time.Time                    `json:"created_at"`
+}
+
+type SimilarityFragmentResponse struct {
+	Text            string `json:"text"`
+	Words           int    `json:"words"`
+	Position        int    `json:"position"`
+	MatchedPosition int    `json:"matched_position"`
+}
+
+type SimilarityMatchResponse struct {
+	MatchedSubmission SubmissionResponse           `json:"matched_submission"`
+	Similarity        float64                      `json:"similarity"`
+	Jaccard           float64                      `json:"jaccard"`
+	Fragments         []SimilarityFragmentResponse `json:"fragments"`
+}
+
+// SimilarityReportResponse - отчёт о заимствованиях для руководителя
+type SimilarityReportResponse struct {
+	Submission SubmissionResponse        `json:"submission"`
+	Matches    []SimilarityMatchResponse `json:"matches"`
+}
+
+// ============================================================================
+// DISCUSSION DTOs
+// ============================================================================
+
+// CreateCommentRequest - текст в markdown; <@ID> упоминает пользователя
+type CreateCommentRequest struct {
+	Body     string `json:"body" validate:"required,min=1,max=20000"`
+	ParentID *uint  `json:"parent_id,omitempty"`
+}
+
+type UpdateCommentRequest struct {
+	Body string `json:"body" validate:"required,min=1,max=20000"`
+}
+
+// MarkThreadReadRequest - без comment_id обсуждение отмечается прочитанным целиком
+type MarkThreadReadRequest struct {
+	CommentID uint `json:"comment_id,omitempty"`
+}
+
+// UnreadThread - обсуждение с числом непрочитанных сообщений
+type UnreadThread struct {
+	Thread models.DiscussionThread
+	Unread int
+}
+
+type CommentAttachmentResponse struct {
+	ID          uint      `json:"id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type,omitempty"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// CommentResponse - у удалённого сообщения, на которое есть ответы, остаётся только место в ветке
+type CommentResponse struct {
+	ID          uint                        `json:"id"`
+	ThreadID    uint                        `json:"thread_id"`
+	ParentID    *uint                       `json:"parent_id,omitempty"`
+	Author      *UserResponse               `json:"author,omitempty"`
+	Body        string                      `json:"body"`
+	BodyHTML    string                      `json:"body_html"`
+	Mentions    []UserResponse              `json:"mentions,omitempty"`
+	Attachments []CommentAttachmentResponse `json:"attachments,omitempty"`
+	IsDeleted   bool                        `json:"is_deleted,omitempty"`
+	IsUnread    bool                        `json:"is_unread,omitempty"`
+	EditedAt    *time.Time                  `json:"edited_at,omitempty"`
+	CreatedAt   time.Time                   `json:"created_at"`
+}
+
+type ThreadResponse struct {
+	ID                  uint              `json:"id"`
+	Kind                models.ThreadKind `json:"kind"`
+	CourseworkID        uint              `json:"coursework_id"`
+	CourseworkTitle     string            `json:"coursework_title,omitempty"`
+	StudentCourseworkID uint              `json:"student_coursework_id,omitempty"`
+	LastReadCommentID   uint              `json:"last_read_comment_id"`
+	UnreadCount         int               `json:"unread_count"`
+	LastCommentAt       *time.Time        `json:"last_comment_at,omitempty"`
+	Comments            []CommentResponse `json:"comments,omitempty"`
+}
+
+type CommentRevisionResponse struct {
+	Body      string       `json:"body"`
+	Editor    UserResponse `json:"editor"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// ============================================================================
+// NOTIFICATION DTOs
+// ============================================================================
+
+// Event - событие предметной области, адресованное одному пользователю
+type Event struct {
+	Type        models.EventType
+	RecipientID uint
+	ActorID     uint // кто вызвал событие, 0 - система
+	EntityType  models.EntityKind
+	EntityID    uint
+	Title       string
+	Message     string
+}
+
+type MarkNotificationsReadRequest struct {
+	IDs []uint `json:"ids" validate:"required,min=1,max=500"`
+}
+
+type NotificationPreferenceRequest struct {
+	Event   models.EventType `json:"event" validate:"required"`
+	Enabled bool             `json:"enabled"`
+}
+
+type UpdateNotificationPreferencesRequest struct {
+	Preferences []NotificationPreferenceRequest `json:"preferences" validate:"required,min=1,dive"`
+}
+
+type NotificationResponse struct {
+	ID         uint              `json:"id"`
+	Event      models.EventType  `json:"event"`
+	Title      string            `json:"title"`
+	Message    string            `json:"message"`
+	ActorID    *uint             `json:"actor_id,omitempty"`
+	EntityType models.EntityKind `json:"entity_type,omitempty"`
+	EntityID   uint              `json:"entity_id,omitempty"`
+	IsRead     bool              `json:"is_read"`
+	ReadAt     *time.Time        `json:"read_at,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+type NotificationListResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+	UnreadCount   int64                  `json:"unread_count"`
+}
+
+// NotificationPreferenceResponse - настройка по виду события с подписью для интерфейса
+type NotificationPreferenceResponse struct {
+	Event       models.EventType `json:"event"`
+	Description string           `json:"description"`
+	Enabled     bool             `json:"enabled"`
+}
+
+// ============================================================================
+// EMAIL DTOs
+// ============================================================================
+
+// MailMessage - письмо с текстовой и HTML-версией
+type MailMessage struct {
+	To       string
+	Subject  string
+	TextBody string
+	HTMLBody string
+}
+
+// UpdateEmailSettingsRequest - пустые поля не меняются
+type UpdateEmailSettingsRequest struct {
+	Locale models.Locale    `json:"locale" validate:"omitempty,oneof=ru en"`
+	Mode   models.EmailMode `json:"mode" validate:"omitempty,oneof=off immediate digest"`
+}
+
+type EmailSettingsResponse struct {
+	Locale       models.Locale    `json:"locale"`
+	Mode         models.EmailMode `json:"mode"`
+	DigestHour   int              `json:"digest_hour"`
+	MailEnabled  bool             `json:"mail_enabled"` // настроена ли отправка писем на сервере
+	LastDigestAt *time.Time       `json:"last_digest_at,omitempty"`
+}
+
+// ============================================================================
+// DEADLINE DTOs
+// ============================================================================
+
+// Deadline - предстоящий срок пользователя
+type Deadline struct {
+	Kind     string    `json:"kind"` // defense | committee | supervision | session | waitlist_offer | selection_round
+	Title    string    `json:"title"`
+	Location string    `json:"location,omitempty"`
+	DueAt    time.Time `json:"due_at"`
+}
+
+// ============================================================================
+// TELEGRAM DTOs
+// ============================================================================
+
+// TelegramUpdate - входящее сообщение боту
+type TelegramUpdate struct {
+	UpdateID int64
+	ChatID   int64 // 0 - обновление без сообщения
+	Username string
+	Text     string
+}
+
+type TelegramLinkCodeResponse struct {
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Link      string    `json:"link,omitempty"` // ссылка t.me, открывающая бота с кодом
+}
+
+type TelegramLinkResponse struct {
+	Enabled     bool       `json:"enabled"` // настроен ли бот на сервере
+	BotUsername string     `json:"bot_username,omitempty"`
+	Linked      bool       `json:"linked"`
+	Username    string     `json:"username,omitempty"`
+	LinkedAt    *time.Time `json:"linked_at,omitempty"`
+}
+
+// ============================================================================
+// REALTIME DTOs
+// ============================================================================
+
+// PubSubMessage - сообщение из шины PubSub
+type PubSubMessage struct {
+	Topic   string
+	Payload []byte
+}
+
+// RealtimeStream - поток обновлений, на который подписывается клиент
+type RealtimeStream string
+
+const (
+	StreamSlots         RealtimeStream = "slots"         // занятость мест в темах
+	StreamAssignments   RealtimeStream = "assignments"   // назначения и статусы работ пользователя
+	StreamNotifications RealtimeStream = "notifications" // новые уведомления пользователя
+)
+
+// RealtimeStreams - все потоки обновлений
+var RealtimeStreams = []RealtimeStream{StreamSlots, StreamAssignments, StreamNotifications}
+
+// RealtimeEvent - обновление для клиента; Stream служит именем события SSE
+type RealtimeEvent struct {
+	Stream RealtimeStream  `json:"stream"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// StreamTicket - одноразовый билет на подключение к потоку обновлений. EventSource не умеет
+// передавать заголовки, а токен в адресе попал бы в журнал запросов, поэтому в адрес
+// кладётся короткоживущий билет
+type StreamTicket struct {
+	Ticket    string    `json:"ticket"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CourseworkSlotsMessage - занятость мест в теме (поток slots)
+type CourseworkSlotsMessage struct {
+	CourseworkID uint `json:"coursework_id"`
+	SubjectID    uint `json:"subject_id"`
+	MaxStudents  int  `json:"max_students"`
+	Taken        int  `json:"taken"`
+	Reserved     int  `json:"reserved"` // удерживаются за студентами из листа ожидания
+	Free         int  `json:"free"`
+	IsAvailable  bool `json:"is_available"`
+}
+
+// AssignmentUpdateMessage - изменение назначения студента (поток assignments)
+type AssignmentUpdateMessage struct {
+	Event        models.EventType        `json:"event"`
+	AssignmentID uint                    `json:"assignment_id"`
+	CourseworkID uint                    `json:"coursework_id"`
+	StudentID    uint                    `json:"student_id"`
+	TeacherID    uint                    `json:"teacher_id"`
+	Status       models.CourseworkStatus `json:"status"`
+	Grade        *int                    `json:"grade,omitempty"`
+	UpdatedAt    time.Time               `json:"updated_at"`
+}
+
+// ============================================================================
+// WEBHOOK DTOs
+// ============================================================================
+
+// WebhookHTTPResult - ответ получателя вебхука
+type WebhookHTTPResult struct {
+	StatusCode int
+	Body       []byte
+}
+
+type CreateWebhookRequest struct {
+	URL         string                `json:"url" validate:"required,url,max=500"`
+	Description string                `json:"description" validate:"max=255"`
+	Events      []models.WebhookEvent `json:"events" validate:"required,min=1"`
+	IsActive    *bool                 `json:"is_active"` // по умолчанию включён
+}
+
+// UpdateWebhookRequest - пустые поля не меняются
+type UpdateWebhookRequest struct {
+	URL          *string               `json:"url" validate:"omitempty,url,max=500"`
+	Description  *string               `json:"description" validate:"omitempty,max=255"`
+	Events       []models.WebhookEvent `json:"events" validate:"omitempty,min=1"`
+	IsActive     *bool                 `json:"is_active"`
+	RotateSecret bool                  `json:"rotate_secret"` // выдать новый ключ подписи
+}
+
+// WebhookResponse - вебхук; ключ подписи показывается только при создании и смене
+type WebhookResponse struct {
+	ID          uint                  `json:"id"`
+	URL         string                `json:"url"`
+	Description string                `json:"description"`
+	Events      []models.WebhookEvent `json:"events"`
+	IsActive    bool                  `json:"is_active"`
+	Secret      string                `json:"secret,omitempty"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+}
+
+type WebhookDeliveryListResponse struct {
+	Deliveries []models.WebhookDelivery `json:"deliveries"`
+	Total      int64                    `json:"total"`
+}
+
+// WebhookPayload - тело запроса на вебхук
+type WebhookPayload struct {
+	ID        string              `json:"id"` // одинаков у всех доставок события
+	Event     models.WebhookEvent `json:"event"`
+	CreatedAt time.Time           `json:"created_at"`
+	Data      interface{}         `json:"data"`
+}
+
+// WebhookUser - пользователь в событиях вебхуков
+type WebhookUser struct {
+	ID        uint            `json:"id"`
+	Email     string          `json:"email"`
+	FirstName string          `json:"first_name"`
+	LastName  string          `json:"last_name"`
+	Role      models.UserRole `json:"role"`
+}
+
+// WebhookAssignmentData - данные событий assignment.created и grade.set
+type WebhookAssignmentData struct {
+	AssignmentID    uint                    `json:"assignment_id"`
+	Status          models.CourseworkStatus `json:"status"`
+	Grade           *int                    `json:"grade,omitempty"`
+	Feedback        string                  `json:"feedback,omitempty"`
+	Student         WebhookUser             `json:"student"`
+	CourseworkID    uint                    `json:"coursework_id"`
+	CourseworkTitle string                  `json:"coursework_title"`
+	SubjectID       uint                    `json:"subject_id"`
+	TeacherID       uint                    `json:"teacher_id"`
+	TermID          uint                    `json:"term_id"`
+	AssignedAt      time.Time               `json:"assigned_at"`
+	UpdatedAt       time.Time               `json:"updated_at"`
+}
+
+// WebhookSubmissionData - данные события submission.created
+type WebhookSubmissionData struct {
+	SubmissionID uint        `json:"submission_id"`
+	AssignmentID uint        `json:"assignment_id"`
+	CourseworkID uint        `json:"coursework_id"`
+	Student      WebhookUser `json:"student"`
+	FileName     string      `json:"file_name"`
+	ContentType  string      `json:"content_type"`
+	Size         int64       `json:"size"`
+	SHA256       string      `json:"sha256"`
+	CreatedAt    time.Time   `json:"created_at"`
+}
+
+// ============================================================================
+// OUTBOX DTOs
+// ============================================================================
+
+// DomainEvent - событие из outbox, переданное подписчику
+type DomainEvent struct {
+	ID        uint
+	Type      models.EventType
+	Payload   json.RawMessage
+	CreatedAt time.Time
+}
+
+// AssignmentEventData - данные событий outbox об изменении назначения студента
+type AssignmentEventData struct {
+	AssignmentID uint                    `json:"assignment_id"`
+	Status       models.CourseworkStatus `json:"status"`
+	Grade        *int                    `json:"grade,omitempty"`
+}
+
+// WaitlistEventData - данные событий outbox о предложении места из листа ожидания
+type WaitlistEventData struct {
+	EntryID uint `json:"entry_id"`
+}
+
+// SlotsEventData - данные события outbox об изменении занятости мест темы
+type SlotsEventData struct {
+	CourseworkID uint `json:"coursework_id"`
+}
+
+// SubmissionEventData - данные события outbox о загруженной работе
+type SubmissionEventData struct {
+	SubmissionID uint `json:"submission_id"`
+	// NewVersion - повторная загрузка; о первой руководителю сообщает событие assignment.submitted
+	NewVersion bool `json:"new_version"`
+}
@@ -3,6 +3,9 @@ package interfaces
 
 import (
 	"context"
+	"errors"
+	"io"
+	"time"
 
 	"github.com/Foxpunk/courseforge/internal/models"
 )
@@ -23,8 +26,10 @@ type UserManager interface {
 	CreateUser(ctx context.Context, req CreateUserRequest) (*models.User, error)
 	GetUser(ctx context.Context, userID uint) (*models.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
-	UpdateUser(ctx context.Context, userID uint, req UpdateUserRequest) (*models.User, error)
-	DeleteUser(ctx context.Context, userID uint) error
+	// UpdateUser и DeleteUser с version != 0 меняют пользователя, только если его версия
+	// совпадает с ней, иначе ErrVersionConflict
+	UpdateUser(ctx context.Context, userID uint, req UpdateUserRequest, version uint) (*models.User, error)
+	DeleteUser(ctx context.Context, userID uint, version uint) error
 	ListUsers(ctx context.Context, req ListUsersRequest) ([]models.User, int, error)
 
 	// Управление ролями и статусами
@@ -66,6 +71,20 @@ type GroupManager interface {
 	RemoveStudentFromGroup(ctx context.Context, studentID uint) error
 }
 
+// ProfileManager - интерфейс для профилей студентов и преподавателей (по ID пользователя)
+type ProfileManager interface {
+	GetStudentProfile(ctx context.Context, userID uint) (*models.StudentProfile, error)
+	CreateStudentProfile(ctx context.Context, req CreateStudentProfileRequest) (*models.StudentProfile, error)
+	UpdateStudentProfile(ctx context.Context, userID uint, req UpdateStudentProfileRequest) (*models.StudentProfile, error)
+	DeleteStudentProfile(ctx context.Context, userID uint) error
+
+	GetTeacherProfile(ctx context.Context, userID uint) (*models.TeacherProfile, error)
+	ListTeacherProfiles(ctx context.Context, departmentID uint) ([]models.TeacherProfile, error)
+	CreateTeacherProfile(ctx context.Context, req CreateTeacherProfileRequest) (*models.TeacherProfile, error)
+	UpdateTeacherProfile(ctx context.Context, userID uint, req UpdateTeacherProfileRequest) (*models.TeacherProfile, error)
+	DeleteTeacherProfile(ctx context.Context, userID uint) error
+}
+
 // SubjectManager - интерфейс для управления дисциплинами
 type SubjectManager interface {
 	CreateSubject(ctx context.Context, req CreateSubjectRequest) (*models.Subject, error)
@@ -77,10 +96,11 @@ type SubjectManager interface {
 	GetSubjectsBySemester(ctx context.Context, semester int) ([]models.Subject, error)
 
 	// Назначение преподавателей на дисциплины
-	AssignTeacherToSubject(ctx context.Context, teacherID, subjectID uint, academicYear string, isLead bool) error
+	AssignTeacherToSubject(ctx context.Context, teacherID, subjectID uint, req CreateTeacherSubjectRequest) error
 	GetTeacherSubjects(ctx context.Context, teacherID uint, academicYear string) ([]models.Subject, error)
 	GetSubjectTeachers(ctx context.Context, subjectID uint, academicYear string) ([]models.TeacherProfile, error)
 	SetLeadTeacher(ctx context.Context, teacherID, subjectID uint, academicYear string) error
+	GetLeadTeacher(ctx context.Context, subjectID uint, academicYear string) (*models.TeacherSubject, error)
 	RemoveTeacherFromSubject(ctx context.Context, teacherID, subjectID uint, academicYear string) error
 }
 
@@ -88,8 +108,10 @@ type SubjectManager interface {
 type CourseworkManager interface {
 	CreateCoursework(ctx context.Context, req CreateCourseworkRequest) (*models.Coursework, error)
 	GetCoursework(ctx context.Context, courseworkID uint) (*models.Coursework, error)
-	UpdateCoursework(ctx context.Context, courseworkID uint, req UpdateCourseworkRequest) (*models.Coursework, error)
-	DeleteCoursework(ctx context.Context, courseworkID uint) error
+	// UpdateCoursework и DeleteCoursework с version != 0 меняют тему, только если её версия
+	// совпадает с ней, иначе ErrVersionConflict
+	UpdateCoursework(ctx context.Context, courseworkID uint, req UpdateCourseworkRequest, version uint) (*models.Coursework, error)
+	DeleteCoursework(ctx context.Context, courseworkID uint, version uint) error
 	ListCourseworks(ctx context.Context, req ListCourseworksRequest) ([]models.Coursework, int, error)
 
 	// Получение курсовых работ по различным критериям
@@ -102,12 +124,16 @@ type CourseworkManager interface {
 
 	// Проверка возможности назначения
 	CanAssignStudentToCoursework(ctx context.Context, studentID, courseworkID uint) error
+
+	// Права ведущего преподавателя дисциплины
+	IsLeadTeacher(ctx context.Context, userID uint, cw *models.Coursework) (bool, error)
 }
 
 // StudentCourseworkManager - интерфейс для управления назначениями студентов на курсовые
 type StudentCourseworkManager interface {
 	AssignStudentToCoursework(ctx context.Context, studentID, courseworkID uint) (*models.StudentCoursework, error)
 	GetStudentCoursework(ctx context.Context, studentID uint) (*models.StudentCoursework, error)
+	GetStudentHistory(ctx context.Context, studentID uint, termID *uint, allTerms bool) ([]models.StudentCoursework, error)
 	// Управление статусами
 	UpdateCourseworkStatus(ctx context.Context, assignmentID uint, status models.CourseworkStatus) error
 	SubmitCoursework(ctx context.Context, assignmentID uint) error
@@ -123,3 +149,380 @@ type StudentCourseworkManager interface {
 	// Отмена назначения
 	UnassignStudentFromCoursework(ctx context.Context, studentID uint) error
 }
+
+// DefenseManager - интерфейс для планирования защит курсовых работ
+type DefenseManager interface {
+	// Аудитории
+	CreateRoom(ctx context.Context, req CreateDefenseRoomRequest) (*models.DefenseRoom, error)
+	ListRooms(ctx context.Context) ([]models.DefenseRoom, error)
+	DeleteRoom(ctx context.Context, roomID uint) error
+
+	// Сессии и комиссии
+	CreateSession(ctx context.Context, req CreateDefenseSessionRequest) (*models.DefenseSession, error)
+	GetSession(ctx context.Context, sessionID uint) (*models.DefenseSession, error)
+	ListSessions(ctx context.Context) ([]models.DefenseSession, error)
+	DeleteSession(ctx context.Context, sessionID uint) error
+	SetCommittee(ctx context.Context, sessionID uint, members []CommitteeMemberRequest) error
+
+	// Распределение студентов по слотам
+	DistributeStudents(ctx context.Context, sessionID uint) (*DefenseDistributionResult, error)
+	AssignSlot(ctx context.Context, slotID, assignmentID uint) error
+	ReleaseSlot(ctx context.Context, slotID uint) error
+
+	// Расписание и календарь
+	GetUserSchedule(ctx context.Context, userID uint) ([]DefenseScheduleEntry, error)
+	BuildCalendar(ctx context.Context, userID uint, now time.Time) ([]byte, error)
+	CalendarToken(userID uint) string
+	VerifyCalendarToken(userID uint, token string) bool
+}
+
+// TopicProposalManager - интерфейс для согласования тем, предложенных студентами
+type TopicProposalManager interface {
+	CreateProposal(ctx context.Context, studentID uint, req CreateTopicProposalRequest) (*models.TopicProposal, error)
+	GetProposal(ctx context.Context, proposalID uint) (*models.TopicProposal, error)
+	UpdateProposal(ctx context.Context, proposalID uint, req UpdateTopicProposalRequest) (*models.TopicProposal, error)
+	WithdrawProposal(ctx context.Context, proposalID uint) error
+	GetStudentProposals(ctx context.Context, studentID uint) ([]models.TopicProposal, error)
+	GetTeacherProposals(ctx context.Context, teacherID uint, onlyOpen bool) ([]models.TopicProposal, error)
+
+	// Решение руководителя
+	AcceptProposal(ctx context.Context, proposalID uint, req AcceptTopicProposalRequest) (*models.StudentCoursework, error)
+	RejectProposal(ctx context.Context, proposalID uint, comment string) error
+	RequestChanges(ctx context.Context, proposalID uint, comment string) error
+}
+
+// SelectionManager - интерфейс для распределения тем по предпочтениям студентов
+type SelectionManager interface {
+	// Раунды
+	CreateRound(ctx context.Context, req CreateSelectionRoundRequest) (*models.SelectionRound, error)
+	GetRound(ctx context.Context, roundID uint) (*models.SelectionRound, error)
+	ListRounds(ctx context.Context) ([]models.SelectionRound, error)
+	SetRoundStatus(ctx context.Context, roundID uint, status models.RoundStatus) error
+
+	// Предпочтения студентов
+	SubmitPreferences(ctx context.Context, roundID, studentID uint, courseworkIDs []uint) ([]models.TopicPreference, error)
+	GetStudentPreferences(ctx context.Context, roundID, studentID uint) ([]models.TopicPreference, error)
+
+	// Рейтинг претендентов руководителем
+	GetApplicants(ctx context.Context, roundID, courseworkID uint) ([]SelectionApplicant, error)
+	RankApplicants(ctx context.Context, roundID, courseworkID uint, studentIDs []uint) error
+
+	// Распределение
+	PreviewAllocation(ctx context.Context, roundID uint) (*AllocationResult, error)
+	CommitAllocation(ctx context.Context, roundID uint) (*AllocationResult, error)
+}
+
+// Notifier - интерфейс для отправки уведомлений пользователям
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+// NotificationChannel - дополнительный канал доставки уже сохранённого уведомления
+// (почта и т.п.); центр уведомлений вызывает каналы после сохранения
+type NotificationChannel interface {
+	Deliver(ctx context.Context, n *models.Notification) error
+}
+
+// WaitlistManager - интерфейс для листов ожидания на заполненные курсовые
+type WaitlistManager interface {
+	JoinWaitlist(ctx context.Context, studentID, courseworkID uint) (*models.WaitlistEntry, error)
+	LeaveWaitlist(ctx context.Context, entryID uint) error
+	GetEntry(ctx context.Context, entryID uint) (*models.WaitlistEntry, error)
+	GetStudentEntries(ctx context.Context, studentID uint) ([]models.WaitlistEntry, error)
+	GetCourseworkWaitlist(ctx context.Context, courseworkID uint) ([]models.WaitlistEntry, error)
+
+	// Предложение освободившегося места
+	AcceptOffer(ctx context.Context, entryID uint) (*models.StudentCoursework, error)
+	DeclineOffer(ctx context.Context, entryID uint) error
+	WithdrawStudent(ctx context.Context, studentID uint) error
+
+	// Продвижение очереди
+	ReservedSlots(ctx context.Context, courseworkID, exceptStudentID uint) (int, error)
+	PromoteNext(ctx context.Context, courseworkID uint) error
+	ExpireOffers(ctx context.Context, now time.Time) (int, error)
+}
+
+// TeamManager - интерфейс для командного выполнения курсовых работ
+type TeamManager interface {
+	CreateTeam(ctx context.Context, leaderID uint, req CreateTeamRequest) (*models.Team, error)
+	GetTeam(ctx context.Context, teamID uint) (*models.Team, error)
+	GetStudentTeam(ctx context.Context, studentID uint) (*models.Team, error)
+	DisbandTeam(ctx context.Context, teamID uint) error
+
+	// Состав команды
+	InviteMember(ctx context.Context, teamID, inviterID, studentID uint) (*models.TeamInvitation, error)
+	GetInvitation(ctx context.Context, invitationID uint) (*models.TeamInvitation, error)
+	GetStudentInvitations(ctx context.Context, studentID uint) ([]models.TeamInvitation, error)
+	GetTeamInvitations(ctx context.Context, teamID uint) ([]models.TeamInvitation, error)
+	RespondInvitation(ctx context.Context, invitationID uint, accept bool) (*models.Team, error)
+	RevokeInvitation(ctx context.Context, invitationID uint) error
+	RemoveMember(ctx context.Context, teamID, studentID uint) error
+	TransferLeadership(ctx context.Context, teamID, newLeaderID uint) error
+	UpdateContribution(ctx context.Context, teamID, studentID uint, note string) error
+
+	// Рабочее пространство и сдача
+	AddPost(ctx context.Context, teamID, authorID uint, req CreateTeamPostRequest) (*models.TeamPost, error)
+	GetPosts(ctx context.Context, teamID uint) ([]models.TeamPost, error)
+	SubmitWork(ctx context.Context, teamID, submitterID uint, req SubmitTeamWorkRequest) error
+
+	// Оценивание (для преподавателей)
+	GradeTeam(ctx context.Context, teamID uint, req GradeTeamRequest) error
+}
+
+// WorkloadManager - интерфейс для квот руководства и отчёта о нагрузке преподавателей
+type WorkloadManager interface {
+	// Квоты
+	SetQuota(ctx context.Context, teacherID, setByID uint, req SetSupervisionQuotaRequest) (*models.SupervisionQuota, error)
+	GetQuotas(ctx context.Context, academicYear string) ([]models.SupervisionQuota, error)
+	DeleteQuota(ctx context.Context, teacherID uint, academicYear string) error
+	CanManageTeacher(ctx context.Context, userID, teacherID uint) (bool, error)
+	GetHeadedDepartments(ctx context.Context, userID uint) ([]models.Department, error)
+	SetDepartmentHead(ctx context.Context, departmentID uint, userID *uint) error
+
+	// Проверки при создании темы и назначении студента; квота берётся на учебный год
+	// семестра termID (0 - активного семестра)
+	CheckTopicQuota(ctx context.Context, teacherID, termID uint) error
+	CheckSupervisionQuota(ctx context.Context, teacherID, termID uint) error
+
+	// Отчёт о нагрузке
+	GetTeacherWorkload(ctx context.Context, teacherID uint, academicYear string) (*TeacherWorkload, error)
+	GetWorkloadReport(ctx context.Context, academicYear string, departmentID uint) (*WorkloadReport, error)
+}
+
+// TermManager - интерфейс для учебных годов и семестров
+type TermManager interface {
+	CreateAcademicYear(ctx context.Context, req CreateAcademicYearRequest) (*models.AcademicYear, error)
+	ListAcademicYears(ctx context.Context) ([]models.AcademicYear, error)
+	GetTerm(ctx context.Context, termID uint) (*models.Term, error)
+	GetActiveTerm(ctx context.Context) (*models.Term, error)
+	ActivateTerm(ctx context.Context, termID uint) (*models.Term, error)
+}
+
+// CurriculumManager - интерфейс для учебного плана групп
+type CurriculumManager interface {
+	AddGroupSubject(ctx context.Context, groupID uint, req AddGroupSubjectRequest) (*models.GroupSubject, error)
+	RemoveGroupSubject(ctx context.Context, groupID, subjectID, termID uint) error
+	GetGroupSubjects(ctx context.Context, groupID uint, termID *uint, allTerms bool) ([]models.GroupSubject, error)
+
+	// Дисциплины, темы которых доступны студенту; у студента без профиля список пуст
+	GetStudentSubjectIDs(ctx context.Context, studentID, termID uint) ([]uint, error)
+}
+
+// ImportManager - интерфейс для загрузки списков студентов и преподавателей
+type ImportManager interface {
+	ImportRoster(ctx context.Context, actorID uint, fileName string, file io.Reader, req ImportRosterRequest) (*ImportReport, error)
+	GetImport(ctx context.Context, importID uint) (*models.ImportJob, error)
+	ListImports(ctx context.Context, limit, offset int) ([]models.ImportJob, error)
+}
+
+// GradebookManager - интерфейс для ведомостей оценок по курсовым работам
+type GradebookManager interface {
+	GetGradebook(ctx context.Context, req GradebookRequest) (*GradebookResponse, error)
+	ExportGradebook(ctx context.Context, req GradebookRequest, format GradebookFormat) (*GradebookFile, error)
+	CanViewGradebook(ctx context.Context, userID uint, req GradebookRequest) (bool, error)
+}
+
+// DocumentManager - интерфейс для шаблонов и выпуска официальных документов
+type DocumentManager interface {
+	ListTemplates(ctx context.Context, kind models.DocumentKind) ([]models.DocumentTemplate, error)
+	GetTemplate(ctx context.Context, templateID uint) (*models.DocumentTemplate, error)
+	CreateTemplate(ctx context.Context, actorID uint, req CreateDocumentTemplateRequest) (*models.DocumentTemplate, error)
+	UpdateTemplate(ctx context.Context, actorID, templateID uint, req UpdateDocumentTemplateRequest) (*models.DocumentTemplate, error)
+	DeleteTemplate(ctx context.Context, templateID uint) error
+
+	IssueTopicOrder(ctx context.Context, actorID uint, req IssueTopicOrderRequest) (*models.IssuedDocument, error)
+	ListDocuments(ctx context.Context, kind models.DocumentKind, subjectID uint, withSuperseded bool) ([]models.IssuedDocument, error)
+	GetDocument(ctx context.Context, documentID uint) (*models.IssuedDocument, error)
+	GetDocumentVersions(ctx context.Context, documentID uint) ([]models.IssuedDocument, error)
+}
+
+// SubmissionManager - интерфейс для сдачи файлов работ и проверки на заимствования
+type SubmissionManager interface {
+	Submit(ctx context.Context, studentID uint, fileName, contentType string, file io.Reader) (*models.Submission, error)
+	GetSubmission(ctx context.Context, submissionID uint) (*models.Submission, error)
+	ListStudentSubmissions(ctx context.Context, studentID uint) ([]models.Submission, error)
+	ListCourseworkSubmissions(ctx context.Context, courseworkID uint) ([]models.Submission, error)
+	OpenFile(ctx context.Context, sub *models.Submission) (io.ReadCloser, error)
+	GetSimilarityReport(ctx context.Context, submissionID uint) ([]models.SimilarityMatch, error)
+	Recheck(ctx context.Context, submissionID uint) error
+	// ProcessPending проверяет очередную порцию работ из очереди, возвращает число проверенных
+	ProcessPending(ctx context.Context) (int, error)
+}
+
+// DiscussionManager - интерфейс для обсуждений: открытые вопросы по теме и
+// переписка студента с руководителем по назначению
+type DiscussionManager interface {
+	GetCourseworkThread(ctx context.Context, courseworkID uint) (*models.DiscussionThread, error)
+	GetAssignmentThread(ctx context.Context, assignmentID uint) (*models.DiscussionThread, error)
+	GetThread(ctx context.Context, threadID uint) (*models.DiscussionThread, error)
+	CanViewThread(ctx context.Context, userID uint, thread *models.DiscussionThread) (bool, error)
+	// CanModerateThread - руководитель темы, ведущий преподаватель дисциплины или админ
+	CanModerateThread(ctx context.Context, userID uint, thread *models.DiscussionThread) (bool, error)
+
+	ListComments(ctx context.Context, threadID uint) ([]models.Comment, error)
+	GetComment(ctx context.Context, commentID uint) (*models.Comment, error)
+	AddComment(ctx context.Context, threadID, authorID uint, req CreateCommentRequest) (*models.Comment, error)
+	EditComment(ctx context.Context, commentID, editorID uint, req UpdateCommentRequest) (*models.Comment, error)
+	DeleteComment(ctx context.Context, commentID uint) error
+	GetCommentHistory(ctx context.Context, commentID uint) ([]models.CommentRevision, error)
+
+	AddAttachment(ctx context.Context, commentID uint, fileName, contentType string, file io.Reader) (*models.CommentAttachment, error)
+	GetAttachment(ctx context.Context, attachmentID uint) (*models.CommentAttachment, error)
+	OpenAttachment(ctx context.Context, attachment *models.CommentAttachment) (io.ReadCloser, error)
+
+	GetReadMark(ctx context.Context, threadID, userID uint) (uint, error)
+	// MarkRead отмечает обсуждение прочитанным до commentID (0 - до последнего сообщения)
+	MarkRead(ctx context.Context, threadID, userID, commentID uint) error
+	ListUnreadThreads(ctx context.Context, userID uint) ([]UnreadThread, error)
+}
+
+// NotificationManager - центр уведомлений: хранит уведомления пользователей
+// с учётом их настроек и отдаёт их в приложение
+type NotificationManager interface {
+	Notifier
+
+	ListNotifications(ctx context.Context, userID uint, unreadOnly bool, limit, offset int) ([]models.Notification, error)
+	CountUnread(ctx context.Context, userID uint) (int64, error)
+	// MarkRead отмечает прочитанными уведомления пользователя (чужие ID пропускаются)
+	MarkRead(ctx context.Context, userID uint, ids []uint) (int64, error)
+	MarkAllRead(ctx context.Context, userID uint) (int64, error)
+
+	// GetPreferences возвращает настройки по всем видам событий
+	GetPreferences(ctx context.Context, userID uint) ([]NotificationPreferenceResponse, error)
+	UpdatePreferences(ctx context.Context, userID uint, req UpdateNotificationPreferencesRequest) ([]NotificationPreferenceResponse, error)
+}
+
+// EmailManager - уведомления по почте: письма по каждому уведомлению или ежедневная
+// сводка, по выбору пользователя, с повторной отправкой при сбоях
+type EmailManager interface {
+	NotificationChannel
+
+	GetSettings(ctx context.Context, userID uint) (*EmailSettingsResponse, error)
+	UpdateSettings(ctx context.Context, userID uint, req UpdateEmailSettingsRequest) (*EmailSettingsResponse, error)
+
+	// SendDigests ставит в очередь сводки, если наступил час рассылки
+	SendDigests(ctx context.Context, now time.Time) (int, error)
+	// ProcessQueue отправляет порцию писем, срок которых подошёл; возвращает число отправленных
+	ProcessQueue(ctx context.Context, now time.Time) (int, error)
+}
+
+// DeadlineManager - ближайшие сроки пользователя и напоминания о них
+type DeadlineManager interface {
+	// ListDeadlines возвращает предстоящие сроки пользователя по порядку
+	ListDeadlines(ctx context.Context, userID uint, now time.Time) ([]Deadline, error)
+	// SendReminders уведомляет о сроках, наступающих в ближайшее время; о каждом - один раз
+	SendReminders(ctx context.Context, now time.Time) (int, error)
+}
+
+// TelegramManager - бот Telegram: привязка чатов и команды. Уведомления в чаты
+// доставляет отдельный канал (managers.NewTelegramChannel).
+type TelegramManager interface {
+	CreateLinkCode(ctx context.Context, userID uint) (*TelegramLinkCodeResponse, error)
+	GetLink(ctx context.Context, userID uint) (*TelegramLinkResponse, error)
+	Unlink(ctx context.Context, userID uint) error
+
+	// HandleUpdate отвечает на сообщение боту
+	HandleUpdate(ctx context.Context, update TelegramUpdate) error
+	// Run получает сообщения боту, пока не отменён ctx
+	Run(ctx context.Context)
+}
+
+// RealtimePublisher - публикация изменений для клиентов, подписанных на обновления в реальном
+// времени. Изменение к этому моменту уже сохранено, поэтому ошибки публикации только логируются.
+type RealtimePublisher interface {
+	// PublishSlots рассылает занятость мест в темах
+	PublishSlots(ctx context.Context, courseworkIDs ...uint)
+	// PublishAssignment сообщает студенту и руководителю об изменении назначения
+	PublishAssignment(ctx context.Context, sc *models.StudentCoursework, event models.EventType)
+}
+
+// RealtimeManager - обновления в реальном времени. Новые уведомления получает как канал
+// центра уведомлений, клиентов подписывает на выбранные потоки.
+type RealtimeManager interface {
+	RealtimePublisher
+	NotificationChannel
+
+	// Subscribe подписывает пользователя на потоки (пусто - на все). Канал закрывается при
+	// отписке или если клиент не успевает читать обновления.
+	Subscribe(ctx context.Context, userID uint, streams []RealtimeStream) (<-chan RealtimeEvent, func(), error)
+	// IssueStreamTicket выдаёт пользователю одноразовый билет на подключение к потоку
+	IssueStreamTicket(ctx context.Context, userID uint) (*StreamTicket, error)
+	// RedeemStreamTicket погашает билет и возвращает ID его владельца; ErrNotFound - билет
+	// неизвестен, уже использован или истёк
+	RedeemStreamTicket(ctx context.Context, ticket string) (uint, error)
+}
+
+// WebhookDispatcher - постановка событий в очередь доставки на вебхуки
+type WebhookDispatcher interface {
+	// Dispatch ставит событие в очередь; событие к этому моменту уже сохранено,
+	// поэтому ошибка постановки только логируется
+	Dispatch(ctx context.Context, event models.WebhookEvent, data interface{})
+	// Enqueue ставит событие в очередь и возвращает ошибку - для подписчиков outbox,
+	// которые повторяют событие при сбое
+	Enqueue(ctx context.Context, event models.WebhookEvent, data interface{}) error
+}
+
+// WebhookManager - исходящие вебхуки для интеграции с системами университета
+type WebhookManager interface {
+	WebhookDispatcher
+
+	CreateWebhook(ctx context.Context, req CreateWebhookRequest) (*WebhookResponse, error)
+	GetWebhook(ctx context.Context, id uint) (*WebhookResponse, error)
+	ListWebhooks(ctx context.Context) ([]WebhookResponse, error)
+	UpdateWebhook(ctx context.Context, id uint, req UpdateWebhookRequest) (*WebhookResponse, error)
+	DeleteWebhook(ctx context.Context, id uint) error
+
+	ListDeliveries(ctx context.Context, webhookID uint, limit, offset int) (*WebhookDeliveryListResponse, error)
+	GetDelivery(ctx context.Context, webhookID, deliveryID uint) (*models.WebhookDelivery, error)
+	// Redeliver сразу повторяет доставку и возвращает новую запись журнала
+	Redeliver(ctx context.Context, webhookID, deliveryID uint) (*models.WebhookDelivery, error)
+	// Ping сразу отправляет проверочное событие
+	Ping(ctx context.Context, webhookID uint) (*models.WebhookDelivery, error)
+
+	// ProcessQueue отправляет порцию доставок, срок которых подошёл; возвращает число доставленных
+	ProcessQueue(ctx context.Context, now time.Time) (int, error)
+}
+
+// EventHandler обрабатывает событие outbox; ошибка приводит к повтору события для этого подписчика
+type EventHandler func(ctx context.Context, event DomainEvent) error
+
+// EventPublisher - запись событий предметной области в outbox
+type EventPublisher interface {
+	// Publish записывает событие в outbox. Вызывается внутри UnitOfWork.Do, чтобы событие
+	// сохранилось вместе с изменением данных или не сохранилось вовсе
+	Publish(ctx context.Context, eventType models.EventType, data interface{}) error
+}
+
+// EventBus - внутренняя шина событий поверх outbox: события доставляются подписчикам
+// хотя бы один раз, поэтому обработчики должны спокойно переносить повтор
+type EventBus interface {
+	EventPublisher
+	// Subscribe регистрирует подписчика до запуска диспетчера; без types подписчик получает
+	// все события. name хранится в outbox для учёта обработанных событий, поэтому должен
+	// быть уникальным и не меняться
+	Subscribe(name string, handler EventHandler, types ...models.EventType)
+	// ProcessOutbox доставляет порцию событий подписчикам и возвращает число полностью обработанных
+	ProcessOutbox(ctx context.Context, now time.Time) (int, error)
+}
+
+var (
+	// ErrIdempotencyKeyReused - ключ уже использован для запроса с другим телом или адресом
+	ErrIdempotencyKeyReused = errors.New("idempotency key has already been used for a different request")
+	// ErrIdempotencyInProgress - запрос с этим ключом ещё выполняется
+	ErrIdempotencyInProgress = errors.New("request with this idempotency key is still in progress")
+)
+
+// IdempotencyManager - повторы запросов с заголовком Idempotency-Key: запрос выполняется
+// один раз, а повторы в пределах срока хранения получают сохранённый ответ
+type IdempotencyManager interface {
+	// Begin занимает ключ под запрос с отпечатком fingerprint. replay=true - ответ на этот
+	// запрос уже сохранён в record и его нужно вернуть без выполнения
+	Begin(ctx context.Context, userID uint, key, fingerprint string) (record *models.IdempotencyKey, replay bool, err error)
+	// Complete сохраняет ответ; после ошибки сервера ключ освобождается для повтора
+	Complete(ctx context.Context, record *models.IdempotencyKey, status int, contentType string, body []byte) error
+	// Release освобождает ключ запроса, который не дошёл до Complete (например, обработчик
+	// упал с паникой), чтобы повтор выполнился заново, а не получал 409
+	Release(ctx context.Context, record *models.IdempotencyKey) error
+	// PurgeExpired удаляет ключи с истёкшим сроком хранения
+	PurgeExpired(ctx context.Context, now time.Time) (int, error)
+}
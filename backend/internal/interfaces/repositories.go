@@ -2,11 +2,20 @@ package interfaces
 
 import (
 	"context"
+	"errors"
+	"io"
 	"time"
 
 	"github.com/Foxpunk/courseforge/internal/models"
 )
 
+var (
+	// ErrVersionConflict - запись изменена другим запросом после того, как клиент её прочитал
+	ErrVersionConflict = errors.New("resource has been modified by another request")
+	// ErrNotFound - записи нет; отличает отсутствие данных от сбоя базы
+	ErrNotFound = errors.New("not found")
+)
+
 type BaseRepository[T any] interface {
 	Create(ctx context.Context, e *T) error
 	GetByID(ctx context.Context, id uint) (*T, error)
@@ -23,8 +32,11 @@ type UserRepository interface {
 	Create(ctx context.Context, user *models.User) error
 	GetByID(ctx context.Context, id uint) (*models.User, error)
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	// Update сохраняет пользователя, если его версия не изменилась с момента чтения,
+	// и увеличивает её; иначе ErrVersionConflict
 	Update(ctx context.Context, user *models.User) error
-	Delete(ctx context.Context, id uint) error
+	// Delete удаляет пользователя; version 0 - без проверки версии
+	Delete(ctx context.Context, id uint, version uint) error
 	List(ctx context.Context, limit, offset int) ([]models.User, error)
 	GetByRole(ctx context.Context, role models.UserRole) ([]models.User, error)
 	UpdateRole(ctx context.Context, userID uint, role models.UserRole) error
@@ -39,6 +51,8 @@ type DepartmentRepository interface {
 	Update(ctx context.Context, department *models.Department) error
 	Delete(ctx context.Context, id uint) error
 	List(ctx context.Context) ([]models.Department, error)
+	GetByHead(ctx context.Context, userID uint) ([]models.Department, error)
+	SetHead(ctx context.Context, departmentID uint, userID *uint) error
 }
 
 // StudentGroupRepository - интерфейс для работы с группами студентов
@@ -60,6 +74,7 @@ type StudentProfileRepository interface {
 	Update(ctx context.Context, profile *models.StudentProfile) error
 	Delete(ctx context.Context, id uint) error
 	GetByGroup(ctx context.Context, groupID uint) ([]models.StudentProfile, error)
+	GetByUserIDs(ctx context.Context, userIDs []uint) ([]models.StudentProfile, error)
 }
 
 // TeacherProfileRepository - интерфейс для работы с профилями преподавателей
@@ -70,6 +85,7 @@ type TeacherProfileRepository interface {
 	Update(ctx context.Context, profile *models.TeacherProfile) error
 	Delete(ctx context.Context, id uint) error
 	GetByDepartment(ctx context.Context, departmentID uint) ([]models.TeacherProfile, error)
+	List(ctx context.Context) ([]models.TeacherProfile, error)
 }
 
 // SubjectRepository - интерфейс для работы с дисциплинами
@@ -92,28 +108,40 @@ type TeacherSubjectRepository interface {
 	GetByTeacher(ctx context.Context, teacherID uint, academicYear string) ([]models.TeacherSubject, error)
 	GetBySubject(ctx context.Context, subjectID uint, academicYear string) ([]models.TeacherSubject, error)
 	GetLeadTeacher(ctx context.Context, subjectID uint, academicYear string) (*models.TeacherSubject, error)
-	SetLead(ctx context.Context, assignmentID uint, isLead bool) error
-	DeleteByTeacherAndSubject(ctx context.Context, teacherID, subjectID uint) error
+	SetLead(ctx context.Context, teacherID, subjectID uint, academicYear string, isLead bool) error
+	IsLead(ctx context.Context, teacherID, subjectID uint, academicYear string) (bool, error)
+	DeleteByTeacherAndSubject(ctx context.Context, teacherID, subjectID, termID uint) error
 }
 
 // CourseworkRepository - интерфейс для работы с курсовыми работами
 type CourseworkRepository interface {
 	Create(ctx context.Context, coursework *models.Coursework) error
 	GetByID(ctx context.Context, id uint) (*models.Coursework, error)
+	// Update сохраняет тему, если её версия не изменилась с момента чтения, и увеличивает её;
+	// иначе ErrVersionConflict
 	Update(ctx context.Context, coursework *models.Coursework) error
-	Delete(ctx context.Context, id uint) error
+	// Delete удаляет тему; version 0 - без проверки версии
+	Delete(ctx context.Context, id uint, version uint) error
 	List(ctx context.Context, limit, offset int) ([]models.Coursework, error)
 	GetBySubject(ctx context.Context, subjectID uint) ([]models.Coursework, error)
 	GetByTeacher(ctx context.Context, teacherID uint) ([]models.Coursework, error)
 	GetAvailable(ctx context.Context, subjectID uint) ([]models.Coursework, error)
 	SetAvailable(ctx context.Context, courseworkID uint, available bool) error
 	GetWithStudentCount(ctx context.Context, courseworkID uint) (*models.Coursework, int, error)
+	CountByTeacher(ctx context.Context, teacherID, academicYearID uint) (int, error)
+	Search(ctx context.Context, filter ListCourseworksRequest) ([]models.Coursework, int, error)
 }
 
 // StudentCourseworkRepository - интерфейс для назначения студентов на курсовые
 type StudentCourseworkRepository interface {
 	Create(ctx context.Context, assignment *models.StudentCoursework) error
+	// CreateIfSlotAvailable создаёт назначение одним условным INSERT, только если занятые места
+	// темы вместе с reserved меньше MaxStudents; false - мест не осталось. Проверка и вставка
+	// атомарны, поэтому параллельные запросы не займут одно место дважды
+	CreateIfSlotAvailable(ctx context.Context, assignment *models.StudentCoursework, reserved int) (bool, error)
 	GetByID(ctx context.Context, id uint) (*models.StudentCoursework, error)
+	// GetByIDWithDeleted находит и отменённое назначение - для событий, доставляемых после отмены
+	GetByIDWithDeleted(ctx context.Context, id uint) (*models.StudentCoursework, error)
 	GetByStudent(ctx context.Context, studentID uint) (*models.StudentCoursework, error)
 	GetByCoursework(ctx context.Context, courseworkID uint) ([]models.StudentCoursework, error)
 	Update(ctx context.Context, assignment *models.StudentCoursework) error
@@ -123,4 +151,357 @@ type StudentCourseworkRepository interface {
 	SetSubmitted(ctx context.Context, id uint, submittedAt time.Time) error
 	SetCompleted(ctx context.Context, id uint, completedAt time.Time) error
 	GetByTeacher(ctx context.Context, teacherID uint) ([]models.StudentCoursework, error)
+	// GetBySubjectAndStatus возвращает назначения дисциплины в семестре; termID 0 - во всех семестрах
+	GetBySubjectAndStatus(ctx context.Context, subjectID, termID uint, statuses ...models.CourseworkStatus) ([]models.StudentCoursework, error)
+	CountByTeacher(ctx context.Context, teacherID, academicYearID uint) (int, error)
+	GetHistoryByStudent(ctx context.Context, studentID uint, termID *uint) ([]models.StudentCoursework, error)
+	GetGradebook(ctx context.Context, subjectID, termID uint) ([]models.StudentCoursework, error)
+}
+
+// DefenseRoomRepository - интерфейс для работы с аудиториями защит
+type DefenseRoomRepository interface {
+	Create(ctx context.Context, room *models.DefenseRoom) error
+	GetByID(ctx context.Context, id uint) (*models.DefenseRoom, error)
+	Delete(ctx context.Context, id uint) error
+	List(ctx context.Context) ([]models.DefenseRoom, error)
+}
+
+// DefenseSessionRepository - интерфейс для работы с сессиями защит и комиссиями
+type DefenseSessionRepository interface {
+	Create(ctx context.Context, session *models.DefenseSession) error
+	GetByID(ctx context.Context, id uint) (*models.DefenseSession, error)
+	Delete(ctx context.Context, id uint) error
+	List(ctx context.Context) ([]models.DefenseSession, error)
+	GetOverlapping(ctx context.Context, start, end time.Time) ([]models.DefenseSession, error)
+	GetByCommitteeMember(ctx context.Context, teacherID uint) ([]models.DefenseSession, error)
+	SetCommittee(ctx context.Context, sessionID uint, members []models.DefenseCommitteeMember) error
+}
+
+// DefenseSlotRepository - интерфейс для работы со слотами защит
+type DefenseSlotRepository interface {
+	CreateBatch(ctx context.Context, slots []models.DefenseSlot) error
+	GetByID(ctx context.Context, id uint) (*models.DefenseSlot, error)
+	GetBySession(ctx context.Context, sessionID uint) ([]models.DefenseSlot, error)
+	GetByAssignment(ctx context.Context, assignmentID uint) (*models.DefenseSlot, error)
+	GetByStudent(ctx context.Context, studentID uint) ([]models.DefenseSlot, error)
+	GetBySupervisor(ctx context.Context, teacherID uint) ([]models.DefenseSlot, error)
+	// GetAssignedBetween возвращает занятые слоты, начинающиеся в [from, to)
+	GetAssignedBetween(ctx context.Context, from, to time.Time) ([]models.DefenseSlot, error)
+	Assign(ctx context.Context, slotID, assignmentID uint) error
+	Release(ctx context.Context, slotID uint) error
+}
+
+// TopicProposalRepository - интерфейс для работы с темами, предложенными студентами
+type TopicProposalRepository interface {
+	Create(ctx context.Context, proposal *models.TopicProposal) error
+	GetByID(ctx context.Context, id uint) (*models.TopicProposal, error)
+	Update(ctx context.Context, proposal *models.TopicProposal) error
+	GetByStudent(ctx context.Context, studentID uint) ([]models.TopicProposal, error)
+	GetByTeacher(ctx context.Context, teacherID uint, statuses ...models.ProposalStatus) ([]models.TopicProposal, error)
+	GetOpenByStudent(ctx context.Context, studentID uint) (*models.TopicProposal, error)
+}
+
+// SelectionRoundRepository - интерфейс для работы с раундами выбора тем
+type SelectionRoundRepository interface {
+	Create(ctx context.Context, round *models.SelectionRound) error
+	GetByID(ctx context.Context, id uint) (*models.SelectionRound, error)
+	Update(ctx context.Context, round *models.SelectionRound) error
+	List(ctx context.Context) ([]models.SelectionRound, error)
+	GetActiveBySubject(ctx context.Context, subjectID uint) (*models.SelectionRound, error)
+}
+
+// SelectionPreferenceRepository - интерфейс для предпочтений студентов и рейтингов руководителей
+type SelectionPreferenceRepository interface {
+	ReplaceStudentPreferences(ctx context.Context, roundID, studentID uint, prefs []models.TopicPreference) error
+	GetStudentPreferences(ctx context.Context, roundID, studentID uint) ([]models.TopicPreference, error)
+	GetRoundPreferences(ctx context.Context, roundID uint) ([]models.TopicPreference, error)
+	GetCourseworkApplicants(ctx context.Context, roundID, courseworkID uint) ([]models.TopicPreference, error)
+
+	ReplaceApplicantRankings(ctx context.Context, roundID, courseworkID uint, rankings []models.ApplicantRanking) error
+	GetRoundRankings(ctx context.Context, roundID uint) ([]models.ApplicantRanking, error)
+}
+
+// WaitlistRepository - интерфейс для работы с листами ожидания
+type WaitlistRepository interface {
+	Create(ctx context.Context, entry *models.WaitlistEntry) error
+	GetByID(ctx context.Context, id uint) (*models.WaitlistEntry, error)
+	Update(ctx context.Context, entry *models.WaitlistEntry) error
+	GetActiveByStudentAndCoursework(ctx context.Context, studentID, courseworkID uint) (*models.WaitlistEntry, error)
+	GetByCoursework(ctx context.Context, courseworkID uint, statuses ...models.WaitlistStatus) ([]models.WaitlistEntry, error)
+	GetByStudent(ctx context.Context, studentID uint) ([]models.WaitlistEntry, error)
+	CountOffered(ctx context.Context, courseworkID, exceptStudentID uint) (int, error)
+	GetExpiredOffers(ctx context.Context, now time.Time) ([]models.WaitlistEntry, error)
+	// GetOffersExpiringBetween возвращает действующие предложения, срок которых истекает в [from, to)
+	GetOffersExpiringBetween(ctx context.Context, from, to time.Time) ([]models.WaitlistEntry, error)
+}
+
+// TeamRepository - интерфейс для работы с командами, их участниками и рабочим пространством
+type TeamRepository interface {
+	Create(ctx context.Context, team *models.Team) error
+	GetByID(ctx context.Context, id uint) (*models.Team, error)
+	GetByCoursework(ctx context.Context, courseworkID uint) (*models.Team, error)
+	GetByStudent(ctx context.Context, studentID uint) (*models.Team, error)
+	Update(ctx context.Context, team *models.Team) error
+	Delete(ctx context.Context, id uint) error
+
+	// Участники
+	AddMember(ctx context.Context, member *models.TeamMember) error
+	UpdateMember(ctx context.Context, member *models.TeamMember) error
+	RemoveMember(ctx context.Context, teamID, studentID uint) error
+
+	// Рабочее пространство
+	CreatePost(ctx context.Context, post *models.TeamPost) error
+	GetPosts(ctx context.Context, teamID uint) ([]models.TeamPost, error)
+}
+
+// TeamInvitationRepository - интерфейс для работы с приглашениями в команды
+type TeamInvitationRepository interface {
+	Create(ctx context.Context, inv *models.TeamInvitation) error
+	GetByID(ctx context.Context, id uint) (*models.TeamInvitation, error)
+	Update(ctx context.Context, inv *models.TeamInvitation) error
+	GetPendingByStudent(ctx context.Context, studentID uint) ([]models.TeamInvitation, error)
+	GetPendingByTeam(ctx context.Context, teamID uint) ([]models.TeamInvitation, error)
+	RevokePendingByStudent(ctx context.Context, studentID uint) error
+}
+
+// SupervisionQuotaRepository - интерфейс для работы с квотами руководства
+type SupervisionQuotaRepository interface {
+	Upsert(ctx context.Context, quota *models.SupervisionQuota) error
+	GetByTeacherAndYear(ctx context.Context, teacherID uint, academicYear string) (*models.SupervisionQuota, error)
+	ListByYear(ctx context.Context, academicYear string) ([]models.SupervisionQuota, error)
+	Delete(ctx context.Context, teacherID uint, academicYear string) error
+}
+
+// TermRepository - интерфейс для работы с учебными годами и семестрами
+type TermRepository interface {
+	CreateYear(ctx context.Context, year *models.AcademicYear) error
+	GetYearByName(ctx context.Context, name string) (*models.AcademicYear, error)
+	ListYears(ctx context.Context) ([]models.AcademicYear, error)
+	GetByID(ctx context.Context, id uint) (*models.Term, error)
+	GetActive(ctx context.Context) (*models.Term, error)
+	GetByYearAndNumber(ctx context.Context, yearName string, number int) (*models.Term, error)
+	SetActive(ctx context.Context, termID uint) error
+}
+
+// GroupSubjectRepository - интерфейс для работы с учебным планом групп
+type GroupSubjectRepository interface {
+	Create(ctx context.Context, entry *models.GroupSubject) error
+	Delete(ctx context.Context, groupID, subjectID, termID uint) error
+	GetByGroup(ctx context.Context, groupID uint, termID *uint) ([]models.GroupSubject, error)
+	GetSubjectIDs(ctx context.Context, groupID, termID uint) ([]uint, error)
+}
+
+// ImportJobRepository - интерфейс для журнала загрузок списков
+type ImportJobRepository interface {
+	Create(ctx context.Context, job *models.ImportJob) error
+	GetByID(ctx context.Context, id uint) (*models.ImportJob, error)
+	List(ctx context.Context, limit, offset int) ([]models.ImportJob, error)
+}
+
+// DocumentTemplateRepository - интерфейс для работы с шаблонами документов
+type DocumentTemplateRepository interface {
+	Create(ctx context.Context, tpl *models.DocumentTemplate) error
+	GetByID(ctx context.Context, id uint) (*models.DocumentTemplate, error)
+	GetDefault(ctx context.Context, kind models.DocumentKind) (*models.DocumentTemplate, error)
+	Update(ctx context.Context, tpl *models.DocumentTemplate) error
+	Delete(ctx context.Context, id uint) error
+	List(ctx context.Context, kind models.DocumentKind) ([]models.DocumentTemplate, error)
+}
+
+// IssuedDocumentRepository - интерфейс для реестра выпущенных документов
+type IssuedDocumentRepository interface {
+	// Issue назначает документу номер и версию и сохраняет его в одной транзакции;
+	// fill вызывается после назначения номера, чтобы номер попал в содержимое
+	Issue(ctx context.Context, doc *models.IssuedDocument, numberPrefix string, fill func(*models.IssuedDocument) error) error
+	GetByID(ctx context.Context, id uint) (*models.IssuedDocument, error)
+	List(ctx context.Context, kind models.DocumentKind, subjectID uint, withSuperseded bool) ([]models.IssuedDocument, error)
+	GetVersions(ctx context.Context, id uint) ([]models.IssuedDocument, error)
+}
+
+// FileStorage - хранилище загруженных файлов
+type FileStorage interface {
+	Save(ctx context.Context, key string, r io.Reader) (int64, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// SubmissionRepository - интерфейс для сданных работ и отчётов о заимствованиях
+type SubmissionRepository interface {
+	Create(ctx context.Context, sub *models.Submission) error
+	GetByID(ctx context.Context, id uint) (*models.Submission, error)
+	ListByStudent(ctx context.Context, studentID uint) ([]models.Submission, error)
+	ListByCoursework(ctx context.Context, courseworkID uint) ([]models.Submission, error)
+	// ClaimPending переводит до limit работ из очереди (и зависшие дольше staleAfter) в running
+	ClaimPending(ctx context.Context, limit int, staleAfter time.Duration) ([]models.Submission, error)
+	SetCheckStatus(ctx context.Context, id uint, status models.SimilarityCheckStatus, checkErr string) error
+
+	SaveFingerprint(ctx context.Context, fp *models.SubmissionFingerprint) error
+	GetFingerprint(ctx context.Context, submissionID uint) (*models.SubmissionFingerprint, error)
+	// ListSignatures возвращает сигнатуры работ, сданных раньше указанной, без текста;
+	// работы того же назначения (повторные сдачи) пропускаются
+	ListSignatures(ctx context.Context, beforeSubmissionID, exceptStudentCourseworkID uint) ([]models.SubmissionFingerprint, error)
+
+	// SaveReport заменяет отчёт работы и отмечает проверку завершённой
+	SaveReport(ctx context.Context, submissionID uint, score float64, matches []models.SimilarityMatch) error
+	GetMatches(ctx context.Context, submissionID uint) ([]models.SimilarityMatch, error)
+}
+
+// DiscussionRepository - интерфейс для обсуждений тем и назначений
+type DiscussionRepository interface {
+	// GetOrCreateThread возвращает обсуждение, создавая его при первом обращении
+	GetOrCreateThread(ctx context.Context, kind models.ThreadKind, courseworkID, studentCourseworkID uint) (*models.DiscussionThread, error)
+	GetThread(ctx context.Context, id uint) (*models.DiscussionThread, error)
+
+	// CreateComment сохраняет сообщение вместе с упоминаниями
+	CreateComment(ctx context.Context, comment *models.Comment) error
+	GetComment(ctx context.Context, id uint) (*models.Comment, error)
+	// ListComments возвращает сообщения по порядку, включая удалённые (с DeletedAt)
+	ListComments(ctx context.Context, threadID uint) ([]models.Comment, error)
+	// UpdateComment сохраняет новый текст и упоминания, прежний текст уходит в историю
+	UpdateComment(ctx context.Context, comment *models.Comment, revision *models.CommentRevision) error
+	DeleteComment(ctx context.Context, id uint) error
+	GetRevisions(ctx context.Context, commentID uint) ([]models.CommentRevision, error)
+
+	AddAttachment(ctx context.Context, attachment *models.CommentAttachment) error
+	GetAttachment(ctx context.Context, id uint) (*models.CommentAttachment, error)
+	CountAttachments(ctx context.Context, commentID uint) (int64, error)
+
+	LatestCommentID(ctx context.Context, threadID uint) (uint, error)
+	GetReadMark(ctx context.Context, threadID, userID uint) (uint, error)
+	// MarkRead сдвигает отметку прочтения вперёд, назад она не возвращается
+	MarkRead(ctx context.Context, threadID, userID, commentID uint) error
+	// ListUnread возвращает обсуждения с непрочитанными чужими сообщениями, в которых
+	// пользователь участвует: автор или руководитель темы, студент назначения,
+	// автор сообщений или упомянутый
+	ListUnread(ctx context.Context, userID uint) ([]models.ThreadUnread, error)
+}
+
+// NotificationRepository - интерфейс для уведомлений и настроек уведомлений
+type NotificationRepository interface {
+	Create(ctx context.Context, n *models.Notification) error
+	List(ctx context.Context, userID uint, unreadOnly bool, limit, offset int) ([]models.Notification, error)
+	CountUnread(ctx context.Context, userID uint) (int64, error)
+	// MarkRead отмечает прочитанными уведомления пользователя; ids == nil - все
+	MarkRead(ctx context.Context, userID uint, ids []uint, at time.Time) (int64, error)
+
+	GetPreferences(ctx context.Context, userID uint) ([]models.NotificationPreference, error)
+	SavePreferences(ctx context.Context, prefs []models.NotificationPreference) error
+	// IsEnabled сообщает, хочет ли пользователь получать события этого вида (по умолчанию - да)
+	IsEnabled(ctx context.Context, userID uint, event models.EventType) (bool, error)
+	// ListUnreadBetween возвращает непрочитанные уведомления, созданные в (since, until], по порядку
+	ListUnreadBetween(ctx context.Context, userID uint, since, until time.Time) ([]models.Notification, error)
+	// MarkReminded сохраняет отметку о напоминании; false - о сроке уже напоминали
+	MarkReminded(ctx context.Context, reminder *models.DeadlineReminder) (bool, error)
+}
+
+// Mailer - отправка писем
+type Mailer interface {
+	Send(ctx context.Context, msg MailMessage) error
+}
+
+// EmailRepository - интерфейс для почтовых настроек и очереди писем
+type EmailRepository interface {
+	// GetSettings возвращает настройки пользователя или настройки по умолчанию
+	GetSettings(ctx context.Context, userID uint) (*models.EmailSettings, error)
+	SaveSettings(ctx context.Context, settings *models.EmailSettings) error
+	// ListDigestDue возвращает настройки пользователей на сводке, не получавших её после before
+	ListDigestDue(ctx context.Context, before time.Time, limit int) ([]models.EmailSettings, error)
+	// CompleteDigest сдвигает отметку сводки и ставит письмо в очередь (email == nil - писать не о чем)
+	CompleteDigest(ctx context.Context, userID uint, at time.Time, email *models.OutgoingEmail) error
+
+	Enqueue(ctx context.Context, email *models.OutgoingEmail) error
+	// ClaimDue переводит до limit писем, срок которых подошёл (и зависшие дольше staleAfter), в sending
+	ClaimDue(ctx context.Context, now time.Time, limit int, staleAfter time.Duration) ([]models.OutgoingEmail, error)
+	MarkSent(ctx context.Context, id uint, at time.Time) error
+	// MarkFailed откладывает письмо до nextAttemptAt; nil - попытки исчерпаны
+	MarkFailed(ctx context.Context, id uint, sendErr string, nextAttemptAt *time.Time) error
+}
+
+// TelegramClient - клиент Bot API Telegram
+type TelegramClient interface {
+	// GetUpdates ждёт новые сообщения боту не дольше timeout (long polling)
+	GetUpdates(ctx context.Context, offset int64, timeout time.Duration) ([]TelegramUpdate, error)
+	SendMessage(ctx context.Context, chatID int64, text string) error
+}
+
+// TelegramRepository - интерфейс для привязки чатов Telegram к пользователям
+type TelegramRepository interface {
+	// CreateLinkCode сохраняет код привязки, прежние коды пользователя удаляются
+	CreateLinkCode(ctx context.Context, code *models.TelegramLinkCode) error
+	// LinkChat погашает действующий код и привязывает чат к его владельцу; прежняя
+	// привязка пользователя и другие привязки этого чата удаляются
+	LinkChat(ctx context.Context, code string, now time.Time, link *models.TelegramLink) error
+	// GetLinkByUser и GetLinkByChat возвращают nil, если чат не привязан
+	GetLinkByUser(ctx context.Context, userID uint) (*models.TelegramLink, error)
+	GetLinkByChat(ctx context.Context, chatID int64) (*models.TelegramLink, error)
+	DeleteLink(ctx context.Context, userID uint) error
+}
+
+// PubSub - шина сообщений между экземплярами сервера. Сообщения передаются в виде байтов,
+// поэтому реализацию в памяти можно заменить внешним брокером (Redis, NATS) без изменений в менеджерах.
+type PubSub interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+	// Subscribe подписывает на темы; канал закрывается при отписке или если подписчик не успевает
+	// читать сообщения - клиенту тогда нужно переподключиться и перечитать состояние
+	Subscribe(ctx context.Context, topics ...string) (<-chan PubSubMessage, func(), error)
+}
+
+// WebhookClient - отправка запросов на вебхуки
+type WebhookClient interface {
+	// Post отправляет JSON; ошибка возвращается, только если ответ не получен
+	Post(ctx context.Context, url string, headers map[string]string, body []byte) (*WebhookHTTPResult, error)
+}
+
+// WebhookRepository - интерфейс для вебхуков и очереди их доставок
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *models.Webhook) error
+	GetByID(ctx context.Context, id uint) (*models.Webhook, error)
+	List(ctx context.Context) ([]models.Webhook, error)
+	ListActive(ctx context.Context) ([]models.Webhook, error)
+	Update(ctx context.Context, webhook *models.Webhook) error
+	// Delete удаляет вебхук вместе с журналом доставок
+	Delete(ctx context.Context, id uint) error
+
+	CreateDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error
+	GetDelivery(ctx context.Context, id uint) (*models.WebhookDelivery, error)
+	// ListDeliveries возвращает журнал доставок вебхука, новые первыми, и общее число записей
+	ListDeliveries(ctx context.Context, webhookID uint, limit, offset int) ([]models.WebhookDelivery, int64, error)
+	// ClaimDue переводит до limit доставок, срок которых подошёл (и зависшие дольше staleAfter), в sending
+	ClaimDue(ctx context.Context, now time.Time, limit int, staleAfter time.Duration) ([]models.WebhookDelivery, error)
+	// SaveAttempt сохраняет итог попытки: статус, ответ получателя и срок следующей попытки
+	SaveAttempt(ctx context.Context, delivery *models.WebhookDelivery) error
+}
+
+// UnitOfWork - единица работы: вызовы репозиториев внутри Do выполняются в одной транзакции
+type UnitOfWork interface {
+	// Do выполняет fn в транзакции; репозитории нужно вызывать с контекстом, переданным в fn.
+	// Ошибка fn откатывает транзакцию, вложенный Do присоединяется к внешней транзакции
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// OutboxRepository - интерфейс для outbox событий предметной области
+type OutboxRepository interface {
+	// Create записывает событие; внутри UnitOfWork - в транзакции изменения данных
+	Create(ctx context.Context, event *models.OutboxEvent) error
+	// ClaimDue переводит до limit событий, срок которых подошёл (и зависшие дольше staleAfter), в processing
+	ClaimDue(ctx context.Context, now time.Time, limit int, staleAfter time.Duration) ([]models.OutboxEvent, error)
+	// SaveResult сохраняет итог доставки: статус, обработавших подписчиков и срок повтора
+	SaveResult(ctx context.Context, event *models.OutboxEvent) error
+}
+
+// AuditRepository - журнал аудита событий предметной области
+type AuditRepository interface {
+	// Create добавляет запись; повторная запись того же события outbox пропускается
+	Create(ctx context.Context, entry *models.AuditEntry) error
+}
+
+// IdempotencyRepository - ключи идемпотентности и сохранённые ответы
+type IdempotencyRepository interface {
+	// Reserve создаёт запись ключа; false - у пользователя уже есть запись с этим ключом
+	Reserve(ctx context.Context, record *models.IdempotencyKey) (bool, error)
+	Get(ctx context.Context, userID uint, key string) (*models.IdempotencyKey, error)
+	// SaveResponse сохраняет ответ и статус записи
+	SaveResponse(ctx context.Context, record *models.IdempotencyKey) error
+	Delete(ctx context.Context, id uint) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
 }
@@ -0,0 +1,216 @@
+package managers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// assignmentEvents - события outbox об изменении назначения студента
+var assignmentEvents = []models.EventType{
+	models.EventAssignmentCreated,
+	models.EventAssignmentCancelled,
+	models.EventAssignmentStatusChanged,
+	models.EventAssignmentSubmitted,
+	models.EventGradeSet,
+	models.EventAssignmentCompleted,
+}
+
+// assignmentWebhooks - события назначений, о которых сообщается вебхуками
+var assignmentWebhooks = map[models.EventType]models.WebhookEvent{
+	models.EventAssignmentCreated: models.WebhookAssignmentCreated,
+	models.EventGradeSet:          models.WebhookGradeSet,
+}
+
+// AssignmentSubscribers - подписчики outbox на изменения назначений и загрузку работ:
+// уведомления, обновления в реальном времени и вебхуки
+type AssignmentSubscribers struct {
+	scRepo   interfaces.StudentCourseworkRepository
+	subRepo  interfaces.SubmissionRepository
+	notifier interfaces.Notifier
+	realtime interfaces.RealtimePublisher
+	webhooks interfaces.WebhookDispatcher
+}
+
+// NewAssignmentSubscribers создаёт подписчиков на события назначений
+func NewAssignmentSubscribers(
+	scRepo interfaces.StudentCourseworkRepository,
+	subRepo interfaces.SubmissionRepository,
+	notifier interfaces.Notifier,
+	realtime interfaces.RealtimePublisher,
+	webhooks interfaces.WebhookDispatcher,
+) *AssignmentSubscribers {
+	return &AssignmentSubscribers{
+		scRepo:   scRepo,
+		subRepo:  subRepo,
+		notifier: notifier,
+		realtime: realtime,
+		webhooks: webhooks,
+	}
+}
+
+// Register подписывает обработчики на шину событий
+func (s *AssignmentSubscribers) Register(bus interfaces.EventBus) {
+	bus.Subscribe("notifications", s.notify, assignmentEvents...)
+	bus.Subscribe("realtime", s.publish, assignmentEvents...)
+	bus.Subscribe("webhooks", s.dispatchWebhook, models.EventAssignmentCreated, models.EventGradeSet)
+	bus.Subscribe("notifications.submission", s.notifySubmission, models.EventSubmissionCreated)
+	bus.Subscribe("webhooks.submission", s.dispatchSubmissionWebhook, models.EventSubmissionCreated)
+}
+
+// notify сообщает об изменении студенту, а о сданной работе - руководителю
+func (s *AssignmentSubscribers) notify(ctx context.Context, event interfaces.DomainEvent) error {
+	data, sc, err := s.load(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	switch event.Type {
+	case models.EventAssignmentCreated:
+		for _, n := range assignmentCreatedEvents(sc, &sc.Student, &sc.Coursework, sc.StudentID) {
+			if err := s.notifier.Notify(ctx, n); err != nil {
+				return err
+			}
+		}
+		return nil
+	case models.EventAssignmentCancelled:
+		message := fmt.Sprintf("Назначение на тему «%s» отменено (студент: %s).", sc.Coursework.Title, sc.Student.GetFullName())
+		for _, userID := range []uint{sc.Coursework.TeacherID, sc.StudentID} {
+			if err := s.notifier.Notify(ctx, interfaces.Event{
+				Type:        event.Type,
+				RecipientID: userID,
+				EntityType:  models.EntityCoursework,
+				EntityID:    sc.CourseworkID,
+				Title:       "Назначение отменено",
+				Message:     message,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	n := interfaces.Event{
+		Type:        event.Type,
+		RecipientID: sc.StudentID,
+		EntityType:  models.EntityAssignment,
+		EntityID:    sc.ID,
+	}
+	switch event.Type {
+	case models.EventAssignmentStatusChanged:
+		n.Title = "Изменён статус работы"
+		n.Message = fmt.Sprintf("Статус курсовой работы «%s»: %s.", sc.Coursework.Title, statusTexts[data.Status])
+	case models.EventAssignmentSubmitted:
+		n.RecipientID = sc.Coursework.TeacherID
+		n.ActorID = sc.StudentID
+		n.Title = "Работа сдана на проверку"
+		n.Message = fmt.Sprintf("%s сдал(а) курсовую работу «%s».", sc.Student.GetFullName(), sc.Coursework.Title)
+	case models.EventGradeSet:
+		grade := sc.Grade
+		if data.Grade != nil {
+			grade = data.Grade
+		}
+		if grade == nil {
+			return nil
+		}
+		n.Title = "Выставлена оценка"
+		n.Message = fmt.Sprintf("За курсовую работу «%s» выставлена оценка «%s».", sc.Coursework.Title, gradeTexts[*grade])
+	case models.EventAssignmentCompleted:
+		n.Title = "Работа принята"
+		n.Message = fmt.Sprintf("Курсовая работа «%s» принята.", sc.Coursework.Title)
+	default:
+		return nil
+	}
+	return s.notifier.Notify(ctx, n)
+}
+
+// publish рассылает текущее состояние назначения подписчикам потока обновлений
+func (s *AssignmentSubscribers) publish(ctx context.Context, event interfaces.DomainEvent) error {
+	_, sc, err := s.load(ctx, event)
+	if err != nil {
+		return err
+	}
+	s.realtime.PublishAssignment(ctx, sc, event.Type)
+	// назначение или отмена меняют число свободных мест в теме
+	if event.Type == models.EventAssignmentCreated || event.Type == models.EventAssignmentCancelled {
+		s.realtime.PublishSlots(ctx, sc.CourseworkID)
+	}
+	return nil
+}
+
+// dispatchWebhook ставит событие назначения в очередь вебхуков
+func (s *AssignmentSubscribers) dispatchWebhook(ctx context.Context, event interfaces.DomainEvent) error {
+	webhook, ok := assignmentWebhooks[event.Type]
+	if !ok {
+		return nil
+	}
+	_, sc, err := s.load(ctx, event)
+	if err != nil {
+		return err
+	}
+	return s.webhooks.Enqueue(ctx, webhook, webhookAssignment(sc))
+}
+
+// notifySubmission сообщает руководителю о новой версии уже сданной работы
+func (s *AssignmentSubscribers) notifySubmission(ctx context.Context, event interfaces.DomainEvent) error {
+	data, sub, err := s.loadSubmission(ctx, event)
+	if err != nil || !data.NewVersion {
+		return err
+	}
+	return s.notifier.Notify(ctx, interfaces.Event{
+		Type:        event.Type,
+		RecipientID: sub.Coursework.TeacherID,
+		ActorID:     sub.StudentID,
+		EntityType:  models.EntitySubmission,
+		EntityID:    sub.ID,
+		Title:       "Новая версия работы",
+		Message:     fmt.Sprintf("%s загрузил(а) новую версию файла «%s» по теме «%s».", sub.Student.GetFullName(), sub.FileName, sub.Coursework.Title),
+	})
+}
+
+// dispatchSubmissionWebhook ставит событие submission.created в очередь вебхуков
+func (s *AssignmentSubscribers) dispatchSubmissionWebhook(ctx context.Context, event interfaces.DomainEvent) error {
+	_, sub, err := s.loadSubmission(ctx, event)
+	if err != nil {
+		return err
+	}
+	return s.webhooks.Enqueue(ctx, models.WebhookSubmissionCreated, interfaces.WebhookSubmissionData{
+		SubmissionID: sub.ID,
+		AssignmentID: sub.StudentCourseworkID,
+		CourseworkID: sub.CourseworkID,
+		Student:      webhookUser(&sub.Student),
+		FileName:     sub.FileName,
+		ContentType:  sub.ContentType,
+		Size:         sub.Size,
+		SHA256:       sub.SHA256,
+		CreatedAt:    sub.CreatedAt,
+	})
+}
+
+func (s *AssignmentSubscribers) load(ctx context.Context, event interfaces.DomainEvent) (*interfaces.AssignmentEventData, *models.StudentCoursework, error) {
+	var data interfaces.AssignmentEventData
+	if err := json.Unmarshal(event.Payload, &data); err != nil {
+		return nil, nil, fmt.Errorf("invalid payload of event %d: %w", event.ID, err)
+	}
+	// назначение могли отменить до доставки события, а о самой отмене сообщать нужно
+	sc, err := s.scRepo.GetByIDWithDeleted(ctx, data.AssignmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &data, sc, nil
+}
+
+func (s *AssignmentSubscribers) loadSubmission(ctx context.Context, event interfaces.DomainEvent) (*interfaces.SubmissionEventData, *models.Submission, error) {
+	var data interfaces.SubmissionEventData
+	if err := json.Unmarshal(event.Payload, &data); err != nil {
+		return nil, nil, fmt.Errorf("invalid payload of event %d: %w", event.ID, err)
+	}
+	sub, err := s.subRepo.GetByID(ctx, data.SubmissionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &data, sub, nil
+}
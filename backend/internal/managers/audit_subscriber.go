@@ -0,0 +1,33 @@
+package managers
+
+import (
+	"context"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// AuditSubscriber - подписчик outbox, записывающий каждое событие в журнал аудита
+type AuditSubscriber struct {
+	repo interfaces.AuditRepository
+}
+
+// NewAuditSubscriber создаёт подписчика журнала аудита
+func NewAuditSubscriber(repo interfaces.AuditRepository) *AuditSubscriber {
+	return &AuditSubscriber{repo: repo}
+}
+
+// Register подписывает журнал на все события шины
+func (s *AuditSubscriber) Register(bus interfaces.EventBus) {
+	bus.Subscribe("audit", s.record)
+}
+
+// record сохраняет событие; при повторной доставке запись уже есть и не дублируется
+func (s *AuditSubscriber) record(ctx context.Context, event interfaces.DomainEvent) error {
+	return s.repo.Create(ctx, &models.AuditEntry{
+		EventID:    event.ID,
+		EventType:  event.Type,
+		Payload:    string(event.Payload),
+		OccurredAt: event.CreatedAt,
+	})
+}
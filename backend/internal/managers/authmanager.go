@@ -17,11 +17,12 @@ import (
 
 type AuthManager struct {
 	userRepo interfaces.UserRepository
+	webhooks interfaces.WebhookDispatcher
 	jwtCfg   config.JWTConfig
 }
 
-func NewAuthManager(userRepo interfaces.UserRepository, jwtCfg config.JWTConfig) interfaces.AuthManager {
-	return &AuthManager{userRepo: userRepo, jwtCfg: jwtCfg}
+func NewAuthManager(userRepo interfaces.UserRepository, webhooks interfaces.WebhookDispatcher, jwtCfg config.JWTConfig) interfaces.AuthManager {
+	return &AuthManager{userRepo: userRepo, webhooks: webhooks, jwtCfg: jwtCfg}
 }
 
 func (a *AuthManager) Register(ctx context.Context, req interfaces.RegisterRequest) (*models.User, error) {
@@ -45,6 +46,7 @@ func (a *AuthManager) Register(ctx context.Context, req interfaces.RegisterReque
 	if err := a.userRepo.Create(ctx, u); err != nil {
 		return nil, err
 	}
+	a.webhooks.Dispatch(ctx, models.WebhookUserCreated, webhookUser(u))
 	return u, nil
 }
 
@@ -10,18 +10,36 @@ import (
 
 // CourseworkManagerImpl реализует interfaces.CourseworkManager
 type CourseworkManagerImpl struct {
-	cwRepo interfaces.CourseworkRepository
-	scRepo interfaces.StudentCourseworkRepository
+	cwRepo     interfaces.CourseworkRepository
+	scRepo     interfaces.StudentCourseworkRepository
+	termRepo   interfaces.TermRepository
+	assignRepo interfaces.TeacherSubjectRepository
+	curriculum interfaces.CurriculumManager
+	waitlist   interfaces.WaitlistManager
+	workload   interfaces.WorkloadManager
+	realtime   interfaces.RealtimePublisher
 }
 
 // NewCourseworkManager создаёт новый CourseworkManager
 func NewCourseworkManager(
 	cwRepo interfaces.CourseworkRepository,
 	scRepo interfaces.StudentCourseworkRepository,
+	termRepo interfaces.TermRepository,
+	assignRepo interfaces.TeacherSubjectRepository,
+	curriculum interfaces.CurriculumManager,
+	waitlist interfaces.WaitlistManager,
+	workload interfaces.WorkloadManager,
+	realtime interfaces.RealtimePublisher,
 ) interfaces.CourseworkManager {
 	return &CourseworkManagerImpl{
-		cwRepo: cwRepo,
-		scRepo: scRepo,
+		cwRepo:     cwRepo,
+		scRepo:     scRepo,
+		termRepo:   termRepo,
+		assignRepo: assignRepo,
+		curriculum: curriculum,
+		waitlist:   waitlist,
+		workload:   workload,
+		realtime:   realtime,
 	}
 }
 
@@ -31,6 +49,14 @@ func (m *CourseworkManagerImpl) CreateCoursework(ctx context.Context, req interf
 	if req.SubjectID == 0 || req.TeacherID == 0 {
 		return nil, errors.New("subject and teacher IDs are required")
 	}
+	termID, err := resolveTermID(ctx, m.termRepo, req.TermID)
+	if err != nil {
+		return nil, err
+	}
+	// проверка: квота тем руководителя на учебный год
+	if err := m.workload.CheckTopicQuota(ctx, req.TeacherID, termID); err != nil {
+		return nil, err
+	}
 	cw := &models.Coursework{
 		Title:           req.Title,
 		Description:     req.Description,
@@ -40,10 +66,12 @@ func (m *CourseworkManagerImpl) CreateCoursework(ctx context.Context, req interf
 		MaxStudents:     req.MaxStudents,
 		DifficultyLevel: req.DifficultyLevel,
 		IsAvailable:     true,
+		TermID:          termID,
 	}
 	if err := m.cwRepo.Create(ctx, cw); err != nil {
 		return nil, err
 	}
+	m.realtime.PublishSlots(ctx, cw.ID)
 	return cw, nil
 }
 
@@ -52,12 +80,15 @@ func (m *CourseworkManagerImpl) GetCoursework(ctx context.Context, cwID uint) (*
 	return m.cwRepo.GetByID(ctx, cwID)
 }
 
-// UpdateCoursework обновляет курсовую работу
-func (m *CourseworkManagerImpl) UpdateCoursework(ctx context.Context, cwID uint, req interfaces.UpdateCourseworkRequest) (*models.Coursework, error) {
+// UpdateCoursework обновляет курсовую работу; version != 0 - версия, которую видел клиент
+func (m *CourseworkManagerImpl) UpdateCoursework(ctx context.Context, cwID uint, req interfaces.UpdateCourseworkRequest, version uint) (*models.Coursework, error) {
 	cw, err := m.cwRepo.GetByID(ctx, cwID)
 	if err != nil {
 		return nil, err
 	}
+	if version != 0 && cw.Version != version {
+		return nil, interfaces.ErrVersionConflict
+	}
 	if req.Title != nil {
 		cw.Title = *req.Title
 	}
@@ -67,35 +98,49 @@ func (m *CourseworkManagerImpl) UpdateCoursework(ctx context.Context, cwID uint,
 	if req.Requirements != nil {
 		cw.Requirements = *req.Requirements
 	}
+	capacityGrew := false
 	if req.MaxStudents != nil {
+		capacityGrew = *req.MaxStudents > cw.MaxStudents
 		cw.MaxStudents = *req.MaxStudents
 	}
 	if req.DifficultyLevel != nil {
 		cw.DifficultyLevel = *req.DifficultyLevel
 	}
 	if req.IsAvailable != nil {
+		capacityGrew = capacityGrew || (*req.IsAvailable && !cw.IsAvailable)
 		cw.IsAvailable = *req.IsAvailable
 	}
 	if err := m.cwRepo.Update(ctx, cw); err != nil {
 		return nil, err
 	}
+	// PromoteNext сам разошлёт занятость мест
+	if capacityGrew {
+		if err := m.waitlist.PromoteNext(ctx, cw.ID); err != nil {
+			return nil, err
+		}
+	} else {
+		m.realtime.PublishSlots(ctx, cw.ID)
+	}
 	return cw, nil
 }
 
 // DeleteCoursework удаляет курсовую работу
-func (m *CourseworkManagerImpl) DeleteCoursework(ctx context.Context, cwID uint) error {
-	return m.cwRepo.Delete(ctx, cwID)
+func (m *CourseworkManagerImpl) DeleteCoursework(ctx context.Context, cwID uint, version uint) error {
+	return m.cwRepo.Delete(ctx, cwID, version)
 }
 
-// ListCourseworks возвращает список и общее число
+// ListCourseworks возвращает страницу списка и общее число; по умолчанию - темы текущего семестра
 func (m *CourseworkManagerImpl) ListCourseworks(ctx context.Context, req interfaces.ListCourseworksRequest) ([]models.Coursework, int, error) {
-	list, err := m.cwRepo.List(ctx, req.Limit, req.Offset)
-	if err != nil {
-		return nil, 0, err
+	if req.TermID == nil && !req.AllTerms {
+		termID, err := resolveTermID(ctx, m.termRepo, 0)
+		if err != nil {
+			return nil, 0, err
+		}
+		if termID != 0 {
+			req.TermID = &termID
+		}
 	}
-	// для MVP считаем total как длину листа
-	total := len(list)
-	return list, total, nil
+	return m.cwRepo.Search(ctx, req)
 }
 
 // GetCourseworksBySubject возвращает по предмету
@@ -108,24 +153,39 @@ func (m *CourseworkManagerImpl) GetCourseworksByTeacher(ctx context.Context, tea
 	return m.cwRepo.GetByTeacher(ctx, teacherID)
 }
 
-// GetAvailableCourseworks возвращает все доступные
+// GetAvailableCourseworks возвращает доступные темы текущего семестра по дисциплинам
+// учебного плана группы студента
 func (m *CourseworkManagerImpl) GetAvailableCourseworks(ctx context.Context, studentID uint) ([]models.Coursework, error) {
-	list, err := m.cwRepo.List(ctx, 0, 0)
+	termID, err := resolveTermID(ctx, m.termRepo, 0)
 	if err != nil {
 		return nil, err
 	}
-	var available []models.Coursework
-	for _, cw := range list {
-		if cw.IsAvailable {
-			available = append(available, cw)
-		}
+	subjectIDs, err := m.curriculum.GetStudentSubjectIDs(ctx, studentID, termID)
+	if err != nil {
+		return nil, err
 	}
-	return available, nil
+
+	available := true
+	list, _, err := m.ListCourseworks(ctx, interfaces.ListCourseworksRequest{
+		Available:  &available,
+		SubjectIDs: subjectIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
 }
 
 // SetCourseworkAvailability задаёт доступность
 func (m *CourseworkManagerImpl) SetCourseworkAvailability(ctx context.Context, cwID uint, available bool) error {
-	return m.cwRepo.SetAvailable(ctx, cwID, available)
+	if err := m.cwRepo.SetAvailable(ctx, cwID, available); err != nil {
+		return err
+	}
+	if available {
+		return m.waitlist.PromoteNext(ctx, cwID)
+	}
+	m.realtime.PublishSlots(ctx, cwID)
+	return nil
 }
 
 // CanAssignStudentToCoursework проверяет, можно ли назначить студента
@@ -144,3 +204,17 @@ func (m *CourseworkManagerImpl) CanAssignStudentToCoursework(ctx context.Context
 	}
 	return nil
 }
+
+// IsLeadTeacher проверяет, является ли пользователь ведущим преподавателем дисциплины
+// курсовой работы в учебном году её семестра - такой преподаватель модерирует все темы дисциплины
+func (m *CourseworkManagerImpl) IsLeadTeacher(ctx context.Context, userID uint, cw *models.Coursework) (bool, error) {
+	year := ""
+	if cw.TermID != 0 {
+		term, err := m.termRepo.GetByID(ctx, cw.TermID)
+		if err != nil {
+			return false, err
+		}
+		year = term.AcademicYear.Name
+	}
+	return m.assignRepo.IsLead(ctx, userID, cw.SubjectID, year)
+}
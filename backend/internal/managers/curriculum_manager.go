@@ -0,0 +1,134 @@
+package managers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// CurriculumManagerImpl реализует interfaces.CurriculumManager
+type CurriculumManagerImpl struct {
+	curriculumRepo interfaces.GroupSubjectRepository
+	groupRepo      interfaces.StudentGroupRepository
+	profileRepo    interfaces.StudentProfileRepository
+	subjRepo       interfaces.SubjectRepository
+	termRepo       interfaces.TermRepository
+}
+
+// NewCurriculumManager создаёт новый CurriculumManager
+func NewCurriculumManager(
+	curriculumRepo interfaces.GroupSubjectRepository,
+	groupRepo interfaces.StudentGroupRepository,
+	profileRepo interfaces.StudentProfileRepository,
+	subjRepo interfaces.SubjectRepository,
+	termRepo interfaces.TermRepository,
+) interfaces.CurriculumManager {
+	return &CurriculumManagerImpl{
+		curriculumRepo: curriculumRepo,
+		groupRepo:      groupRepo,
+		profileRepo:    profileRepo,
+		subjRepo:       subjRepo,
+		termRepo:       termRepo,
+	}
+}
+
+// AddGroupSubject включает дисциплину в учебный план группы
+func (m *CurriculumManagerImpl) AddGroupSubject(ctx context.Context, groupID uint, req interfaces.AddGroupSubjectRequest) (*models.GroupSubject, error) {
+	if _, err := m.groupRepo.GetByID(ctx, groupID); err != nil {
+		return nil, err
+	}
+	termID, err := m.subjectTerm(ctx, req.SubjectID, req.TermID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &models.GroupSubject{
+		GroupID:   groupID,
+		SubjectID: req.SubjectID,
+		TermID:    termID,
+	}
+	if err := m.curriculumRepo.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// RemoveGroupSubject исключает дисциплину из учебного плана группы
+func (m *CurriculumManagerImpl) RemoveGroupSubject(ctx context.Context, groupID, subjectID, termID uint) error {
+	termID, err := m.subjectTerm(ctx, subjectID, termID)
+	if err != nil {
+		return err
+	}
+	return m.curriculumRepo.Delete(ctx, groupID, subjectID, termID)
+}
+
+// GetGroupSubjects возвращает учебный план группы; по умолчанию - на текущий семестр
+func (m *CurriculumManagerImpl) GetGroupSubjects(ctx context.Context, groupID uint, termID *uint, allTerms bool) ([]models.GroupSubject, error) {
+	if allTerms {
+		return m.curriculumRepo.GetByGroup(ctx, groupID, nil)
+	}
+	if termID == nil {
+		active, err := resolveTermID(ctx, m.termRepo, 0)
+		if err != nil {
+			return nil, err
+		}
+		termID = &active
+	}
+	return m.curriculumRepo.GetByGroup(ctx, groupID, termID)
+}
+
+// GetStudentSubjectIDs определяет дисциплины, темы которых может выбирать студент в семестре.
+// Если для группы составлен учебный план на семестр - берётся он, иначе дисциплины
+// курса группы, читаемые в этом семестре. Студент без профиля не привязан к группе,
+// и доступных дисциплин у него нет.
+func (m *CurriculumManagerImpl) GetStudentSubjectIDs(ctx context.Context, studentID, termID uint) ([]uint, error) {
+	profile, err := m.profileRepo.GetByUserID(ctx, studentID)
+	if errors.Is(err, interfaces.ErrNotFound) {
+		return []uint{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := m.curriculumRepo.GetSubjectIDs(ctx, profile.GroupID, termID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) > 0 {
+		return ids, nil
+	}
+
+	termNumber := 0
+	if termID != 0 {
+		term, err := m.termRepo.GetByID(ctx, termID)
+		if err != nil {
+			return nil, err
+		}
+		termNumber = term.Number
+	}
+	ids = []uint{}
+	for _, semester := range models.SemestersOfCourseYear(profile.StudentGroup.CourseYear, termNumber) {
+		subjects, err := m.subjRepo.GetBySemester(ctx, semester)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range subjects {
+			ids = append(ids, s.ID)
+		}
+	}
+	return ids, nil
+}
+
+// subjectTerm возвращает указанный семестр или семестр текущего учебного года, в котором читается дисциплина
+func (m *CurriculumManagerImpl) subjectTerm(ctx context.Context, subjectID, termID uint) (uint, error) {
+	subj, err := m.subjRepo.GetByID(ctx, subjectID)
+	if err != nil {
+		return 0, err
+	}
+	if termID != 0 {
+		return resolveTermID(ctx, m.termRepo, termID)
+	}
+	return resolveYearTerm(ctx, m.termRepo, "", subj.Semester)
+}
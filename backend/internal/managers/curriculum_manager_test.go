@@ -0,0 +1,53 @@
+package managers
+
+import (
+	"context"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"github.com/Foxpunk/courseforge/internal/drivers"
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"github.com/Foxpunk/courseforge/internal/testutil"
+)
+
+func newTestCurriculumManager(db *gorm.DB) interfaces.CurriculumManager {
+	return NewCurriculumManager(drivers.NewGroupSubjectRepository(db), drivers.NewStudentGroupRepository(db),
+		drivers.NewStudentProfileRepository(db), drivers.NewSubjectRepository(db), drivers.NewTermRepository(db))
+}
+
+func TestGetStudentSubjectIDsWithoutProfile(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	term := testutil.CreateActiveTerm(t, db)
+	testutil.MustCreate(t, db, &models.Subject{Name: "Базы данных", Code: "DB", Semester: 5, IsActive: true})
+	student := testutil.CreateUser(t, db, models.RoleStudent, "student@example.com")
+
+	ids, err := newTestCurriculumManager(db).GetStudentSubjectIDs(ctx, student.ID, term.ID)
+	if err != nil {
+		t.Fatalf("GetStudentSubjectIDs: %v", err)
+	}
+	// nil снял бы фильтр по дисциплинам, а студенту без группы темы не положены
+	if ids == nil || len(ids) != 0 {
+		t.Errorf("subject IDs = %#v, want an empty non-nil slice", ids)
+	}
+}
+
+func TestGetStudentSubjectIDsDatabaseError(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	student := testutil.CreateUser(t, db, models.RoleStudent, "student@example.com")
+	manager := newTestCurriculumManager(db)
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.Close()
+
+	ids, err := manager.GetStudentSubjectIDs(ctx, student.ID, 0)
+	if err == nil {
+		t.Fatalf("GetStudentSubjectIDs on a closed database = %v, want an error", ids)
+	}
+}
@@ -0,0 +1,228 @@
+package managers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/config"
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// deadlineTimeFormat - формат сроков в напоминаниях и ответах бота
+const deadlineTimeFormat = "02.01.2006 15:04"
+
+// DeadlineManagerImpl реализует interfaces.DeadlineManager
+type DeadlineManagerImpl struct {
+	defenseManager    interfaces.DefenseManager
+	slotRepo          interfaces.DefenseSlotRepository
+	waitlistRepo      interfaces.WaitlistRepository
+	roundRepo         interfaces.SelectionRoundRepository
+	termRepo          interfaces.TermRepository
+	curriculumManager interfaces.CurriculumManager
+	userRepo          interfaces.UserRepository
+	notifRepo         interfaces.NotificationRepository
+	notifier          interfaces.Notifier
+	cfg               config.DeadlineConfig
+}
+
+// NewDeadlineManager создаёт новый DeadlineManager
+func NewDeadlineManager(
+	defenseManager interfaces.DefenseManager,
+	slotRepo interfaces.DefenseSlotRepository,
+	waitlistRepo interfaces.WaitlistRepository,
+	roundRepo interfaces.SelectionRoundRepository,
+	termRepo interfaces.TermRepository,
+	curriculumManager interfaces.CurriculumManager,
+	userRepo interfaces.UserRepository,
+	notifRepo interfaces.NotificationRepository,
+	notifier interfaces.Notifier,
+	cfg config.DeadlineConfig,
+) interfaces.DeadlineManager {
+	return &DeadlineManagerImpl{
+		defenseManager:    defenseManager,
+		slotRepo:          slotRepo,
+		waitlistRepo:      waitlistRepo,
+		roundRepo:         roundRepo,
+		termRepo:          termRepo,
+		curriculumManager: curriculumManager,
+		userRepo:          userRepo,
+		notifRepo:         notifRepo,
+		notifier:          notifier,
+		cfg:               cfg,
+	}
+}
+
+// ListDeadlines собирает предстоящие сроки: защиты из расписания, а для студента ещё
+// неподтверждённые предложения из листов ожидания и раунды выбора тем по его дисциплинам
+func (m *DeadlineManagerImpl) ListDeadlines(ctx context.Context, userID uint, now time.Time) ([]interfaces.Deadline, error) {
+	user, err := m.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := m.defenseManager.GetUserSchedule(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	deadlines := []interfaces.Deadline{}
+	for _, e := range schedule {
+		if e.StartsAt.After(now) {
+			deadlines = append(deadlines, interfaces.Deadline{Kind: e.Kind, Title: e.Title, Location: e.Location, DueAt: e.StartsAt})
+		}
+	}
+
+	if user.IsStudent() {
+		entries, err := m.waitlistRepo.GetByStudent(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if e.Status == models.WaitlistOffered && e.OfferExpiresAt != nil && e.OfferExpiresAt.After(now) {
+				deadlines = append(deadlines, interfaces.Deadline{
+					Kind:  "waitlist_offer",
+					Title: fmt.Sprintf("Подтвердить место по теме «%s»", e.Coursework.Title),
+					DueAt: *e.OfferExpiresAt,
+				})
+			}
+		}
+
+		rounds, err := m.studentRounds(ctx, userID, now)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rounds {
+			deadlines = append(deadlines, interfaces.Deadline{
+				Kind:  "selection_round",
+				Title: "Выбор тем: " + r.Title,
+				DueAt: *r.ClosesAt,
+			})
+		}
+	}
+
+	sort.SliceStable(deadlines, func(i, j int) bool {
+		return deadlines[i].DueAt.Before(deadlines[j].DueAt)
+	})
+	return deadlines, nil
+}
+
+// studentRounds возвращает открытые раунды с назначенным сроком по дисциплинам студента
+func (m *DeadlineManagerImpl) studentRounds(ctx context.Context, studentID uint, now time.Time) ([]models.SelectionRound, error) {
+	var termID uint
+	if term, err := m.termRepo.GetActive(ctx); err == nil {
+		termID = term.ID
+	}
+	subjectIDs, err := m.curriculumManager.GetStudentSubjectIDs(ctx, studentID, termID)
+	if err != nil {
+		return nil, err
+	}
+	allowed := make(map[uint]bool, len(subjectIDs))
+	for _, id := range subjectIDs {
+		allowed[id] = true
+	}
+
+	rounds, err := m.roundRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var result []models.SelectionRound
+	for _, r := range rounds {
+		if r.Status != models.RoundOpen || r.ClosesAt == nil || !r.ClosesAt.After(now) {
+			continue
+		}
+		if subjectIDs != nil && !allowed[r.SubjectID] {
+			continue
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// SendReminders напоминает о защитах (студенту и руководителю) и о неподтверждённых
+// предложениях из листов ожидания, если до срока осталось меньше RemindBefore
+func (m *DeadlineManagerImpl) SendReminders(ctx context.Context, now time.Time) (int, error) {
+	until := now.Add(m.cfg.RemindBefore)
+	sent := 0
+
+	slots, err := m.slotRepo.GetAssignedBetween(ctx, now, until)
+	if err != nil {
+		return 0, err
+	}
+	for _, slot := range slots {
+		sc := slot.StudentCoursework
+		if sc == nil {
+			continue
+		}
+		when := fmt.Sprintf("%s, %s", slot.StartsAt.Local().Format(deadlineTimeFormat), roomLocation(slot.Session.Room))
+		events := []interfaces.Event{
+			{
+				RecipientID: sc.StudentID,
+				Title:       "Скоро защита",
+				Message:     fmt.Sprintf("Защита курсовой работы «%s»: %s.", sc.Coursework.Title, when),
+			},
+			{
+				RecipientID: sc.Coursework.TeacherID,
+				Title:       "Скоро защита студента",
+				Message:     fmt.Sprintf("Защита студента %s по теме «%s»: %s.", sc.Student.GetFullName(), sc.Coursework.Title, when),
+			},
+		}
+		for _, event := range events {
+			event.Type = models.EventDeadlineApproaching
+			event.EntityType = models.EntityDefense
+			event.EntityID = slot.ID
+			ok, err := m.remind(ctx, event, now)
+			if err != nil {
+				return sent, err
+			}
+			if ok {
+				sent++
+			}
+		}
+	}
+
+	offers, err := m.waitlistRepo.GetOffersExpiringBetween(ctx, now, until)
+	if err != nil {
+		return sent, err
+	}
+	for _, entry := range offers {
+		ok, err := m.remind(ctx, interfaces.Event{
+			Type:        models.EventDeadlineApproaching,
+			RecipientID: entry.StudentID,
+			EntityType:  models.EntityWaitlist,
+			EntityID:    entry.ID,
+			Title:       "Истекает срок предложения",
+			Message: fmt.Sprintf("Подтвердите место по теме «%s» до %s, иначе оно перейдёт следующему в очереди.",
+				entry.Coursework.Title, entry.OfferExpiresAt.Local().Format(deadlineTimeFormat)),
+		}, now)
+		if err != nil {
+			return sent, err
+		}
+		if ok {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// remind отправляет напоминание, если о сроке этому пользователю ещё не напоминали
+func (m *DeadlineManagerImpl) remind(ctx context.Context, event interfaces.Event, now time.Time) (bool, error) {
+	if event.RecipientID == 0 {
+		return false, nil
+	}
+	first, err := m.notifRepo.MarkReminded(ctx, &models.DeadlineReminder{
+		UserID:     event.RecipientID,
+		EntityType: event.EntityType,
+		EntityID:   event.EntityID,
+		SentAt:     now,
+	})
+	if err != nil || !first {
+		return false, err
+	}
+	if err := m.notifier.Notify(ctx, event); err != nil {
+		log.Printf("failed to notify user %d: %v", event.RecipientID, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,529 @@
+package managers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// timeRange - полуоткрытый интервал [Start, End)
+type timeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (t timeRange) overlaps(start, end time.Time) bool {
+	return t.Start.Before(end) && start.Before(t.End)
+}
+
+func overlapsAny(ranges []timeRange, start, end time.Time) bool {
+	for _, r := range ranges {
+		if r.overlaps(start, end) {
+			return true
+		}
+	}
+	return false
+}
+
+// DefenseManagerImpl реализует interfaces.DefenseManager
+type DefenseManagerImpl struct {
+	roomRepo       interfaces.DefenseRoomRepository
+	sessionRepo    interfaces.DefenseSessionRepository
+	slotRepo       interfaces.DefenseSlotRepository
+	scRepo         interfaces.StudentCourseworkRepository
+	userRepo       interfaces.UserRepository
+	termRepo       interfaces.TermRepository
+	uow            interfaces.UnitOfWork
+	calendarSecret []byte
+}
+
+// NewDefenseManager создаёт новый DefenseManager
+func NewDefenseManager(
+	roomRepo interfaces.DefenseRoomRepository,
+	sessionRepo interfaces.DefenseSessionRepository,
+	slotRepo interfaces.DefenseSlotRepository,
+	scRepo interfaces.StudentCourseworkRepository,
+	userRepo interfaces.UserRepository,
+	termRepo interfaces.TermRepository,
+	uow interfaces.UnitOfWork,
+	calendarSecret string,
+) interfaces.DefenseManager {
+	return &DefenseManagerImpl{
+		roomRepo:       roomRepo,
+		sessionRepo:    sessionRepo,
+		slotRepo:       slotRepo,
+		scRepo:         scRepo,
+		userRepo:       userRepo,
+		termRepo:       termRepo,
+		uow:            uow,
+		calendarSecret: []byte(calendarSecret),
+	}
+}
+
+// CreateRoom создаёт аудиторию
+func (m *DefenseManagerImpl) CreateRoom(ctx context.Context, req interfaces.CreateDefenseRoomRequest) (*models.DefenseRoom, error) {
+	room := &models.DefenseRoom{
+		Name:     req.Name,
+		Building: req.Building,
+		Capacity: req.Capacity,
+	}
+	if err := m.roomRepo.Create(ctx, room); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// ListRooms возвращает все аудитории
+func (m *DefenseManagerImpl) ListRooms(ctx context.Context) ([]models.DefenseRoom, error) {
+	return m.roomRepo.List(ctx)
+}
+
+// DeleteRoom удаляет аудиторию
+func (m *DefenseManagerImpl) DeleteRoom(ctx context.Context, roomID uint) error {
+	return m.roomRepo.Delete(ctx, roomID)
+}
+
+// CreateSession создаёт сессию защит, проверяет конфликты и нарезает слоты
+func (m *DefenseManagerImpl) CreateSession(ctx context.Context, req interfaces.CreateDefenseSessionRequest) (*models.DefenseSession, error) {
+	if !req.StartsAt.Before(req.EndsAt) {
+		return nil, errors.New("session must start before it ends")
+	}
+	if req.SlotMinutes <= 0 {
+		return nil, errors.New("slot duration must be positive")
+	}
+	slotLen := time.Duration(req.SlotMinutes) * time.Minute
+	if req.EndsAt.Sub(req.StartsAt) < slotLen {
+		return nil, errors.New("session is shorter than one slot")
+	}
+	if _, err := m.roomRepo.GetByID(ctx, req.RoomID); err != nil {
+		return nil, err
+	}
+	termID, err := resolveTermID(ctx, m.termRepo, req.TermID)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &models.DefenseSession{
+		Title:       req.Title,
+		SubjectID:   req.SubjectID,
+		RoomID:      req.RoomID,
+		StartsAt:    req.StartsAt,
+		EndsAt:      req.EndsAt,
+		SlotMinutes: req.SlotMinutes,
+		TermID:      termID,
+	}
+
+	// проверки конфликтов и создание сессии со слотами - одна транзакция, чтобы сбой
+	// не оставил сессию без слотов
+	err = m.uow.Do(ctx, func(ctx context.Context) error {
+		overlapping, err := m.sessionRepo.GetOverlapping(ctx, session.StartsAt, session.EndsAt)
+		if err != nil {
+			return err
+		}
+		for _, other := range overlapping {
+			if other.RoomID == session.RoomID {
+				return fmt.Errorf("room %s is already booked by session %d", other.Room.Name, other.ID)
+			}
+		}
+
+		members, err := m.buildCommittee(ctx, req.Committee)
+		if err != nil {
+			return err
+		}
+		if err := m.checkCommitteeConflicts(ctx, session, members); err != nil {
+			return err
+		}
+		session.Committee = members
+
+		if err := m.sessionRepo.Create(ctx, session); err != nil {
+			return err
+		}
+
+		var slots []models.DefenseSlot
+		for start := session.StartsAt; !start.Add(slotLen).After(session.EndsAt); start = start.Add(slotLen) {
+			slots = append(slots, models.DefenseSlot{
+				SessionID: session.ID,
+				StartsAt:  start,
+				EndsAt:    start.Add(slotLen),
+			})
+		}
+		return m.slotRepo.CreateBatch(ctx, slots)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return m.sessionRepo.GetByID(ctx, session.ID)
+}
+
+// GetSession возвращает сессию со слотами
+func (m *DefenseManagerImpl) GetSession(ctx context.Context, sessionID uint) (*models.DefenseSession, error) {
+	return m.sessionRepo.GetByID(ctx, sessionID)
+}
+
+// ListSessions возвращает все сессии
+func (m *DefenseManagerImpl) ListSessions(ctx context.Context) ([]models.DefenseSession, error) {
+	return m.sessionRepo.List(ctx)
+}
+
+// DeleteSession удаляет сессию вместе со слотами
+func (m *DefenseManagerImpl) DeleteSession(ctx context.Context, sessionID uint) error {
+	return m.sessionRepo.Delete(ctx, sessionID)
+}
+
+// SetCommittee заменяет состав комиссии с проверкой конфликтов
+func (m *DefenseManagerImpl) SetCommittee(ctx context.Context, sessionID uint, reqMembers []interfaces.CommitteeMemberRequest) error {
+	session, err := m.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	members, err := m.buildCommittee(ctx, reqMembers)
+	if err != nil {
+		return err
+	}
+	if err := m.checkCommitteeConflicts(ctx, session, members); err != nil {
+		return err
+	}
+	return m.sessionRepo.SetCommittee(ctx, sessionID, members)
+}
+
+// DistributeStudents распределяет студентов семестра сессии со статусом submitted/reviewed
+// по свободным слотам. Распределение идёт одной транзакцией: сбой откатывает все
+// назначенные слоты, и сессия не остаётся распределённой наполовину
+func (m *DefenseManagerImpl) DistributeStudents(ctx context.Context, sessionID uint) (*interfaces.DefenseDistributionResult, error) {
+	var result *interfaces.DefenseDistributionResult
+	err := m.uow.Do(ctx, func(ctx context.Context) error {
+		// сессия читается внутри транзакции, поэтому свободные слоты не займут параллельно
+		session, err := m.sessionRepo.GetByID(ctx, sessionID)
+		if err != nil {
+			return err
+		}
+		candidates, err := m.scRepo.GetBySubjectAndStatus(ctx, session.SubjectID, session.TermID, models.StatusSubmitted, models.StatusReviewed)
+		if err != nil {
+			return err
+		}
+		busy, err := m.teacherBusy(ctx, session)
+		if err != nil {
+			return err
+		}
+
+		result = &interfaces.DefenseDistributionResult{
+			SessionID:   session.ID,
+			Scheduled:   []interfaces.DefenseSlotAssignment{},
+			Unscheduled: []interfaces.DefenseSlotAssignment{},
+		}
+		used := make([]bool, len(session.Slots))
+		for i, slot := range session.Slots {
+			used[i] = !slot.IsFree()
+		}
+
+		for _, sc := range candidates {
+			if _, err := m.slotRepo.GetByAssignment(ctx, sc.ID); err == nil {
+				continue // уже стоит в расписании
+			}
+			studentBusy, err := m.studentBusy(ctx, sc.StudentID)
+			if err != nil {
+				return err
+			}
+
+			entry := interfaces.DefenseSlotAssignment{
+				AssignmentID: sc.ID,
+				StudentID:    sc.StudentID,
+				StudentName:  sc.Student.GetFullName(),
+			}
+			reason := "no free slots left"
+			for i := range session.Slots {
+				slot := &session.Slots[i]
+				if used[i] {
+					continue
+				}
+				if overlapsAny(busy[sc.Coursework.TeacherID], slot.StartsAt, slot.EndsAt) {
+					reason = "supervisor is busy during all free slots"
+					continue
+				}
+				if overlapsAny(studentBusy, slot.StartsAt, slot.EndsAt) {
+					reason = "student is busy during all free slots"
+					continue
+				}
+				if err := m.slotRepo.Assign(ctx, slot.ID, sc.ID); err != nil {
+					return err
+				}
+				used[i] = true
+				slotID, startsAt := slot.ID, slot.StartsAt
+				entry.SlotID = &slotID
+				entry.StartsAt = &startsAt
+				break
+			}
+
+			if entry.SlotID == nil {
+				entry.Reason = reason
+				result.Unscheduled = append(result.Unscheduled, entry)
+				continue
+			}
+			result.Scheduled = append(result.Scheduled, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// AssignSlot вручную ставит назначение студента в слот
+func (m *DefenseManagerImpl) AssignSlot(ctx context.Context, slotID, assignmentID uint) error {
+	slot, err := m.slotRepo.GetByID(ctx, slotID)
+	if err != nil {
+		return err
+	}
+	if !slot.IsFree() {
+		return errors.New("slot is already taken")
+	}
+	sc, err := m.scRepo.GetByID(ctx, assignmentID)
+	if err != nil {
+		return err
+	}
+	if sc.Status != models.StatusSubmitted && sc.Status != models.StatusReviewed {
+		return fmt.Errorf("coursework in status %q cannot be scheduled for defense", sc.Status)
+	}
+	if sc.Coursework.SubjectID != slot.Session.SubjectID {
+		return errors.New("coursework belongs to another subject")
+	}
+	if _, err := m.slotRepo.GetByAssignment(ctx, assignmentID); err == nil {
+		return errors.New("student is already scheduled for defense")
+	}
+
+	busy, err := m.teacherBusy(ctx, &slot.Session)
+	if err != nil {
+		return err
+	}
+	if overlapsAny(busy[sc.Coursework.TeacherID], slot.StartsAt, slot.EndsAt) {
+		return errors.New("supervisor is busy at that time")
+	}
+	studentBusy, err := m.studentBusy(ctx, sc.StudentID)
+	if err != nil {
+		return err
+	}
+	if overlapsAny(studentBusy, slot.StartsAt, slot.EndsAt) {
+		return errors.New("student is busy at that time")
+	}
+	return m.slotRepo.Assign(ctx, slotID, assignmentID)
+}
+
+// ReleaseSlot освобождает слот
+func (m *DefenseManagerImpl) ReleaseSlot(ctx context.Context, slotID uint) error {
+	return m.slotRepo.Release(ctx, slotID)
+}
+
+// GetUserSchedule возвращает расписание защит пользователя в зависимости от роли
+func (m *DefenseManagerImpl) GetUserSchedule(ctx context.Context, userID uint) ([]interfaces.DefenseScheduleEntry, error) {
+	user, err := m.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []interfaces.DefenseScheduleEntry{}
+	switch user.Role {
+	case models.RoleStudent:
+		slots, err := m.slotRepo.GetByStudent(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range slots {
+			entries = append(entries, slotEntry(s, "defense", "Защита: "+s.StudentCoursework.Coursework.Title))
+		}
+	case models.RoleTeacher:
+		sessions, err := m.sessionRepo.GetByCommitteeMember(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range sessions {
+			entries = append(entries, sessionEntry(s, "committee"))
+		}
+		slots, err := m.slotRepo.GetBySupervisor(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range slots {
+			entries = append(entries, slotEntry(s, "supervision", "Защита студента "+s.StudentCoursework.Student.GetFullName()))
+		}
+	case models.RoleAdmin:
+		sessions, err := m.sessionRepo.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range sessions {
+			entries = append(entries, sessionEntry(s, "session"))
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].StartsAt.Before(entries[j].StartsAt)
+	})
+	return entries, nil
+}
+
+// BuildCalendar формирует iCalendar-ленту расписания защит пользователя
+func (m *DefenseManagerImpl) BuildCalendar(ctx context.Context, userID uint, now time.Time) ([]byte, error) {
+	entries, err := m.GetUserSchedule(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]icalEvent, 0, len(entries))
+	for _, e := range entries {
+		uid := fmt.Sprintf("session-%d-%s@courseforge", e.SessionID, e.Kind)
+		if e.SlotID != nil {
+			uid = fmt.Sprintf("slot-%d-%s@courseforge", *e.SlotID, e.Kind)
+		}
+		events = append(events, icalEvent{
+			UID:      uid,
+			Summary:  e.Title,
+			Location: e.Location,
+			Start:    e.StartsAt,
+			End:      e.EndsAt,
+		})
+	}
+	return renderICalendar("CourseForge — защиты", events, now), nil
+}
+
+// CalendarToken возвращает подпись для публичной ссылки на календарь пользователя
+func (m *DefenseManagerImpl) CalendarToken(userID uint) string {
+	mac := hmac.New(sha256.New, m.calendarSecret)
+	fmt.Fprintf(mac, "calendar:%d", userID)
+	return hex.EncodeToString(mac.Sum(nil))[:32]
+}
+
+// VerifyCalendarToken проверяет подпись ссылки на календарь
+func (m *DefenseManagerImpl) VerifyCalendarToken(userID uint, token string) bool {
+	return hmac.Equal([]byte(m.CalendarToken(userID)), []byte(token))
+}
+
+// buildCommittee проверяет состав комиссии и собирает модели
+func (m *DefenseManagerImpl) buildCommittee(ctx context.Context, req []interfaces.CommitteeMemberRequest) ([]models.DefenseCommitteeMember, error) {
+	members := make([]models.DefenseCommitteeMember, 0, len(req))
+	seen := make(map[uint]bool, len(req))
+	chairs := 0
+	for _, r := range req {
+		if seen[r.TeacherID] {
+			return nil, fmt.Errorf("teacher %d is listed twice", r.TeacherID)
+		}
+		seen[r.TeacherID] = true
+
+		teacher, err := m.userRepo.GetByID(ctx, r.TeacherID)
+		if err != nil {
+			return nil, err
+		}
+		if !teacher.IsTeacher() {
+			return nil, fmt.Errorf("user %d is not a teacher", r.TeacherID)
+		}
+
+		role := r.Role
+		if role == "" {
+			role = models.CommitteeMember
+		}
+		if role == models.CommitteeChair {
+			chairs++
+		}
+		members = append(members, models.DefenseCommitteeMember{TeacherID: r.TeacherID, Role: role})
+	}
+	if chairs > 1 {
+		return nil, errors.New("committee can have only one chair")
+	}
+	return members, nil
+}
+
+// checkCommitteeConflicts проверяет, что члены комиссии свободны на время сессии
+func (m *DefenseManagerImpl) checkCommitteeConflicts(ctx context.Context, session *models.DefenseSession, members []models.DefenseCommitteeMember) error {
+	busy, err := m.teacherBusy(ctx, session)
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		if overlapsAny(busy[member.TeacherID], session.StartsAt, session.EndsAt) {
+			return fmt.Errorf("teacher %d is busy in another defense session at that time", member.TeacherID)
+		}
+	}
+	return nil
+}
+
+// teacherBusy собирает занятость преподавателей в других сессиях, пересекающихся с данной:
+// участие в комиссии занимает всю сессию, защита подопечного - свой слот
+func (m *DefenseManagerImpl) teacherBusy(ctx context.Context, session *models.DefenseSession) (map[uint][]timeRange, error) {
+	overlapping, err := m.sessionRepo.GetOverlapping(ctx, session.StartsAt, session.EndsAt)
+	if err != nil {
+		return nil, err
+	}
+	busy := make(map[uint][]timeRange)
+	for _, other := range overlapping {
+		if other.ID == session.ID {
+			continue
+		}
+		for _, member := range other.Committee {
+			busy[member.TeacherID] = append(busy[member.TeacherID], timeRange{other.StartsAt, other.EndsAt})
+		}
+		slots, err := m.slotRepo.GetBySession(ctx, other.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, slot := range slots {
+			if slot.StudentCoursework == nil {
+				continue
+			}
+			supervisorID := slot.StudentCoursework.Coursework.TeacherID
+			busy[supervisorID] = append(busy[supervisorID], timeRange{slot.StartsAt, slot.EndsAt})
+		}
+	}
+	return busy, nil
+}
+
+// studentBusy собирает уже назначенные защиты студента
+func (m *DefenseManagerImpl) studentBusy(ctx context.Context, studentID uint) ([]timeRange, error) {
+	slots, err := m.slotRepo.GetByStudent(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	ranges := make([]timeRange, 0, len(slots))
+	for _, s := range slots {
+		ranges = append(ranges, timeRange{s.StartsAt, s.EndsAt})
+	}
+	return ranges, nil
+}
+
+func roomLocation(room models.DefenseRoom) string {
+	if room.Building == "" {
+		return room.Name
+	}
+	return room.Name + ", " + room.Building
+}
+
+func slotEntry(s models.DefenseSlot, kind, title string) interfaces.DefenseScheduleEntry {
+	slotID := s.ID
+	return interfaces.DefenseScheduleEntry{
+		SessionID: s.SessionID,
+		SlotID:    &slotID,
+		Kind:      kind,
+		Title:     title,
+		Location:  roomLocation(s.Session.Room),
+		StartsAt:  s.StartsAt,
+		EndsAt:    s.EndsAt,
+	}
+}
+
+func sessionEntry(s models.DefenseSession, kind string) interfaces.DefenseScheduleEntry {
+	return interfaces.DefenseScheduleEntry{
+		SessionID: s.ID,
+		Kind:      kind,
+		Title:     s.Title,
+		Location:  roomLocation(s.Room),
+		StartsAt:  s.StartsAt,
+		EndsAt:    s.EndsAt,
+	}
+}
@@ -72,8 +72,15 @@ func (m *DepartmentManagerImpl) UpdateDepartment(
 	return dept, nil
 }
 
-// DeleteDepartment удаляет кафедру
+// DeleteDepartment удаляет кафедру, если к ней не привязаны преподаватели и группы
 func (m *DepartmentManagerImpl) DeleteDepartment(ctx context.Context, departmentID uint) error {
+	dept, err := m.deptRepo.GetByID(ctx, departmentID)
+	if err != nil {
+		return err
+	}
+	if len(dept.TeacherProfiles) > 0 || len(dept.StudentGroups) > 0 {
+		return errors.New("department still has teachers or student groups")
+	}
 	return m.deptRepo.Delete(ctx, departmentID)
 }
 
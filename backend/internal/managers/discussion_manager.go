@@ -0,0 +1,427 @@
+package managers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+const (
+	maxCommentAttachments = 10
+	commentKeyPrefix      = "comments"
+	notifySnippetLength   = 200 // символов сообщения в уведомлении
+)
+
+// DiscussionManagerImpl реализует interfaces.DiscussionManager
+type DiscussionManagerImpl struct {
+	repo              interfaces.DiscussionRepository
+	cwRepo            interfaces.CourseworkRepository
+	scRepo            interfaces.StudentCourseworkRepository
+	userRepo          interfaces.UserRepository
+	courseworkManager interfaces.CourseworkManager
+	storage           interfaces.FileStorage
+	notifier          interfaces.Notifier
+}
+
+// NewDiscussionManager создаёт новый DiscussionManager
+func NewDiscussionManager(
+	repo interfaces.DiscussionRepository,
+	cwRepo interfaces.CourseworkRepository,
+	scRepo interfaces.StudentCourseworkRepository,
+	userRepo interfaces.UserRepository,
+	courseworkManager interfaces.CourseworkManager,
+	storage interfaces.FileStorage,
+	notifier interfaces.Notifier,
+) interfaces.DiscussionManager {
+	return &DiscussionManagerImpl{
+		repo:              repo,
+		cwRepo:            cwRepo,
+		scRepo:            scRepo,
+		userRepo:          userRepo,
+		courseworkManager: courseworkManager,
+		storage:           storage,
+		notifier:          notifier,
+	}
+}
+
+// GetCourseworkThread возвращает открытое обсуждение темы
+func (m *DiscussionManagerImpl) GetCourseworkThread(ctx context.Context, courseworkID uint) (*models.DiscussionThread, error) {
+	cw, err := m.cwRepo.GetByID(ctx, courseworkID)
+	if err != nil {
+		return nil, err
+	}
+	return m.repo.GetOrCreateThread(ctx, models.ThreadCoursework, cw.ID, 0)
+}
+
+// GetAssignmentThread возвращает переписку студента с руководителем по назначению
+func (m *DiscussionManagerImpl) GetAssignmentThread(ctx context.Context, assignmentID uint) (*models.DiscussionThread, error) {
+	sc, err := m.scRepo.GetByID(ctx, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	return m.repo.GetOrCreateThread(ctx, models.ThreadAssignment, sc.CourseworkID, sc.ID)
+}
+
+// GetThread возвращает обсуждение
+func (m *DiscussionManagerImpl) GetThread(ctx context.Context, threadID uint) (*models.DiscussionThread, error) {
+	return m.repo.GetThread(ctx, threadID)
+}
+
+// CanViewThread проверяет доступ к обсуждению: открытые обсуждения тем видны всем,
+// переписку по назначению видят студент, руководители темы и админ
+func (m *DiscussionManagerImpl) CanViewThread(ctx context.Context, userID uint, thread *models.DiscussionThread) (bool, error) {
+	if thread.Kind == models.ThreadCoursework {
+		return true, nil
+	}
+	sc, err := m.scRepo.GetByID(ctx, thread.StudentCourseworkID)
+	if err == nil && sc.StudentID == userID {
+		return true, nil
+	}
+	return m.CanModerateThread(ctx, userID, thread)
+}
+
+// CanModerateThread - руководитель темы, ведущий преподаватель дисциплины или админ
+func (m *DiscussionManagerImpl) CanModerateThread(ctx context.Context, userID uint, thread *models.DiscussionThread) (bool, error) {
+	if thread.Coursework.TeacherID == userID {
+		return true, nil
+	}
+	user, err := m.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if user.IsAdmin() {
+		return true, nil
+	}
+	return m.courseworkManager.IsLeadTeacher(ctx, userID, &thread.Coursework)
+}
+
+// ListComments возвращает сообщения обсуждения
+func (m *DiscussionManagerImpl) ListComments(ctx context.Context, threadID uint) ([]models.Comment, error) {
+	return m.repo.ListComments(ctx, threadID)
+}
+
+// GetComment возвращает сообщение
+func (m *DiscussionManagerImpl) GetComment(ctx context.Context, commentID uint) (*models.Comment, error) {
+	return m.repo.GetComment(ctx, commentID)
+}
+
+// AddComment публикует сообщение и уведомляет участников обсуждения и упомянутых
+func (m *DiscussionManagerImpl) AddComment(ctx context.Context, threadID, authorID uint, req interfaces.CreateCommentRequest) (*models.Comment, error) {
+	thread, err := m.repo.GetThread(ctx, threadID)
+	if err != nil {
+		return nil, err
+	}
+
+	var parent *models.Comment
+	if req.ParentID != nil {
+		parent, err = m.repo.GetComment(ctx, *req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.ThreadID != thread.ID {
+			return nil, errors.New("parent comment belongs to another thread")
+		}
+	}
+
+	mentions, bodyHTML, err := m.renderBody(ctx, thread, req.Body)
+	if err != nil {
+		return nil, err
+	}
+	comment := &models.Comment{
+		ThreadID: thread.ID,
+		ParentID: req.ParentID,
+		AuthorID: authorID,
+		Body:     req.Body,
+		BodyHTML: bodyHTML,
+		Mentions: mentions,
+	}
+	if err := m.repo.CreateComment(ctx, comment); err != nil {
+		return nil, err
+	}
+	created, err := m.repo.GetComment(ctx, comment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	mentioned := make(map[uint]bool)
+	for _, mention := range mentions {
+		mentioned[mention.UserID] = true
+	}
+	recipients, err := m.participants(ctx, thread)
+	if err != nil {
+		return nil, err
+	}
+	if parent != nil {
+		recipients = append(recipients, parent.AuthorID)
+	}
+	notified := map[uint]bool{authorID: true}
+	for _, userID := range recipients {
+		if notified[userID] || mentioned[userID] {
+			continue
+		}
+		notified[userID] = true
+		m.notify(ctx, commentEvent(models.EventCommentCreated, userID, thread, created))
+	}
+	for userID := range mentioned {
+		if userID != authorID {
+			m.notify(ctx, commentEvent(models.EventCommentMention, userID, thread, created))
+		}
+	}
+	return created, nil
+}
+
+// EditComment меняет текст сообщения, сохраняя прежний в истории правок
+func (m *DiscussionManagerImpl) EditComment(ctx context.Context, commentID, editorID uint, req interfaces.UpdateCommentRequest) (*models.Comment, error) {
+	comment, err := m.repo.GetComment(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Body == req.Body {
+		return comment, nil
+	}
+	thread, err := m.repo.GetThread(ctx, comment.ThreadID)
+	if err != nil {
+		return nil, err
+	}
+
+	mentions, bodyHTML, err := m.renderBody(ctx, thread, req.Body)
+	if err != nil {
+		return nil, err
+	}
+	previous := make(map[uint]bool)
+	for _, mention := range comment.Mentions {
+		previous[mention.UserID] = true
+	}
+
+	now := time.Now()
+	revision := &models.CommentRevision{
+		CommentID: comment.ID,
+		Body:      comment.Body,
+		EditorID:  editorID,
+	}
+	comment.Body = req.Body
+	comment.BodyHTML = bodyHTML
+	comment.EditedAt = &now
+	comment.Mentions = mentions
+	if err := m.repo.UpdateComment(ctx, comment, revision); err != nil {
+		return nil, err
+	}
+	updated, err := m.repo.GetComment(ctx, comment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Уведомляем только тех, кого упомянули при правке
+	for _, mention := range mentions {
+		if !previous[mention.UserID] && mention.UserID != updated.AuthorID {
+			m.notify(ctx, commentEvent(models.EventCommentMention, mention.UserID, thread, updated))
+		}
+	}
+	return updated, nil
+}
+
+// DeleteComment удаляет сообщение; ответы на него остаются в ветке
+func (m *DiscussionManagerImpl) DeleteComment(ctx context.Context, commentID uint) error {
+	return m.repo.DeleteComment(ctx, commentID)
+}
+
+// GetCommentHistory возвращает прежние версии сообщения
+func (m *DiscussionManagerImpl) GetCommentHistory(ctx context.Context, commentID uint) ([]models.CommentRevision, error) {
+	return m.repo.GetRevisions(ctx, commentID)
+}
+
+// AddAttachment прикладывает файл к сообщению
+func (m *DiscussionManagerImpl) AddAttachment(ctx context.Context, commentID uint, fileName, contentType string, file io.Reader) (*models.CommentAttachment, error) {
+	comment, err := m.repo.GetComment(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	count, err := m.repo.CountAttachments(ctx, comment.ID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxCommentAttachments {
+		return nil, fmt.Errorf("a comment can have at most %d attachments", maxCommentAttachments)
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, errors.New("file is empty")
+	}
+	key, err := newStorageKey(commentKeyPrefix, comment.ThreadID, fileName)
+	if err != nil {
+		return nil, err
+	}
+	size, err := m.storage.Save(ctx, key, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := &models.CommentAttachment{
+		CommentID:   comment.ID,
+		FileName:    filepath.Base(fileName),
+		ContentType: contentType,
+		Size:        size,
+		StorageKey:  key,
+	}
+	if err := m.repo.AddAttachment(ctx, attachment); err != nil {
+		_ = m.storage.Delete(ctx, key)
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// GetAttachment возвращает приложенный файл
+func (m *DiscussionManagerImpl) GetAttachment(ctx context.Context, attachmentID uint) (*models.CommentAttachment, error) {
+	return m.repo.GetAttachment(ctx, attachmentID)
+}
+
+// OpenAttachment открывает приложенный файл
+func (m *DiscussionManagerImpl) OpenAttachment(ctx context.Context, attachment *models.CommentAttachment) (io.ReadCloser, error) {
+	return m.storage.Open(ctx, attachment.StorageKey)
+}
+
+// GetReadMark возвращает ID последнего прочитанного сообщения
+func (m *DiscussionManagerImpl) GetReadMark(ctx context.Context, threadID, userID uint) (uint, error) {
+	return m.repo.GetReadMark(ctx, threadID, userID)
+}
+
+// MarkRead отмечает обсуждение прочитанным до commentID (0 - до последнего сообщения)
+func (m *DiscussionManagerImpl) MarkRead(ctx context.Context, threadID, userID, commentID uint) error {
+	if commentID == 0 {
+		latest, err := m.repo.LatestCommentID(ctx, threadID)
+		if err != nil {
+			return err
+		}
+		if latest == 0 {
+			return nil
+		}
+		commentID = latest
+	} else {
+		comment, err := m.repo.GetComment(ctx, commentID)
+		if err != nil {
+			return err
+		}
+		if comment.ThreadID != threadID {
+			return errors.New("comment belongs to another thread")
+		}
+	}
+	return m.repo.MarkRead(ctx, threadID, userID, commentID)
+}
+
+// ListUnreadThreads возвращает обсуждения пользователя с непрочитанными сообщениями
+func (m *DiscussionManagerImpl) ListUnreadThreads(ctx context.Context, userID uint) ([]interfaces.UnreadThread, error) {
+	rows, err := m.repo.ListUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]interfaces.UnreadThread, 0, len(rows))
+	for _, row := range rows {
+		thread, err := m.repo.GetThread(ctx, row.ThreadID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, interfaces.UnreadThread{Thread: *thread, Unread: row.Unread})
+	}
+	return result, nil
+}
+
+// renderBody проверяет упоминания и переводит текст в HTML. Упомянуть можно только
+// того, кто видит обсуждение, иначе переписка по назначению раскроется посторонним.
+func (m *DiscussionManagerImpl) renderBody(ctx context.Context, thread *models.DiscussionThread, body string) ([]models.CommentMention, string, error) {
+	ids := parseMentions(body)
+	mentions := make([]models.CommentMention, 0, len(ids))
+	names := make(map[uint]string, len(ids))
+	for _, id := range ids {
+		user, err := m.userRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, "", fmt.Errorf("mentioned user %d not found", id)
+		}
+		canView, err := m.CanViewThread(ctx, id, thread)
+		if err != nil {
+			return nil, "", err
+		}
+		if !canView {
+			return nil, "", fmt.Errorf("user %d cannot see this thread and cannot be mentioned", id)
+		}
+		mentions = append(mentions, models.CommentMention{UserID: id})
+		names[id] = strings.TrimSpace(user.FirstName + " " + user.LastName)
+	}
+
+	bodyHTML, err := renderMarkdown(body, names)
+	if err != nil {
+		return nil, "", err
+	}
+	return mentions, bodyHTML, nil
+}
+
+// participants - кому сообщать о новых сообщениях: руководителю темы,
+// а в переписке по назначению - ещё и студенту
+func (m *DiscussionManagerImpl) participants(ctx context.Context, thread *models.DiscussionThread) ([]uint, error) {
+	users := []uint{thread.Coursework.TeacherID}
+	if thread.Kind == models.ThreadAssignment {
+		sc, err := m.scRepo.GetByID(ctx, thread.StudentCourseworkID)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, sc.StudentID)
+	}
+	return users, nil
+}
+
+func (m *DiscussionManagerImpl) notify(ctx context.Context, event interfaces.Event) {
+	if err := m.notifier.Notify(ctx, event); err != nil {
+		log.Printf("failed to notify user %d: %v", event.RecipientID, err)
+	}
+}
+
+// commentEvent - уведомление о сообщении с началом его текста
+func commentEvent(eventType models.EventType, recipientID uint, thread *models.DiscussionThread, c *models.Comment) interfaces.Event {
+	title := "Новое сообщение в обсуждении"
+	if eventType == models.EventCommentMention {
+		title = "Вас упомянули в обсуждении"
+	}
+	return interfaces.Event{
+		Type:        eventType,
+		RecipientID: recipientID,
+		ActorID:     c.AuthorID,
+		EntityType:  models.EntityThread,
+		EntityID:    thread.ID,
+		Title:       title,
+		Message:     commentNotice(thread, c),
+	}
+}
+
+// commentNotice - текст уведомления о сообщении с началом его текста
+func commentNotice(thread *models.DiscussionThread, c *models.Comment) string {
+	names := make(map[string]string, len(c.Mentions))
+	for _, mention := range c.Mentions {
+		names[fmt.Sprintf("<@%d>", mention.UserID)] = "@" + strings.TrimSpace(mention.User.FirstName+" "+mention.User.LastName)
+	}
+	snippet := []rune(mentionPattern.ReplaceAllStringFunc(c.Body, func(s string) string {
+		if name, ok := names[s]; ok {
+			return name
+		}
+		return s
+	}))
+	if len(snippet) > notifySnippetLength {
+		snippet = append(snippet[:notifySnippetLength], '…')
+	}
+	where := fmt.Sprintf("по теме «%s»", thread.Coursework.Title)
+	if thread.Kind == models.ThreadAssignment {
+		where = fmt.Sprintf("в переписке по курсовой работе «%s»", thread.Coursework.Title)
+	}
+	return fmt.Sprintf("%s %s пишет %s: %s", c.Author.FirstName, c.Author.LastName, where, string(snippet))
+}
@@ -0,0 +1,356 @@
+package managers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// DocumentManagerImpl реализует interfaces.DocumentManager
+type DocumentManagerImpl struct {
+	templateRepo interfaces.DocumentTemplateRepository
+	docRepo      interfaces.IssuedDocumentRepository
+	groupRepo    interfaces.StudentGroupRepository
+	userRepo     interfaces.UserRepository
+	termRepo     interfaces.TermRepository
+	gradebook    interfaces.GradebookManager
+}
+
+// NewDocumentManager создаёт новый DocumentManager
+func NewDocumentManager(
+	templateRepo interfaces.DocumentTemplateRepository,
+	docRepo interfaces.IssuedDocumentRepository,
+	groupRepo interfaces.StudentGroupRepository,
+	userRepo interfaces.UserRepository,
+	termRepo interfaces.TermRepository,
+	gradebook interfaces.GradebookManager,
+) interfaces.DocumentManager {
+	return &DocumentManagerImpl{
+		templateRepo: templateRepo,
+		docRepo:      docRepo,
+		groupRepo:    groupRepo,
+		userRepo:     userRepo,
+		termRepo:     termRepo,
+		gradebook:    gradebook,
+	}
+}
+
+// defaultTopicOrderTemplate - приказ о закреплении тем, пока администратор не завёл свой шаблон
+var defaultTopicOrderTemplate = models.DocumentTemplate{
+	Kind:         models.DocumentKindTopicOrder,
+	Name:         "Приказ о закреплении тем курсовых работ",
+	NumberPrefix: "КР-",
+	Header: `# ПРИКАЗ № {{.Number}}
+# о закреплении тем курсовых работ
+от {{.Date}}{{if gt .Version 1}} (редакция {{.Version}}){{end}}
+
+В соответствии с учебным планом{{with .Term}} ({{.}}){{end}} по дисциплине «{{.Subject}}»{{with .Group}} для студентов группы {{.}}{{end}}
+ПРИКАЗЫВАЮ закрепить за студентами темы курсовых работ и назначить руководителей согласно списку:`,
+	Footer: `Основание: результаты выбора тем курсовых работ. Всего студентов: {{.Count}}.
+
+Заведующий кафедрой{{with .Department}} «{{.}}»{{end}} _______________ {{.DepartmentHead}}`,
+}
+
+// topicOrderColumns - таблица приказа о закреплении тем
+var topicOrderColumns = []pdfColumn{
+	{"№", 10, "C"},
+	{"ФИО студента", 42, "L"},
+	{"Группа", 20, "C"},
+	{"Тема курсовой работы", 78, "L"},
+	{"Руководитель", 30, "L"},
+}
+
+// documentData - поля, доступные в шаблоне документа
+type documentData struct {
+	Number         string
+	Version        int
+	Date           string
+	AcademicYear   string
+	Term           string
+	Subject        string
+	SubjectCode    string
+	Group          string
+	Department     string
+	DepartmentHead string
+	Count          int
+	Rows           []interfaces.GradebookRow
+}
+
+// ListTemplates возвращает шаблоны документов вида
+func (m *DocumentManagerImpl) ListTemplates(ctx context.Context, kind models.DocumentKind) ([]models.DocumentTemplate, error) {
+	return m.templateRepo.List(ctx, kind)
+}
+
+// GetTemplate возвращает шаблон по ID
+func (m *DocumentManagerImpl) GetTemplate(ctx context.Context, templateID uint) (*models.DocumentTemplate, error) {
+	return m.templateRepo.GetByID(ctx, templateID)
+}
+
+// CreateTemplate сохраняет шаблон, предварительно проверив, что он собирается на тестовых данных
+func (m *DocumentManagerImpl) CreateTemplate(ctx context.Context, actorID uint, req interfaces.CreateDocumentTemplateRequest) (*models.DocumentTemplate, error) {
+	tpl := &models.DocumentTemplate{
+		Kind:         req.Kind,
+		Name:         req.Name,
+		NumberPrefix: req.NumberPrefix,
+		Header:       req.Header,
+		Footer:       req.Footer,
+		IsDefault:    req.IsDefault,
+		UpdatedByID:  actorID,
+	}
+	if err := checkTemplate(tpl); err != nil {
+		return nil, err
+	}
+	if err := m.templateRepo.Create(ctx, tpl); err != nil {
+		return nil, err
+	}
+	return tpl, nil
+}
+
+// UpdateTemplate изменяет шаблон; уже выпущенные документы не меняются
+func (m *DocumentManagerImpl) UpdateTemplate(ctx context.Context, actorID, templateID uint, req interfaces.UpdateDocumentTemplateRequest) (*models.DocumentTemplate, error) {
+	tpl, err := m.templateRepo.GetByID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		tpl.Name = *req.Name
+	}
+	if req.NumberPrefix != nil {
+		tpl.NumberPrefix = *req.NumberPrefix
+	}
+	if req.Header != nil {
+		tpl.Header = *req.Header
+	}
+	if req.Footer != nil {
+		tpl.Footer = *req.Footer
+	}
+	if req.IsDefault != nil {
+		tpl.IsDefault = *req.IsDefault
+	}
+	tpl.UpdatedByID = actorID
+
+	if err := checkTemplate(tpl); err != nil {
+		return nil, err
+	}
+	if err := m.templateRepo.Update(ctx, tpl); err != nil {
+		return nil, err
+	}
+	return tpl, nil
+}
+
+// DeleteTemplate удаляет шаблон
+func (m *DocumentManagerImpl) DeleteTemplate(ctx context.Context, templateID uint) error {
+	return m.templateRepo.Delete(ctx, templateID)
+}
+
+// IssueTopicOrder выпускает приказ о закреплении тем по дисциплине (и группе) за семестр.
+// В приказ попадают студенты с назначенной темой; повторный выпуск по тем же данным -
+// новая редакция под прежним номером.
+func (m *DocumentManagerImpl) IssueTopicOrder(ctx context.Context, actorID uint, req interfaces.IssueTopicOrderRequest) (*models.IssuedDocument, error) {
+	format := req.Format
+	if format == "" {
+		format = models.DocumentFormatDOCX
+	}
+	tpl, err := m.resolveTemplate(ctx, models.DocumentKindTopicOrder, req.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+
+	book, err := m.gradebook.GetGradebook(ctx, interfaces.GradebookRequest{
+		SubjectID: req.SubjectID,
+		GroupID:   req.GroupID,
+		TermID:    req.TermID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data := documentData{
+		Subject:     book.SubjectName,
+		SubjectCode: book.SubjectCode,
+		Term:        book.Term,
+		Group:       book.GroupCode,
+		Department:  book.Department,
+	}
+	for _, row := range book.Rows {
+		if row.CourseworkID == 0 {
+			continue
+		}
+		row.No = len(data.Rows) + 1
+		data.Rows = append(data.Rows, row)
+	}
+	if len(data.Rows) == 0 {
+		return nil, errors.New("no students with assigned topics for this order")
+	}
+	data.Count = len(data.Rows)
+	if book.TermID != 0 {
+		term, err := m.termRepo.GetByID(ctx, book.TermID)
+		if err != nil {
+			return nil, err
+		}
+		data.AcademicYear = term.AcademicYear.Name
+	}
+	if book.GroupID != 0 {
+		data.DepartmentHead = m.departmentHead(ctx, book.GroupID)
+	}
+
+	rows := make([][]string, len(data.Rows))
+	for i, row := range data.Rows {
+		rows[i] = []string{strconv.Itoa(row.No), row.StudentName, row.GroupCode, row.Topic, row.Supervisor}
+	}
+
+	doc := &models.IssuedDocument{
+		Kind:         models.DocumentKindTopicOrder,
+		AcademicYear: data.AcademicYear,
+		Format:       format,
+		TemplateID:   tpl.ID,
+		SubjectID:    book.SubjectID,
+		TermID:       book.TermID,
+		CreatedByID:  actorID,
+	}
+	if book.GroupID != 0 {
+		groupID := book.GroupID
+		doc.GroupID = &groupID
+	}
+
+	now := time.Now()
+	err = m.docRepo.Issue(ctx, doc, tpl.NumberPrefix, func(doc *models.IssuedDocument) error {
+		data.Number = doc.Number
+		data.Version = doc.Version
+		data.Date = now.Format("02.01.2006")
+
+		body, err := buildDocumentBody(tpl, data, topicOrderColumns, rows)
+		if err != nil {
+			return err
+		}
+		content, err := renderDocument(body, format)
+		if err != nil {
+			return err
+		}
+		doc.Content = content
+		doc.FileName = documentFileName(doc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m.docRepo.GetByID(ctx, doc.ID)
+}
+
+// ListDocuments возвращает реестр выпущенных документов
+func (m *DocumentManagerImpl) ListDocuments(ctx context.Context, kind models.DocumentKind, subjectID uint, withSuperseded bool) ([]models.IssuedDocument, error) {
+	return m.docRepo.List(ctx, kind, subjectID, withSuperseded)
+}
+
+// GetDocument возвращает документ вместе с файлом
+func (m *DocumentManagerImpl) GetDocument(ctx context.Context, documentID uint) (*models.IssuedDocument, error) {
+	return m.docRepo.GetByID(ctx, documentID)
+}
+
+// GetDocumentVersions возвращает все редакции документа
+func (m *DocumentManagerImpl) GetDocumentVersions(ctx context.Context, documentID uint) ([]models.IssuedDocument, error) {
+	return m.docRepo.GetVersions(ctx, documentID)
+}
+
+// resolveTemplate - указанный шаблон, шаблон по умолчанию или встроенный
+func (m *DocumentManagerImpl) resolveTemplate(ctx context.Context, kind models.DocumentKind, templateID uint) (*models.DocumentTemplate, error) {
+	if templateID != 0 {
+		tpl, err := m.templateRepo.GetByID(ctx, templateID)
+		if err != nil {
+			return nil, err
+		}
+		if tpl.Kind != kind {
+			return nil, fmt.Errorf("template %d is not a %s template", templateID, kind)
+		}
+		return tpl, nil
+	}
+	if tpl, err := m.templateRepo.GetDefault(ctx, kind); err == nil {
+		return tpl, nil
+	}
+	tpl := defaultTopicOrderTemplate
+	return &tpl, nil
+}
+
+// departmentHead - заведующий кафедрой группы в виде "Фамилия И."
+func (m *DocumentManagerImpl) departmentHead(ctx context.Context, groupID uint) string {
+	group, err := m.groupRepo.GetByID(ctx, groupID)
+	if err != nil || group.Department.HeadUserID == nil {
+		return ""
+	}
+	head, err := m.userRepo.GetByID(ctx, *group.Department.HeadUserID)
+	if err != nil {
+		return ""
+	}
+	return shortName(head)
+}
+
+// buildDocumentBody подставляет данные в шаблон
+func buildDocumentBody(tpl *models.DocumentTemplate, data documentData, columns []pdfColumn, rows [][]string) (*documentBody, error) {
+	header, err := executeTemplate("header", tpl.Header, data)
+	if err != nil {
+		return nil, err
+	}
+	footer, err := executeTemplate("footer", tpl.Footer, data)
+	if err != nil {
+		return nil, err
+	}
+	return &documentBody{
+		header:  parseDocParagraphs(header),
+		footer:  parseDocParagraphs(footer),
+		columns: columns,
+		rows:    rows,
+	}, nil
+}
+
+func executeTemplate(name, text string, data documentData) (string, error) {
+	t, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid template %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render template %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// checkTemplate прогоняет шаблон на тестовых данных, чтобы ошибка в поле
+// обнаружилась при сохранении, а не при выпуске приказа
+func checkTemplate(tpl *models.DocumentTemplate) error {
+	grade := 5
+	sample := documentData{
+		Number:         tpl.NumberPrefix + "1",
+		Version:        2,
+		Date:           "01.09.2025",
+		AcademicYear:   "2025-2026",
+		Term:           "Осенний семестр 2025-2026",
+		Subject:        "Базы данных",
+		SubjectCode:    "DB",
+		Group:          "ИВТ-21",
+		Department:     "Вычислительная техника",
+		DepartmentHead: "Иванов И.",
+		Count:          1,
+		Rows: []interfaces.GradebookRow{{
+			No: 1, StudentName: "Петров Пётр", GroupCode: "ИВТ-21", Topic: "Тема", Supervisor: "Иванов И.", Grade: &grade,
+		}},
+	}
+	_, err := buildDocumentBody(tpl, sample, topicOrderColumns, nil)
+	return err
+}
+
+// documentFileName - например "topic_order-КР-7-v2.docx"
+func documentFileName(doc *models.IssuedDocument) string {
+	name := fmt.Sprintf("%s-%s", doc.Kind, doc.Number)
+	if doc.Version > 1 {
+		name += fmt.Sprintf("-v%d", doc.Version)
+	}
+	return name + "." + string(doc.Format)
+}
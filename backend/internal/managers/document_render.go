@@ -0,0 +1,208 @@
+package managers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// documentBody - свёрстанный документ: текст шаблона до и после таблицы и сама таблица
+type documentBody struct {
+	header  []docParagraph
+	footer  []docParagraph
+	columns []pdfColumn
+	rows    [][]string
+}
+
+// docParagraph - абзац документа; heading - строка шаблона с "# ", печатается жирным по центру
+type docParagraph struct {
+	text    string
+	heading bool
+}
+
+// parseDocParagraphs разбивает текст шаблона на абзацы по строкам
+func parseDocParagraphs(text string) []docParagraph {
+	text = strings.TrimRight(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if text == "" {
+		return nil
+	}
+	lines := strings.Split(text, "\n")
+	paragraphs := make([]docParagraph, len(lines))
+	for i, line := range lines {
+		if rest, ok := strings.CutPrefix(line, "# "); ok {
+			paragraphs[i] = docParagraph{text: rest, heading: true}
+		} else {
+			paragraphs[i] = docParagraph{text: line}
+		}
+	}
+	return paragraphs
+}
+
+// renderDocument верстает документ в нужном формате
+func renderDocument(body *documentBody, format models.DocumentFormat) ([]byte, error) {
+	switch format {
+	case models.DocumentFormatDOCX:
+		return renderDOCX(body)
+	case models.DocumentFormatPDF:
+		return renderDocumentPDF(body)
+	}
+	return nil, fmt.Errorf("unsupported document format %q", format)
+}
+
+func renderDocumentPDF(body *documentBody) ([]byte, error) {
+	pdf, err := newPDF()
+	if err != nil {
+		return nil, err
+	}
+
+	writeParagraphs := func(paragraphs []docParagraph) {
+		for _, p := range paragraphs {
+			if p.heading {
+				pdf.SetFont(pdfFontFamily, "B", 13)
+				pdf.MultiCell(0, 7, p.text, "", "C", false)
+				continue
+			}
+			pdf.SetFont(pdfFontFamily, "", 11)
+			if p.text == "" {
+				pdf.Ln(4)
+				continue
+			}
+			pdf.MultiCell(0, 6, p.text, "", "J", false)
+		}
+	}
+
+	writeParagraphs(body.header)
+	pdf.Ln(4)
+	pdfTable(pdf, body.columns, body.rows)
+	pdf.Ln(6)
+	for _, p := range body.footer {
+		if pdf.GetY()+8 > pdfBottom(pdf) {
+			pdf.AddPage()
+		}
+		writeParagraphs([]docParagraph{p})
+	}
+	return pdfBytes(pdf)
+}
+
+// Части пакета DOCX, которые не зависят от содержимого
+const (
+	docxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>` +
+		`</Types>`
+	docxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>` +
+		`</Relationships>`
+	// A4, поля 30/15/20/20 мм - по ГОСТ Р 7.0.97 для организационно-распорядительных документов
+	docxSection = `<w:sectPr><w:pgSz w:w="11906" w:h="16838"/>` +
+		`<w:pgMar w:top="1134" w:right="850" w:bottom="1134" w:left="1701" w:header="708" w:footer="708" w:gutter="0"/></w:sectPr>`
+	docxTwipsPerMM = 56.7
+)
+
+// renderDOCX собирает минимальный пакет WordprocessingML: документ открывается в Word и LibreOffice
+// и остаётся редактируемым, если в приказ нужно внести правки от руки
+func renderDOCX(body *documentBody) ([]byte, error) {
+	var doc bytes.Buffer
+	doc.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
+	doc.WriteString(`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>`)
+	for _, p := range body.header {
+		writeDOCXParagraph(&doc, p, "both", 28)
+	}
+	writeDOCXParagraph(&doc, docParagraph{}, "left", 28)
+	writeDOCXTable(&doc, body.columns, body.rows)
+	writeDOCXParagraph(&doc, docParagraph{}, "left", 28)
+	for _, p := range body.footer {
+		writeDOCXParagraph(&doc, p, "both", 28)
+	}
+	doc.WriteString(docxSection)
+	doc.WriteString(`</w:body></w:document>`)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, part := range []struct {
+		name string
+		data []byte
+	}{
+		{"[Content_Types].xml", []byte(docxContentTypes)},
+		{"_rels/.rels", []byte(docxRels)},
+		{"word/document.xml", doc.Bytes()},
+	} {
+		w, err := zw.Create(part.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write docx part %s: %w", part.name, err)
+		}
+		if _, err := w.Write(part.data); err != nil {
+			return nil, fmt.Errorf("failed to write docx part %s: %w", part.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write docx: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// writeDOCXParagraph пишет абзац; size - кегль в половинах пункта
+func writeDOCXParagraph(doc *bytes.Buffer, p docParagraph, align string, size int) {
+	if p.heading {
+		align = "center"
+	}
+	fmt.Fprintf(doc, `<w:p><w:pPr><w:jc w:val="%s"/><w:spacing w:after="0"/></w:pPr>`, align)
+	if p.text != "" {
+		doc.WriteString(`<w:r><w:rPr><w:rFonts w:ascii="Times New Roman" w:hAnsi="Times New Roman" w:cs="Times New Roman"/>`)
+		if p.heading {
+			doc.WriteString(`<w:b/>`)
+		}
+		fmt.Fprintf(doc, `<w:sz w:val="%d"/></w:rPr><w:t xml:space="preserve">`, size)
+		_ = xml.EscapeText(doc, []byte(p.text))
+		doc.WriteString(`</w:t></w:r>`)
+	}
+	doc.WriteString(`</w:p>`)
+}
+
+// writeDOCXTable пишет таблицу с рамками; строка заголовка повторяется на каждой странице
+func writeDOCXTable(doc *bytes.Buffer, columns []pdfColumn, rows [][]string) {
+	doc.WriteString(`<w:tbl><w:tblPr><w:tblW w:w="5000" w:type="pct"/><w:tblBorders>`)
+	for _, side := range []string{"top", "left", "bottom", "right", "insideH", "insideV"} {
+		fmt.Fprintf(doc, `<w:%s w:val="single" w:sz="4" w:space="0" w:color="000000"/>`, side)
+	}
+	doc.WriteString(`</w:tblBorders></w:tblPr><w:tblGrid>`)
+	for _, col := range columns {
+		fmt.Fprintf(doc, `<w:gridCol w:w="%d"/>`, int(col.width*docxTwipsPerMM))
+	}
+	doc.WriteString(`</w:tblGrid>`)
+
+	writeRow := func(cells []string, header bool) {
+		doc.WriteString(`<w:tr>`)
+		if header {
+			doc.WriteString(`<w:trPr><w:tblHeader/></w:trPr>`)
+		}
+		for i, text := range cells {
+			col := columns[i]
+			fmt.Fprintf(doc, `<w:tc><w:tcPr><w:tcW w:w="%d" w:type="dxa"/></w:tcPr>`, int(col.width*docxTwipsPerMM))
+			align := map[string]string{"L": "left", "C": "center", "R": "right"}[col.align]
+			if header {
+				align = "center"
+			}
+			writeDOCXParagraph(doc, docParagraph{text: text, heading: header}, align, 22)
+			doc.WriteString(`</w:tc>`)
+		}
+		doc.WriteString(`</w:tr>`)
+	}
+
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.title
+	}
+	writeRow(header, true)
+	for _, row := range rows {
+		writeRow(row, false)
+	}
+	doc.WriteString(`</w:tbl>`)
+}
@@ -0,0 +1,362 @@
+package managers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"log"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/assets"
+	"github.com/Foxpunk/courseforge/internal/config"
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// Параметры очереди писем
+const (
+	staleSendAfter = 10 * time.Minute // письмо в sending дольше этого считается зависшим
+	maxRetryDelay  = 6 * time.Hour
+)
+
+// mailTimeFormats - формат времени событий в письмах
+var mailTimeFormats = map[models.Locale]string{
+	models.LocaleRU: "02.01.2006 15:04",
+	models.LocaleEN: "Jan 2, 2006 15:04",
+}
+
+// mailDateFormats - формат даты сводки в теме письма
+var mailDateFormats = map[models.Locale]string{
+	models.LocaleRU: "02.01.2006",
+	models.LocaleEN: "Jan 2, 2006",
+}
+
+// mailTemplate - текстовая (с темой в блоке subject) и HTML-версия письма одного вида
+type mailTemplate struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// mailItem - уведомление в письме
+type mailItem struct {
+	Label   string
+	Title   string
+	Message string
+	Time    string
+}
+
+// mailView - данные шаблона письма
+type mailView struct {
+	Name   string
+	AppURL string
+	Date   string
+	Item   mailItem   // письмо об одном уведомлении
+	Items  []mailItem // сводка
+}
+
+// EmailManagerImpl реализует interfaces.EmailManager
+type EmailManagerImpl struct {
+	repo      interfaces.EmailRepository
+	notifRepo interfaces.NotificationRepository
+	userRepo  interfaces.UserRepository
+	mailer    interfaces.Mailer
+	cfg       config.MailConfig
+	templates map[string]*mailTemplate // ключ - <язык>/<вид>
+}
+
+// NewEmailManager создаёт менеджер почтовых уведомлений. mailer == nil - отправка
+// писем на сервере не настроена, доступны только настройки пользователей.
+func NewEmailManager(
+	repo interfaces.EmailRepository,
+	notifRepo interfaces.NotificationRepository,
+	userRepo interfaces.UserRepository,
+	mailer interfaces.Mailer,
+	cfg config.MailConfig,
+) (interfaces.EmailManager, error) {
+	templates := make(map[string]*mailTemplate)
+	for _, locale := range []models.Locale{models.LocaleRU, models.LocaleEN} {
+		for _, kind := range []models.EmailKind{models.EmailKindNotification, models.EmailKindDigest} {
+			base := fmt.Sprintf("mail/%s/%s", locale, kind)
+			text, err := texttemplate.ParseFS(assets.Mail, base+".txt")
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse mail template: %w", err)
+			}
+			if text.Lookup("subject") == nil {
+				return nil, fmt.Errorf("mail template %s.txt has no subject block", base)
+			}
+			html, err := htmltemplate.ParseFS(assets.Mail, base+".html")
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse mail template: %w", err)
+			}
+			templates[string(locale)+"/"+string(kind)] = &mailTemplate{text: text, html: html}
+		}
+	}
+
+	return &EmailManagerImpl{
+		repo:      repo,
+		notifRepo: notifRepo,
+		userRepo:  userRepo,
+		mailer:    mailer,
+		cfg:       cfg,
+		templates: templates,
+	}, nil
+}
+
+// Deliver ставит в очередь письмо об уведомлении, если пользователь получает письма сразу
+func (m *EmailManagerImpl) Deliver(ctx context.Context, n *models.Notification) error {
+	if m.mailer == nil {
+		return nil
+	}
+	settings, err := m.repo.GetSettings(ctx, n.UserID)
+	if err != nil {
+		return err
+	}
+	if settings.Mode != models.EmailImmediate {
+		return nil
+	}
+	user, err := m.userRepo.GetByID(ctx, n.UserID)
+	if err != nil {
+		return err
+	}
+	if !user.IsActive {
+		return nil
+	}
+
+	locale := mailLocale(settings.Locale)
+	view := mailView{
+		Name:   user.FirstName,
+		AppURL: strings.TrimRight(m.cfg.AppURL, "/"),
+		Item:   buildMailItem(n, locale),
+	}
+	email, err := m.render(locale, models.EmailKindNotification, view)
+	if err != nil {
+		return err
+	}
+	notificationID := n.ID
+	email.UserID = user.ID
+	email.NotificationID = &notificationID
+	email.To = user.Email
+	return m.repo.Enqueue(ctx, email)
+}
+
+// GetSettings возвращает почтовые настройки пользователя
+func (m *EmailManagerImpl) GetSettings(ctx context.Context, userID uint) (*interfaces.EmailSettingsResponse, error) {
+	settings, err := m.repo.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return m.buildSettingsResponse(settings), nil
+}
+
+// UpdateSettings меняет язык писем и способ получения. При переходе на сводку
+// отсчёт начинается с момента переключения, чтобы уже отправленное не пришло повторно.
+func (m *EmailManagerImpl) UpdateSettings(ctx context.Context, userID uint, req interfaces.UpdateEmailSettingsRequest) (*interfaces.EmailSettingsResponse, error) {
+	settings, err := m.repo.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Locale != "" {
+		settings.Locale = req.Locale
+	}
+	if req.Mode != "" {
+		if req.Mode == models.EmailDigest && settings.Mode != models.EmailDigest {
+			now := time.Now()
+			settings.LastDigestAt = &now
+		}
+		settings.Mode = req.Mode
+	}
+	if err := m.repo.SaveSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+	return m.buildSettingsResponse(settings), nil
+}
+
+// SendDigests ставит в очередь сводки пользователям, не получавшим её после последнего
+// часа рассылки. Если сервер не работал в час рассылки, сводка уйдёт после запуска.
+func (m *EmailManagerImpl) SendDigests(ctx context.Context, now time.Time) (int, error) {
+	if m.mailer == nil {
+		return 0, nil
+	}
+	digestAt := time.Date(now.Year(), now.Month(), now.Day(), m.cfg.DigestHour, 0, 0, 0, now.Location())
+	if now.Before(digestAt) {
+		digestAt = digestAt.AddDate(0, 0, -1)
+	}
+
+	sent := 0
+	for {
+		due, err := m.repo.ListDigestDue(ctx, digestAt, m.cfg.BatchSize)
+		if err != nil {
+			return sent, err
+		}
+		if len(due) == 0 {
+			return sent, nil
+		}
+		for i := range due {
+			queued, err := m.digest(ctx, &due[i], now)
+			if err != nil {
+				return sent, fmt.Errorf("digest for user %d: %w", due[i].UserID, err)
+			}
+			if queued {
+				sent++
+			}
+		}
+	}
+}
+
+// digest собирает сводку непрочитанных уведомлений с прошлой сводки; пустая не отправляется
+func (m *EmailManagerImpl) digest(ctx context.Context, settings *models.EmailSettings, now time.Time) (bool, error) {
+	since := now.Add(-24 * time.Hour)
+	if settings.LastDigestAt != nil {
+		since = *settings.LastDigestAt
+	}
+	items, err := m.notifRepo.ListUnreadBetween(ctx, settings.UserID, since, now)
+	if err != nil {
+		return false, err
+	}
+	user, err := m.userRepo.GetByID(ctx, settings.UserID)
+	if err != nil {
+		return false, err
+	}
+	if len(items) == 0 || !user.IsActive {
+		return false, m.repo.CompleteDigest(ctx, settings.UserID, now, nil)
+	}
+
+	locale := mailLocale(settings.Locale)
+	view := mailView{
+		Name:   user.FirstName,
+		AppURL: strings.TrimRight(m.cfg.AppURL, "/"),
+		Date:   now.Format(mailDateFormats[locale]),
+		Items:  make([]mailItem, len(items)),
+	}
+	for i := range items {
+		view.Items[i] = buildMailItem(&items[i], locale)
+	}
+	email, err := m.render(locale, models.EmailKindDigest, view)
+	if err != nil {
+		return false, err
+	}
+	email.UserID = user.ID
+	email.To = user.Email
+	return true, m.repo.CompleteDigest(ctx, settings.UserID, now, email)
+}
+
+// ProcessQueue отправляет порцию писем. Неудачная отправка повторяется с паузой
+// RetryBackoff, удваивающейся с каждой попыткой; после MaxAttempts письмо остаётся failed.
+func (m *EmailManagerImpl) ProcessQueue(ctx context.Context, now time.Time) (int, error) {
+	if m.mailer == nil {
+		return 0, nil
+	}
+	emails, err := m.repo.ClaimDue(ctx, now, m.cfg.BatchSize, staleSendAfter)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, email := range emails {
+		sendErr := m.mailer.Send(ctx, interfaces.MailMessage{
+			To:       email.To,
+			Subject:  email.Subject,
+			TextBody: email.TextBody,
+			HTMLBody: email.HTMLBody,
+		})
+		if sendErr == nil {
+			if err := m.repo.MarkSent(ctx, email.ID, time.Now()); err != nil {
+				return sent, err
+			}
+			sent++
+			continue
+		}
+
+		var next *time.Time
+		if email.Attempts < m.cfg.MaxAttempts {
+			at := now.Add(retryDelay(m.cfg.RetryBackoff, email.Attempts))
+			next = &at
+			log.Printf("email %d to %s failed (attempt %d), retry at %s: %v", email.ID, email.To, email.Attempts, at.Format(time.RFC3339), sendErr)
+		} else {
+			log.Printf("email %d to %s failed after %d attempts: %v", email.ID, email.To, email.Attempts, sendErr)
+		}
+		if err := m.repo.MarkFailed(ctx, email.ID, sendErr.Error(), next); err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+// render собирает письмо по шаблону языка
+func (m *EmailManagerImpl) render(locale models.Locale, kind models.EmailKind, view mailView) (*models.OutgoingEmail, error) {
+	tpl := m.templates[string(locale)+"/"+string(kind)]
+	if tpl == nil {
+		return nil, fmt.Errorf("no %s mail template for locale %q", kind, locale)
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := tpl.text.ExecuteTemplate(&subject, "subject", view); err != nil {
+		return nil, fmt.Errorf("failed to render mail subject: %w", err)
+	}
+	if err := tpl.text.Execute(&text, view); err != nil {
+		return nil, fmt.Errorf("failed to render mail text: %w", err)
+	}
+	if err := tpl.html.Execute(&html, view); err != nil {
+		return nil, fmt.Errorf("failed to render mail html: %w", err)
+	}
+
+	return &models.OutgoingEmail{
+		Kind:          kind,
+		Subject:       truncateRunes(strings.Join(strings.Fields(subject.String()), " "), 255),
+		TextBody:      text.String(),
+		HTMLBody:      html.String(),
+		Status:        models.EmailPending,
+		NextAttemptAt: time.Now(),
+	}, nil
+}
+
+func (m *EmailManagerImpl) buildSettingsResponse(s *models.EmailSettings) *interfaces.EmailSettingsResponse {
+	return &interfaces.EmailSettingsResponse{
+		Locale:       s.Locale,
+		Mode:         s.Mode,
+		DigestHour:   m.cfg.DigestHour,
+		MailEnabled:  m.mailer != nil,
+		LastDigestAt: s.LastDigestAt,
+	}
+}
+
+func buildMailItem(n *models.Notification, locale models.Locale) mailItem {
+	return mailItem{
+		Label:   eventDescriptions[locale][n.Event],
+		Title:   n.Title,
+		Message: n.Message,
+		Time:    n.CreatedAt.Local().Format(mailTimeFormats[locale]),
+	}
+}
+
+// mailLocale - язык письма; неизвестные языки заменяются русским
+func mailLocale(locale models.Locale) models.Locale {
+	if locale == models.LocaleEN {
+		return models.LocaleEN
+	}
+	return models.LocaleRU
+}
+
+// retryDelay - пауза перед следующей попыткой после attempts неудачных: base, 2*base, 4*base...
+func retryDelay(base time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
+
+// truncateRunes обрезает строку до n символов
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
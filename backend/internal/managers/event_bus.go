@@ -0,0 +1,121 @@
+package managers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/config"
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// eventSubscriber - подписчик шины событий
+type eventSubscriber struct {
+	name    string
+	handler interfaces.EventHandler
+	types   map[models.EventType]bool // пусто - все события
+}
+
+func (s eventSubscriber) accepts(eventType models.EventType) bool {
+	return len(s.types) == 0 || s.types[eventType]
+}
+
+// EventBusImpl реализует interfaces.EventBus поверх outbox
+type EventBusImpl struct {
+	repo        interfaces.OutboxRepository
+	cfg         config.OutboxConfig
+	subscribers []eventSubscriber
+}
+
+// NewEventBus создаёт новую шину событий
+func NewEventBus(repo interfaces.OutboxRepository, cfg config.OutboxConfig) interfaces.EventBus {
+	return &EventBusImpl{repo: repo, cfg: cfg}
+}
+
+// Publish записывает событие в outbox
+func (b *EventBusImpl) Publish(ctx context.Context, eventType models.EventType, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode event %s: %w", eventType, err)
+	}
+	return b.repo.Create(ctx, &models.OutboxEvent{
+		Type:          eventType,
+		Payload:       string(payload),
+		Status:        models.OutboxPending,
+		NextAttemptAt: time.Now(),
+	})
+}
+
+// Subscribe регистрирует подписчика на события; без types - на все события
+func (b *EventBusImpl) Subscribe(name string, handler interfaces.EventHandler, types ...models.EventType) {
+	set := make(map[models.EventType]bool, len(types))
+	for _, t := range types {
+		set[t] = true
+	}
+	b.subscribers = append(b.subscribers, eventSubscriber{name: name, handler: handler, types: set})
+}
+
+// ProcessOutbox доставляет порцию событий подписчикам
+func (b *EventBusImpl) ProcessOutbox(ctx context.Context, now time.Time) (int, error) {
+	events, err := b.repo.ClaimDue(ctx, now, b.cfg.BatchSize, staleSendAfter)
+	if err != nil {
+		return 0, err
+	}
+
+	done := 0
+	for i := range events {
+		event := &events[i]
+		b.deliver(ctx, event, now)
+		if err := b.repo.SaveResult(ctx, event); err != nil {
+			return done, err
+		}
+		if event.Status == models.OutboxDone {
+			done++
+		}
+	}
+	return done, nil
+}
+
+// deliver передаёт событие подписчикам, ещё не обработавшим его, и выставляет итог
+func (b *EventBusImpl) deliver(ctx context.Context, event *models.OutboxEvent, now time.Time) {
+	domainEvent := interfaces.DomainEvent{
+		ID:        event.ID,
+		Type:      event.Type,
+		Payload:   json.RawMessage(event.Payload),
+		CreatedAt: event.CreatedAt,
+	}
+
+	var failures []string
+	for _, sub := range b.subscribers {
+		if !sub.accepts(event.Type) || event.HandledBy(sub.name) {
+			continue
+		}
+		if err := sub.handler(ctx, domainEvent); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", sub.name, err))
+			continue
+		}
+		event.MarkHandled(sub.name)
+	}
+
+	if len(failures) == 0 {
+		at := time.Now()
+		event.Status = models.OutboxDone
+		event.ProcessedAt = &at
+		event.LastError = ""
+		return
+	}
+	event.LastError = strings.Join(failures, "; ")
+	if event.Attempts < b.cfg.MaxAttempts {
+		event.Status = models.OutboxPending
+		event.NextAttemptAt = now.Add(retryDelay(b.cfg.RetryBackoff, event.Attempts))
+		log.Printf("outbox event %d (%s) failed (attempt %d), retry at %s: %s",
+			event.ID, event.Type, event.Attempts, event.NextAttemptAt.Format(time.RFC3339), event.LastError)
+	} else {
+		event.Status = models.OutboxFailed
+		log.Printf("outbox event %d (%s) failed after %d attempts: %s", event.ID, event.Type, event.Attempts, event.LastError)
+	}
+}
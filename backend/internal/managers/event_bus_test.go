@@ -0,0 +1,136 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/config"
+	"github.com/Foxpunk/courseforge/internal/drivers"
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"github.com/Foxpunk/courseforge/internal/testutil"
+)
+
+func TestProcessOutboxRetriesOnlyFailedSubscriber(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	const backoff = time.Minute
+	bus := NewEventBus(drivers.NewOutboxRepository(db), config.OutboxConfig{BatchSize: 10, MaxAttempts: 3, RetryBackoff: backoff})
+
+	calls := make(map[string]int)
+	counting := func(name string, fail int) interfaces.EventHandler {
+		return func(ctx context.Context, event interfaces.DomainEvent) error {
+			calls[name]++
+			if calls[name] <= fail {
+				return errors.New("temporary failure")
+			}
+			return nil
+		}
+	}
+	bus.Subscribe("notifications", counting("notifications", 0), models.EventAssignmentCreated)
+	bus.Subscribe("webhooks", counting("webhooks", 1), models.EventAssignmentCreated)
+	bus.Subscribe("other", counting("other", 0), models.EventGradeSet)
+	NewAuditSubscriber(drivers.NewAuditRepository(db)).Register(bus)
+
+	if err := bus.Publish(ctx, models.EventAssignmentCreated, interfaces.AssignmentEventData{AssignmentID: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	// первый проход: webhooks падает, остальные подписчики отмечены как обработавшие
+	now := time.Now()
+	if done, err := bus.ProcessOutbox(ctx, now); err != nil || done != 0 {
+		t.Fatalf("first pass: done = %d, err = %v, want 0 events done", done, err)
+	}
+	var event models.OutboxEvent
+	if err := db.First(&event).Error; err != nil {
+		t.Fatal(err)
+	}
+	if event.Status != models.OutboxPending || event.Attempts != 1 {
+		t.Errorf("after failure: status = %s, attempts = %d, want pending after 1 attempt", event.Status, event.Attempts)
+	}
+	if !event.HandledBy("notifications") || !event.HandledBy("audit") || event.HandledBy("webhooks") {
+		t.Errorf("handled = %q, want notifications and audit without webhooks", event.Handled)
+	}
+	if event.LastError == "" {
+		t.Error("last error of the failed subscriber is not saved")
+	}
+
+	// до срока повтора событие не выбирается
+	if done, err := bus.ProcessOutbox(ctx, now); err != nil || done != 0 {
+		t.Fatalf("pass before retry: done = %d, err = %v", done, err)
+	}
+	if calls["webhooks"] != 1 {
+		t.Errorf("webhooks called %d times before the retry is due, want 1", calls["webhooks"])
+	}
+
+	// повтор вызывает только упавшего подписчика
+	if done, err := bus.ProcessOutbox(ctx, now.Add(backoff)); err != nil || done != 1 {
+		t.Fatalf("retry: done = %d, err = %v, want 1 event done", done, err)
+	}
+	want := map[string]int{"notifications": 1, "webhooks": 2}
+	for name, n := range want {
+		if calls[name] != n {
+			t.Errorf("%s called %d times, want %d", name, calls[name], n)
+		}
+	}
+	if calls["other"] != 0 {
+		t.Errorf("subscriber of another event type called %d times", calls["other"])
+	}
+	if err := db.First(&event).Error; err != nil {
+		t.Fatal(err)
+	}
+	if event.Status != models.OutboxDone || event.ProcessedAt == nil || event.LastError != "" {
+		t.Errorf("after retry: status = %s, processed_at = %v, last error = %q", event.Status, event.ProcessedAt, event.LastError)
+	}
+
+	var entries []models.AuditEntry
+	if err := db.Find(&entries).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].EventID != event.ID || entries[0].EventType != models.EventAssignmentCreated {
+		t.Errorf("audit log = %+v, want one entry for event %d", entries, event.ID)
+	}
+}
+
+func TestAuditSubscriberSkipsRedelivery(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	audit := NewAuditSubscriber(drivers.NewAuditRepository(db))
+
+	event := interfaces.DomainEvent{
+		ID:        42,
+		Type:      models.EventGradeSet,
+		Payload:   []byte(`{"assignment_id":7,"grade":5}`),
+		CreatedAt: time.Now(),
+	}
+	// доставка хотя бы один раз: тот же обработчик может получить событие повторно
+	for i := 0; i < 2; i++ {
+		if err := audit.record(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var entries []models.AuditEntry
+	if err := db.Find(&entries).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Payload != string(event.Payload) {
+		t.Errorf("audit log = %+v, want one entry with the event payload", entries)
+	}
+}
+
+func TestSubscriberNamesAreUnique(t *testing.T) {
+	bus := &EventBusImpl{}
+	NewAssignmentSubscribers(nil, nil, testutil.NopNotifier{}, testutil.NopRealtime{}, testutil.NopWebhooks{}).Register(bus)
+	NewWaitlistSubscribers(nil, testutil.NopNotifier{}, testutil.NopRealtime{}).Register(bus)
+	NewAuditSubscriber(nil).Register(bus)
+
+	seen := make(map[string]bool)
+	for _, sub := range bus.subscribers {
+		if seen[sub.name] {
+			t.Errorf("subscriber name %q is registered twice", sub.name)
+		}
+		seen[sub.name] = true
+	}
+}
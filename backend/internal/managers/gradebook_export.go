@@ -0,0 +1,226 @@
+package managers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+)
+
+// gradebookColumns - столбцы табличных выгрузок ведомости
+var gradebookColumns = []string{"№", "ФИО студента", "№ зачётной книжки", "Группа", "Тема курсовой работы", "Руководитель", "Статус", "Оценка", "Оценка прописью"}
+
+// renderGradebook выгружает ведомость в нужном формате
+func renderGradebook(book *interfaces.GradebookResponse, format interfaces.GradebookFormat) (*interfaces.GradebookFile, error) {
+	var (
+		data        []byte
+		contentType string
+		err         error
+	)
+	switch format {
+	case interfaces.GradebookFormatCSV:
+		data, err = gradebookCSV(book)
+		contentType = "text/csv; charset=utf-8"
+	case interfaces.GradebookFormatXLSX:
+		data, err = gradebookXLSX(book)
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case interfaces.GradebookFormatPDF:
+		data, err = gradebookPDF(book)
+		contentType = "application/pdf"
+	default:
+		return nil, fmt.Errorf("unsupported gradebook format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &interfaces.GradebookFile{
+		FileName:    gradebookFileName(book, format),
+		ContentType: contentType,
+		Data:        data,
+	}, nil
+}
+
+// gradebookFileName - например "vedomost-CS301-ИВТ-21-2025-12-20.pdf"
+func gradebookFileName(book *interfaces.GradebookResponse, format interfaces.GradebookFormat) string {
+	parts := []string{"vedomost", book.SubjectCode}
+	if book.GroupCode != "" {
+		parts = append(parts, book.GroupCode)
+	}
+	parts = append(parts, book.GeneratedAt.Format("2006-01-02"))
+	return strings.Join(parts, "-") + "." + string(format)
+}
+
+func gradebookRecord(row interfaces.GradebookRow) []string {
+	grade := ""
+	if row.Grade != nil {
+		grade = strconv.Itoa(*row.Grade)
+	}
+	return []string{
+		strconv.Itoa(row.No), row.StudentName, row.StudentNumber, row.GroupCode,
+		row.Topic, row.Supervisor, string(row.Status), grade, row.GradeText,
+	}
+}
+
+// gradebookCSV - CSV с BOM и разделителем ";", чтобы русский Excel открыл его без мастера импорта
+func gradebookCSV(book *interfaces.GradebookResponse) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff")
+	w := csv.NewWriter(&buf)
+	w.Comma = ';'
+	_ = w.Write(gradebookColumns)
+	for _, row := range book.Rows {
+		_ = w.Write(gradebookRecord(row))
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write gradebook csv: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func gradebookXLSX(book *interfaces.GradebookResponse) ([]byte, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheet := f.GetSheetName(0)
+	bold, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create xlsx style: %w", err)
+	}
+
+	line := 1
+	for _, title := range gradebookTitle(book) {
+		_ = f.SetCellValue(sheet, "A"+strconv.Itoa(line), title)
+		line++
+	}
+	line++
+
+	header := make([]interface{}, len(gradebookColumns))
+	for i, col := range gradebookColumns {
+		header[i] = col
+	}
+	headerCell := "A" + strconv.Itoa(line)
+	if err := f.SetSheetRow(sheet, headerCell, &header); err != nil {
+		return nil, fmt.Errorf("failed to write xlsx header: %w", err)
+	}
+	lastCol, _ := excelize.ColumnNumberToName(len(gradebookColumns))
+	_ = f.SetCellStyle(sheet, headerCell, lastCol+strconv.Itoa(line), bold)
+	_ = f.SetPanes(sheet, &excelize.Panes{Freeze: true, YSplit: line, TopLeftCell: "A" + strconv.Itoa(line+1), ActivePane: "bottomLeft"})
+
+	for _, row := range book.Rows {
+		line++
+		values := []interface{}{row.No, row.StudentName, row.StudentNumber, row.GroupCode, row.Topic, row.Supervisor, string(row.Status), nil, row.GradeText}
+		if row.Grade != nil {
+			values[7] = *row.Grade
+		}
+		if err := f.SetSheetRow(sheet, "A"+strconv.Itoa(line), &values); err != nil {
+			return nil, fmt.Errorf("failed to write xlsx row: %w", err)
+		}
+	}
+
+	for col, width := range map[string]float64{"A": 5, "B": 30, "C": 16, "D": 12, "E": 50, "F": 20, "G": 12, "H": 8, "I": 20} {
+		_ = f.SetColWidth(sheet, col, col, width)
+	}
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		return nil, fmt.Errorf("failed to write gradebook xlsx: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// gradebookTitle - шапка ведомости
+func gradebookTitle(book *interfaces.GradebookResponse) []string {
+	title := []string{"Ведомость защиты курсовых работ", "Дисциплина: " + book.SubjectName + " (" + book.SubjectCode + ")"}
+	if book.Department != "" {
+		title = append(title, "Кафедра: "+book.Department)
+	}
+	if book.GroupCode != "" {
+		title = append(title, "Группа: "+book.GroupCode)
+	}
+	if book.Term != "" {
+		title = append(title, book.Term)
+	}
+	return title
+}
+
+// gradebookPDFColumns - столбцы печатной ведомости; статус и группа в печатную форму не входят
+var gradebookPDFColumns = []pdfColumn{
+	{"№", 8, "C"},
+	{"ФИО студента", 38, "L"},
+	{"№ зач. книжки", 20, "C"},
+	{"Тема курсовой работы", 52, "L"},
+	{"Руководитель", 26, "L"},
+	{"Оценка", 16, "C"},
+	{"Подпись", 20, "C"},
+}
+
+func pdfRecord(row interfaces.GradebookRow) []string {
+	return []string{strconv.Itoa(row.No), row.StudentName, row.StudentNumber, row.Topic, row.Supervisor, row.GradeText, ""}
+}
+
+// gradebookPDF - печатная ведомость с местом для подписей
+func gradebookPDF(book *interfaces.GradebookResponse) ([]byte, error) {
+	pdf, err := newPDF()
+	if err != nil {
+		return nil, err
+	}
+
+	title := gradebookTitle(book)
+	pdf.SetFont(pdfFontFamily, "B", 14)
+	pdf.CellFormat(0, 8, strings.ToUpper(title[0]), "", 1, "C", false, 0, "")
+	pdf.SetFont(pdfFontFamily, "", 11)
+	for _, line := range title[1:] {
+		pdf.MultiCell(0, 6, line, "", "L", false)
+	}
+	pdf.Ln(4)
+
+	records := make([][]string, len(book.Rows))
+	for i, row := range book.Rows {
+		records[i] = pdfRecord(row)
+	}
+	pdfTable(pdf, gradebookPDFColumns, records)
+
+	// итоги и подписи не разрываем между страницами
+	if pdf.GetY()+60 > pdfBottom(pdf) {
+		pdf.AddPage()
+	}
+	pdf.Ln(4)
+	pdf.SetFont(pdfFontFamily, "", 10)
+	pdf.MultiCell(0, 5, gradebookSummary(book), "", "L", false)
+	pdf.Ln(8)
+	for _, signer := range []string{"Руководитель курсовых работ", "Заведующий кафедрой", "Секретарь деканата"} {
+		pdf.CellFormat(70, 8, signer, "", 0, "L", false, 0, "")
+		pdf.CellFormat(50, 8, "_______________", "", 0, "C", false, 0, "")
+		pdf.CellFormat(0, 8, "/ ______________________ /", "", 1, "L", false, 0, "")
+		pdf.Ln(2)
+	}
+	pdf.Ln(4)
+	pdf.CellFormat(0, 6, "Дата составления: "+book.GeneratedAt.Format("02.01.2006"), "", 1, "L", false, 0, "")
+
+	return pdfBytes(pdf)
+}
+
+// gradebookSummary - сколько каких оценок выставлено
+func gradebookSummary(book *interfaces.GradebookResponse) string {
+	counts := make(map[int]int)
+	missing := 0
+	for _, row := range book.Rows {
+		if row.Grade == nil {
+			missing++
+			continue
+		}
+		counts[*row.Grade]++
+	}
+	parts := make([]string, 0, len(gradeTexts)+1)
+	for grade := 5; grade >= 2; grade-- {
+		parts = append(parts, fmt.Sprintf("%s - %d", gradeTexts[grade], counts[grade]))
+	}
+	parts = append(parts, fmt.Sprintf("без оценки - %d", missing))
+	return fmt.Sprintf("Всего студентов: %d. Из них: %s.", len(book.Rows), strings.Join(parts, ", "))
+}
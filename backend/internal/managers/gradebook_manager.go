@@ -0,0 +1,219 @@
+package managers
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// GradebookManagerImpl реализует interfaces.GradebookManager
+type GradebookManagerImpl struct {
+	scRepo      interfaces.StudentCourseworkRepository
+	subjRepo    interfaces.SubjectRepository
+	groupRepo   interfaces.StudentGroupRepository
+	profileRepo interfaces.StudentProfileRepository
+	termRepo    interfaces.TermRepository
+	assignRepo  interfaces.TeacherSubjectRepository
+}
+
+// NewGradebookManager создаёт новый GradebookManager
+func NewGradebookManager(
+	scRepo interfaces.StudentCourseworkRepository,
+	subjRepo interfaces.SubjectRepository,
+	groupRepo interfaces.StudentGroupRepository,
+	profileRepo interfaces.StudentProfileRepository,
+	termRepo interfaces.TermRepository,
+	assignRepo interfaces.TeacherSubjectRepository,
+) interfaces.GradebookManager {
+	return &GradebookManagerImpl{
+		scRepo:      scRepo,
+		subjRepo:    subjRepo,
+		groupRepo:   groupRepo,
+		profileRepo: profileRepo,
+		termRepo:    termRepo,
+		assignRepo:  assignRepo,
+	}
+}
+
+// gradeTexts - оценки прописью, как в ведомости деканата
+var gradeTexts = map[int]string{
+	5: "отлично",
+	4: "хорошо",
+	3: "удовлетворительно",
+	2: "неудовлетворительно",
+}
+
+// GetGradebook собирает ведомость по дисциплине. Для группы в ведомость попадают все её
+// студенты, в том числе без темы; без группы - все студенты с темами по дисциплине.
+func (m *GradebookManagerImpl) GetGradebook(ctx context.Context, req interfaces.GradebookRequest) (*interfaces.GradebookResponse, error) {
+	subj, err := m.subjRepo.GetByID(ctx, req.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+	term, err := m.gradebookTerm(ctx, req, subj)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &interfaces.GradebookResponse{
+		SubjectID:   subj.ID,
+		SubjectCode: subj.Code,
+		SubjectName: subj.Name,
+		GeneratedAt: time.Now(),
+	}
+	var termID uint
+	if term != nil {
+		termID = term.ID
+		resp.TermID = term.ID
+		resp.Term = term.Name
+	}
+
+	assignments, err := m.scRepo.GetGradebook(ctx, subj.ID, termID)
+	if err != nil {
+		return nil, err
+	}
+	byStudent := make(map[uint]*models.StudentCoursework, len(assignments))
+	for i := range assignments {
+		byStudent[assignments[i].StudentID] = &assignments[i]
+	}
+
+	var profiles []models.StudentProfile
+	if req.GroupID != 0 {
+		group, err := m.groupRepo.GetByID(ctx, req.GroupID)
+		if err != nil {
+			return nil, err
+		}
+		resp.GroupID = group.ID
+		resp.GroupCode = group.GroupCode
+		resp.Department = group.Department.DepartmentName
+		if profiles, err = m.profileRepo.GetByGroup(ctx, group.ID); err != nil {
+			return nil, err
+		}
+	} else {
+		studentIDs := make([]uint, 0, len(assignments))
+		for _, sc := range assignments {
+			studentIDs = append(studentIDs, sc.StudentID)
+		}
+		if profiles, err = m.profileRepo.GetByUserIDs(ctx, studentIDs); err != nil {
+			return nil, err
+		}
+	}
+	byProfile := make(map[uint]*models.StudentProfile, len(profiles))
+	for i := range profiles {
+		byProfile[profiles[i].UserID] = &profiles[i]
+	}
+
+	if req.GroupID != 0 {
+		for i := range profiles {
+			resp.Rows = append(resp.Rows, buildGradebookRow(&profiles[i].User, &profiles[i], byStudent[profiles[i].UserID]))
+		}
+	} else {
+		for i := range assignments {
+			resp.Rows = append(resp.Rows, buildGradebookRow(&assignments[i].Student, byProfile[assignments[i].StudentID], &assignments[i]))
+		}
+	}
+
+	sort.SliceStable(resp.Rows, func(i, j int) bool {
+		a, b := resp.Rows[i], resp.Rows[j]
+		if a.GroupCode != b.GroupCode {
+			return a.GroupCode < b.GroupCode
+		}
+		return a.StudentName < b.StudentName
+	})
+	for i := range resp.Rows {
+		resp.Rows[i].No = i + 1
+	}
+	if resp.Rows == nil {
+		resp.Rows = []interfaces.GradebookRow{}
+	}
+	return resp, nil
+}
+
+// ExportGradebook выгружает ведомость в CSV, XLSX или PDF
+func (m *GradebookManagerImpl) ExportGradebook(ctx context.Context, req interfaces.GradebookRequest, format interfaces.GradebookFormat) (*interfaces.GradebookFile, error) {
+	book, err := m.GetGradebook(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return renderGradebook(book, format)
+}
+
+// CanViewGradebook проверяет, что пользователь ведёт дисциплину в учебном году ведомости
+func (m *GradebookManagerImpl) CanViewGradebook(ctx context.Context, userID uint, req interfaces.GradebookRequest) (bool, error) {
+	subj, err := m.subjRepo.GetByID(ctx, req.SubjectID)
+	if err != nil {
+		return false, err
+	}
+	term, err := m.gradebookTerm(ctx, req, subj)
+	if err != nil {
+		return false, err
+	}
+	year := ""
+	if term != nil {
+		year = term.AcademicYear.Name
+	}
+
+	assignments, err := m.assignRepo.GetBySubject(ctx, subj.ID, year)
+	if err != nil {
+		return false, err
+	}
+	for _, a := range assignments {
+		if a.UserID == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// gradebookTerm - семестр ведомости: указанный или семестр дисциплины в текущем учебном году;
+// nil, если семестры не заведены
+func (m *GradebookManagerImpl) gradebookTerm(ctx context.Context, req interfaces.GradebookRequest, subj *models.Subject) (*models.Term, error) {
+	termID := req.TermID
+	if termID == 0 {
+		var err error
+		if termID, err = resolveYearTerm(ctx, m.termRepo, "", subj.Semester); err != nil {
+			return nil, err
+		}
+		if termID == 0 {
+			return nil, nil
+		}
+	}
+	return m.termRepo.GetByID(ctx, termID)
+}
+
+func buildGradebookRow(student *models.User, profile *models.StudentProfile, sc *models.StudentCoursework) interfaces.GradebookRow {
+	row := interfaces.GradebookRow{
+		StudentID:   student.ID,
+		StudentName: student.LastName + " " + student.FirstName,
+	}
+	if profile != nil {
+		row.StudentNumber = profile.StudentNumber
+		row.GroupCode = profile.StudentGroup.GroupCode
+	}
+	if sc != nil {
+		row.CourseworkID = sc.CourseworkID
+		row.Topic = sc.Coursework.Title
+		row.Supervisor = shortName(&sc.Coursework.Teacher)
+		row.Status = sc.Status
+		row.Grade = sc.Grade
+		if sc.Grade != nil {
+			row.GradeText = gradeTexts[*sc.Grade]
+		}
+	}
+	return row
+}
+
+// shortName - фамилия и инициал: "Петров П."
+func shortName(u *models.User) string {
+	if u.LastName == "" {
+		return ""
+	}
+	name := u.LastName
+	if r := []rune(u.FirstName); len(r) > 0 {
+		name += " " + string(r[0]) + "."
+	}
+	return name
+}
@@ -12,16 +12,19 @@ import (
 type GroupManagerImpl struct {
 	groupRepo   interfaces.StudentGroupRepository
 	profileRepo interfaces.StudentProfileRepository
+	deptRepo    interfaces.DepartmentRepository
 }
 
 // NewGroupManager создаёт новый GroupManager
 func NewGroupManager(
 	groupRepo interfaces.StudentGroupRepository,
 	profileRepo interfaces.StudentProfileRepository,
+	deptRepo interfaces.DepartmentRepository,
 ) interfaces.GroupManager {
 	return &GroupManagerImpl{
 		groupRepo:   groupRepo,
 		profileRepo: profileRepo,
+		deptRepo:    deptRepo,
 	}
 }
 
@@ -31,6 +34,9 @@ func (m *GroupManagerImpl) CreateGroup(ctx context.Context, req interfaces.Creat
 	if _, err := m.groupRepo.GetByCode(ctx, req.GroupCode); err == nil {
 		return nil, errors.New("group code already exists")
 	}
+	if _, err := m.deptRepo.GetByID(ctx, req.DepartmentID); err != nil {
+		return nil, err
+	}
 	group := &models.StudentGroup{
 		GroupCode:    req.GroupCode,
 		CourseYear:   req.CourseYear,
@@ -64,6 +70,9 @@ func (m *GroupManagerImpl) UpdateGroup(ctx context.Context, groupID uint, req in
 		group.Specialty = *req.Specialty
 	}
 	if req.DepartmentID != nil {
+		if _, err := m.deptRepo.GetByID(ctx, *req.DepartmentID); err != nil {
+			return nil, err
+		}
 		group.DepartmentID = *req.DepartmentID
 	}
 	if err := m.groupRepo.Update(ctx, group); err != nil {
@@ -72,8 +81,15 @@ func (m *GroupManagerImpl) UpdateGroup(ctx context.Context, groupID uint, req in
 	return group, nil
 }
 
-// DeleteGroup удаляет группу по ID
+// DeleteGroup удаляет группу по ID, если в ней не осталось студентов
 func (m *GroupManagerImpl) DeleteGroup(ctx context.Context, groupID uint) error {
+	students, err := m.profileRepo.GetByGroup(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	if len(students) > 0 {
+		return errors.New("group still has students")
+	}
 	return m.groupRepo.Delete(ctx, groupID)
 }
 
@@ -0,0 +1,78 @@
+package managers
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// icalEvent - событие для экспорта в формат iCalendar (RFC 5545)
+type icalEvent struct {
+	UID         string
+	Summary     string
+	Location    string
+	Description string
+	Start       time.Time
+	End         time.Time
+}
+
+const icalTimeFormat = "20060102T150405Z"
+
+// renderICalendar формирует календарь VCALENDAR с набором событий
+func renderICalendar(name string, events []icalEvent, now time.Time) []byte {
+	var buf bytes.Buffer
+	writeICalLine(&buf, "BEGIN:VCALENDAR")
+	writeICalLine(&buf, "VERSION:2.0")
+	writeICalLine(&buf, "PRODID:-//CourseForge//Schedule//RU")
+	writeICalLine(&buf, "CALSCALE:GREGORIAN")
+	writeICalLine(&buf, "METHOD:PUBLISH")
+	writeICalLine(&buf, "X-WR-CALNAME:"+escapeICalText(name))
+
+	stamp := now.UTC().Format(icalTimeFormat)
+	for _, ev := range events {
+		writeICalLine(&buf, "BEGIN:VEVENT")
+		writeICalLine(&buf, "UID:"+ev.UID)
+		writeICalLine(&buf, "DTSTAMP:"+stamp)
+		writeICalLine(&buf, "DTSTART:"+ev.Start.UTC().Format(icalTimeFormat))
+		writeICalLine(&buf, "DTEND:"+ev.End.UTC().Format(icalTimeFormat))
+		writeICalLine(&buf, "SUMMARY:"+escapeICalText(ev.Summary))
+		if ev.Location != "" {
+			writeICalLine(&buf, "LOCATION:"+escapeICalText(ev.Location))
+		}
+		if ev.Description != "" {
+			writeICalLine(&buf, "DESCRIPTION:"+escapeICalText(ev.Description))
+		}
+		writeICalLine(&buf, "END:VEVENT")
+	}
+	writeICalLine(&buf, "END:VCALENDAR")
+	return buf.Bytes()
+}
+
+// escapeICalText экранирует спецсимволы текстовых значений
+func escapeICalText(s string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return r.Replace(s)
+}
+
+// writeICalLine пишет строку, сворачивая её по 75 октетов без разрыва UTF-8 символов
+func writeICalLine(buf *bytes.Buffer, line string) {
+	const limit = 75
+	width := 0
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if width+size > limit {
+			buf.WriteString("\r\n ")
+			width = 1
+		}
+		buf.WriteRune(r)
+		width += size
+	}
+	buf.WriteString("\r\n")
+}
@@ -0,0 +1,94 @@
+package managers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/config"
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// idempotencyLockTimeout - после этого срока незавершённый запрос (например, сервер упал
+// посреди обработки) больше не держит ключ и повтор выполняется заново
+const idempotencyLockTimeout = time.Minute
+
+// IdempotencyManagerImpl реализует interfaces.IdempotencyManager
+type IdempotencyManagerImpl struct {
+	repo interfaces.IdempotencyRepository
+	cfg  config.IdempotencyConfig
+}
+
+// NewIdempotencyManager создаёт новый IdempotencyManager
+func NewIdempotencyManager(repo interfaces.IdempotencyRepository, cfg config.IdempotencyConfig) interfaces.IdempotencyManager {
+	return &IdempotencyManagerImpl{repo: repo, cfg: cfg}
+}
+
+// Begin занимает ключ; если он занят, решает по существующей записи: повтор того же
+// запроса получает сохранённый ответ, другой запрос с тем же ключом - ошибку
+func (m *IdempotencyManagerImpl) Begin(ctx context.Context, userID uint, key, fingerprint string) (*models.IdempotencyKey, bool, error) {
+	// вторая попытка нужна, когда занявшая ключ запись устарела и удалена
+	for attempt := 0; attempt < 2; attempt++ {
+		now := time.Now()
+		record := &models.IdempotencyKey{
+			UserID:      userID,
+			Key:         key,
+			Fingerprint: fingerprint,
+			Status:      models.IdempotencyPending,
+			ExpiresAt:   now.Add(m.cfg.TTL),
+		}
+		ok, err := m.repo.Reserve(ctx, record)
+		if err != nil {
+			return nil, false, err
+		}
+		if ok {
+			return record, false, nil
+		}
+
+		existing, err := m.repo.Get(ctx, userID, key)
+		if err != nil {
+			// запись успели удалить между вставкой и чтением - пробуем занять ключ снова
+			continue
+		}
+		stale := existing.Status == models.IdempotencyPending && existing.UpdatedAt.Before(now.Add(-idempotencyLockTimeout))
+		if existing.ExpiresAt.Before(now) || stale {
+			if err := m.repo.Delete(ctx, existing.ID); err != nil {
+				return nil, false, err
+			}
+			continue
+		}
+		if existing.Fingerprint != fingerprint {
+			return nil, false, interfaces.ErrIdempotencyKeyReused
+		}
+		if existing.Status == models.IdempotencyPending {
+			return nil, false, interfaces.ErrIdempotencyInProgress
+		}
+		return existing, true, nil
+	}
+	return nil, false, interfaces.ErrIdempotencyInProgress
+}
+
+// Complete сохраняет ответ для повторов. Ответ 5xx не сохраняется: ключ освобождается,
+// и повтор выполнит запрос заново
+func (m *IdempotencyManagerImpl) Complete(ctx context.Context, record *models.IdempotencyKey, status int, contentType string, body []byte) error {
+	if status >= http.StatusInternalServerError {
+		return m.repo.Delete(ctx, record.ID)
+	}
+	record.Status = models.IdempotencyDone
+	record.ResponseCode = status
+	record.ContentType = contentType
+	record.ResponseBody = string(body)
+	return m.repo.SaveResponse(ctx, record)
+}
+
+// Release освобождает ключ незавершённого запроса
+func (m *IdempotencyManagerImpl) Release(ctx context.Context, record *models.IdempotencyKey) error {
+	return m.repo.Delete(ctx, record.ID)
+}
+
+// PurgeExpired удаляет ключи с истёкшим сроком хранения
+func (m *IdempotencyManagerImpl) PurgeExpired(ctx context.Context, now time.Time) (int, error) {
+	n, err := m.repo.DeleteExpired(ctx, now)
+	return int(n), err
+}
@@ -0,0 +1,416 @@
+package managers
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/mail"
+	"unicode/utf8"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// ImportManagerImpl реализует interfaces.ImportManager
+type ImportManagerImpl struct {
+	importRepo         interfaces.ImportJobRepository
+	userRepo           interfaces.UserRepository
+	groupRepo          interfaces.StudentGroupRepository
+	deptRepo           interfaces.DepartmentRepository
+	studentProfileRepo interfaces.StudentProfileRepository
+	teacherProfileRepo interfaces.TeacherProfileRepository
+	webhooks           interfaces.WebhookDispatcher
+	uow                interfaces.UnitOfWork
+}
+
+// NewImportManager создаёт новый ImportManager
+func NewImportManager(
+	importRepo interfaces.ImportJobRepository,
+	userRepo interfaces.UserRepository,
+	groupRepo interfaces.StudentGroupRepository,
+	deptRepo interfaces.DepartmentRepository,
+	studentProfileRepo interfaces.StudentProfileRepository,
+	teacherProfileRepo interfaces.TeacherProfileRepository,
+	webhooks interfaces.WebhookDispatcher,
+	uow interfaces.UnitOfWork,
+) interfaces.ImportManager {
+	return &ImportManagerImpl{
+		importRepo:         importRepo,
+		userRepo:           userRepo,
+		groupRepo:          groupRepo,
+		deptRepo:           deptRepo,
+		studentProfileRepo: studentProfileRepo,
+		teacherProfileRepo: teacherProfileRepo,
+		webhooks:           webhooks,
+		uow:                uow,
+	}
+}
+
+// importRun - состояние одной загрузки
+type importRun struct {
+	req    interfaces.ImportRosterRequest
+	report *interfaces.ImportReport
+	emails map[string]int                  // email -> строка, где он встретился впервые
+	groups map[string]*models.StudentGroup // группы по коду, включая созданные этой загрузкой
+}
+
+// ImportRoster загружает список студентов или преподавателей. Пользователи сопоставляются
+// по email: новые создаются с начальным паролем, существующие обновляются. При dry_run
+// изменения не записываются, но отчёт и журнал с ошибками строк сохраняются.
+func (m *ImportManagerImpl) ImportRoster(
+	ctx context.Context,
+	actorID uint,
+	fileName string,
+	file io.Reader,
+	req interfaces.ImportRosterRequest,
+) (*interfaces.ImportReport, error) {
+	rows, err := readRoster(fileName, file)
+	if err != nil {
+		return nil, err
+	}
+	if req.DepartmentID != 0 {
+		if _, err := m.deptRepo.GetByID(ctx, req.DepartmentID); err != nil {
+			return nil, err
+		}
+	}
+
+	run := &importRun{
+		req: req,
+		report: &interfaces.ImportReport{
+			Kind:     req.Kind,
+			FileName: fileName,
+			DryRun:   req.DryRun,
+			Total:    len(rows),
+			Rows:     make([]interfaces.ImportRowResult, 0, len(rows)),
+		},
+		emails: make(map[string]int),
+		groups: make(map[string]*models.StudentGroup),
+	}
+
+	job := &models.ImportJob{
+		Kind:        req.Kind,
+		FileName:    fileName,
+		DryRun:      req.DryRun,
+		CreatedByID: actorID,
+		Total:       len(rows),
+	}
+	for _, row := range rows {
+		result := interfaces.ImportRowResult{Row: row.Line, Email: row.Email}
+		action, err := m.importRow(ctx, run, row)
+		if err != nil {
+			result.Action = interfaces.ImportRowError
+			result.Message = err.Error()
+			job.Errors = append(job.Errors, models.ImportRowError{Row: row.Line, Email: row.Email, Message: err.Error()})
+		} else {
+			result.Action = action
+		}
+		switch result.Action {
+		case interfaces.ImportRowCreate:
+			run.report.Created++
+		case interfaces.ImportRowUpdate:
+			run.report.Updated++
+		case interfaces.ImportRowUnchanged:
+			run.report.Unchanged++
+		case interfaces.ImportRowError:
+			run.report.Failed++
+		}
+		run.report.Rows = append(run.report.Rows, result)
+	}
+
+	job.Created = run.report.Created
+	job.Updated = run.report.Updated
+	job.Unchanged = run.report.Unchanged
+	job.Failed = run.report.Failed
+	if err := m.importRepo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+	run.report.ImportID = job.ID
+	return run.report, nil
+}
+
+// GetImport возвращает журнал загрузки с ошибками строк
+func (m *ImportManagerImpl) GetImport(ctx context.Context, importID uint) (*models.ImportJob, error) {
+	return m.importRepo.GetByID(ctx, importID)
+}
+
+// ListImports возвращает последние загрузки
+func (m *ImportManagerImpl) ListImports(ctx context.Context, limit, offset int) ([]models.ImportJob, error) {
+	return m.importRepo.List(ctx, limit, offset)
+}
+
+// importRow проверяет строку и применяет её (кроме dry_run)
+func (m *ImportManagerImpl) importRow(ctx context.Context, run *importRun, row rosterRow) (interfaces.ImportRowAction, error) {
+	if err := validateRosterRow(row); err != nil {
+		return "", err
+	}
+	if first, dup := run.emails[row.Email]; dup {
+		return "", fmt.Errorf("duplicate email, first seen in row %d", first)
+	}
+	run.emails[row.Email] = row.Line
+
+	role := models.RoleStudent
+	if run.req.Kind == models.ImportKindTeachers {
+		role = models.RoleTeacher
+	}
+	user, err := m.userRepo.GetByEmail(ctx, row.Email)
+	switch {
+	case errors.Is(err, interfaces.ErrNotFound):
+		user = nil
+	case err != nil:
+		return "", err
+	case user.Role != role:
+		return "", fmt.Errorf("user already exists with role %s", user.Role)
+	}
+
+	if role == models.RoleTeacher {
+		return m.importTeacher(ctx, run, row, user)
+	}
+	return m.importStudent(ctx, run, row, user)
+}
+
+func (m *ImportManagerImpl) importStudent(ctx context.Context, run *importRun, row rosterRow, user *models.User) (interfaces.ImportRowAction, error) {
+	if row.GroupCode == "" {
+		return "", errors.New("group code is required")
+	}
+	group, err := m.resolveGroup(ctx, run, row)
+	if err != nil {
+		return "", err
+	}
+
+	var profile *models.StudentProfile
+	if user != nil {
+		if profile, err = m.studentProfileRepo.GetByUserID(ctx, user.ID); err != nil && !errors.Is(err, interfaces.ErrNotFound) {
+			return "", err
+		}
+		changed := user.LastName != row.LastName || user.FirstName != row.FirstName ||
+			profile == nil || profile.GroupID != group.ID ||
+			(row.StudentNumber != "" && profile.StudentNumber != row.StudentNumber)
+		if !changed {
+			return interfaces.ImportRowUnchanged, nil
+		}
+	}
+	action := actionFor(user)
+	if run.req.DryRun {
+		return action, nil
+	}
+
+	err = m.saveRow(ctx, run, row, user, models.RoleStudent, func(ctx context.Context, user *models.User) error {
+		if profile == nil {
+			return m.studentProfileRepo.Create(ctx, &models.StudentProfile{
+				UserID:        user.ID,
+				GroupID:       group.ID,
+				StudentNumber: row.StudentNumber,
+			})
+		}
+		profile.GroupID = group.ID
+		if row.StudentNumber != "" {
+			profile.StudentNumber = row.StudentNumber
+		}
+		return m.studentProfileRepo.Update(ctx, profile)
+	})
+	if err != nil {
+		return "", err
+	}
+	return action, nil
+}
+
+func (m *ImportManagerImpl) importTeacher(ctx context.Context, run *importRun, row rosterRow, user *models.User) (interfaces.ImportRowAction, error) {
+	var (
+		dept *models.Department
+		err  error
+	)
+	switch {
+	case row.DepartmentCode != "":
+		dept, err = m.deptRepo.GetByCode(ctx, row.DepartmentCode)
+	case run.req.DepartmentID != 0:
+		dept, err = m.deptRepo.GetByID(ctx, run.req.DepartmentID)
+	default:
+		err = errors.New("department is required: fill the department column or pass department_id")
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var profile *models.TeacherProfile
+	if user != nil {
+		if profile, err = m.teacherProfileRepo.GetByUserID(ctx, user.ID); err != nil && !errors.Is(err, interfaces.ErrNotFound) {
+			return "", err
+		}
+		changed := user.LastName != row.LastName || user.FirstName != row.FirstName ||
+			profile == nil || profile.DepartmentID != dept.ID ||
+			(row.Position != "" && profile.Position != row.Position) ||
+			(row.AcademicDegree != "" && profile.AcademicDegree != row.AcademicDegree)
+		if !changed {
+			return interfaces.ImportRowUnchanged, nil
+		}
+	}
+	action := actionFor(user)
+	if run.req.DryRun {
+		return action, nil
+	}
+
+	err = m.saveRow(ctx, run, row, user, models.RoleTeacher, func(ctx context.Context, user *models.User) error {
+		if profile == nil {
+			return m.teacherProfileRepo.Create(ctx, &models.TeacherProfile{
+				UserID:         user.ID,
+				DepartmentID:   dept.ID,
+				Position:       row.Position,
+				AcademicDegree: row.AcademicDegree,
+			})
+		}
+		profile.DepartmentID = dept.ID
+		if row.Position != "" {
+			profile.Position = row.Position
+		}
+		if row.AcademicDegree != "" {
+			profile.AcademicDegree = row.AcademicDegree
+		}
+		return m.teacherProfileRepo.Update(ctx, profile)
+	})
+	if err != nil {
+		return "", err
+	}
+	return action, nil
+}
+
+// resolveGroup находит группу по коду или заводит новую (при dry_run - только в памяти)
+func (m *ImportManagerImpl) resolveGroup(ctx context.Context, run *importRun, row rosterRow) (*models.StudentGroup, error) {
+	if group, ok := run.groups[row.GroupCode]; ok {
+		return group, nil
+	}
+	group, err := m.groupRepo.GetByCode(ctx, row.GroupCode)
+	if err == nil {
+		run.groups[row.GroupCode] = group
+		return group, nil
+	}
+	if !errors.Is(err, interfaces.ErrNotFound) {
+		return nil, err
+	}
+
+	courseYear := row.CourseYear
+	if courseYear == 0 {
+		courseYear = run.req.CourseYear
+	}
+	if run.req.DepartmentID == 0 || courseYear == 0 {
+		return nil, fmt.Errorf("group %s not found; pass department_id and course year to create it", row.GroupCode)
+	}
+	group = &models.StudentGroup{
+		GroupCode:    row.GroupCode,
+		CourseYear:   courseYear,
+		Specialty:    row.Specialty,
+		DepartmentID: run.req.DepartmentID,
+	}
+	if !run.req.DryRun {
+		if err := m.groupRepo.Create(ctx, group); err != nil {
+			return nil, err
+		}
+	}
+	run.groups[row.GroupCode] = group
+	run.report.GroupsCreated = append(run.report.GroupsCreated, row.GroupCode)
+	return group, nil
+}
+
+// saveRow записывает пользователя и его профиль одной транзакцией: сбой профиля не оставит
+// пользователя без профиля, который помешал бы повторной загрузке. Начальный пароль попадает
+// в отчёт только после фиксации.
+func (m *ImportManagerImpl) saveRow(ctx context.Context, run *importRun, row rosterRow, user *models.User, role models.UserRole, saveProfile func(ctx context.Context, user *models.User) error) error {
+	var password string
+	err := m.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if user, password, err = m.saveUser(ctx, row, user, role); err != nil {
+			return err
+		}
+		return saveProfile(ctx, user)
+	})
+	if err != nil {
+		return err
+	}
+	if password != "" {
+		run.report.Credentials = append(run.report.Credentials, interfaces.ImportCredential{Email: user.Email, Password: password})
+	}
+	return nil
+}
+
+// saveUser создаёт пользователя с начальным паролем или обновляет ФИО существующего;
+// пароль возвращается только для нового пользователя
+func (m *ImportManagerImpl) saveUser(ctx context.Context, row rosterRow, user *models.User, role models.UserRole) (*models.User, string, error) {
+	if user != nil {
+		user.LastName = row.LastName
+		user.FirstName = row.FirstName
+		if err := m.userRepo.Update(ctx, user); err != nil {
+			return nil, "", err
+		}
+		return user, "", nil
+	}
+
+	password, err := generatePassword(initialPasswordLength)
+	if err != nil {
+		return nil, "", err
+	}
+	hash, err := models.HashPassword(password)
+	if err != nil {
+		return nil, "", err
+	}
+	user = &models.User{
+		Email:        row.Email,
+		PasswordHash: hash,
+		FirstName:    row.FirstName,
+		LastName:     row.LastName,
+		Role:         role,
+		IsActive:     true,
+	}
+	if err := m.userRepo.Create(ctx, user); err != nil {
+		return nil, "", err
+	}
+	// очередь вебхуков пишется в той же транзакции и откатится вместе со строкой
+	if err := m.webhooks.Enqueue(ctx, models.WebhookUserCreated, webhookUser(user)); err != nil {
+		return nil, "", err
+	}
+	return user, password, nil
+}
+
+// validateRosterRow проверяет обязательные поля строки по тем же правилам, что и регистрация
+func validateRosterRow(row rosterRow) error {
+	if row.ParseError != "" {
+		return errors.New(row.ParseError)
+	}
+	if addr, err := mail.ParseAddress(row.Email); err != nil || addr.Address != row.Email {
+		return fmt.Errorf("invalid email %q", row.Email)
+	}
+	for _, name := range []string{row.LastName, row.FirstName} {
+		if n := utf8.RuneCountInString(name); n < 2 || n > 50 {
+			return errors.New("first and last name must be 2-50 characters")
+		}
+	}
+	return nil
+}
+
+func actionFor(user *models.User) interfaces.ImportRowAction {
+	if user == nil {
+		return interfaces.ImportRowCreate
+	}
+	return interfaces.ImportRowUpdate
+}
+
+const (
+	initialPasswordLength = 10
+	// без похожих символов (0/O, 1/l/I), чтобы пароль можно было продиктовать
+	passwordAlphabet = "abcdefghjkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+// generatePassword создаёт случайный начальный пароль
+func generatePassword(length int) (string, error) {
+	buf := make([]byte, length)
+	max := big.NewInt(int64(len(passwordAlphabet)))
+	for i := range buf {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate password: %w", err)
+		}
+		buf[i] = passwordAlphabet[n.Int64()]
+	}
+	return string(buf), nil
+}
@@ -0,0 +1,62 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"github.com/Foxpunk/courseforge/internal/drivers"
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"github.com/Foxpunk/courseforge/internal/testutil"
+)
+
+// failingStudentProfiles - хранилище профилей, в которое не удаётся записать новый профиль
+type failingStudentProfiles struct {
+	interfaces.StudentProfileRepository
+}
+
+func (failingStudentProfiles) Create(context.Context, *models.StudentProfile) error {
+	return errors.New("profile storage is unavailable")
+}
+
+func newTestImportManager(db *gorm.DB, profiles interfaces.StudentProfileRepository) interfaces.ImportManager {
+	return NewImportManager(drivers.NewImportJobRepository(db), drivers.NewUserRepository(db),
+		drivers.NewStudentGroupRepository(db), drivers.NewDepartmentRepository(db), profiles,
+		drivers.NewTeacherProfileRepository(db), testutil.NopWebhooks{}, drivers.NewUnitOfWork(db))
+}
+
+func TestImportRowRollsBackUserWhenProfileFails(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	dept := &models.Department{DepartmentCode: "IT", DepartmentName: "Информационные технологии"}
+	testutil.MustCreate(t, db, dept)
+	testutil.MustCreate(t, db, &models.StudentGroup{GroupCode: "IT-31", CourseYear: 3, DepartmentID: dept.ID})
+	roster := "фамилия,имя,email,группа\nИванов,Иван,ivanov@example.com,IT-31\n"
+	req := interfaces.ImportRosterRequest{Kind: models.ImportKindStudents}
+
+	report, err := newTestImportManager(db, failingStudentProfiles{drivers.NewStudentProfileRepository(db)}).
+		ImportRoster(ctx, 1, "students.csv", strings.NewReader(roster), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Failed != 1 || len(report.Credentials) != 0 {
+		t.Fatalf("failed = %d, credentials = %d; want 1 failed row and no credentials", report.Failed, len(report.Credentials))
+	}
+	if _, err := drivers.NewUserRepository(db).GetByEmail(ctx, "ivanov@example.com"); !errors.Is(err, interfaces.ErrNotFound) {
+		t.Fatalf("user after a failed profile insert: %v, want ErrNotFound", err)
+	}
+
+	// повторная загрузка создаёт пользователя заново, а не спотыкается о полузаписанную строку
+	report, err = newTestImportManager(db, drivers.NewStudentProfileRepository(db)).
+		ImportRoster(ctx, 1, "students.csv", strings.NewReader(roster), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Created != 1 || len(report.Credentials) != 1 {
+		t.Errorf("created = %d, credentials = %d; want 1 and 1", report.Created, len(report.Credentials))
+	}
+}
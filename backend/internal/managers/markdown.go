@@ -0,0 +1,58 @@
+package managers
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	gmhtml "github.com/yuin/goldmark/renderer/html"
+)
+
+// markdown переводит сообщения в HTML. Небезопасный режим не включён: сырой HTML
+// из текста выбрасывается, ссылки вида javascript: не выводятся.
+var markdown = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithRendererOptions(gmhtml.WithHardWraps()),
+)
+
+// mentionPattern - упоминание пользователя в тексте: <@ID>
+var mentionPattern = regexp.MustCompile(`<@(\d+)>`)
+
+// renderedMention - то же упоминание после экранирования markdown-рендером
+var renderedMention = regexp.MustCompile(`&lt;@(\d+)&gt;`)
+
+// parseMentions возвращает ID упомянутых пользователей без повторов в порядке появления
+func parseMentions(body string) []uint {
+	var ids []uint
+	seen := make(map[uint]bool)
+	for _, m := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		id, err := strconv.ParseUint(m[1], 10, 32)
+		if err != nil || id == 0 || seen[uint(id)] {
+			continue
+		}
+		seen[uint(id)] = true
+		ids = append(ids, uint(id))
+	}
+	return ids
+}
+
+// renderMarkdown переводит текст в HTML и подставляет имена упомянутых пользователей
+func renderMarkdown(body string, names map[uint]string) (string, error) {
+	var buf bytes.Buffer
+	if err := markdown.Convert([]byte(body), &buf); err != nil {
+		return "", fmt.Errorf("failed to render markdown: %w", err)
+	}
+	out := renderedMention.ReplaceAllStringFunc(buf.String(), func(s string) string {
+		id, _ := strconv.ParseUint(renderedMention.FindStringSubmatch(s)[1], 10, 32)
+		name, ok := names[uint(id)]
+		if !ok {
+			return s
+		}
+		return fmt.Sprintf(`<span class="mention" data-user-id="%d">@%s</span>`, id, html.EscapeString(name))
+	})
+	return out, nil
+}
@@ -0,0 +1,113 @@
+package managers
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"sort"
+)
+
+// matchApplicant - участник распределения со списком тем по убыванию желательности
+type matchApplicant struct {
+	ID    uint
+	Prefs []uint
+}
+
+// matchTopic - тема с числом свободных мест и рейтингом руководителя (1 - лучший)
+type matchTopic struct {
+	ID       uint
+	Capacity int
+	Ranking  map[uint]int
+}
+
+// matchPriority - приоритет студента у темы: сначала рейтинг руководителя, затем жребий
+type matchPriority struct {
+	rank    int
+	lottery uint64
+}
+
+func (p matchPriority) less(o matchPriority) bool {
+	if p.rank != o.rank {
+		return p.rank < o.rank
+	}
+	return p.lottery < o.lottery
+}
+
+// lotteryTicket - детерминированный жребий студента в раунде, одинаковый для превью и фиксации
+func lotteryTicket(roundID, studentID uint) uint64 {
+	var buf [16]byte
+	binary.BigEndian.PutUint64(buf[:8], uint64(roundID))
+	binary.BigEndian.PutUint64(buf[8:], uint64(studentID))
+	h := fnv.New64a()
+	h.Write(buf[:])
+	return h.Sum64()
+}
+
+// deferredAcceptance реализует алгоритм отложенного принятия (Гейла-Шепли) с предложениями от студентов.
+// Результат стабилен: ни одна пара студент-тема не предпочла бы друг друга текущему распределению,
+// и ни одному студенту невыгодно искажать свой список предпочтений.
+// Возвращает отображение студент -> тема для распределённых студентов.
+func deferredAcceptance(roundID uint, applicants []matchApplicant, topics []matchTopic) map[uint]uint {
+	topicByID := make(map[uint]*matchTopic, len(topics))
+	for i := range topics {
+		topicByID[topics[i].ID] = &topics[i]
+	}
+
+	priority := func(t *matchTopic, studentID uint) matchPriority {
+		rank, ok := t.Ranking[studentID]
+		if !ok {
+			// студенты без оценки руководителя идут после оценённых
+			rank = int(^uint(0) >> 1)
+		}
+		return matchPriority{rank: rank, lottery: lotteryTicket(roundID, studentID)}
+	}
+
+	next := make(map[uint]int, len(applicants))
+	prefsOf := make(map[uint][]uint, len(applicants))
+	held := make(map[uint][]uint, len(topics))
+
+	free := make([]uint, 0, len(applicants))
+	for _, a := range applicants {
+		prefsOf[a.ID] = a.Prefs
+		free = append(free, a.ID)
+	}
+
+	for len(free) > 0 {
+		studentID := free[0]
+		free = free[1:]
+
+		prefs := prefsOf[studentID]
+		for next[studentID] < len(prefs) {
+			topic, ok := topicByID[prefs[next[studentID]]]
+			next[studentID]++
+			if !ok || topic.Capacity <= 0 {
+				continue
+			}
+
+			list := append(held[topic.ID], studentID)
+			sort.SliceStable(list, func(i, j int) bool {
+				return priority(topic, list[i]).less(priority(topic, list[j]))
+			})
+			if len(list) > topic.Capacity {
+				// тема отклоняет наименее приоритетного студента, он продолжает со следующей темы
+				rejected := list[len(list)-1]
+				list = list[:len(list)-1]
+				held[topic.ID] = list
+				if rejected != studentID {
+					free = append(free, rejected)
+					break
+				}
+				continue
+			}
+			held[topic.ID] = list
+			break
+		}
+	}
+
+	result := make(map[uint]uint)
+	for topicID, students := range held {
+		for _, studentID := range students {
+			result[studentID] = topicID
+		}
+	}
+	return result
+}
@@ -0,0 +1,198 @@
+package managers
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testRoundID = 7
+
+// lotteryOrder возвращает двух студентов в порядке их жребия в тестовом раунде
+func lotteryOrder(a, b uint) (winner, loser uint) {
+	if lotteryTicket(testRoundID, a) < lotteryTicket(testRoundID, b) {
+		return a, b
+	}
+	return b, a
+}
+
+func TestDeferredAcceptance(t *testing.T) {
+	winner, loser := lotteryOrder(1, 2)
+
+	tests := []struct {
+		name       string
+		applicants []matchApplicant
+		topics     []matchTopic
+		want       map[uint]uint
+	}{
+		{
+			name: "capacity limits a popular topic",
+			applicants: []matchApplicant{
+				{ID: 1, Prefs: []uint{10, 20}},
+				{ID: 2, Prefs: []uint{10, 20}},
+				{ID: 3, Prefs: []uint{10, 20}},
+			},
+			topics: []matchTopic{
+				{ID: 10, Capacity: 2, Ranking: map[uint]int{3: 1, 1: 2, 2: 3}},
+				{ID: 20, Capacity: 1, Ranking: map[uint]int{}},
+			},
+			want: map[uint]uint{1: 10, 3: 10, 2: 20},
+		},
+		{
+			name: "supervisor ranking displaces an earlier applicant",
+			applicants: []matchApplicant{
+				{ID: 1, Prefs: []uint{10, 20}},
+				{ID: 2, Prefs: []uint{10}},
+			},
+			topics: []matchTopic{
+				{ID: 10, Capacity: 1, Ranking: map[uint]int{2: 1, 1: 2}},
+				{ID: 20, Capacity: 1, Ranking: map[uint]int{}},
+			},
+			want: map[uint]uint{2: 10, 1: 20},
+		},
+		{
+			name: "lottery breaks a tie between unranked applicants",
+			applicants: []matchApplicant{
+				{ID: 1, Prefs: []uint{10}},
+				{ID: 2, Prefs: []uint{10}},
+			},
+			topics: []matchTopic{
+				{ID: 10, Capacity: 1, Ranking: map[uint]int{}},
+			},
+			want: map[uint]uint{winner: 10},
+		},
+		{
+			name: "ranked applicants go before unranked ones",
+			applicants: []matchApplicant{
+				{ID: winner, Prefs: []uint{10}},
+				{ID: loser, Prefs: []uint{10}},
+			},
+			topics: []matchTopic{
+				{ID: 10, Capacity: 1, Ranking: map[uint]int{loser: 1}},
+			},
+			want: map[uint]uint{loser: 10},
+		},
+		{
+			name: "unknown and full topics are skipped",
+			applicants: []matchApplicant{
+				{ID: 1, Prefs: []uint{99, 10, 20}},
+			},
+			topics: []matchTopic{
+				{ID: 10, Capacity: 0, Ranking: map[uint]int{}},
+				{ID: 20, Capacity: 1, Ranking: map[uint]int{}},
+			},
+			want: map[uint]uint{1: 20},
+		},
+		{
+			name: "student stays unmatched when all ranked topics are filled",
+			applicants: []matchApplicant{
+				{ID: 1, Prefs: []uint{10}},
+				{ID: 2, Prefs: []uint{10}},
+			},
+			topics: []matchTopic{
+				{ID: 10, Capacity: 1, Ranking: map[uint]int{2: 1}},
+			},
+			want: map[uint]uint{2: 10},
+		},
+		{
+			name: "student without preferences is not matched",
+			applicants: []matchApplicant{
+				{ID: 1},
+				{ID: 2, Prefs: []uint{10}},
+			},
+			topics: []matchTopic{
+				{ID: 10, Capacity: 2, Ranking: map[uint]int{}},
+			},
+			want: map[uint]uint{2: 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := deferredAcceptance(testRoundID, tt.applicants, tt.topics)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("matching = %v, want %v", got, tt.want)
+			}
+			checkStable(t, tt.applicants, tt.topics, got)
+
+			// порядок подачи не влияет на результат
+			reversed := make([]matchApplicant, len(tt.applicants))
+			for i, a := range tt.applicants {
+				reversed[len(reversed)-1-i] = a
+			}
+			if again := deferredAcceptance(testRoundID, reversed, tt.topics); !reflect.DeepEqual(again, got) {
+				t.Errorf("matching for reversed applicants = %v, want %v", again, got)
+			}
+		})
+	}
+}
+
+func TestDeferredAcceptanceStableOnCrossedPreferences(t *testing.T) {
+	// у руководителей свой порядок студентов: студент 1 уступает первую тему студенту 4
+	applicants := []matchApplicant{
+		{ID: 1, Prefs: []uint{10, 20, 30}},
+		{ID: 2, Prefs: []uint{20, 30, 10}},
+		{ID: 3, Prefs: []uint{30, 10, 20}},
+		{ID: 4, Prefs: []uint{10, 20, 30}},
+	}
+	topics := []matchTopic{
+		{ID: 10, Capacity: 1, Ranking: map[uint]int{2: 1, 3: 2, 4: 3, 1: 4}},
+		{ID: 20, Capacity: 2, Ranking: map[uint]int{3: 1, 1: 2, 4: 3, 2: 4}},
+		{ID: 30, Capacity: 1, Ranking: map[uint]int{1: 1, 2: 2, 4: 3, 3: 4}},
+	}
+	got := deferredAcceptance(testRoundID, applicants, topics)
+	want := map[uint]uint{1: 20, 2: 20, 3: 30, 4: 10}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("matching = %v, want %v", got, want)
+	}
+	checkStable(t, applicants, topics, got)
+}
+
+// checkStable проверяет, что ни один студент не предпочёл бы тему, у которой есть свободное
+// место или назначенный студент с меньшим приоритетом
+func checkStable(t *testing.T, applicants []matchApplicant, topics []matchTopic, matching map[uint]uint) {
+	t.Helper()
+	held := make(map[uint][]uint)
+	for studentID, topicID := range matching {
+		held[topicID] = append(held[topicID], studentID)
+	}
+	priority := func(topic matchTopic, studentID uint) matchPriority {
+		rank, ok := topic.Ranking[studentID]
+		if !ok {
+			rank = int(^uint(0) >> 1)
+		}
+		return matchPriority{rank: rank, lottery: lotteryTicket(testRoundID, studentID)}
+	}
+
+	for _, topic := range topics {
+		if len(held[topic.ID]) > topic.Capacity {
+			t.Errorf("topic %d holds %d students, capacity %d", topic.ID, len(held[topic.ID]), topic.Capacity)
+		}
+	}
+	for _, a := range applicants {
+		assigned, matched := matching[a.ID]
+		for _, topicID := range a.Prefs {
+			if matched && topicID == assigned {
+				break
+			}
+			var topic *matchTopic
+			for i := range topics {
+				if topics[i].ID == topicID {
+					topic = &topics[i]
+				}
+			}
+			if topic == nil || topic.Capacity <= 0 {
+				continue
+			}
+			if len(held[topicID]) < topic.Capacity {
+				t.Errorf("student %d prefers topic %d, which has a free slot", a.ID, topicID)
+				continue
+			}
+			for _, other := range held[topicID] {
+				if priority(*topic, a.ID).less(priority(*topic, other)) {
+					t.Errorf("student %d and topic %d block the matching: topic holds lower-priority student %d",
+						a.ID, topicID, other)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package managers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// eventDescriptions - подписи видов событий по языкам: в настройках уведомлений и в письмах
+var eventDescriptions = map[models.Locale]map[models.EventType]string{
+	models.LocaleRU: {
+		models.EventAssignmentCreated:       "Назначение темы курсовой работы",
+		models.EventAssignmentCancelled:     "Отмена назначения темы",
+		models.EventAssignmentStatusChanged: "Изменение статуса работы",
+		models.EventAssignmentSubmitted:     "Сдача работы на проверку",
+		models.EventAssignmentCompleted:     "Работа принята",
+		models.EventGradeSet:                "Выставлена оценка",
+		models.EventSubmissionCreated:       "Загружена новая версия файла работы",
+		models.EventProposalCreated:         "Студент предложил свою тему",
+		models.EventProposalReviewed:        "Решение по предложенной теме",
+		models.EventWaitlistOffer:           "Освободилось место в листе ожидания",
+		models.EventWaitlistOfferExpired:    "Истёк срок предложения из листа ожидания",
+		models.EventTeamInvitation:          "Приглашение в команду",
+		models.EventTeamMemberJoined:        "Новый участник команды",
+		models.EventCommentCreated:          "Новые сообщения в обсуждениях",
+		models.EventCommentMention:          "Упоминания в обсуждениях",
+		models.EventDeadlineApproaching:     "Напоминания о приближающихся сроках",
+	},
+	models.LocaleEN: {
+		models.EventAssignmentCreated:       "Coursework topic assigned",
+		models.EventAssignmentCancelled:     "Topic assignment cancelled",
+		models.EventAssignmentStatusChanged: "Coursework status changed",
+		models.EventAssignmentSubmitted:     "Coursework submitted for review",
+		models.EventAssignmentCompleted:     "Coursework accepted",
+		models.EventGradeSet:                "Grade posted",
+		models.EventSubmissionCreated:       "New version of a coursework file uploaded",
+		models.EventProposalCreated:         "Student proposed a topic",
+		models.EventProposalReviewed:        "Decision on a proposed topic",
+		models.EventWaitlistOffer:           "A place opened up on the waitlist",
+		models.EventWaitlistOfferExpired:    "Waitlist offer expired",
+		models.EventTeamInvitation:          "Team invitation",
+		models.EventTeamMemberJoined:        "New team member",
+		models.EventCommentCreated:          "New messages in discussions",
+		models.EventCommentMention:          "Mentions in discussions",
+		models.EventDeadlineApproaching:     "Upcoming deadline reminders",
+	},
+}
+
+// NotificationManagerImpl реализует interfaces.NotificationManager
+type NotificationManagerImpl struct {
+	repo     interfaces.NotificationRepository
+	channels []interfaces.NotificationChannel
+}
+
+// NewNotificationManager создаёт новый центр уведомлений; сохранённые уведомления
+// дополнительно передаются в каналы доставки
+func NewNotificationManager(repo interfaces.NotificationRepository, channels ...interfaces.NotificationChannel) interfaces.NotificationManager {
+	return &NotificationManagerImpl{repo: repo, channels: channels}
+}
+
+// Notify сохраняет уведомление, если получатель не отключил события этого вида.
+// О собственных действиях пользователь не уведомляется.
+func (m *NotificationManagerImpl) Notify(ctx context.Context, event interfaces.Event) error {
+	if event.RecipientID == 0 || event.RecipientID == event.ActorID {
+		return nil
+	}
+	enabled, err := m.repo.IsEnabled(ctx, event.RecipientID, event.Type)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	n := &models.Notification{
+		UserID:     event.RecipientID,
+		Event:      event.Type,
+		EntityType: event.EntityType,
+		EntityID:   event.EntityID,
+		Title:      event.Title,
+		Message:    event.Message,
+	}
+	if event.ActorID != 0 {
+		actorID := event.ActorID
+		n.ActorID = &actorID
+	}
+	if err := m.repo.Create(ctx, n); err != nil {
+		return err
+	}
+	// Сбой канала не отменяет уведомление в приложении
+	for _, ch := range m.channels {
+		if err := ch.Deliver(ctx, n); err != nil {
+			log.Printf("failed to deliver notification %d: %v", n.ID, err)
+		}
+	}
+	return nil
+}
+
+// ListNotifications возвращает уведомления пользователя, новые сверху
+func (m *NotificationManagerImpl) ListNotifications(ctx context.Context, userID uint, unreadOnly bool, limit, offset int) ([]models.Notification, error) {
+	return m.repo.List(ctx, userID, unreadOnly, limit, offset)
+}
+
+// CountUnread возвращает число непрочитанных уведомлений
+func (m *NotificationManagerImpl) CountUnread(ctx context.Context, userID uint) (int64, error) {
+	return m.repo.CountUnread(ctx, userID)
+}
+
+// MarkRead отмечает прочитанными уведомления пользователя
+func (m *NotificationManagerImpl) MarkRead(ctx context.Context, userID uint, ids []uint) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	return m.repo.MarkRead(ctx, userID, ids, time.Now())
+}
+
+// MarkAllRead отмечает прочитанными все уведомления пользователя
+func (m *NotificationManagerImpl) MarkAllRead(ctx context.Context, userID uint) (int64, error) {
+	return m.repo.MarkRead(ctx, userID, nil, time.Now())
+}
+
+// GetPreferences возвращает настройки по всем видам событий
+func (m *NotificationManagerImpl) GetPreferences(ctx context.Context, userID uint) ([]interfaces.NotificationPreferenceResponse, error) {
+	saved, err := m.repo.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	enabled := make(map[models.EventType]bool, len(saved))
+	for _, p := range saved {
+		enabled[p.Event] = p.Enabled
+	}
+
+	result := make([]interfaces.NotificationPreferenceResponse, 0, len(models.EventTypes))
+	for _, event := range models.EventTypes {
+		on, ok := enabled[event]
+		result = append(result, interfaces.NotificationPreferenceResponse{
+			Event:       event,
+			Description: eventDescriptions[models.LocaleRU][event],
+			Enabled:     !ok || on,
+		})
+	}
+	return result, nil
+}
+
+// UpdatePreferences включает и отключает виды событий; не упомянутые в запросе не меняются
+func (m *NotificationManagerImpl) UpdatePreferences(ctx context.Context, userID uint, req interfaces.UpdateNotificationPreferencesRequest) ([]interfaces.NotificationPreferenceResponse, error) {
+	prefs := make([]models.NotificationPreference, 0, len(req.Preferences))
+	for _, p := range req.Preferences {
+		if !p.Event.IsValid() {
+			return nil, fmt.Errorf("unknown event %q", p.Event)
+		}
+		prefs = append(prefs, models.NotificationPreference{UserID: userID, Event: p.Event, Enabled: p.Enabled})
+	}
+	if err := m.repo.SavePreferences(ctx, prefs); err != nil {
+		return nil, err
+	}
+	return m.GetPreferences(ctx, userID)
+}
+
+// assignmentCreatedEvents - о новом назначении узнают руководитель темы и студент.
+// actorID - кто назначил (сам студент при выборе темы, 0 - распределение или очередь).
+func assignmentCreatedEvents(sc *models.StudentCoursework, student *models.User, cw *models.Coursework, actorID uint) []interfaces.Event {
+	return []interfaces.Event{
+		{
+			Type:        models.EventAssignmentCreated,
+			RecipientID: cw.TeacherID,
+			ActorID:     actorID,
+			EntityType:  models.EntityAssignment,
+			EntityID:    sc.ID,
+			Title:       "Тема выбрана",
+			Message:     fmt.Sprintf("%s получил(а) тему «%s».", student.GetFullName(), cw.Title),
+		},
+		{
+			Type:        models.EventAssignmentCreated,
+			RecipientID: sc.StudentID,
+			ActorID:     actorID,
+			EntityType:  models.EntityAssignment,
+			EntityID:    sc.ID,
+			Title:       "Назначена тема курсовой работы",
+			Message:     fmt.Sprintf("Вам назначена тема «%s».", cw.Title),
+		},
+	}
+}
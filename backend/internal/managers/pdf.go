@@ -0,0 +1,114 @@
+package managers
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/go-pdf/fpdf"
+
+	"github.com/Foxpunk/courseforge/internal/assets"
+)
+
+// Параметры печатных форм (A4, мм)
+const (
+	pdfMargin     = 15.0
+	pdfLineHeight = 5.0
+	pdfFontFamily = "DejaVuSerif"
+)
+
+// pdfColumn - столбец таблицы печатной формы
+type pdfColumn struct {
+	title string
+	width float64
+	align string
+}
+
+// newPDF создаёт страницу A4 со шрифтами с кириллицей и нумерацией страниц
+func newPDF() (*fpdf.Fpdf, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(false, pdfMargin)
+	for style, file := range map[string]string{"": "fonts/DejaVuSerif.ttf", "B": "fonts/DejaVuSerif-Bold.ttf"} {
+		data, err := assets.Fonts.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load font %s: %w", file, err)
+		}
+		pdf.AddUTF8FontFromBytes(pdfFontFamily, style, data)
+	}
+	if err := pdf.Error(); err != nil {
+		return nil, fmt.Errorf("failed to load fonts: %w", err)
+	}
+
+	pdf.AliasNbPages("{nb}")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pdfMargin + 5)
+		pdf.SetFont(pdfFontFamily, "", 8)
+		pdf.CellFormat(0, 4, fmt.Sprintf("Стр. %d из {nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+	pdf.AddPage()
+	return pdf, nil
+}
+
+// pdfBottom - нижняя граница области текста над колонтитулом
+func pdfBottom(pdf *fpdf.Fpdf) float64 {
+	_, pageHeight := pdf.GetPageSize()
+	return pageHeight - pdfMargin - 5
+}
+
+// pdfTable рисует таблицу, повторяя шапку на каждой новой странице
+func pdfTable(pdf *fpdf.Fpdf, columns []pdfColumn, records [][]string) {
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.title
+	}
+	pdf.SetFont(pdfFontFamily, "B", 9)
+	pdfTableRow(pdf, columns, header, true)
+
+	pdf.SetFont(pdfFontFamily, "", 9)
+	for _, record := range records {
+		if pdf.GetY()+pdfRowHeight(pdf, columns, record) > pdfBottom(pdf) {
+			pdf.AddPage()
+			pdf.SetFont(pdfFontFamily, "B", 9)
+			pdfTableRow(pdf, columns, header, true)
+			pdf.SetFont(pdfFontFamily, "", 9)
+		}
+		pdfTableRow(pdf, columns, record, false)
+	}
+}
+
+func pdfRowHeight(pdf *fpdf.Fpdf, columns []pdfColumn, record []string) float64 {
+	lines := 1
+	for i, text := range record {
+		if n := len(pdf.SplitText(text, columns[i].width-2)); n > lines {
+			lines = n
+		}
+	}
+	return float64(lines)*pdfLineHeight + 2
+}
+
+// pdfTableRow рисует строку таблицы с переносом текста внутри ячеек
+func pdfTableRow(pdf *fpdf.Fpdf, columns []pdfColumn, record []string, header bool) {
+	height := pdfRowHeight(pdf, columns, record)
+	x, y := pdf.GetXY()
+	for i, text := range record {
+		col := columns[i]
+		align := col.align
+		if header {
+			align = "C"
+		}
+		pdf.Rect(x, y, col.width, height, "D")
+		pdf.SetXY(x+1, y+1)
+		pdf.MultiCell(col.width-2, pdfLineHeight, text, "", align, false)
+		x += col.width
+	}
+	pdf.SetXY(pdfMargin, y+height)
+}
+
+// pdfBytes завершает документ
+func pdfBytes(pdf *fpdf.Fpdf) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to write pdf: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,175 @@
+package managers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// ProfileManagerImpl реализует interfaces.ProfileManager
+type ProfileManagerImpl struct {
+	userRepo           interfaces.UserRepository
+	studentProfileRepo interfaces.StudentProfileRepository
+	teacherProfileRepo interfaces.TeacherProfileRepository
+	groupRepo          interfaces.StudentGroupRepository
+	deptRepo           interfaces.DepartmentRepository
+}
+
+// NewProfileManager создаёт новый ProfileManager
+func NewProfileManager(
+	userRepo interfaces.UserRepository,
+	studentProfileRepo interfaces.StudentProfileRepository,
+	teacherProfileRepo interfaces.TeacherProfileRepository,
+	groupRepo interfaces.StudentGroupRepository,
+	deptRepo interfaces.DepartmentRepository,
+) interfaces.ProfileManager {
+	return &ProfileManagerImpl{
+		userRepo:           userRepo,
+		studentProfileRepo: studentProfileRepo,
+		teacherProfileRepo: teacherProfileRepo,
+		groupRepo:          groupRepo,
+		deptRepo:           deptRepo,
+	}
+}
+
+// GetStudentProfile возвращает профиль студента с группой
+func (m *ProfileManagerImpl) GetStudentProfile(ctx context.Context, userID uint) (*models.StudentProfile, error) {
+	return m.studentProfileRepo.GetByUserID(ctx, userID)
+}
+
+// CreateStudentProfile заводит профиль студента и зачисляет его в группу
+func (m *ProfileManagerImpl) CreateStudentProfile(ctx context.Context, req interfaces.CreateStudentProfileRequest) (*models.StudentProfile, error) {
+	if err := m.checkRole(ctx, req.UserID, models.RoleStudent); err != nil {
+		return nil, err
+	}
+	if _, err := m.studentProfileRepo.GetByUserID(ctx, req.UserID); err == nil {
+		return nil, errors.New("student profile already exists")
+	}
+	if _, err := m.groupRepo.GetByID(ctx, req.GroupID); err != nil {
+		return nil, err
+	}
+
+	profile := &models.StudentProfile{
+		UserID:        req.UserID,
+		GroupID:       req.GroupID,
+		StudentNumber: req.StudentNumber,
+	}
+	if err := m.studentProfileRepo.Create(ctx, profile); err != nil {
+		return nil, err
+	}
+	return m.studentProfileRepo.GetByUserID(ctx, req.UserID)
+}
+
+// UpdateStudentProfile переводит студента в другую группу или меняет номер зачётки
+func (m *ProfileManagerImpl) UpdateStudentProfile(ctx context.Context, userID uint, req interfaces.UpdateStudentProfileRequest) (*models.StudentProfile, error) {
+	profile, err := m.studentProfileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.GroupID != nil {
+		if _, err := m.groupRepo.GetByID(ctx, *req.GroupID); err != nil {
+			return nil, err
+		}
+		profile.GroupID = *req.GroupID
+	}
+	if req.StudentNumber != nil {
+		profile.StudentNumber = *req.StudentNumber
+	}
+	if err := m.studentProfileRepo.Update(ctx, profile); err != nil {
+		return nil, err
+	}
+	return m.studentProfileRepo.GetByUserID(ctx, userID)
+}
+
+// DeleteStudentProfile удаляет профиль студента
+func (m *ProfileManagerImpl) DeleteStudentProfile(ctx context.Context, userID uint) error {
+	profile, err := m.studentProfileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return m.studentProfileRepo.Delete(ctx, profile.ID)
+}
+
+// GetTeacherProfile возвращает профиль преподавателя с кафедрой
+func (m *ProfileManagerImpl) GetTeacherProfile(ctx context.Context, userID uint) (*models.TeacherProfile, error) {
+	return m.teacherProfileRepo.GetByUserID(ctx, userID)
+}
+
+// ListTeacherProfiles возвращает профили преподавателей; departmentID == 0 - всех кафедр
+func (m *ProfileManagerImpl) ListTeacherProfiles(ctx context.Context, departmentID uint) ([]models.TeacherProfile, error) {
+	if departmentID != 0 {
+		return m.teacherProfileRepo.GetByDepartment(ctx, departmentID)
+	}
+	return m.teacherProfileRepo.List(ctx)
+}
+
+// CreateTeacherProfile заводит профиль преподавателя на кафедре
+func (m *ProfileManagerImpl) CreateTeacherProfile(ctx context.Context, req interfaces.CreateTeacherProfileRequest) (*models.TeacherProfile, error) {
+	if err := m.checkRole(ctx, req.UserID, models.RoleTeacher); err != nil {
+		return nil, err
+	}
+	if _, err := m.teacherProfileRepo.GetByUserID(ctx, req.UserID); err == nil {
+		return nil, errors.New("teacher profile already exists")
+	}
+	if _, err := m.deptRepo.GetByID(ctx, req.DepartmentID); err != nil {
+		return nil, err
+	}
+
+	profile := &models.TeacherProfile{
+		UserID:         req.UserID,
+		DepartmentID:   req.DepartmentID,
+		Position:       req.Position,
+		AcademicDegree: req.AcademicDegree,
+	}
+	if err := m.teacherProfileRepo.Create(ctx, profile); err != nil {
+		return nil, err
+	}
+	return m.teacherProfileRepo.GetByUserID(ctx, req.UserID)
+}
+
+// UpdateTeacherProfile обновляет кафедру, должность или учёную степень преподавателя
+func (m *ProfileManagerImpl) UpdateTeacherProfile(ctx context.Context, userID uint, req interfaces.UpdateTeacherProfileRequest) (*models.TeacherProfile, error) {
+	profile, err := m.teacherProfileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.DepartmentID != nil {
+		if _, err := m.deptRepo.GetByID(ctx, *req.DepartmentID); err != nil {
+			return nil, err
+		}
+		profile.DepartmentID = *req.DepartmentID
+	}
+	if req.Position != nil {
+		profile.Position = *req.Position
+	}
+	if req.AcademicDegree != nil {
+		profile.AcademicDegree = *req.AcademicDegree
+	}
+	if err := m.teacherProfileRepo.Update(ctx, profile); err != nil {
+		return nil, err
+	}
+	return m.teacherProfileRepo.GetByUserID(ctx, userID)
+}
+
+// DeleteTeacherProfile удаляет профиль преподавателя
+func (m *ProfileManagerImpl) DeleteTeacherProfile(ctx context.Context, userID uint) error {
+	profile, err := m.teacherProfileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return m.teacherProfileRepo.Delete(ctx, profile.ID)
+}
+
+// checkRole проверяет, что профиль заводится пользователю подходящей роли
+func (m *ProfileManagerImpl) checkRole(ctx context.Context, userID uint, role models.UserRole) error {
+	user, err := m.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Role != role {
+		return errors.New("user role does not match the profile type")
+	}
+	return nil
+}
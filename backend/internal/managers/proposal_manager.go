@@ -0,0 +1,290 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// TopicProposalManagerImpl реализует interfaces.TopicProposalManager
+type TopicProposalManagerImpl struct {
+	proposalRepo interfaces.TopicProposalRepository
+	userRepo     interfaces.UserRepository
+	subjRepo     interfaces.SubjectRepository
+	cwRepo       interfaces.CourseworkRepository
+	scRepo       interfaces.StudentCourseworkRepository
+	termRepo     interfaces.TermRepository
+	scManager    interfaces.StudentCourseworkManager
+	workload     interfaces.WorkloadManager
+	notifier     interfaces.Notifier
+	uow          interfaces.UnitOfWork
+}
+
+// NewTopicProposalManager создаёт новый TopicProposalManager
+func NewTopicProposalManager(
+	proposalRepo interfaces.TopicProposalRepository,
+	userRepo interfaces.UserRepository,
+	subjRepo interfaces.SubjectRepository,
+	cwRepo interfaces.CourseworkRepository,
+	scRepo interfaces.StudentCourseworkRepository,
+	termRepo interfaces.TermRepository,
+	scManager interfaces.StudentCourseworkManager,
+	workload interfaces.WorkloadManager,
+	notifier interfaces.Notifier,
+	uow interfaces.UnitOfWork,
+) interfaces.TopicProposalManager {
+	return &TopicProposalManagerImpl{
+		proposalRepo: proposalRepo,
+		userRepo:     userRepo,
+		subjRepo:     subjRepo,
+		cwRepo:       cwRepo,
+		scRepo:       scRepo,
+		termRepo:     termRepo,
+		scManager:    scManager,
+		workload:     workload,
+		notifier:     notifier,
+		uow:          uow,
+	}
+}
+
+// CreateProposal создаёт предложение темы от студента выбранному руководителю
+func (m *TopicProposalManagerImpl) CreateProposal(ctx context.Context, studentID uint, req interfaces.CreateTopicProposalRequest) (*models.TopicProposal, error) {
+	if _, err := m.scRepo.GetByStudent(ctx, studentID); err == nil {
+		return nil, errors.New("student already has an assigned coursework")
+	}
+	if open, err := m.proposalRepo.GetOpenByStudent(ctx, studentID); err == nil {
+		return nil, fmt.Errorf("student already has an open proposal %d", open.ID)
+	}
+	teacher, err := m.userRepo.GetByID(ctx, req.TeacherID)
+	if err != nil {
+		return nil, err
+	}
+	if !teacher.IsTeacher() {
+		return nil, errors.New("desired supervisor must be a teacher")
+	}
+	if _, err := m.subjRepo.GetByID(ctx, req.SubjectID); err != nil {
+		return nil, err
+	}
+
+	proposal := &models.TopicProposal{
+		StudentID:   studentID,
+		TeacherID:   req.TeacherID,
+		SubjectID:   req.SubjectID,
+		Title:       req.Title,
+		Description: req.Description,
+		Status:      models.ProposalPending,
+		Revision:    1,
+	}
+	if err := m.proposalRepo.Create(ctx, proposal); err != nil {
+		return nil, err
+	}
+	created, err := m.proposalRepo.GetByID(ctx, proposal.ID)
+	if err != nil {
+		return nil, err
+	}
+	m.notify(ctx, interfaces.Event{
+		Type:        models.EventProposalCreated,
+		RecipientID: created.TeacherID,
+		ActorID:     studentID,
+		EntityType:  models.EntityProposal,
+		EntityID:    created.ID,
+		Title:       "Предложена тема",
+		Message:     fmt.Sprintf("%s предлагает тему «%s».", created.Student.GetFullName(), created.Title),
+	})
+	return created, nil
+}
+
+// GetProposal возвращает предложение по ID
+func (m *TopicProposalManagerImpl) GetProposal(ctx context.Context, proposalID uint) (*models.TopicProposal, error) {
+	return m.proposalRepo.GetByID(ctx, proposalID)
+}
+
+// UpdateProposal правит предложение; доработанное предложение снова уходит на рассмотрение
+func (m *TopicProposalManagerImpl) UpdateProposal(ctx context.Context, proposalID uint, req interfaces.UpdateTopicProposalRequest) (*models.TopicProposal, error) {
+	proposal, err := m.proposalRepo.GetByID(ctx, proposalID)
+	if err != nil {
+		return nil, err
+	}
+	if !proposal.IsOpen() {
+		return nil, fmt.Errorf("proposal in status %q cannot be edited", proposal.Status)
+	}
+	if req.Title != nil {
+		proposal.Title = *req.Title
+	}
+	if req.Description != nil {
+		proposal.Description = *req.Description
+	}
+	resubmitted := proposal.Status == models.ProposalChangesRequested
+	if resubmitted {
+		proposal.Status = models.ProposalPending
+		proposal.Revision++
+	}
+	if err := m.proposalRepo.Update(ctx, proposal); err != nil {
+		return nil, err
+	}
+	if resubmitted {
+		m.notify(ctx, interfaces.Event{
+			Type:        models.EventProposalCreated,
+			RecipientID: proposal.TeacherID,
+			ActorID:     proposal.StudentID,
+			EntityType:  models.EntityProposal,
+			EntityID:    proposal.ID,
+			Title:       "Тема доработана",
+			Message:     fmt.Sprintf("%s доработал(а) предложенную тему «%s».", proposal.Student.GetFullName(), proposal.Title),
+		})
+	}
+	return proposal, nil
+}
+
+// WithdrawProposal отзывает предложение студентом
+func (m *TopicProposalManagerImpl) WithdrawProposal(ctx context.Context, proposalID uint) error {
+	proposal, err := m.proposalRepo.GetByID(ctx, proposalID)
+	if err != nil {
+		return err
+	}
+	if !proposal.IsOpen() {
+		return fmt.Errorf("proposal in status %q cannot be withdrawn", proposal.Status)
+	}
+	proposal.Status = models.ProposalWithdrawn
+	return m.proposalRepo.Update(ctx, proposal)
+}
+
+// GetStudentProposals возвращает предложения студента
+func (m *TopicProposalManagerImpl) GetStudentProposals(ctx context.Context, studentID uint) ([]models.TopicProposal, error) {
+	return m.proposalRepo.GetByStudent(ctx, studentID)
+}
+
+// GetTeacherProposals возвращает предложения, адресованные руководителю
+func (m *TopicProposalManagerImpl) GetTeacherProposals(ctx context.Context, teacherID uint, onlyOpen bool) ([]models.TopicProposal, error) {
+	if onlyOpen {
+		return m.proposalRepo.GetByTeacher(ctx, teacherID, models.ProposalPending, models.ProposalChangesRequested)
+	}
+	return m.proposalRepo.GetByTeacher(ctx, teacherID)
+}
+
+// AcceptProposal превращает предложение в курсовую работу на одного студента и назначает автора.
+// Тема, назначение и решение по предложению сохраняются в одной транзакции: если студента
+// назначить нельзя, тема не создаётся.
+func (m *TopicProposalManagerImpl) AcceptProposal(ctx context.Context, proposalID uint, req interfaces.AcceptTopicProposalRequest) (*models.StudentCoursework, error) {
+	var (
+		proposal   *models.TopicProposal
+		assignment *models.StudentCoursework
+	)
+	err := m.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if proposal, err = m.proposalRepo.GetByID(ctx, proposalID); err != nil {
+			return err
+		}
+		if proposal.Status != models.ProposalPending {
+			return fmt.Errorf("proposal in status %q cannot be accepted", proposal.Status)
+		}
+
+		// персональная тема входит в квоту тем руководителя, как и предложенная им самим
+		termID, err := resolveTermID(ctx, m.termRepo, 0)
+		if err != nil {
+			return err
+		}
+		if err := m.workload.CheckTopicQuota(ctx, proposal.TeacherID, termID); err != nil {
+			return err
+		}
+
+		difficulty := req.DifficultyLevel
+		if difficulty == "" {
+			difficulty = models.Medium
+		}
+		// персональная тема не должна появляться в списке доступных
+		cw := &models.Coursework{
+			Title:           proposal.Title,
+			Description:     proposal.Description,
+			Requirements:    req.Requirements,
+			SubjectID:       proposal.SubjectID,
+			TeacherID:       proposal.TeacherID,
+			MaxStudents:     1,
+			DifficultyLevel: difficulty,
+			IsAvailable:     false,
+			TermID:          termID,
+		}
+		if err := m.cwRepo.Create(ctx, cw); err != nil {
+			return err
+		}
+		if assignment, err = m.scManager.AssignStudentToCoursework(ctx, proposal.StudentID, cw.ID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		proposal.Status = models.ProposalAccepted
+		proposal.CourseworkID = &cw.ID
+		proposal.DecidedAt = &now
+		return m.proposalRepo.Update(ctx, proposal)
+	})
+	if err != nil {
+		return nil, err
+	}
+	m.notifyReviewed(ctx, proposal, fmt.Sprintf("Предложенная тема «%s» принята.", proposal.Title))
+	return assignment, nil
+}
+
+// RejectProposal отклоняет предложение с комментарием
+func (m *TopicProposalManagerImpl) RejectProposal(ctx context.Context, proposalID uint, comment string) error {
+	proposal, err := m.proposalRepo.GetByID(ctx, proposalID)
+	if err != nil {
+		return err
+	}
+	if !proposal.IsOpen() {
+		return fmt.Errorf("proposal in status %q cannot be rejected", proposal.Status)
+	}
+	now := time.Now()
+	proposal.Status = models.ProposalRejected
+	proposal.TeacherComment = comment
+	proposal.DecidedAt = &now
+	if err := m.proposalRepo.Update(ctx, proposal); err != nil {
+		return err
+	}
+	m.notifyReviewed(ctx, proposal, fmt.Sprintf("Предложенная тема «%s» отклонена.", proposal.Title))
+	return nil
+}
+
+// RequestChanges возвращает предложение студенту на доработку
+func (m *TopicProposalManagerImpl) RequestChanges(ctx context.Context, proposalID uint, comment string) error {
+	proposal, err := m.proposalRepo.GetByID(ctx, proposalID)
+	if err != nil {
+		return err
+	}
+	if proposal.Status != models.ProposalPending {
+		return fmt.Errorf("proposal in status %q cannot be sent back", proposal.Status)
+	}
+	proposal.Status = models.ProposalChangesRequested
+	proposal.TeacherComment = comment
+	if err := m.proposalRepo.Update(ctx, proposal); err != nil {
+		return err
+	}
+	m.notifyReviewed(ctx, proposal, fmt.Sprintf("Предложенную тему «%s» нужно доработать.", proposal.Title))
+	return nil
+}
+
+// notifyReviewed сообщает студенту решение руководителя по его теме
+func (m *TopicProposalManagerImpl) notifyReviewed(ctx context.Context, proposal *models.TopicProposal, message string) {
+	if proposal.TeacherComment != "" {
+		message += " Комментарий: " + proposal.TeacherComment
+	}
+	m.notify(ctx, interfaces.Event{
+		Type:        models.EventProposalReviewed,
+		RecipientID: proposal.StudentID,
+		ActorID:     proposal.TeacherID,
+		EntityType:  models.EntityProposal,
+		EntityID:    proposal.ID,
+		Title:       "Решение по предложенной теме",
+		Message:     message,
+	})
+}
+
+func (m *TopicProposalManagerImpl) notify(ctx context.Context, event interfaces.Event) {
+	if err := m.notifier.Notify(ctx, event); err != nil {
+		log.Printf("failed to notify user %d: %v", event.RecipientID, err)
+	}
+}
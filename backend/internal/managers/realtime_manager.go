@@ -0,0 +1,221 @@
+package managers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// topicCourseworks - тема шины с занятостью мест, её получают все подписчики потока slots
+const topicCourseworks = "courseworks"
+
+// userTopic - тема шины с обновлениями одного пользователя
+func userTopic(userID uint) string {
+	return fmt.Sprintf("user.%d", userID)
+}
+
+// streamTicket - выданный билет на подключение к потоку
+type streamTicket struct {
+	userID    uint
+	expiresAt time.Time
+}
+
+// RealtimeManagerImpl реализует interfaces.RealtimeManager. Билеты хранятся в памяти, как и
+// подписки шины: подключиться по билету можно только к экземпляру, который его выдал
+type RealtimeManagerImpl struct {
+	bus          interfaces.PubSub
+	cwRepo       interfaces.CourseworkRepository
+	waitlistRepo interfaces.WaitlistRepository
+	ticketTTL    time.Duration
+
+	mu      sync.Mutex
+	tickets map[string]streamTicket
+}
+
+// NewRealtimeManager создаёт новый RealtimeManager
+func NewRealtimeManager(
+	bus interfaces.PubSub,
+	cwRepo interfaces.CourseworkRepository,
+	waitlistRepo interfaces.WaitlistRepository,
+	ticketTTL time.Duration,
+) interfaces.RealtimeManager {
+	return &RealtimeManagerImpl{
+		bus:          bus,
+		cwRepo:       cwRepo,
+		waitlistRepo: waitlistRepo,
+		ticketTTL:    ticketTTL,
+		tickets:      make(map[string]streamTicket),
+	}
+}
+
+// IssueStreamTicket выдаёт одноразовый билет; заодно забываются просроченные билеты
+func (m *RealtimeManagerImpl) IssueStreamTicket(ctx context.Context, userID uint) (*interfaces.StreamTicket, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate stream ticket: %w", err)
+	}
+	ticket := hex.EncodeToString(buf)
+	now := time.Now()
+	expires := now.Add(m.ticketTTL)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, t := range m.tickets {
+		if !now.Before(t.expiresAt) {
+			delete(m.tickets, key)
+		}
+	}
+	m.tickets[ticket] = streamTicket{userID: userID, expiresAt: expires}
+	return &interfaces.StreamTicket{Ticket: ticket, ExpiresAt: expires}, nil
+}
+
+// RedeemStreamTicket погашает билет: повторно по нему подключиться нельзя
+func (m *RealtimeManagerImpl) RedeemStreamTicket(ctx context.Context, ticket string) (uint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t, ok := m.tickets[ticket]
+	if !ok {
+		return 0, interfaces.ErrNotFound
+	}
+	delete(m.tickets, ticket)
+	if !time.Now().Before(t.expiresAt) {
+		return 0, interfaces.ErrNotFound
+	}
+	return t.userID, nil
+}
+
+// Subscribe подписывает пользователя на его темы шины и оставляет только выбранные потоки
+func (m *RealtimeManagerImpl) Subscribe(ctx context.Context, userID uint, streams []interfaces.RealtimeStream) (<-chan interfaces.RealtimeEvent, func(), error) {
+	if len(streams) == 0 {
+		streams = interfaces.RealtimeStreams
+	}
+	wanted := make(map[interfaces.RealtimeStream]bool, len(streams))
+	for _, s := range streams {
+		wanted[s] = true
+	}
+
+	var topics []string
+	if wanted[interfaces.StreamSlots] {
+		topics = append(topics, topicCourseworks)
+	}
+	if wanted[interfaces.StreamAssignments] || wanted[interfaces.StreamNotifications] {
+		topics = append(topics, userTopic(userID))
+	}
+	if len(topics) == 0 {
+		return nil, nil, fmt.Errorf("unknown realtime streams: %v", streams)
+	}
+
+	messages, cancel, err := m.bus.Subscribe(ctx, topics...)
+	if err != nil {
+		return nil, nil, err
+	}
+	events := make(chan interfaces.RealtimeEvent)
+	go func() {
+		defer close(events)
+		for msg := range messages {
+			var event interfaces.RealtimeEvent
+			if err := json.Unmarshal(msg.Payload, &event); err != nil {
+				log.Printf("invalid realtime message in %s: %v", msg.Topic, err)
+				continue
+			}
+			if !wanted[event.Stream] {
+				continue
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				cancel()
+				return
+			}
+		}
+	}()
+	return events, cancel, nil
+}
+
+// PublishSlots рассылает занятость мест; места, предложенные студентам из очереди, считаются занятыми
+func (m *RealtimeManagerImpl) PublishSlots(ctx context.Context, courseworkIDs ...uint) {
+	seen := make(map[uint]bool, len(courseworkIDs))
+	for _, id := range courseworkIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		cw, taken, err := m.cwRepo.GetWithStudentCount(ctx, id)
+		if err != nil {
+			log.Printf("failed to publish slots of coursework %d: %v", id, err)
+			continue
+		}
+		reserved, err := m.waitlistRepo.CountOffered(ctx, id, 0)
+		if err != nil {
+			log.Printf("failed to publish slots of coursework %d: %v", id, err)
+			continue
+		}
+		msg := interfaces.CourseworkSlotsMessage{
+			CourseworkID: cw.ID,
+			SubjectID:    cw.SubjectID,
+			MaxStudents:  cw.MaxStudents,
+			Taken:        taken,
+			Reserved:     reserved,
+			Free:         max(cw.MaxStudents-taken-reserved, 0),
+			IsAvailable:  cw.IsAvailable,
+		}
+		m.publish(ctx, topicCourseworks, interfaces.StreamSlots, msg)
+	}
+}
+
+// PublishAssignment сообщает об изменении назначения студенту и руководителю темы
+func (m *RealtimeManagerImpl) PublishAssignment(ctx context.Context, sc *models.StudentCoursework, event models.EventType) {
+	if sc == nil {
+		return
+	}
+	msg := interfaces.AssignmentUpdateMessage{
+		Event:        event,
+		AssignmentID: sc.ID,
+		CourseworkID: sc.CourseworkID,
+		StudentID:    sc.StudentID,
+		TeacherID:    sc.Coursework.TeacherID,
+		Status:       sc.Status,
+		Grade:        sc.Grade,
+		UpdatedAt:    sc.UpdatedAt,
+	}
+	m.publish(ctx, userTopic(sc.StudentID), interfaces.StreamAssignments, msg)
+	if sc.Coursework.TeacherID != 0 {
+		m.publish(ctx, userTopic(sc.Coursework.TeacherID), interfaces.StreamAssignments, msg)
+	}
+}
+
+// Deliver передаёт новое уведомление открытым подключениям получателя
+func (m *RealtimeManagerImpl) Deliver(ctx context.Context, n *models.Notification) error {
+	data, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	return m.send(ctx, userTopic(n.UserID), interfaces.RealtimeEvent{Stream: interfaces.StreamNotifications, Data: data})
+}
+
+func (m *RealtimeManagerImpl) publish(ctx context.Context, topic string, stream interfaces.RealtimeStream, data interface{}) {
+	raw, err := json.Marshal(data)
+	if err == nil {
+		err = m.send(ctx, topic, interfaces.RealtimeEvent{Stream: stream, Data: raw})
+	}
+	if err != nil {
+		log.Printf("failed to publish %s update: %v", stream, err)
+	}
+}
+
+func (m *RealtimeManagerImpl) send(ctx context.Context, topic string, event interfaces.RealtimeEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return m.bus.Publish(ctx, topic, payload)
+}
@@ -0,0 +1,43 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+)
+
+func TestStreamTicketIsSingleUse(t *testing.T) {
+	ctx := context.Background()
+	manager := NewRealtimeManager(nil, nil, nil, time.Minute)
+
+	ticket, err := manager.IssueStreamTicket(ctx, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	userID, err := manager.RedeemStreamTicket(ctx, ticket.Ticket)
+	if err != nil || userID != 42 {
+		t.Fatalf("RedeemStreamTicket = %d, %v; want 42, nil", userID, err)
+	}
+	if _, err := manager.RedeemStreamTicket(ctx, ticket.Ticket); !errors.Is(err, interfaces.ErrNotFound) {
+		t.Errorf("second RedeemStreamTicket: %v, want ErrNotFound", err)
+	}
+	if _, err := manager.RedeemStreamTicket(ctx, ""); !errors.Is(err, interfaces.ErrNotFound) {
+		t.Errorf("RedeemStreamTicket without a ticket: %v, want ErrNotFound", err)
+	}
+}
+
+func TestStreamTicketExpires(t *testing.T) {
+	ctx := context.Background()
+	manager := NewRealtimeManager(nil, nil, nil, -time.Second)
+
+	ticket, err := manager.IssueStreamTicket(ctx, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.RedeemStreamTicket(ctx, ticket.Ticket); !errors.Is(err, interfaces.ErrNotFound) {
+		t.Errorf("RedeemStreamTicket of an expired ticket: %v, want ErrNotFound", err)
+	}
+}
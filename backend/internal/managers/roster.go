@@ -0,0 +1,189 @@
+package managers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// rosterRow - строка списка студентов или преподавателей
+type rosterRow struct {
+	Line           int // номер строки в файле, заголовок - строка 1
+	LastName       string
+	FirstName      string
+	Email          string
+	GroupCode      string
+	StudentNumber  string
+	CourseYear     int
+	Specialty      string
+	DepartmentCode string
+	Position       string
+	AcademicDegree string
+	ParseError     string // ошибка разбора ячеек строки
+}
+
+// rosterColumns - допустимые названия столбцов (без учёта регистра)
+var rosterColumns = map[string][]string{
+	"name":            {"name", "full_name", "фио"},
+	"last_name":       {"last_name", "фамилия"},
+	"first_name":      {"first_name", "имя"},
+	"email":           {"email", "e-mail", "почта"},
+	"group_code":      {"group", "group_code", "группа"},
+	"student_number":  {"student_number", "номер зачётки", "номер зачетки", "зачётка", "зачетка"},
+	"course_year":     {"course_year", "курс"},
+	"specialty":       {"specialty", "специальность", "направление"},
+	"department":      {"department", "department_code", "кафедра"},
+	"position":        {"position", "должность"},
+	"academic_degree": {"academic_degree", "degree", "учёная степень", "ученая степень", "степень"},
+}
+
+// readRoster разбирает CSV или XLSX по расширению файла; первая строка - заголовок.
+// Строки с ошибками не отбрасываются: ошибка разбора сохраняется в ParseError.
+func readRoster(fileName string, r io.Reader) ([]rosterRow, error) {
+	var (
+		records [][]string
+		err     error
+	)
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		records, err = readCSVRecords(r)
+	case ".xlsx":
+		records, err = readXLSXRecords(r)
+	default:
+		return nil, errors.New("unsupported file format, expected .csv or .xlsx")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("file is empty")
+	}
+
+	index := rosterHeaderIndex(records[0])
+	if _, ok := index["email"]; !ok {
+		return nil, errors.New("email column is required")
+	}
+	_, hasName := index["name"]
+	_, hasLast := index["last_name"]
+	if !hasName && !hasLast {
+		return nil, errors.New("name or last_name/first_name columns are required")
+	}
+
+	cell := func(rec []string, key string) string {
+		i, ok := index[key]
+		if !ok || i >= len(rec) {
+			return ""
+		}
+		return strings.TrimSpace(rec[i])
+	}
+
+	var rows []rosterRow
+	for n, rec := range records[1:] {
+		line := n + 2
+		if isBlankRecord(rec) {
+			continue
+		}
+		row := rosterRow{
+			Line:           line,
+			LastName:       cell(rec, "last_name"),
+			FirstName:      cell(rec, "first_name"),
+			Email:          strings.ToLower(cell(rec, "email")),
+			GroupCode:      cell(rec, "group_code"),
+			StudentNumber:  cell(rec, "student_number"),
+			Specialty:      cell(rec, "specialty"),
+			DepartmentCode: cell(rec, "department"),
+			Position:       cell(rec, "position"),
+			AcademicDegree: cell(rec, "academic_degree"),
+		}
+		// ФИО в одном столбце: "Фамилия Имя [Отчество]"
+		if row.LastName == "" {
+			if parts := strings.Fields(cell(rec, "name")); len(parts) >= 2 {
+				row.LastName, row.FirstName = parts[0], parts[1]
+			}
+		}
+		if raw := cell(rec, "course_year"); raw != "" {
+			year, err := strconv.Atoi(raw)
+			if err != nil {
+				row.ParseError = fmt.Sprintf("invalid course year %q", raw)
+			}
+			row.CourseYear = year
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func readCSVRecords(r io.Reader) ([][]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	// Excel с русской локалью сохраняет CSV через точку с запятой
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+	return records, nil
+}
+
+func readXLSXRecords(r io.Reader) ([][]string, error) {
+	book, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open XLSX: %w", err)
+	}
+	defer book.Close()
+
+	sheets := book.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, errors.New("workbook has no sheets")
+	}
+	records, err := book.GetRows(sheets[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read XLSX sheet: %w", err)
+	}
+	return records, nil
+}
+
+// rosterHeaderIndex сопоставляет столбцы заголовка с полями строки списка
+func rosterHeaderIndex(header []string) map[string]int {
+	index := make(map[string]int)
+	for i, title := range header {
+		title = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(title, "\ufeff")))
+		for key, aliases := range rosterColumns {
+			for _, alias := range aliases {
+				if title == alias {
+					if _, seen := index[key]; !seen {
+						index[key] = i
+					}
+				}
+			}
+		}
+	}
+	return index
+}
+
+func isBlankRecord(rec []string) bool {
+	for _, v := range rec {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,514 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// SelectionManagerImpl реализует interfaces.SelectionManager
+type SelectionManagerImpl struct {
+	roundRepo interfaces.SelectionRoundRepository
+	prefRepo  interfaces.SelectionPreferenceRepository
+	subjRepo  interfaces.SubjectRepository
+	cwRepo    interfaces.CourseworkRepository
+	scRepo    interfaces.StudentCourseworkRepository
+	termRepo  interfaces.TermRepository
+	waitlist  interfaces.WaitlistManager
+	workload  interfaces.WorkloadManager
+	uow       interfaces.UnitOfWork
+	events    interfaces.EventPublisher
+}
+
+// NewSelectionManager создаёт новый SelectionManager
+func NewSelectionManager(
+	roundRepo interfaces.SelectionRoundRepository,
+	prefRepo interfaces.SelectionPreferenceRepository,
+	subjRepo interfaces.SubjectRepository,
+	cwRepo interfaces.CourseworkRepository,
+	scRepo interfaces.StudentCourseworkRepository,
+	termRepo interfaces.TermRepository,
+	waitlist interfaces.WaitlistManager,
+	workload interfaces.WorkloadManager,
+	uow interfaces.UnitOfWork,
+	events interfaces.EventPublisher,
+) interfaces.SelectionManager {
+	return &SelectionManagerImpl{
+		roundRepo: roundRepo,
+		prefRepo:  prefRepo,
+		subjRepo:  subjRepo,
+		cwRepo:    cwRepo,
+		scRepo:    scRepo,
+		termRepo:  termRepo,
+		waitlist:  waitlist,
+		workload:  workload,
+		uow:       uow,
+		events:    events,
+	}
+}
+
+// CreateRound создаёт раунд выбора тем в статусе черновика
+func (m *SelectionManagerImpl) CreateRound(ctx context.Context, req interfaces.CreateSelectionRoundRequest) (*models.SelectionRound, error) {
+	if _, err := m.subjRepo.GetByID(ctx, req.SubjectID); err != nil {
+		return nil, err
+	}
+	round := &models.SelectionRound{
+		Title:      req.Title,
+		SubjectID:  req.SubjectID,
+		MaxChoices: req.MaxChoices,
+		Status:     models.RoundDraft,
+		ClosesAt:   req.ClosesAt,
+	}
+	if err := m.roundRepo.Create(ctx, round); err != nil {
+		return nil, err
+	}
+	return m.roundRepo.GetByID(ctx, round.ID)
+}
+
+// GetRound возвращает раунд по ID
+func (m *SelectionManagerImpl) GetRound(ctx context.Context, roundID uint) (*models.SelectionRound, error) {
+	return m.roundRepo.GetByID(ctx, roundID)
+}
+
+// ListRounds возвращает все раунды
+func (m *SelectionManagerImpl) ListRounds(ctx context.Context) ([]models.SelectionRound, error) {
+	return m.roundRepo.List(ctx)
+}
+
+// SetRoundStatus переводит раунд между черновиком, приёмом предпочтений и закрытием
+func (m *SelectionManagerImpl) SetRoundStatus(ctx context.Context, roundID uint, status models.RoundStatus) error {
+	round, err := m.roundRepo.GetByID(ctx, roundID)
+	if err != nil {
+		return err
+	}
+	if round.Status == models.RoundCommitted {
+		return errors.New("round is already committed")
+	}
+	if round.Status == status {
+		return nil
+	}
+
+	switch status {
+	case models.RoundOpen:
+		// по дисциплине может идти только один раунд
+		if active, err := m.roundRepo.GetActiveBySubject(ctx, round.SubjectID); err == nil && active.ID != round.ID {
+			return fmt.Errorf("subject already has an active selection round %d", active.ID)
+		}
+	case models.RoundClosed:
+		if round.Status != models.RoundOpen {
+			return errors.New("only an open round can be closed")
+		}
+	case models.RoundDraft:
+		if round.Status != models.RoundOpen {
+			return errors.New("only an open round can be returned to draft")
+		}
+	default:
+		return fmt.Errorf("unsupported round status %q", status)
+	}
+
+	round.Status = status
+	return m.roundRepo.Update(ctx, round)
+}
+
+// SubmitPreferences сохраняет упорядоченный список тем студента
+func (m *SelectionManagerImpl) SubmitPreferences(ctx context.Context, roundID, studentID uint, courseworkIDs []uint) ([]models.TopicPreference, error) {
+	round, err := m.roundRepo.GetByID(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+	if round.Status != models.RoundOpen {
+		return nil, errors.New("round is not accepting preferences")
+	}
+	if round.ClosesAt != nil && time.Now().After(*round.ClosesAt) {
+		return nil, errors.New("round deadline has passed")
+	}
+	if len(courseworkIDs) > round.MaxChoices {
+		return nil, fmt.Errorf("at most %d topics can be ranked", round.MaxChoices)
+	}
+	if _, err := m.scRepo.GetByStudent(ctx, studentID); err == nil {
+		return nil, errors.New("student already has an assigned coursework")
+	}
+
+	seen := make(map[uint]bool, len(courseworkIDs))
+	prefs := make([]models.TopicPreference, 0, len(courseworkIDs))
+	for i, cwID := range courseworkIDs {
+		if seen[cwID] {
+			return nil, fmt.Errorf("coursework %d is listed more than once", cwID)
+		}
+		seen[cwID] = true
+
+		cw, err := m.cwRepo.GetByID(ctx, cwID)
+		if err != nil {
+			return nil, err
+		}
+		if cw.SubjectID != round.SubjectID {
+			return nil, fmt.Errorf("coursework %d does not belong to the round subject", cwID)
+		}
+		if !cw.IsAvailable {
+			return nil, fmt.Errorf("coursework %d is not available", cwID)
+		}
+		prefs = append(prefs, models.TopicPreference{CourseworkID: cwID, Rank: i + 1})
+	}
+
+	if err := m.prefRepo.ReplaceStudentPreferences(ctx, roundID, studentID, prefs); err != nil {
+		return nil, err
+	}
+	return m.prefRepo.GetStudentPreferences(ctx, roundID, studentID)
+}
+
+// GetStudentPreferences возвращает список тем студента
+func (m *SelectionManagerImpl) GetStudentPreferences(ctx context.Context, roundID, studentID uint) ([]models.TopicPreference, error) {
+	return m.prefRepo.GetStudentPreferences(ctx, roundID, studentID)
+}
+
+// GetApplicants возвращает претендентов на тему с их приоритетом и рейтингом руководителя
+func (m *SelectionManagerImpl) GetApplicants(ctx context.Context, roundID, courseworkID uint) ([]interfaces.SelectionApplicant, error) {
+	prefs, err := m.prefRepo.GetCourseworkApplicants(ctx, roundID, courseworkID)
+	if err != nil {
+		return nil, err
+	}
+	rankings, err := m.prefRepo.GetRoundRankings(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+	teacherRank := make(map[uint]int)
+	for _, r := range rankings {
+		if r.CourseworkID == courseworkID {
+			teacherRank[r.StudentID] = r.Rank
+		}
+	}
+
+	result := make([]interfaces.SelectionApplicant, 0, len(prefs))
+	for _, p := range prefs {
+		applicant := interfaces.SelectionApplicant{
+			StudentID:      p.StudentID,
+			StudentName:    p.Student.GetFullName(),
+			PreferenceRank: p.Rank,
+		}
+		if rank, ok := teacherRank[p.StudentID]; ok {
+			applicant.TeacherRank = &rank
+		}
+		result = append(result, applicant)
+	}
+	return result, nil
+}
+
+// RankApplicants сохраняет рейтинг претендентов на тему; неупомянутые студенты идут после оценённых
+func (m *SelectionManagerImpl) RankApplicants(ctx context.Context, roundID, courseworkID uint, studentIDs []uint) error {
+	round, err := m.roundRepo.GetByID(ctx, roundID)
+	if err != nil {
+		return err
+	}
+	if round.Status == models.RoundCommitted {
+		return errors.New("round is already committed")
+	}
+	cw, err := m.cwRepo.GetByID(ctx, courseworkID)
+	if err != nil {
+		return err
+	}
+	if cw.SubjectID != round.SubjectID {
+		return errors.New("coursework does not belong to the round subject")
+	}
+
+	applicants, err := m.prefRepo.GetCourseworkApplicants(ctx, roundID, courseworkID)
+	if err != nil {
+		return err
+	}
+	isApplicant := make(map[uint]bool, len(applicants))
+	for _, a := range applicants {
+		isApplicant[a.StudentID] = true
+	}
+
+	seen := make(map[uint]bool, len(studentIDs))
+	rankings := make([]models.ApplicantRanking, 0, len(studentIDs))
+	for i, studentID := range studentIDs {
+		if seen[studentID] {
+			return fmt.Errorf("student %d is listed more than once", studentID)
+		}
+		seen[studentID] = true
+		if !isApplicant[studentID] {
+			return fmt.Errorf("student %d did not apply for this coursework", studentID)
+		}
+		rankings = append(rankings, models.ApplicantRanking{StudentID: studentID, Rank: i + 1})
+	}
+
+	return m.prefRepo.ReplaceApplicantRankings(ctx, roundID, courseworkID, rankings)
+}
+
+// PreviewAllocation рассчитывает распределение без сохранения
+func (m *SelectionManagerImpl) PreviewAllocation(ctx context.Context, roundID uint) (*interfaces.AllocationResult, error) {
+	round, err := m.roundRepo.GetByID(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+	if round.Status == models.RoundDraft {
+		return nil, errors.New("round has not been opened yet")
+	}
+	return m.allocate(ctx, round)
+}
+
+// CommitAllocation рассчитывает распределение, создаёт назначения и фиксирует раунд
+func (m *SelectionManagerImpl) CommitAllocation(ctx context.Context, roundID uint) (*interfaces.AllocationResult, error) {
+	round, err := m.roundRepo.GetByID(ctx, roundID)
+	if err != nil {
+		return nil, err
+	}
+	if round.Status != models.RoundClosed {
+		return nil, errors.New("round must be closed before committing")
+	}
+
+	result, err := m.allocate(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+
+	// назначения и фиксация раунда идут одной транзакцией: сбой откатывает весь раунд,
+	// а места занимаются условной вставкой, поэтому параллельные прямые назначения
+	// не переполнят тему. Уведомления, обновления мест и вебхуки рассылают подписчики
+	// события assignment.created
+	err = m.uow.Do(ctx, func(ctx context.Context) error {
+		committed := make([]interfaces.AllocationEntry, 0, len(result.Assignments))
+		failed := 0
+		for _, entry := range result.Assignments {
+			reason, err := m.commitEntry(ctx, entry)
+			if err != nil {
+				return err
+			}
+			if reason != "" {
+				entry.Reason = reason
+				result.Unmatched = append(result.Unmatched, entry)
+				failed++
+				continue
+			}
+			committed = append(committed, entry)
+		}
+		result.Assignments = committed
+		result.Stats = allocationStats(result.Assignments, result.Unmatched, result.Stats.TopicsFilled, result.Stats.FreeSlotsRemained+failed)
+		result.Committed = true
+
+		now := time.Now()
+		round.Status = models.RoundCommitted
+		round.CommittedAt = &now
+		return m.roundRepo.Update(ctx, round)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// allocate собирает входные данные раунда и запускает алгоритм отложенного принятия
+func (m *SelectionManagerImpl) allocate(ctx context.Context, round *models.SelectionRound) (*interfaces.AllocationResult, error) {
+	prefs, err := m.prefRepo.GetRoundPreferences(ctx, round.ID)
+	if err != nil {
+		return nil, err
+	}
+	rankings, err := m.prefRepo.GetRoundRankings(ctx, round.ID)
+	if err != nil {
+		return nil, err
+	}
+	// распределяются только темы текущего семестра, а не прошлых лет
+	filter := interfaces.ListCourseworksRequest{SubjectID: &round.SubjectID}
+	termID, err := resolveTermID(ctx, m.termRepo, 0)
+	if err != nil {
+		return nil, err
+	}
+	if termID != 0 {
+		filter.TermID = &termID
+	}
+	courseworks, _, err := m.cwRepo.Search(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// темы и оставшиеся места
+	cwByID := make(map[uint]models.Coursework, len(courseworks))
+	topics := make([]matchTopic, 0, len(courseworks))
+	for _, cw := range courseworks {
+		cwByID[cw.ID] = cw
+		if !cw.IsAvailable {
+			continue
+		}
+		_, count, err := m.cwRepo.GetWithStudentCount(ctx, cw.ID)
+		if err != nil {
+			return nil, err
+		}
+		// места, предложенные студентам из листа ожидания, заняты до их ответа
+		reserved, err := m.waitlist.ReservedSlots(ctx, cw.ID, 0)
+		if err != nil {
+			return nil, err
+		}
+		topics = append(topics, matchTopic{
+			ID:       cw.ID,
+			Capacity: cw.MaxStudents - count - reserved,
+			Ranking:  make(map[uint]int),
+		})
+	}
+	topicIdx := make(map[uint]int, len(topics))
+	for i := range topics {
+		topicIdx[topics[i].ID] = i
+	}
+	for _, r := range rankings {
+		if i, ok := topicIdx[r.CourseworkID]; ok {
+			topics[i].Ranking[r.StudentID] = r.Rank
+		}
+	}
+
+	// студенты в порядке ID, списки уже отсортированы по приоритету
+	names := make(map[uint]string)
+	rankOf := make(map[[2]uint]int)
+	applicantIdx := make(map[uint]int)
+	applicants := make([]matchApplicant, 0)
+	for _, p := range prefs {
+		i, ok := applicantIdx[p.StudentID]
+		if !ok {
+			i = len(applicants)
+			applicantIdx[p.StudentID] = i
+			applicants = append(applicants, matchApplicant{ID: p.StudentID})
+			names[p.StudentID] = p.Student.GetFullName()
+		}
+		applicants[i].Prefs = append(applicants[i].Prefs, p.CourseworkID)
+		rankOf[[2]uint{p.StudentID, p.CourseworkID}] = p.Rank
+	}
+
+	result := &interfaces.AllocationResult{
+		RoundID:     round.ID,
+		Assignments: []interfaces.AllocationEntry{},
+		Unmatched:   []interfaces.AllocationEntry{},
+	}
+
+	// уже назначенные студенты не участвуют в распределении
+	eligible := applicants[:0]
+	for _, a := range applicants {
+		if _, err := m.scRepo.GetByStudent(ctx, a.ID); err == nil {
+			result.Unmatched = append(result.Unmatched, interfaces.AllocationEntry{
+				StudentID:   a.ID,
+				StudentName: names[a.ID],
+				Reason:      "student already has an assigned coursework",
+			})
+			continue
+		}
+		eligible = append(eligible, a)
+	}
+
+	matching := deferredAcceptance(round.ID, eligible, topics)
+
+	filled := make(map[uint]int)
+	for _, a := range eligible {
+		cwID, ok := matching[a.ID]
+		if !ok {
+			result.Unmatched = append(result.Unmatched, interfaces.AllocationEntry{
+				StudentID:   a.ID,
+				StudentName: names[a.ID],
+				Reason:      "all ranked topics were filled by higher-priority applicants",
+			})
+			continue
+		}
+		filled[cwID]++
+		result.Assignments = append(result.Assignments, interfaces.AllocationEntry{
+			StudentID:       a.ID,
+			StudentName:     names[a.ID],
+			CourseworkID:    cwID,
+			CourseworkTitle: cwByID[cwID].Title,
+			PreferenceRank:  rankOf[[2]uint{a.ID, cwID}],
+		})
+	}
+	sort.Slice(result.Assignments, func(i, j int) bool {
+		return result.Assignments[i].StudentID < result.Assignments[j].StudentID
+	})
+
+	topicsFilled, freeSlots := 0, 0
+	for _, t := range topics {
+		if t.Capacity <= 0 {
+			continue
+		}
+		if filled[t.ID] >= t.Capacity {
+			topicsFilled++
+		}
+		freeSlots += t.Capacity - filled[t.ID]
+	}
+
+	result.Stats = allocationStats(result.Assignments, result.Unmatched, topicsFilled, freeSlots)
+	return result, nil
+}
+
+// allocationStats считает показатели справедливости распределения
+func allocationStats(assigned, unmatched []interfaces.AllocationEntry, topicsFilled, freeSlots int) interfaces.AllocationStats {
+	stats := interfaces.AllocationStats{
+		Participants:      len(assigned) + len(unmatched),
+		Matched:           len(assigned),
+		Unmatched:         len(unmatched),
+		RankDistribution:  make(map[int]int),
+		TopicsFilled:      topicsFilled,
+		FreeSlotsRemained: freeSlots,
+	}
+	total := 0
+	for _, a := range assigned {
+		stats.RankDistribution[a.PreferenceRank]++
+		total += a.PreferenceRank
+		if a.PreferenceRank == 1 {
+			stats.FirstChoice++
+		}
+		if a.PreferenceRank > stats.WorstRank {
+			stats.WorstRank = a.PreferenceRank
+		}
+	}
+	if stats.Matched > 0 {
+		stats.AverageRank = float64(total) / float64(stats.Matched)
+		stats.FirstChoiceShare = float64(stats.FirstChoice) / float64(stats.Matched)
+	}
+	return stats
+}
+
+// commitEntry назначает студента по итогам раунда; непустая причина - назначение не состоялось
+func (m *SelectionManagerImpl) commitEntry(ctx context.Context, entry interfaces.AllocationEntry) (string, error) {
+	// студент мог получить тему напрямую, пока раунд был закрыт
+	if _, err := m.scRepo.GetByStudent(ctx, entry.StudentID); err == nil {
+		return "student already has an assigned coursework", nil
+	}
+	// руководитель мог исчерпать квоту за пределами раунда
+	if err := m.checkSupervisionQuota(ctx, entry.CourseworkID); err != nil {
+		return err.Error(), nil
+	}
+	// предложенные из листа ожидания места не занимаются, как и при прямом назначении
+	reserved, err := m.waitlist.ReservedSlots(ctx, entry.CourseworkID, entry.StudentID)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	assign := &models.StudentCoursework{
+		StudentID:    entry.StudentID,
+		CourseworkID: entry.CourseworkID,
+		Status:       models.StatusAssigned,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	ok, err := m.scRepo.CreateIfSlotAvailable(ctx, assign, reserved)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "no slots left, the topic was filled outside the round", nil
+	}
+	// студент получил тему, его места в листах ожидания освобождаются
+	if err := m.waitlist.WithdrawStudent(ctx, entry.StudentID); err != nil {
+		return "", err
+	}
+	return "", m.events.Publish(ctx, models.EventAssignmentCreated, interfaces.AssignmentEventData{
+		AssignmentID: assign.ID,
+		Status:       assign.Status,
+	})
+}
+
+func (m *SelectionManagerImpl) checkSupervisionQuota(ctx context.Context, courseworkID uint) error {
+	cw, err := m.cwRepo.GetByID(ctx, courseworkID)
+	if err != nil {
+		return err
+	}
+	return m.workload.CheckSupervisionQuota(ctx, cw.TeacherID, cw.TermID)
+}
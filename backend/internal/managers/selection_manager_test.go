@@ -0,0 +1,90 @@
+package managers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/config"
+	"github.com/Foxpunk/courseforge/internal/drivers"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"github.com/Foxpunk/courseforge/internal/testutil"
+)
+
+func TestCommitAllocationKeepsWaitlistOffers(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	term := testutil.CreateActiveTerm(t, db)
+	teacher := testutil.CreateUser(t, db, models.RoleTeacher, "teacher@example.com")
+	offered := testutil.CreateUser(t, db, models.RoleStudent, "offered@example.com")
+	first := testutil.CreateUser(t, db, models.RoleStudent, "first@example.com")
+	second := testutil.CreateUser(t, db, models.RoleStudent, "second@example.com")
+	subject := &models.Subject{Name: "Базы данных", Code: "DB", Semester: 5, IsActive: true}
+	testutil.MustCreate(t, db, subject)
+	newTopic := func(title string, slots int) *models.Coursework {
+		cw := &models.Coursework{
+			Title:           title,
+			Description:     "Тема раунда выбора",
+			SubjectID:       subject.ID,
+			TeacherID:       teacher.ID,
+			MaxStudents:     slots,
+			DifficultyLevel: models.Medium,
+			IsAvailable:     true,
+			TermID:          term.ID,
+		}
+		testutil.MustCreate(t, db, cw)
+		return cw
+	}
+	popular := newTopic("Проектирование базы данных", 2)
+	spare := newTopic("Оптимизация запросов", 1)
+
+	// одно из двух мест популярной темы уже предложено студенту из листа ожидания
+	offeredAt := time.Now()
+	expiresAt := offeredAt.Add(time.Hour)
+	offer := &models.WaitlistEntry{CourseworkID: popular.ID, StudentID: offered.ID, Position: 1,
+		Status: models.WaitlistOffered, OfferedAt: &offeredAt, OfferExpiresAt: &expiresAt}
+	testutil.MustCreate(t, db, offer)
+
+	round := &models.SelectionRound{Title: "Весенний раунд", SubjectID: subject.ID, MaxChoices: 2, Status: models.RoundClosed}
+	testutil.MustCreate(t, db, round)
+	for _, studentID := range []uint{first.ID, second.ID} {
+		for rank, cwID := range []uint{popular.ID, spare.ID} {
+			testutil.MustCreate(t, db, &models.TopicPreference{RoundID: round.ID, StudentID: studentID, CourseworkID: cwID, Rank: rank + 1})
+		}
+	}
+
+	cwRepo := drivers.NewCourseworkRepository(db)
+	scRepo := drivers.NewStudentCourseworkRepository(db)
+	termRepo := drivers.NewTermRepository(db)
+	uow := drivers.NewUnitOfWork(db)
+	workload := NewWorkloadManager(drivers.NewSupervisionQuotaRepository(db), drivers.NewTeacherProfileRepository(db),
+		drivers.NewDepartmentRepository(db), drivers.NewUserRepository(db), cwRepo, scRepo, termRepo, config.WorkloadConfig{})
+	events := NewEventBus(drivers.NewOutboxRepository(db), config.OutboxConfig{BatchSize: 50, MaxAttempts: 1})
+	waitlist := NewWaitlistManager(drivers.NewWaitlistRepository(db), cwRepo, scRepo, workload, uow, events, time.Hour)
+	manager := NewSelectionManager(drivers.NewSelectionRoundRepository(db), drivers.NewSelectionPreferenceRepository(db),
+		drivers.NewSubjectRepository(db), cwRepo, scRepo, termRepo, waitlist, workload, uow, events)
+
+	result, err := manager.CommitAllocation(ctx, round.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Assignments) != 2 {
+		t.Fatalf("assignments = %+v, want both students matched", result.Assignments)
+	}
+	perTopic := make(map[uint]int)
+	for _, a := range result.Assignments {
+		perTopic[a.CourseworkID]++
+	}
+	if perTopic[popular.ID] != 1 || perTopic[spare.ID] != 1 {
+		t.Errorf("assignments per topic = %v, want one seat of the popular topic left for the offer", perTopic)
+	}
+
+	// предложенное место по-прежнему можно подтвердить
+	assign, err := waitlist.AcceptOffer(ctx, offer.ID)
+	if err != nil {
+		t.Fatalf("AcceptOffer after the round: %v", err)
+	}
+	if assign.CourseworkID != popular.ID || assign.StudentID != offered.ID {
+		t.Errorf("accepted assignment = %+v", assign)
+	}
+}
@@ -0,0 +1,284 @@
+package managers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// errUnsupportedFormat - из файла такого типа текст не извлекается
+var errUnsupportedFormat = errors.New("unsupported file format")
+
+// maxExtractedText - сколько текста работы хранить и сравнивать; курсовая укладывается с запасом
+const maxExtractedText = 2 << 20
+
+// plainTextExtensions - файлы, которые сравниваются как есть (отчёты в разметке и исходный код)
+var plainTextExtensions = map[string]bool{
+	".txt": true, ".md": true, ".tex": true, ".rst": true, ".csv": true,
+	".go": true, ".py": true, ".java": true, ".c": true, ".h": true, ".cpp": true, ".hpp": true,
+	".cs": true, ".js": true, ".ts": true, ".sql": true, ".kt": true, ".rb": true, ".php": true,
+}
+
+// extractText достаёт текст из сданного файла по расширению
+func extractText(fileName string, data []byte) (text string, err error) {
+	ext := strings.ToLower(filepath.Ext(fileName))
+	switch {
+	case plainTextExtensions[ext]:
+		if !utf8.Valid(data) {
+			return "", errors.New("text file is not valid UTF-8")
+		}
+		text = string(data)
+	case ext == ".docx":
+		text, err = extractZipXMLText(data, "word/document.xml", "p")
+	case ext == ".odt":
+		text, err = extractZipXMLText(data, "content.xml", "p", "h")
+	case ext == ".pdf":
+		text, err = extractPDFText(data)
+	default:
+		return "", errUnsupportedFormat
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(text) > maxExtractedText {
+		text = strings.ToValidUTF8(text[:maxExtractedText], "")
+	}
+	return text, nil
+}
+
+// extractZipXMLText собирает текст из XML-части офисного документа;
+// paragraphs - локальные имена элементов, после которых ставится перевод строки
+func extractZipXMLText(data []byte, part string, paragraphs ...string) (string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("failed to open document: %w", err)
+	}
+	f, err := zr.Open(part)
+	if err != nil {
+		return "", fmt.Errorf("document has no %s: %w", part, err)
+	}
+	defer f.Close()
+
+	var sb strings.Builder
+	dec := xml.NewDecoder(io.LimitReader(f, 8*maxExtractedText))
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to parse document: %w", err)
+		}
+		switch t := tok.(type) {
+		case xml.CharData:
+			sb.Write(t)
+		case xml.EndElement:
+			for _, name := range paragraphs {
+				if t.Name.Local == name {
+					sb.WriteByte('\n')
+				}
+			}
+		}
+		if sb.Len() > maxExtractedText {
+			break
+		}
+	}
+	return sb.String(), nil
+}
+
+// extractPDFText извлекает текстовый слой PDF; сканы без текста дают пустую строку
+func extractPDFText(data []byte) (text string, err error) {
+	// разбор повреждённых PDF в библиотеке может паниковать
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to read pdf: %v", r)
+		}
+	}()
+
+	r, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("failed to open pdf: %w", err)
+	}
+	plain, err := r.GetPlainText()
+	if err != nil {
+		return "", fmt.Errorf("failed to read pdf text: %w", err)
+	}
+	b, err := io.ReadAll(io.LimitReader(plain, maxExtractedText))
+	if err != nil {
+		return "", fmt.Errorf("failed to read pdf text: %w", err)
+	}
+	return string(b), nil
+}
+
+// textToken - нормализованное слово и его место в исходном тексте
+type textToken struct {
+	word       string
+	start, end int
+}
+
+// tokenize разбивает текст на слова в нижнем регистре; ё приравнивается к е,
+// знаки препинания и регистр на совпадение не влияют
+func tokenize(text string) []textToken {
+	var (
+		tokens []textToken
+		word   strings.Builder
+		start  = -1
+	)
+	flush := func(end int) {
+		if start >= 0 {
+			tokens = append(tokens, textToken{word: word.String(), start: start, end: end})
+			word.Reset()
+			start = -1
+		}
+	}
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			r = unicode.ToLower(r)
+			if r == 'ё' {
+				r = 'е'
+			}
+			word.WriteRune(r)
+			continue
+		}
+		flush(i)
+	}
+	flush(len(text))
+	return tokens
+}
+
+// shingleHashes - хэши k-словных шинглов по порядку; i-й шингл начинается с i-го слова
+func shingleHashes(tokens []textToken, k int) []uint64 {
+	if len(tokens) < k {
+		return nil
+	}
+	hashes := make([]uint64, len(tokens)-k+1)
+	h := fnv.New64a()
+	for i := range hashes {
+		h.Reset()
+		for _, t := range tokens[i : i+k] {
+			h.Write([]byte(t.word))
+			h.Write([]byte{0})
+		}
+		hashes[i] = h.Sum64()
+	}
+	return hashes
+}
+
+// minHashSize - число хэш-функций сигнатуры; погрешность оценки сходства около 1/sqrt(128) ≈ 0.09
+const minHashSize = 128
+
+// minHashSeeds - коэффициенты хэш-функций h(x) = a*x + b. Сигнатуры хранятся в архиве
+// годами, поэтому коэффициенты фиксированы и не должны меняться.
+var minHashSeeds = func() [minHashSize][2]uint64 {
+	var seeds [minHashSize][2]uint64
+	state := uint64(0x636f75727365666f) // splitmix64
+	next := func() uint64 {
+		state += 0x9e3779b97f4a7c15
+		z := state
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		return z ^ (z >> 31)
+	}
+	for i := range seeds {
+		seeds[i] = [2]uint64{next() | 1, next()}
+	}
+	return seeds
+}()
+
+// minHashSignature считает MinHash-сигнатуру множества шинглов
+func minHashSignature(hashes []uint64) []byte {
+	var sig [minHashSize]uint64
+	for i := range sig {
+		sig[i] = ^uint64(0)
+	}
+	for _, x := range hashes {
+		for i, seed := range minHashSeeds {
+			if v := seed[0]*x + seed[1]; v < sig[i] {
+				sig[i] = v
+			}
+		}
+	}
+	out := make([]byte, 8*minHashSize)
+	for i, v := range sig {
+		binary.LittleEndian.PutUint64(out[8*i:], v)
+	}
+	return out
+}
+
+// estimateJaccard - доля совпавших позиций сигнатур, оценка коэффициента Жаккара
+func estimateJaccard(a, b []byte) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	same := 0
+	for i := 0; i < len(a); i += 8 {
+		if binary.LittleEndian.Uint64(a[i:]) == binary.LittleEndian.Uint64(b[i:]) {
+			same++
+		}
+	}
+	return float64(same) / float64(len(a)/8)
+}
+
+// textFragment - совпавший участок: слова [from, to) проверяемой работы
+type textFragment struct {
+	from, to     int
+	matchedStart int // байтовое смещение начала фрагмента в совпавшей работе
+}
+
+// matchFragments находит участки из подряд идущих шинглов, которые есть в другой работе.
+// Возвращает фрагменты и отметки, какие шинглы проверяемой работы совпали.
+func matchFragments(hashes []uint64, k int, other []textToken, otherHashes []uint64) ([]textFragment, []bool) {
+	firstPos := make(map[uint64]int, len(otherHashes))
+	for i := len(otherHashes) - 1; i >= 0; i-- {
+		firstPos[otherHashes[i]] = i
+	}
+
+	matched := make([]bool, len(hashes))
+	var fragments []textFragment
+	for i := 0; i < len(hashes); {
+		pos, ok := firstPos[hashes[i]]
+		if !ok {
+			i++
+			continue
+		}
+		start := i
+		for i < len(hashes) {
+			if _, ok := firstPos[hashes[i]]; !ok {
+				break
+			}
+			matched[i] = true
+			i++
+		}
+		fragments = append(fragments, textFragment{from: start, to: i - 1 + k, matchedStart: other[pos].start})
+	}
+	return fragments, matched
+}
+
+// longestFragments оставляет limit самых длинных фрагментов в порядке следования в тексте
+func longestFragments(fragments []textFragment, limit int) []textFragment {
+	if len(fragments) <= limit {
+		return fragments
+	}
+	sorted := append([]textFragment(nil), fragments...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].to-sorted[i].from > sorted[j].to-sorted[j].from
+	})
+	sorted = sorted[:limit]
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].from < sorted[j].from })
+	return sorted
+}
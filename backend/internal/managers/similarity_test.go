@@ -0,0 +1,145 @@
+package managers
+
+import (
+	"strings"
+	"testing"
+)
+
+const testShingleSize = 5
+
+const similarityBaseText = `Курсовая работа посвящена разработке системы учёта курсовых проектов кафедры.
+В первой главе рассматриваются существующие решения и их недостатки, во второй главе
+описывается архитектура приложения, модель данных и выбор технологий. Третья глава
+содержит описание реализации серверной части, интерфейса преподавателя и студента,
+а также результаты нагрузочного тестирования. В заключении подводятся итоги работы
+и намечаются направления дальнейшего развития системы.`
+
+// compareTexts сравнивает две работы так же, как check: оценка Жаккара по сигнатурам
+// и доля шинглов a, найденных в b. ok = false, если в одном из текстов нет шинглов.
+func compareTexts(a, b string) (jaccard, similarity float64, ok bool) {
+	tokensA, tokensB := tokenize(a), tokenize(b)
+	hashesA := shingleHashes(tokensA, testShingleSize)
+	hashesB := shingleHashes(tokensB, testShingleSize)
+	if len(hashesA) == 0 || len(hashesB) == 0 {
+		return 0, 0, false
+	}
+	jaccard = estimateJaccard(minHashSignature(hashesA), minHashSignature(hashesB))
+	_, matched := matchFragments(hashesA, testShingleSize, tokensB, hashesB)
+	count := 0
+	for _, m := range matched {
+		if m {
+			count++
+		}
+	}
+	return jaccard, float64(count) / float64(len(hashesA)), true
+}
+
+func TestCompareTexts(t *testing.T) {
+	tests := []struct {
+		name          string
+		a, b          string
+		wantOK        bool
+		minJaccard    float64
+		maxJaccard    float64
+		minSimilarity float64
+		maxSimilarity float64
+	}{
+		{
+			name:          "identical",
+			a:             similarityBaseText,
+			b:             similarityBaseText,
+			wantOK:        true,
+			minJaccard:    1,
+			maxJaccard:    1,
+			minSimilarity: 1,
+			maxSimilarity: 1,
+		},
+		{
+			name: "case, punctuation and yo do not matter",
+			a:    similarityBaseText,
+			b: strings.NewReplacer("ё", "е", ",", ";", ".", "!", "Курсовая", "КУРСОВАЯ").
+				Replace(similarityBaseText),
+			wantOK:        true,
+			minJaccard:    1,
+			maxJaccard:    1,
+			minSimilarity: 1,
+			maxSimilarity: 1,
+		},
+		{
+			name: "lightly edited",
+			a:    similarityBaseText,
+			b: strings.NewReplacer("существующие", "известные", "серверной", "клиентской").
+				Replace(similarityBaseText),
+			wantOK:        true,
+			minJaccard:    0.5,
+			maxJaccard:    0.95,
+			minSimilarity: 0.75,
+			maxSimilarity: 0.95,
+		},
+		{
+			name: "unrelated",
+			a:    similarityBaseText,
+			b: `Лабораторный стенд предназначен для измерения теплопроводности образцов
+				при температурах от комнатной до восьмисот градусов. Нагреватель питается от
+				регулируемого источника, показания термопар записывает многоканальный регистратор.`,
+			wantOK:        true,
+			maxJaccard:    0.1,
+			maxSimilarity: 0,
+		},
+		{
+			name:   "empty",
+			a:      "",
+			b:      similarityBaseText,
+			wantOK: false,
+		},
+		{
+			name:   "punctuation only",
+			a:      " .,;— \n\t",
+			b:      " .,;— \n\t",
+			wantOK: false,
+		},
+		{
+			name:   "shorter than a shingle",
+			a:      "Система учёта курсовых",
+			b:      "Система учёта курсовых",
+			wantOK: false,
+		},
+		{
+			name:          "exactly one shingle",
+			a:             "Системы учёта курсовых проектов кафедры",
+			b:             similarityBaseText,
+			wantOK:        true,
+			maxJaccard:    0.1,
+			minSimilarity: 1,
+			maxSimilarity: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jaccard, similarity, ok := compareTexts(tt.a, tt.b)
+			if ok != tt.wantOK {
+				t.Fatalf("comparable = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if jaccard < tt.minJaccard || jaccard > tt.maxJaccard {
+				t.Errorf("jaccard = %.3f, want [%.2f, %.2f]", jaccard, tt.minJaccard, tt.maxJaccard)
+			}
+			if similarity < tt.minSimilarity || similarity > tt.maxSimilarity {
+				t.Errorf("similarity = %.3f, want [%.2f, %.2f]", similarity, tt.minSimilarity, tt.maxSimilarity)
+			}
+		})
+	}
+}
+
+func TestEstimateJaccardMismatchedSignatures(t *testing.T) {
+	sig := minHashSignature(shingleHashes(tokenize(similarityBaseText), testShingleSize))
+	if got := estimateJaccard(sig, nil); got != 0 {
+		t.Errorf("jaccard with empty signature = %v, want 0", got)
+	}
+	if got := estimateJaccard(sig, sig[:len(sig)-8]); got != 0 {
+		t.Errorf("jaccard with truncated signature = %v, want 0", got)
+	}
+}
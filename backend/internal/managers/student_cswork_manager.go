@@ -3,6 +3,7 @@ package managers
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/Foxpunk/courseforge/internal/interfaces"
@@ -11,47 +12,111 @@ import (
 
 // StudentCourseworkManagerImpl реализует interfaces.StudentCourseworkManager
 type StudentCourseworkManagerImpl struct {
-	scRepo interfaces.StudentCourseworkRepository
-	cwRepo interfaces.CourseworkRepository
+	scRepo    interfaces.StudentCourseworkRepository
+	cwRepo    interfaces.CourseworkRepository
+	roundRepo interfaces.SelectionRoundRepository
+	termRepo  interfaces.TermRepository
+	waitlist  interfaces.WaitlistManager
+	workload  interfaces.WorkloadManager
+	uow       interfaces.UnitOfWork
+	events    interfaces.EventPublisher
+}
+
+// errNoSlots - на теме не осталось мест для прямого назначения
+var errNoSlots = errors.New("no slots available for this coursework, join the waitlist")
+
+// statusTexts - статусы работы для уведомлений
+var statusTexts = map[models.CourseworkStatus]string{
+	models.StatusAssigned:   "тема назначена",
+	models.StatusInProgress: "в работе",
+	models.StatusSubmitted:  "сдана на проверку",
+	models.StatusReviewed:   "проверена",
+	models.StatusCompleted:  "принята",
+	models.StatusFailed:     "не зачтена",
 }
 
 // NewStudentCourseworkManager создаёт новый StudentCourseworkManager
 func NewStudentCourseworkManager(
 	scRepo interfaces.StudentCourseworkRepository,
 	cwRepo interfaces.CourseworkRepository,
+	roundRepo interfaces.SelectionRoundRepository,
+	termRepo interfaces.TermRepository,
+	waitlist interfaces.WaitlistManager,
+	workload interfaces.WorkloadManager,
+	uow interfaces.UnitOfWork,
+	events interfaces.EventPublisher,
 ) interfaces.StudentCourseworkManager {
 	return &StudentCourseworkManagerImpl{
-		scRepo: scRepo,
-		cwRepo: cwRepo,
+		scRepo:    scRepo,
+		cwRepo:    cwRepo,
+		roundRepo: roundRepo,
+		termRepo:  termRepo,
+		waitlist:  waitlist,
+		workload:  workload,
+		uow:       uow,
+		events:    events,
 	}
 }
 
-// AssignStudentToCoursework назначает студента на курсовую работу
+// AssignStudentToCoursework назначает студента на курсовую работу. Проверки и вставка идут
+// в одной транзакции, а место занимается условной вставкой, поэтому параллельные запросы
+// не займут последнее место дважды и не создадут студенту второе назначение.
+// Уведомления, обновления мест и вебхуки рассылают подписчики события assignment.created.
 func (m *StudentCourseworkManagerImpl) AssignStudentToCoursework(ctx context.Context, studentID, courseworkID uint) (*models.StudentCoursework, error) {
-	// проверка: студент ещё не назначен
-	if _, err := m.scRepo.GetByStudent(ctx, studentID); err == nil {
-		return nil, errors.New("student already has an assigned coursework")
-	}
-	// проверка: свободные места
-	cw, count, err := m.cwRepo.GetWithStudentCount(ctx, courseworkID)
+	var assign *models.StudentCoursework
+	err := m.uow.Do(ctx, func(ctx context.Context) error {
+		// проверка: студент ещё не назначен
+		if _, err := m.scRepo.GetByStudent(ctx, studentID); err == nil {
+			return errors.New("student already has an assigned coursework")
+		}
+		// проверка: свободные места
+		cw, count, err := m.cwRepo.GetWithStudentCount(ctx, courseworkID)
+		if err != nil {
+			return err
+		}
+		// места, предложенные студентам из листа ожидания, заняты до ответа
+		reserved, err := m.waitlist.ReservedSlots(ctx, courseworkID, studentID)
+		if err != nil {
+			return err
+		}
+		if count+reserved >= cw.MaxStudents {
+			return errNoSlots
+		}
+		// проверка: темы дисциплины не распределяются через раунд выбора
+		if round, err := m.roundRepo.GetActiveBySubject(ctx, cw.SubjectID); err == nil && round.BlocksDirectAssignment() {
+			return fmt.Errorf("topics of this subject are allocated through selection round %d", round.ID)
+		}
+		// проверка: квота руководства преподавателя
+		if err := m.workload.CheckSupervisionQuota(ctx, cw.TeacherID, cw.TermID); err != nil {
+			return err
+		}
+		now := time.Now()
+		assign = &models.StudentCoursework{
+			StudentID:    studentID,
+			CourseworkID: courseworkID,
+			Status:       models.StatusAssigned,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+		ok, err := m.scRepo.CreateIfSlotAvailable(ctx, assign, reserved)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errNoSlots
+		}
+		if err := m.waitlist.WithdrawStudent(ctx, studentID); err != nil {
+			return err
+		}
+		return m.events.Publish(ctx, models.EventAssignmentCreated, interfaces.AssignmentEventData{
+			AssignmentID: assign.ID,
+			Status:       assign.Status,
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
-	if count >= cw.MaxStudents {
-		return nil, errors.New("no slots available for this coursework")
-	}
-	now := time.Now()
-	assign := &models.StudentCoursework{
-		StudentID:    studentID,
-		CourseworkID: courseworkID,
-		Status:       models.StatusAssigned,
-		CreatedAt:    now,
-		UpdatedAt:    now,
-	}
-	if err := m.scRepo.Create(ctx, assign); err != nil {
-		return nil, err
-	}
-	return assign, nil
+	return m.scRepo.GetByID(ctx, assign.ID)
 }
 
 // GetStudentCoursework возвращает текущее назначение студента
@@ -59,33 +124,76 @@ func (m *StudentCourseworkManagerImpl) GetStudentCoursework(ctx context.Context,
 	return m.scRepo.GetByStudent(ctx, studentID)
 }
 
+// GetStudentHistory возвращает назначения студента за семестр (по умолчанию - текущий) или за все семестры
+func (m *StudentCourseworkManagerImpl) GetStudentHistory(ctx context.Context, studentID uint, termID *uint, allTerms bool) ([]models.StudentCoursework, error) {
+	if termID == nil && !allTerms {
+		if active, err := m.termRepo.GetActive(ctx); err == nil {
+			termID = &active.ID
+		}
+	}
+	return m.scRepo.GetHistoryByStudent(ctx, studentID, termID)
+}
+
 // UpdateCourseworkStatus обновляет статус выполнения
 func (m *StudentCourseworkManagerImpl) UpdateCourseworkStatus(ctx context.Context, assignmentID uint, status models.CourseworkStatus) error {
-	return m.scRepo.UpdateStatus(ctx, assignmentID, status)
+	return m.uow.Do(ctx, func(ctx context.Context) error {
+		if err := m.scRepo.UpdateStatus(ctx, assignmentID, status); err != nil {
+			return err
+		}
+		return m.events.Publish(ctx, models.EventAssignmentStatusChanged, interfaces.AssignmentEventData{
+			AssignmentID: assignmentID,
+			Status:       status,
+		})
+	})
 }
 
 // SubmitCoursework отмечает отправку курсовой работы
 func (m *StudentCourseworkManagerImpl) SubmitCoursework(ctx context.Context, assignmentID uint) error {
-	if err := m.scRepo.UpdateStatus(ctx, assignmentID, models.StatusSubmitted); err != nil {
-		return err
-	}
-	return m.scRepo.SetSubmitted(ctx, assignmentID, time.Now())
+	return m.uow.Do(ctx, func(ctx context.Context) error {
+		if err := m.scRepo.UpdateStatus(ctx, assignmentID, models.StatusSubmitted); err != nil {
+			return err
+		}
+		if err := m.scRepo.SetSubmitted(ctx, assignmentID, time.Now()); err != nil {
+			return err
+		}
+		return m.events.Publish(ctx, models.EventAssignmentSubmitted, interfaces.AssignmentEventData{
+			AssignmentID: assignmentID,
+			Status:       models.StatusSubmitted,
+		})
+	})
 }
 
 // GradeCoursework выставляет оценку и фидбэк (для преподавателя)
 func (m *StudentCourseworkManagerImpl) GradeCoursework(ctx context.Context, assignmentID uint, grade int, feedback string) error {
-	if err := m.scRepo.UpdateStatus(ctx, assignmentID, models.StatusReviewed); err != nil {
-		return err
-	}
-	return m.scRepo.SetGrade(ctx, assignmentID, grade, feedback)
+	return m.uow.Do(ctx, func(ctx context.Context) error {
+		if err := m.scRepo.UpdateStatus(ctx, assignmentID, models.StatusReviewed); err != nil {
+			return err
+		}
+		if err := m.scRepo.SetGrade(ctx, assignmentID, grade, feedback); err != nil {
+			return err
+		}
+		return m.events.Publish(ctx, models.EventGradeSet, interfaces.AssignmentEventData{
+			AssignmentID: assignmentID,
+			Status:       models.StatusReviewed,
+			Grade:        &grade,
+		})
+	})
 }
 
 // CompleteCoursework отмечает выполнение курсовой работы
 func (m *StudentCourseworkManagerImpl) CompleteCoursework(ctx context.Context, assignmentID uint) error {
-	if err := m.scRepo.UpdateStatus(ctx, assignmentID, models.StatusCompleted); err != nil {
-		return err
-	}
-	return m.scRepo.SetCompleted(ctx, assignmentID, time.Now())
+	return m.uow.Do(ctx, func(ctx context.Context) error {
+		if err := m.scRepo.UpdateStatus(ctx, assignmentID, models.StatusCompleted); err != nil {
+			return err
+		}
+		if err := m.scRepo.SetCompleted(ctx, assignmentID, time.Now()); err != nil {
+			return err
+		}
+		return m.events.Publish(ctx, models.EventAssignmentCompleted, interfaces.AssignmentEventData{
+			AssignmentID: assignmentID,
+			Status:       models.StatusCompleted,
+		})
+	})
 }
 
 // GetTeacherCourseworks возвращает все задания для работ преподавателя
@@ -135,11 +243,26 @@ func (m *StudentCourseworkManagerImpl) GetCourseworkProgress(ctx context.Context
 	return report, nil
 }
 
-// UnassignStudentFromCoursework отменяет назначение студента
+// UnassignStudentFromCoursework отменяет назначение студента; уведомления рассылают
+// подписчики события assignment.cancelled
 func (m *StudentCourseworkManagerImpl) UnassignStudentFromCoursework(ctx context.Context, studentID uint) error {
-	assignment, err := m.scRepo.GetByStudent(ctx, studentID)
+	var assignment *models.StudentCoursework
+	err := m.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if assignment, err = m.scRepo.GetByStudent(ctx, studentID); err != nil {
+			return err
+		}
+		if err := m.scRepo.Delete(ctx, assignment.ID); err != nil {
+			return err
+		}
+		return m.events.Publish(ctx, models.EventAssignmentCancelled, interfaces.AssignmentEventData{
+			AssignmentID: assignment.ID,
+			Status:       assignment.Status,
+		})
+	})
 	if err != nil {
 		return err
 	}
-	return m.scRepo.Delete(ctx, assignment.ID)
+	// освободившееся место предлагается следующему в очереди, PromoteNext разошлёт занятость мест
+	return m.waitlist.PromoteNext(ctx, assignment.CourseworkID)
 }
@@ -0,0 +1,138 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/Foxpunk/courseforge/internal/config"
+	"github.com/Foxpunk/courseforge/internal/drivers"
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"github.com/Foxpunk/courseforge/internal/testutil"
+)
+
+func newTestStudentCourseworkManager(db *gorm.DB) interfaces.StudentCourseworkManager {
+	scRepo := drivers.NewStudentCourseworkRepository(db)
+	cwRepo := drivers.NewCourseworkRepository(db)
+	uow := drivers.NewUnitOfWork(db)
+	workload := NewWorkloadManager(drivers.NewSupervisionQuotaRepository(db), drivers.NewTeacherProfileRepository(db),
+		drivers.NewDepartmentRepository(db), drivers.NewUserRepository(db), cwRepo, scRepo, drivers.NewTermRepository(db), config.WorkloadConfig{})
+	events := NewEventBus(drivers.NewOutboxRepository(db), config.OutboxConfig{BatchSize: 50, MaxAttempts: 1})
+	waitlist := NewWaitlistManager(drivers.NewWaitlistRepository(db), cwRepo, scRepo, workload, uow, events, time.Hour)
+	return NewStudentCourseworkManager(scRepo, cwRepo, drivers.NewSelectionRoundRepository(db), drivers.NewTermRepository(db),
+		waitlist, workload, uow, events)
+}
+
+func TestAssignStudentToCourseworkConcurrent(t *testing.T) {
+	const (
+		slots    = 3
+		students = 20
+		repeat   = 3
+	)
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	term := testutil.CreateActiveTerm(t, db)
+	teacher := testutil.CreateUser(t, db, models.RoleTeacher, "teacher@example.com")
+	cw := testutil.CreateCoursework(t, db, teacher, term, slots)
+	studentIDs := make([]uint, students)
+	for i := range studentIDs {
+		studentIDs[i] = testutil.CreateUser(t, db, models.RoleStudent, fmt.Sprintf("s%d@example.com", i)).ID
+	}
+	manager := newTestStudentCourseworkManager(db)
+
+	// каждый студент отправляет несколько запросов, и все они стартуют одновременно
+	type result struct {
+		studentID uint
+		assign    *models.StudentCoursework
+		err       error
+	}
+	start := make(chan struct{})
+	results := make(chan result, students*repeat)
+	var wg sync.WaitGroup
+	for _, id := range studentIDs {
+		for j := 0; j < repeat; j++ {
+			wg.Add(1)
+			go func(id uint) {
+				defer wg.Done()
+				<-start
+				assign, err := manager.AssignStudentToCoursework(ctx, id, cw.ID)
+				results <- result{studentID: id, assign: assign, err: err}
+			}(id)
+		}
+	}
+	close(start)
+	wg.Wait()
+	close(results)
+
+	assigned := make(map[uint]int)
+	slotErrors := 0
+	for r := range results {
+		switch {
+		case r.err == nil:
+			assigned[r.studentID]++
+			if r.assign == nil || r.assign.ID == 0 || r.assign.StudentID != r.studentID || r.assign.TermID != term.ID {
+				t.Errorf("unexpected assignment returned: %+v", r.assign)
+			}
+		case errors.Is(r.err, errNoSlots):
+			slotErrors++
+		default:
+			// повторный запрос студента, которому место уже досталось
+			if assigned := assignedStudent(t, db, r.studentID); !assigned {
+				t.Errorf("student %d: unexpected error: %v", r.studentID, r.err)
+			}
+		}
+	}
+
+	if len(assigned) != slots {
+		t.Errorf("%d students assigned, want %d", len(assigned), slots)
+	}
+	for id, n := range assigned {
+		if n != 1 {
+			t.Errorf("student %d assigned %d times", id, n)
+		}
+	}
+	// студенты без места получают отказ по местам на каждый свой запрос
+	if want := (students - slots) * repeat; slotErrors != want {
+		t.Errorf("%d slot-full errors, want %d", slotErrors, want)
+	}
+
+	var duplicates int64
+	if err := db.Raw(`SELECT COUNT(*) FROM (SELECT student_id FROM student_courseworks
+		WHERE deleted_at IS NULL GROUP BY student_id, term_id HAVING COUNT(*) > 1)`).Scan(&duplicates).Error; err != nil {
+		t.Fatal(err)
+	}
+	if duplicates != 0 {
+		t.Errorf("%d students have several active assignments in the term", duplicates)
+	}
+	var active int64
+	if err := db.Model(&models.StudentCoursework{}).Where("coursework_id = ?", cw.ID).Count(&active).Error; err != nil {
+		t.Fatal(err)
+	}
+	if active != slots {
+		t.Errorf("%d active assignments, want %d", active, slots)
+	}
+	// об отклонённых запросах событий в outbox не остаётся
+	var events int64
+	if err := db.Model(&models.OutboxEvent{}).Where("type = ?", models.EventAssignmentCreated).Count(&events).Error; err != nil {
+		t.Fatal(err)
+	}
+	if events != slots {
+		t.Errorf("%d assignment.created events in outbox, want %d", events, slots)
+	}
+}
+
+// assignedStudent проверяет, есть ли у студента активное назначение
+func assignedStudent(t *testing.T, db *gorm.DB, studentID uint) bool {
+	t.Helper()
+	var n int64
+	if err := db.Model(&models.StudentCoursework{}).Where("student_id = ?", studentID).Count(&n).Error; err != nil {
+		t.Fatal(err)
+	}
+	return n > 0
+}
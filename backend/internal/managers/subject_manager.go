@@ -14,6 +14,7 @@ type SubjectManagerImpl struct {
 	subjRepo   interfaces.SubjectRepository
 	assignRepo interfaces.TeacherSubjectRepository
 	profRepo   interfaces.TeacherProfileRepository
+	termRepo   interfaces.TermRepository
 }
 
 // NewSubjectManager создаёт новый SubjectManager
@@ -21,11 +22,13 @@ func NewSubjectManager(
 	subjRepo interfaces.SubjectRepository,
 	assignRepo interfaces.TeacherSubjectRepository,
 	profRepo interfaces.TeacherProfileRepository,
+	termRepo interfaces.TermRepository,
 ) interfaces.SubjectManager {
 	return &SubjectManagerImpl{
 		subjRepo:   subjRepo,
 		assignRepo: assignRepo,
 		profRepo:   profRepo,
+		termRepo:   termRepo,
 	}
 }
 
@@ -93,27 +96,41 @@ func (m *SubjectManagerImpl) GetSubjectsBySemester(ctx context.Context, semester
 	return m.subjRepo.GetBySemester(ctx, semester)
 }
 
-// AssignTeacherToSubject привязывает преподавателя к дисциплине
+// AssignTeacherToSubject привязывает преподавателя к дисциплине; назначение ведущим
+// снимает этот флаг с прежнего ведущего преподавателя того же учебного года
 func (m *SubjectManagerImpl) AssignTeacherToSubject(
 	ctx context.Context,
 	teacherID, subjectID uint,
-	academicYear string,
-	isLead bool,
+	req interfaces.CreateTeacherSubjectRequest,
 ) error {
 	if teacherID == 0 || subjectID == 0 {
 		return errors.New("invalid parameters for assignment")
 	}
+	role := models.TeacherSubjectRoleSupervisor
+	if req.Role != "" {
+		role = models.TeacherSubjectRole(req.Role)
+	}
+	if !role.IsValid() {
+		return errors.New("invalid teacher role")
+	}
+	termID, year, err := m.subjectTerm(ctx, subjectID, req.AcademicYear)
+	if err != nil {
+		return err
+	}
 
-	log.Printf("Creating TeacherSubject: UserID=%d, SubjectID=%d", teacherID, subjectID)
+	log.Printf("Creating TeacherSubject: UserID=%d, SubjectID=%d, TermID=%d", teacherID, subjectID, termID)
 
 	assign := &models.TeacherSubject{
-		UserID:    teacherID, // Изменено на UserID
-		SubjectID: subjectID,
-		// academicYear и isLead игнорируем - их нет в таблице
+		UserID:       teacherID, // Изменено на UserID
+		SubjectID:    subjectID,
+		TermID:       termID,
+		AcademicYear: year,
+		IsLead:       req.IsLead,
+		Role:         role,
 	}
 	log.Printf("TeacherSubject object: %+v", assign)
 
-	err := m.assignRepo.Create(ctx, assign)
+	err = m.assignRepo.Create(ctx, assign)
 	if err != nil {
 		log.Printf("Error creating TeacherSubject: %v", err)
 		return err
@@ -123,9 +140,9 @@ func (m *SubjectManagerImpl) AssignTeacherToSubject(
 	return nil
 }
 
-// GetTeacherSubjects возвращает дисциплины преподавателя за год
+// GetTeacherSubjects возвращает дисциплины преподавателя за год (по умолчанию - текущий)
 func (m *SubjectManagerImpl) GetTeacherSubjects(ctx context.Context, teacherID uint, academicYear string) ([]models.Subject, error) {
-	assigns, err := m.assignRepo.GetByTeacher(ctx, teacherID, academicYear)
+	assigns, err := m.assignRepo.GetByTeacher(ctx, teacherID, m.yearOrCurrent(ctx, academicYear))
 	if err != nil {
 		return nil, err
 	}
@@ -136,9 +153,9 @@ func (m *SubjectManagerImpl) GetTeacherSubjects(ctx context.Context, teacherID u
 	return subjects, nil
 }
 
-// GetSubjectTeachers возвращает профили преподавателей дисциплины за год
+// GetSubjectTeachers возвращает профили преподавателей дисциплины за год (по умолчанию - текущий)
 func (m *SubjectManagerImpl) GetSubjectTeachers(ctx context.Context, subjectID uint, academicYear string) ([]models.TeacherProfile, error) {
-	assigns, err := m.assignRepo.GetBySubject(ctx, subjectID, academicYear)
+	assigns, err := m.assignRepo.GetBySubject(ctx, subjectID, m.yearOrCurrent(ctx, academicYear))
 	if err != nil {
 		return nil, err
 	}
@@ -151,12 +168,49 @@ func (m *SubjectManagerImpl) GetSubjectTeachers(ctx context.Context, subjectID u
 	return profiles, nil
 }
 
-// SetLeadTeacher меняет ведущего преподавателя (не работает с простой таблицей)
+// SetLeadTeacher назначает ведущего преподавателя дисциплины в учебном году (по умолчанию - текущем)
 func (m *SubjectManagerImpl) SetLeadTeacher(ctx context.Context, teacherID, subjectID uint, academicYear string) error {
-	return errors.New("lead teacher functionality not supported with simple teacher_subjects table")
+	return m.assignRepo.SetLead(ctx, teacherID, subjectID, m.yearOrCurrent(ctx, academicYear), true)
 }
 
-// RemoveTeacherFromSubject удаляет преподавателя с дисциплины
+// GetLeadTeacher возвращает назначение ведущего преподавателя дисциплины в учебном году (по умолчанию - текущем)
+func (m *SubjectManagerImpl) GetLeadTeacher(ctx context.Context, subjectID uint, academicYear string) (*models.TeacherSubject, error) {
+	return m.assignRepo.GetLeadTeacher(ctx, subjectID, m.yearOrCurrent(ctx, academicYear))
+}
+
+// RemoveTeacherFromSubject удаляет преподавателя с дисциплины в указанном учебном году
 func (m *SubjectManagerImpl) RemoveTeacherFromSubject(ctx context.Context, teacherID, subjectID uint, academicYear string) error {
-	return m.assignRepo.DeleteByTeacherAndSubject(ctx, teacherID, subjectID)
+	termID, _, err := m.subjectTerm(ctx, subjectID, academicYear)
+	if err != nil {
+		return err
+	}
+	return m.assignRepo.DeleteByTeacherAndSubject(ctx, teacherID, subjectID, termID)
+}
+
+// subjectTerm определяет семестр учебного года, в котором читается дисциплина, и название этого года
+func (m *SubjectManagerImpl) subjectTerm(ctx context.Context, subjectID uint, academicYear string) (uint, string, error) {
+	subj, err := m.subjRepo.GetByID(ctx, subjectID)
+	if err != nil {
+		return 0, "", err
+	}
+	termID, err := resolveYearTerm(ctx, m.termRepo, academicYear, subj.Semester)
+	if err != nil || termID == 0 {
+		return termID, academicYear, err
+	}
+	term, err := m.termRepo.GetByID(ctx, termID)
+	if err != nil {
+		return 0, "", err
+	}
+	return termID, term.AcademicYear.Name, nil
+}
+
+// yearOrCurrent подставляет учебный год активного семестра, если год не указан
+func (m *SubjectManagerImpl) yearOrCurrent(ctx context.Context, academicYear string) string {
+	if academicYear != "" {
+		return academicYear
+	}
+	if term, err := m.termRepo.GetActive(ctx); err == nil {
+		return term.AcademicYear.Name
+	}
+	return ""
 }
@@ -0,0 +1,298 @@
+package managers
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/config"
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// Параметры отчёта о заимствованиях
+const (
+	maxReportFragments  = 20   // фрагментов на одно совпадение
+	maxFragmentText     = 2000 // байт текста фрагмента в отчёте
+	staleCheckAfter     = 10 * time.Minute
+	submissionKeyPrefix = "submissions"
+)
+
+// SubmissionManagerImpl реализует interfaces.SubmissionManager
+type SubmissionManagerImpl struct {
+	subRepo   interfaces.SubmissionRepository
+	scRepo    interfaces.StudentCourseworkRepository
+	scManager interfaces.StudentCourseworkManager
+	storage   interfaces.FileStorage
+	uow       interfaces.UnitOfWork
+	events    interfaces.EventPublisher
+	cfg       config.SimilarityConfig
+}
+
+// NewSubmissionManager создаёт новый SubmissionManager
+func NewSubmissionManager(
+	subRepo interfaces.SubmissionRepository,
+	scRepo interfaces.StudentCourseworkRepository,
+	scManager interfaces.StudentCourseworkManager,
+	storage interfaces.FileStorage,
+	uow interfaces.UnitOfWork,
+	events interfaces.EventPublisher,
+	cfg config.SimilarityConfig,
+) interfaces.SubmissionManager {
+	return &SubmissionManagerImpl{
+		subRepo:   subRepo,
+		scRepo:    scRepo,
+		scManager: scManager,
+		storage:   storage,
+		uow:       uow,
+		events:    events,
+		cfg:       cfg,
+	}
+}
+
+// Submit сохраняет файл работы по текущему назначению студента и ставит его в очередь проверки.
+// Повторная сдача добавляет новую версию файла, прежние остаются в архиве.
+func (m *SubmissionManagerImpl) Submit(ctx context.Context, studentID uint, fileName, contentType string, file io.Reader) (*models.Submission, error) {
+	sc, err := m.scRepo.GetByStudent(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	switch sc.Status {
+	case models.StatusReviewed, models.StatusCompleted, models.StatusFailed:
+		return nil, errors.New("coursework has already been graded")
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, errors.New("file is empty")
+	}
+	sum := sha256.Sum256(data)
+
+	key, err := newStorageKey(submissionKeyPrefix, sc.ID, fileName)
+	if err != nil {
+		return nil, err
+	}
+	size, err := m.storage.Save(ctx, key, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &models.Submission{
+		StudentCourseworkID: sc.ID,
+		StudentID:           studentID,
+		CourseworkID:        sc.CourseworkID,
+		TermID:              sc.TermID,
+		FileName:            filepath.Base(fileName),
+		ContentType:         contentType,
+		Size:                size,
+		SHA256:              hex.EncodeToString(sum[:]),
+		StorageKey:          key,
+		CheckStatus:         models.CheckPending,
+	}
+	// работа, событие submission.created и смена статуса назначения сохраняются вместе
+	err = m.uow.Do(ctx, func(ctx context.Context) error {
+		if err := m.subRepo.Create(ctx, sub); err != nil {
+			return err
+		}
+		if err := m.events.Publish(ctx, models.EventSubmissionCreated, interfaces.SubmissionEventData{
+			SubmissionID: sub.ID,
+			NewVersion:   sc.Status == models.StatusSubmitted,
+		}); err != nil {
+			return err
+		}
+		if sc.Status == models.StatusSubmitted {
+			return nil
+		}
+		return m.scManager.SubmitCoursework(ctx, sc.ID)
+	})
+	if err != nil {
+		_ = m.storage.Delete(ctx, key)
+		return nil, err
+	}
+	return m.subRepo.GetByID(ctx, sub.ID)
+}
+
+// GetSubmission возвращает сданную работу
+func (m *SubmissionManagerImpl) GetSubmission(ctx context.Context, submissionID uint) (*models.Submission, error) {
+	return m.subRepo.GetByID(ctx, submissionID)
+}
+
+// ListStudentSubmissions возвращает работы студента
+func (m *SubmissionManagerImpl) ListStudentSubmissions(ctx context.Context, studentID uint) ([]models.Submission, error) {
+	return m.subRepo.ListByStudent(ctx, studentID)
+}
+
+// ListCourseworkSubmissions возвращает работы по теме
+func (m *SubmissionManagerImpl) ListCourseworkSubmissions(ctx context.Context, courseworkID uint) ([]models.Submission, error) {
+	return m.subRepo.ListByCoursework(ctx, courseworkID)
+}
+
+// OpenFile открывает файл работы
+func (m *SubmissionManagerImpl) OpenFile(ctx context.Context, sub *models.Submission) (io.ReadCloser, error) {
+	return m.storage.Open(ctx, sub.StorageKey)
+}
+
+// GetSimilarityReport возвращает совпадения работы с архивом
+func (m *SubmissionManagerImpl) GetSimilarityReport(ctx context.Context, submissionID uint) ([]models.SimilarityMatch, error) {
+	return m.subRepo.GetMatches(ctx, submissionID)
+}
+
+// Recheck возвращает работу в очередь, например после изменения порогов
+func (m *SubmissionManagerImpl) Recheck(ctx context.Context, submissionID uint) error {
+	sub, err := m.subRepo.GetByID(ctx, submissionID)
+	if err != nil {
+		return err
+	}
+	if sub.CheckStatus == models.CheckPending || sub.CheckStatus == models.CheckRunning {
+		return errors.New("submission is already queued for checking")
+	}
+	return m.subRepo.SetCheckStatus(ctx, sub.ID, models.CheckPending, "")
+}
+
+// ProcessPending проверяет порцию работ из очереди. Ошибка одной работы
+// отмечается в ней самой и не мешает остальным.
+func (m *SubmissionManagerImpl) ProcessPending(ctx context.Context) (int, error) {
+	subs, err := m.subRepo.ClaimPending(ctx, m.cfg.BatchSize, staleCheckAfter)
+	if err != nil {
+		return 0, err
+	}
+	for i := range subs {
+		status, checkErr := m.check(ctx, &subs[i])
+		if checkErr == nil {
+			continue
+		}
+		log.Printf("similarity check of submission %d failed: %v", subs[i].ID, checkErr)
+		if err := m.subRepo.SetCheckStatus(ctx, subs[i].ID, status, checkErr.Error()); err != nil {
+			return i, err
+		}
+	}
+	return len(subs), nil
+}
+
+// check снимает отпечаток работы и сравнивает его с более ранними работами архива.
+// При ошибке возвращает статус, с которым работа остаётся.
+func (m *SubmissionManagerImpl) check(ctx context.Context, sub *models.Submission) (models.SimilarityCheckStatus, error) {
+	f, err := m.storage.Open(ctx, sub.StorageKey)
+	if err != nil {
+		return models.CheckFailed, err
+	}
+	data, err := io.ReadAll(f)
+	f.Close()
+	if err != nil {
+		return models.CheckFailed, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	text, err := extractText(sub.FileName, data)
+	if errors.Is(err, errUnsupportedFormat) {
+		return models.CheckUnsupported, fmt.Errorf("text cannot be extracted from %s files", strings.ToLower(filepath.Ext(sub.FileName)))
+	}
+	if err != nil {
+		return models.CheckFailed, err
+	}
+
+	k := m.cfg.ShingleSize
+	tokens := tokenize(text)
+	hashes := shingleHashes(tokens, k)
+	fp := &models.SubmissionFingerprint{
+		SubmissionID: sub.ID,
+		ShingleCount: len(hashes),
+		Signature:    minHashSignature(hashes),
+		Text:         text,
+	}
+	if err := m.subRepo.SaveFingerprint(ctx, fp); err != nil {
+		return models.CheckFailed, err
+	}
+	if len(hashes) == 0 {
+		return models.CheckUnsupported, errors.New("file contains too little text to compare")
+	}
+
+	archive, err := m.subRepo.ListSignatures(ctx, sub.ID, sub.StudentCourseworkID)
+	if err != nil {
+		return models.CheckFailed, err
+	}
+
+	covered := make([]bool, len(hashes))
+	var matches []models.SimilarityMatch
+	for _, other := range archive {
+		jaccard := estimateJaccard(fp.Signature, other.Signature)
+		if jaccard < m.cfg.CandidateThreshold {
+			continue
+		}
+		otherFP, err := m.subRepo.GetFingerprint(ctx, other.SubmissionID)
+		if err != nil {
+			return models.CheckFailed, err
+		}
+		otherTokens := tokenize(otherFP.Text)
+		fragments, matched := matchFragments(hashes, k, otherTokens, shingleHashes(otherTokens, k))
+
+		count := 0
+		for _, ok := range matched {
+			if ok {
+				count++
+			}
+		}
+		similarity := float64(count) / float64(len(hashes))
+		if similarity < m.cfg.ReportThreshold {
+			continue
+		}
+		for i, ok := range matched {
+			covered[i] = covered[i] || ok
+		}
+
+		match := models.SimilarityMatch{
+			MatchedSubmissionID: other.SubmissionID,
+			Similarity:          similarity,
+			Jaccard:             jaccard,
+		}
+		for _, fr := range longestFragments(fragments, maxReportFragments) {
+			start, end := tokens[fr.from].start, tokens[fr.to-1].end
+			if end-start > maxFragmentText {
+				end = start + maxFragmentText
+			}
+			match.Fragments = append(match.Fragments, models.SimilarityFragment{
+				Text:            strings.ToValidUTF8(text[start:end], ""),
+				Words:           fr.to - fr.from,
+				Position:        start,
+				MatchedPosition: fr.matchedStart,
+			})
+		}
+		matches = append(matches, match)
+	}
+
+	score := 0.0
+	for _, ok := range covered {
+		if ok {
+			score++
+		}
+	}
+	score /= float64(len(hashes))
+	if err := m.subRepo.SaveReport(ctx, sub.ID, score, matches); err != nil {
+		return models.CheckFailed, err
+	}
+	return models.CheckDone, nil
+}
+
+// newStorageKey - путь файла в хранилище: <раздел>/<владелец>/<случайное имя><расширение>
+func newStorageKey(prefix string, ownerID uint, fileName string) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate file name: %w", err)
+	}
+	ext := strings.ToLower(filepath.Ext(fileName))
+	if len(ext) > 10 {
+		ext = ""
+	}
+	return fmt.Sprintf("%s/%d/%s%s", prefix, ownerID, hex.EncodeToString(buf), ext), nil
+}
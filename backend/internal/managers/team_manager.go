@@ -0,0 +1,425 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// TeamManagerImpl реализует interfaces.TeamManager
+type TeamManagerImpl struct {
+	teamRepo  interfaces.TeamRepository
+	invRepo   interfaces.TeamInvitationRepository
+	userRepo  interfaces.UserRepository
+	scRepo    interfaces.StudentCourseworkRepository
+	scManager interfaces.StudentCourseworkManager
+	notifier  interfaces.Notifier
+	uow       interfaces.UnitOfWork
+}
+
+// NewTeamManager создаёт новый TeamManager
+func NewTeamManager(
+	teamRepo interfaces.TeamRepository,
+	invRepo interfaces.TeamInvitationRepository,
+	userRepo interfaces.UserRepository,
+	scRepo interfaces.StudentCourseworkRepository,
+	scManager interfaces.StudentCourseworkManager,
+	notifier interfaces.Notifier,
+	uow interfaces.UnitOfWork,
+) interfaces.TeamManager {
+	return &TeamManagerImpl{
+		teamRepo:  teamRepo,
+		invRepo:   invRepo,
+		userRepo:  userRepo,
+		scRepo:    scRepo,
+		scManager: scManager,
+		notifier:  notifier,
+		uow:       uow,
+	}
+}
+
+// CreateTeam создаёт команду на курсовую работу, за которой закреплён лидер
+func (m *TeamManagerImpl) CreateTeam(ctx context.Context, leaderID uint, req interfaces.CreateTeamRequest) (*models.Team, error) {
+	assignment, err := m.scRepo.GetByStudent(ctx, leaderID)
+	if err != nil {
+		return nil, errors.New("student must be assigned to a coursework before creating a team")
+	}
+	if assignment.Coursework.MaxStudents < 2 {
+		return nil, errors.New("coursework is intended for a single student")
+	}
+	if _, err := m.teamRepo.GetByStudent(ctx, leaderID); err == nil {
+		return nil, errors.New("student is already a member of a team")
+	}
+	if existing, err := m.teamRepo.GetByCoursework(ctx, assignment.CourseworkID); err == nil {
+		return nil, fmt.Errorf("coursework already has team %q", existing.Name)
+	}
+
+	team := &models.Team{
+		Name:         req.Name,
+		CourseworkID: assignment.CourseworkID,
+		LeaderID:     leaderID,
+		GradingMode:  models.GradingTeam,
+	}
+	if err := m.teamRepo.Create(ctx, team); err != nil {
+		return nil, err
+	}
+	return m.teamRepo.GetByID(ctx, team.ID)
+}
+
+// GetTeam возвращает команду по ID
+func (m *TeamManagerImpl) GetTeam(ctx context.Context, teamID uint) (*models.Team, error) {
+	return m.teamRepo.GetByID(ctx, teamID)
+}
+
+// GetStudentTeam возвращает команду студента
+func (m *TeamManagerImpl) GetStudentTeam(ctx context.Context, studentID uint) (*models.Team, error) {
+	return m.teamRepo.GetByStudent(ctx, studentID)
+}
+
+// DisbandTeam распускает команду; назначения участников на курсовую сохраняются
+func (m *TeamManagerImpl) DisbandTeam(ctx context.Context, teamID uint) error {
+	team, err := m.teamRepo.GetByID(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	if team.SubmittedAt != nil {
+		return errors.New("team has already submitted its work")
+	}
+	return m.teamRepo.Delete(ctx, teamID)
+}
+
+// InviteMember приглашает студента в команду
+func (m *TeamManagerImpl) InviteMember(ctx context.Context, teamID, inviterID, studentID uint) (*models.TeamInvitation, error) {
+	team, err := m.teamRepo.GetByID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if team.SubmittedAt != nil {
+		return nil, errors.New("team has already submitted its work")
+	}
+	student, err := m.userRepo.GetByID(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if !student.IsStudent() {
+		return nil, errors.New("only students can be invited")
+	}
+	if team.HasMember(studentID) {
+		return nil, errors.New("student is already a member of this team")
+	}
+	if _, err := m.teamRepo.GetByStudent(ctx, studentID); err == nil {
+		return nil, errors.New("student is already a member of another team")
+	}
+	if assignment, err := m.scRepo.GetByStudent(ctx, studentID); err == nil && assignment.CourseworkID != team.CourseworkID {
+		return nil, errors.New("student is assigned to a different coursework")
+	}
+
+	pending, err := m.invRepo.GetPendingByTeam(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	for _, inv := range pending {
+		if inv.StudentID == studentID {
+			return nil, errors.New("student already has a pending invitation to this team")
+		}
+	}
+	if len(team.Members)+len(pending) >= team.Coursework.MaxStudents {
+		return nil, fmt.Errorf("team cannot have more than %d members", team.Coursework.MaxStudents)
+	}
+
+	inv := &models.TeamInvitation{
+		TeamID:      teamID,
+		StudentID:   studentID,
+		InvitedByID: inviterID,
+		Status:      models.InvitationPending,
+	}
+	if err := m.invRepo.Create(ctx, inv); err != nil {
+		return nil, err
+	}
+
+	m.notify(ctx, interfaces.Event{
+		Type:        models.EventTeamInvitation,
+		RecipientID: studentID,
+		ActorID:     inviterID,
+		EntityType:  models.EntityTeam,
+		EntityID:    team.ID,
+		Title:       "Приглашение в команду",
+		Message:     fmt.Sprintf("Вас пригласили в команду «%s» по курсовой работе «%s».", team.Name, team.Coursework.Title),
+	})
+	return m.invRepo.GetByID(ctx, inv.ID)
+}
+
+// GetInvitation возвращает приглашение по ID
+func (m *TeamManagerImpl) GetInvitation(ctx context.Context, invitationID uint) (*models.TeamInvitation, error) {
+	return m.invRepo.GetByID(ctx, invitationID)
+}
+
+// GetStudentInvitations возвращает входящие приглашения студента
+func (m *TeamManagerImpl) GetStudentInvitations(ctx context.Context, studentID uint) ([]models.TeamInvitation, error) {
+	return m.invRepo.GetPendingByStudent(ctx, studentID)
+}
+
+// GetTeamInvitations возвращает неотвеченные приглашения команды
+func (m *TeamManagerImpl) GetTeamInvitations(ctx context.Context, teamID uint) ([]models.TeamInvitation, error) {
+	return m.invRepo.GetPendingByTeam(ctx, teamID)
+}
+
+// RespondInvitation принимает или отклоняет приглашение; при принятии студент назначается на курсовую
+func (m *TeamManagerImpl) RespondInvitation(ctx context.Context, invitationID uint, accept bool) (*models.Team, error) {
+	inv, err := m.invRepo.GetByID(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if inv.Status != models.InvitationPending {
+		return nil, fmt.Errorf("invitation in status %q cannot be answered", inv.Status)
+	}
+
+	now := time.Now()
+	inv.RespondedAt = &now
+	if !accept {
+		inv.Status = models.InvitationDeclined
+		if err := m.invRepo.Update(ctx, inv); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	if _, err := m.teamRepo.GetByStudent(ctx, inv.StudentID); err == nil {
+		return nil, errors.New("student is already a member of a team")
+	}
+	assignment, err := m.scRepo.GetByStudent(ctx, inv.StudentID)
+	switch {
+	case err != nil:
+		// студент ещё не выбрал тему - назначаем на курсовую команды с обычными проверками мест
+		if _, err := m.scManager.AssignStudentToCoursework(ctx, inv.StudentID, inv.Team.CourseworkID); err != nil {
+			return nil, err
+		}
+	case assignment.CourseworkID != inv.Team.CourseworkID:
+		return nil, errors.New("student is assigned to a different coursework")
+	}
+
+	if err := m.teamRepo.AddMember(ctx, &models.TeamMember{TeamID: inv.TeamID, StudentID: inv.StudentID}); err != nil {
+		return nil, err
+	}
+	inv.Status = models.InvitationAccepted
+	if err := m.invRepo.Update(ctx, inv); err != nil {
+		return nil, err
+	}
+	if err := m.invRepo.RevokePendingByStudent(ctx, inv.StudentID); err != nil {
+		return nil, err
+	}
+
+	m.notify(ctx, interfaces.Event{
+		Type:        models.EventTeamMemberJoined,
+		RecipientID: inv.Team.LeaderID,
+		ActorID:     inv.StudentID,
+		EntityType:  models.EntityTeam,
+		EntityID:    inv.TeamID,
+		Title:       "Новый участник команды",
+		Message:     fmt.Sprintf("%s присоединился к команде «%s».", inv.Student.GetFullName(), inv.Team.Name),
+	})
+	return m.teamRepo.GetByID(ctx, inv.TeamID)
+}
+
+// RevokeInvitation отзывает неотвеченное приглашение
+func (m *TeamManagerImpl) RevokeInvitation(ctx context.Context, invitationID uint) error {
+	inv, err := m.invRepo.GetByID(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+	if inv.Status != models.InvitationPending {
+		return fmt.Errorf("invitation in status %q cannot be revoked", inv.Status)
+	}
+	now := time.Now()
+	inv.Status = models.InvitationRevoked
+	inv.RespondedAt = &now
+	return m.invRepo.Update(ctx, inv)
+}
+
+// RemoveMember исключает участника; сам студент остаётся назначенным на курсовую
+func (m *TeamManagerImpl) RemoveMember(ctx context.Context, teamID, studentID uint) error {
+	team, err := m.teamRepo.GetByID(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	if team.LeaderID == studentID {
+		return errors.New("leader cannot leave the team, transfer leadership or disband it")
+	}
+	if team.SubmittedAt != nil {
+		return errors.New("team has already submitted its work")
+	}
+	return m.teamRepo.RemoveMember(ctx, teamID, studentID)
+}
+
+// TransferLeadership передаёт роль лидера другому участнику
+func (m *TeamManagerImpl) TransferLeadership(ctx context.Context, teamID, newLeaderID uint) error {
+	team, err := m.teamRepo.GetByID(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	if !team.HasMember(newLeaderID) {
+		return errors.New("new leader must be a member of the team")
+	}
+	team.LeaderID = newLeaderID
+	return m.teamRepo.Update(ctx, team)
+}
+
+// UpdateContribution сохраняет описание личного вклада участника
+func (m *TeamManagerImpl) UpdateContribution(ctx context.Context, teamID, studentID uint, note string) error {
+	team, err := m.teamRepo.GetByID(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	for i := range team.Members {
+		if team.Members[i].StudentID == studentID {
+			team.Members[i].ContributionNote = note
+			return m.teamRepo.UpdateMember(ctx, &team.Members[i])
+		}
+	}
+	return fmt.Errorf("student %d is not a member of team %d", studentID, teamID)
+}
+
+// AddPost публикует запись в рабочем пространстве команды
+func (m *TeamManagerImpl) AddPost(ctx context.Context, teamID, authorID uint, req interfaces.CreateTeamPostRequest) (*models.TeamPost, error) {
+	if _, err := m.teamRepo.GetByID(ctx, teamID); err != nil {
+		return nil, err
+	}
+	post := &models.TeamPost{
+		TeamID:        teamID,
+		AuthorID:      authorID,
+		Body:          req.Body,
+		AttachmentURL: req.AttachmentURL,
+	}
+	if err := m.teamRepo.CreatePost(ctx, post); err != nil {
+		return nil, err
+	}
+	author, err := m.userRepo.GetByID(ctx, authorID)
+	if err != nil {
+		return nil, err
+	}
+	post.Author = *author
+	return post, nil
+}
+
+// GetPosts возвращает записи рабочего пространства команды
+func (m *TeamManagerImpl) GetPosts(ctx context.Context, teamID uint) ([]models.TeamPost, error) {
+	return m.teamRepo.GetPosts(ctx, teamID)
+}
+
+// SubmitWork сдаёт общую работу команды и отмечает сдачу у каждого участника
+func (m *TeamManagerImpl) SubmitWork(ctx context.Context, teamID, submitterID uint, req interfaces.SubmitTeamWorkRequest) error {
+	team, err := m.teamRepo.GetByID(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	assignments, err := m.memberAssignments(ctx, team)
+	if err != nil {
+		return err
+	}
+	for _, a := range assignments {
+		if a.Status == models.StatusReviewed || a.Status == models.StatusCompleted {
+			return errors.New("team work has already been graded")
+		}
+	}
+
+	now := time.Now()
+	team.SubmissionURL = req.SubmissionURL
+	team.SubmissionComment = req.Comment
+	team.SubmittedByID = &submitterID
+	team.SubmittedAt = &now
+	return m.uow.Do(ctx, func(ctx context.Context) error {
+		if err := m.teamRepo.Update(ctx, team); err != nil {
+			return err
+		}
+		for _, a := range assignments {
+			if err := m.scManager.SubmitCoursework(ctx, a.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GradeTeam выставляет общую оценку команде или индивидуальные оценки участникам
+func (m *TeamManagerImpl) GradeTeam(ctx context.Context, teamID uint, req interfaces.GradeTeamRequest) error {
+	team, err := m.teamRepo.GetByID(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	if team.SubmittedAt == nil {
+		return errors.New("team has not submitted its work yet")
+	}
+	assignments, err := m.memberAssignments(ctx, team)
+	if err != nil {
+		return err
+	}
+
+	type grade struct {
+		value    int
+		feedback string
+	}
+	grades := make(map[uint]grade, len(assignments))
+
+	switch req.Mode {
+	case models.GradingTeam:
+		if req.Grade == nil {
+			return errors.New("grade is required for team grading")
+		}
+		for studentID := range assignments {
+			grades[studentID] = grade{value: *req.Grade, feedback: req.Feedback}
+		}
+	case models.GradingIndividual:
+		for _, g := range req.Members {
+			if _, ok := assignments[g.StudentID]; !ok {
+				return fmt.Errorf("student %d is not a member of the team", g.StudentID)
+			}
+			feedback := g.Feedback
+			if feedback == "" {
+				feedback = req.Feedback
+			}
+			grades[g.StudentID] = grade{value: g.Grade, feedback: feedback}
+		}
+		if len(grades) != len(assignments) {
+			return errors.New("individual grading requires a grade for every member")
+		}
+	default:
+		return fmt.Errorf("unsupported grading mode %q", req.Mode)
+	}
+
+	// оценки участников и режим оценивания сохраняются вместе или не сохраняются вовсе
+	return m.uow.Do(ctx, func(ctx context.Context) error {
+		for studentID, g := range grades {
+			if err := m.scManager.GradeCoursework(ctx, assignments[studentID].ID, g.value, g.feedback); err != nil {
+				return err
+			}
+		}
+		team.GradingMode = req.Mode
+		return m.teamRepo.Update(ctx, team)
+	})
+}
+
+// Вспомогательные методы
+
+// memberAssignments возвращает назначения участников на курсовую команды
+func (m *TeamManagerImpl) memberAssignments(ctx context.Context, team *models.Team) (map[uint]*models.StudentCoursework, error) {
+	result := make(map[uint]*models.StudentCoursework, len(team.Members))
+	for _, member := range team.Members {
+		a, err := m.scRepo.GetByStudent(ctx, member.StudentID)
+		if err != nil || a.CourseworkID != team.CourseworkID {
+			return nil, fmt.Errorf("member %s is no longer assigned to the team coursework", member.Student.GetFullName())
+		}
+		result[member.StudentID] = a
+	}
+	return result, nil
+}
+
+func (m *TeamManagerImpl) notify(ctx context.Context, event interfaces.Event) {
+	if err := m.notifier.Notify(ctx, event); err != nil {
+		log.Printf("failed to notify user %d: %v", event.RecipientID, err)
+	}
+}
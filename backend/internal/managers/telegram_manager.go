@@ -0,0 +1,349 @@
+package managers
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/config"
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// Параметры бота
+const (
+	linkCodeAlphabet   = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // без похожих 0/O и 1/I
+	linkCodeLength     = 8
+	telegramSendWait   = 15 * time.Second
+	telegramRetryPause = 5 * time.Second
+	maxTelegramText    = 4000 // Telegram принимает до 4096 символов
+	maxBotDeadlines    = 20
+)
+
+const botCommandsHelp = `Команды:
+/mytopic - моя тема курсовой работы (для преподавателя - мои студенты)
+/deadlines - ближайшие сроки
+/unlink - отвязать чат от аккаунта`
+
+const botLinkHelp = `Это бот CourseForge. Чтобы получать уведомления, привяжите чат к аккаунту: ` +
+	`в профиле на сайте получите код привязки и отправьте его сюда командой /start КОД.`
+
+// telegramChannel доставляет уведомления в привязанные чаты
+type telegramChannel struct {
+	repo   interfaces.TelegramRepository
+	client interfaces.TelegramClient
+}
+
+// NewTelegramChannel создаёт канал доставки уведомлений в Telegram
+func NewTelegramChannel(repo interfaces.TelegramRepository, client interfaces.TelegramClient) interfaces.NotificationChannel {
+	return &telegramChannel{repo: repo, client: client}
+}
+
+// Deliver пересылает уведомление в привязанный чат. Отправка идёт в фоне, чтобы
+// медленный ответ Telegram не задерживал действие, вызвавшее уведомление.
+func (ch *telegramChannel) Deliver(ctx context.Context, n *models.Notification) error {
+	if ch.client == nil {
+		return nil
+	}
+	link, err := ch.repo.GetLinkByUser(ctx, n.UserID)
+	if err != nil || link == nil {
+		return err
+	}
+	sendInBackground(ch.client, link.ChatID, n.Title+"\n\n"+n.Message)
+	return nil
+}
+
+// TelegramManagerImpl реализует interfaces.TelegramManager
+type TelegramManagerImpl struct {
+	repo            interfaces.TelegramRepository
+	client          interfaces.TelegramClient
+	scRepo          interfaces.StudentCourseworkRepository
+	termRepo        interfaces.TermRepository
+	userRepo        interfaces.UserRepository
+	deadlineManager interfaces.DeadlineManager
+	cfg             config.TelegramConfig
+}
+
+// NewTelegramManager создаёт менеджер бота. client == nil - бот на сервере не настроен.
+func NewTelegramManager(
+	repo interfaces.TelegramRepository,
+	client interfaces.TelegramClient,
+	scRepo interfaces.StudentCourseworkRepository,
+	termRepo interfaces.TermRepository,
+	userRepo interfaces.UserRepository,
+	deadlineManager interfaces.DeadlineManager,
+	cfg config.TelegramConfig,
+) interfaces.TelegramManager {
+	return &TelegramManagerImpl{
+		repo:            repo,
+		client:          client,
+		scRepo:          scRepo,
+		termRepo:        termRepo,
+		userRepo:        userRepo,
+		deadlineManager: deadlineManager,
+		cfg:             cfg,
+	}
+}
+
+// CreateLinkCode выдаёт одноразовый код привязки чата; прежний код перестаёт действовать
+func (m *TelegramManagerImpl) CreateLinkCode(ctx context.Context, userID uint) (*interfaces.TelegramLinkCodeResponse, error) {
+	if m.client == nil {
+		return nil, errors.New("telegram bot is not configured")
+	}
+	code, err := newLinkCode()
+	if err != nil {
+		return nil, err
+	}
+	linkCode := &models.TelegramLinkCode{
+		Code:      code,
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(m.cfg.LinkCodeTTL),
+	}
+	if err := m.repo.CreateLinkCode(ctx, linkCode); err != nil {
+		return nil, err
+	}
+
+	resp := &interfaces.TelegramLinkCodeResponse{Code: code, ExpiresAt: linkCode.ExpiresAt}
+	if m.cfg.BotUsername != "" {
+		resp.Link = fmt.Sprintf("https://t.me/%s?start=%s", m.cfg.BotUsername, code)
+	}
+	return resp, nil
+}
+
+// GetLink сообщает, привязан ли чат пользователя
+func (m *TelegramManagerImpl) GetLink(ctx context.Context, userID uint) (*interfaces.TelegramLinkResponse, error) {
+	link, err := m.repo.GetLinkByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &interfaces.TelegramLinkResponse{
+		Enabled:     m.client != nil,
+		BotUsername: m.cfg.BotUsername,
+	}
+	if link != nil {
+		linkedAt := link.CreatedAt
+		resp.Linked = true
+		resp.Username = link.Username
+		resp.LinkedAt = &linkedAt
+	}
+	return resp, nil
+}
+
+// Unlink отвязывает чат и сообщает об этом в него
+func (m *TelegramManagerImpl) Unlink(ctx context.Context, userID uint) error {
+	link, err := m.repo.GetLinkByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := m.repo.DeleteLink(ctx, userID); err != nil {
+		return err
+	}
+	if link != nil && m.client != nil {
+		sendInBackground(m.client, link.ChatID, "Чат отвязан от аккаунта CourseForge, уведомления сюда больше не придут.")
+	}
+	return nil
+}
+
+// Run получает сообщения боту через long polling, пока не отменён ctx
+func (m *TelegramManagerImpl) Run(ctx context.Context) {
+	if m.client == nil {
+		return
+	}
+	var offset int64
+	for ctx.Err() == nil {
+		updates, err := m.client.GetUpdates(ctx, offset, m.cfg.PollTimeout)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("telegram polling failed: %v", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(telegramRetryPause):
+			}
+			continue
+		}
+		for _, u := range updates {
+			offset = u.UpdateID + 1
+			if err := m.HandleUpdate(ctx, u); err != nil {
+				log.Printf("telegram update %d failed: %v", u.UpdateID, err)
+			}
+		}
+	}
+}
+
+// HandleUpdate отвечает на команду в сообщении боту
+func (m *TelegramManagerImpl) HandleUpdate(ctx context.Context, update interfaces.TelegramUpdate) error {
+	if update.ChatID == 0 || strings.TrimSpace(update.Text) == "" {
+		return nil
+	}
+	reply, err := m.reply(ctx, update)
+	if err != nil {
+		return err
+	}
+	return m.client.SendMessage(ctx, update.ChatID, truncateRunes(reply, maxTelegramText))
+}
+
+// reply составляет ответ на команду
+func (m *TelegramManagerImpl) reply(ctx context.Context, update interfaces.TelegramUpdate) (string, error) {
+	command, arg := parseBotCommand(update.Text)
+	if (command == "/start" || command == "/link") && arg != "" {
+		return m.linkChat(ctx, update, arg)
+	}
+
+	link, err := m.repo.GetLinkByChat(ctx, update.ChatID)
+	if err != nil {
+		return "", err
+	}
+	if link == nil {
+		return botLinkHelp, nil
+	}
+
+	switch command {
+	case "/start", "/help":
+		return fmt.Sprintf("Чат привязан к аккаунту %s.\n\n%s", link.User.GetFullName(), botCommandsHelp), nil
+	case "/mytopic":
+		return m.myTopic(ctx, &link.User)
+	case "/deadlines":
+		return m.deadlines(ctx, link.UserID)
+	case "/unlink":
+		if err := m.repo.DeleteLink(ctx, link.UserID); err != nil {
+			return "", err
+		}
+		return "Чат отвязан от аккаунта. Уведомления сюда больше не придут.", nil
+	default:
+		return "Не знаю такой команды.\n\n" + botCommandsHelp, nil
+	}
+}
+
+// linkChat привязывает чат по коду из профиля
+func (m *TelegramManagerImpl) linkChat(ctx context.Context, update interfaces.TelegramUpdate, code string) (string, error) {
+	err := m.repo.LinkChat(ctx, strings.ToUpper(code), time.Now(), &models.TelegramLink{
+		ChatID:   update.ChatID,
+		Username: update.Username,
+	})
+	if err != nil {
+		log.Printf("telegram chat %d link failed: %v", update.ChatID, err)
+		return "Код не подошёл: он неверный или уже истёк. Получите новый код в профиле на сайте.", nil
+	}
+	link, err := m.repo.GetLinkByChat(ctx, update.ChatID)
+	if err != nil || link == nil {
+		return "", fmt.Errorf("failed to load telegram link: %v", err)
+	}
+	return fmt.Sprintf("Готово! Чат привязан к аккаунту %s, сюда будут приходить уведомления.\n\n%s",
+		link.User.GetFullName(), botCommandsHelp), nil
+}
+
+// myTopic - тема студента или студенты преподавателя в текущем семестре
+func (m *TelegramManagerImpl) myTopic(ctx context.Context, user *models.User) (string, error) {
+	switch {
+	case user.IsStudent():
+		sc, err := m.scRepo.GetByStudent(ctx, user.ID)
+		if err != nil {
+			return "В текущем семестре тема не назначена.", nil
+		}
+		var b strings.Builder
+		fmt.Fprintf(&b, "Тема: «%s»\n", sc.Coursework.Title)
+		if teacher, err := m.userRepo.GetByID(ctx, sc.Coursework.TeacherID); err == nil {
+			fmt.Fprintf(&b, "Руководитель: %s\n", teacher.GetFullName())
+		}
+		fmt.Fprintf(&b, "Статус: %s", statusTexts[sc.Status])
+		if sc.Grade != nil {
+			fmt.Fprintf(&b, "\nОценка: %d (%s)", *sc.Grade, gradeTexts[*sc.Grade])
+		}
+		return b.String(), nil
+
+	case user.IsTeacher():
+		list, err := m.scRepo.GetByTeacher(ctx, user.ID)
+		if err != nil {
+			return "", err
+		}
+		var termID uint
+		if term, err := m.termRepo.GetActive(ctx); err == nil {
+			termID = term.ID
+		}
+		var b strings.Builder
+		for _, sc := range list {
+			if termID != 0 && sc.TermID != termID {
+				continue
+			}
+			fmt.Fprintf(&b, "\n• %s - «%s» (%s)", sc.Student.GetFullName(), sc.Coursework.Title, statusTexts[sc.Status])
+		}
+		if b.Len() == 0 {
+			return "В текущем семестре у вас нет студентов.", nil
+		}
+		return "Ваши студенты в текущем семестре:" + b.String(), nil
+
+	default:
+		return "Команда доступна студентам и преподавателям.", nil
+	}
+}
+
+// deadlines - ближайшие сроки пользователя
+func (m *TelegramManagerImpl) deadlines(ctx context.Context, userID uint) (string, error) {
+	list, err := m.deadlineManager.ListDeadlines(ctx, userID, time.Now())
+	if err != nil {
+		return "", err
+	}
+	if len(list) == 0 {
+		return "Ближайших сроков нет.", nil
+	}
+	if len(list) > maxBotDeadlines {
+		list = list[:maxBotDeadlines]
+	}
+
+	var b strings.Builder
+	b.WriteString("Ближайшие сроки:")
+	for _, d := range list {
+		fmt.Fprintf(&b, "\n• %s - %s", d.DueAt.Local().Format(deadlineTimeFormat), d.Title)
+		if d.Location != "" {
+			fmt.Fprintf(&b, " (%s)", d.Location)
+		}
+	}
+	return b.String(), nil
+}
+
+// sendInBackground отправляет сообщение в фоне, ошибка только записывается в журнал
+func sendInBackground(client interfaces.TelegramClient, chatID int64, text string) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), telegramSendWait)
+		defer cancel()
+		if err := client.SendMessage(ctx, chatID, truncateRunes(text, maxTelegramText)); err != nil {
+			log.Printf("failed to send telegram message to chat %d: %v", chatID, err)
+		}
+	}()
+}
+
+// parseBotCommand разбирает "/команда@бот аргумент"
+func parseBotCommand(text string) (string, string) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return "", ""
+	}
+	command := strings.ToLower(fields[0])
+	if at := strings.Index(command, "@"); at >= 0 {
+		command = command[:at]
+	}
+	arg := ""
+	if len(fields) > 1 {
+		arg = fields[1]
+	}
+	return command, arg
+}
+
+// newLinkCode - случайный код привязки из букв и цифр, которые не спутать
+func newLinkCode() (string, error) {
+	buf := make([]byte, linkCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate link code: %w", err)
+	}
+	for i, b := range buf {
+		buf[i] = linkCodeAlphabet[int(b)%len(linkCodeAlphabet)]
+	}
+	return string(buf), nil
+}
@@ -0,0 +1,188 @@
+package managers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/config"
+	"github.com/Foxpunk/courseforge/internal/drivers"
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"github.com/Foxpunk/courseforge/internal/testutil"
+)
+
+const testBotToken = "123:test-token"
+
+// fakeBotAPI отвечает как Bot API: отдаёт подготовленные сообщения боту и запоминает ответы бота
+type fakeBotAPI struct {
+	t       *testing.T
+	mu      sync.Mutex
+	updates []map[string]interface{}
+	sent    []fakeBotMessage
+	replied chan struct{}
+}
+
+type fakeBotMessage struct {
+	ChatID int64  `json:"chat_id"`
+	Text   string `json:"text"`
+}
+
+func newFakeBotAPI(t *testing.T) (*fakeBotAPI, *httptest.Server) {
+	api := &fakeBotAPI{t: t, replied: make(chan struct{}, 16)}
+	server := httptest.NewServer(http.HandlerFunc(api.serve))
+	t.Cleanup(server.Close)
+	return api, server
+}
+
+// message добавляет в очередь сообщение пользователя боту
+func (a *fakeBotAPI) message(updateID, chatID int64, username, text string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.updates = append(a.updates, map[string]interface{}{
+		"update_id": updateID,
+		"message": map[string]interface{}{
+			"text": text,
+			"chat": map[string]interface{}{"id": chatID},
+			"from": map[string]interface{}{"username": username},
+		},
+	})
+}
+
+func (a *fakeBotAPI) serve(w http.ResponseWriter, r *http.Request) {
+	method, ok := strings.CutPrefix(r.URL.Path, "/bot"+testBotToken+"/")
+	if !ok || r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error_code": 401, "description": "Unauthorized"})
+		return
+	}
+	var params map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		a.t.Errorf("%s: invalid request body: %v", method, err)
+	}
+
+	var result interface{} = true
+	switch method {
+	case "getUpdates":
+		var offset int64
+		json.Unmarshal(params["offset"], &offset)
+		a.mu.Lock()
+		pending := []map[string]interface{}{}
+		for _, u := range a.updates {
+			if u["update_id"].(int64) >= offset {
+				pending = append(pending, u)
+			}
+		}
+		a.mu.Unlock()
+		if len(pending) == 0 {
+			// long polling без новых сообщений: короткая пауза вместо ожидания timeout
+			time.Sleep(10 * time.Millisecond)
+		}
+		result = pending
+	case "sendMessage":
+		var msg fakeBotMessage
+		json.Unmarshal(params["chat_id"], &msg.ChatID)
+		json.Unmarshal(params["text"], &msg.Text)
+		a.mu.Lock()
+		a.sent = append(a.sent, msg)
+		a.mu.Unlock()
+		a.replied <- struct{}{}
+	default:
+		a.t.Errorf("unexpected Bot API method %q", method)
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "result": result})
+}
+
+// waitReplies ждёт n ответов бота
+func (a *fakeBotAPI) waitReplies(t *testing.T, n int) []fakeBotMessage {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		select {
+		case <-a.replied:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("bot sent %d of %d replies", i, n)
+		}
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]fakeBotMessage(nil), a.sent...)
+}
+
+func TestTelegramBotLinksChatAndAnswersCommands(t *testing.T) {
+	const chatID = 555
+	db := testutil.NewDB(t)
+	student := testutil.CreateUser(t, db, models.RoleStudent, "student@example.com")
+	api, server := newFakeBotAPI(t)
+
+	cfg := config.TelegramConfig{
+		BotToken:    testBotToken,
+		APIURL:      server.URL,
+		BotUsername: "courseforge_bot",
+		PollTimeout: time.Second,
+		LinkCodeTTL: time.Hour,
+	}
+	repo := drivers.NewTelegramRepository(db)
+	manager := NewTelegramManager(repo, drivers.NewTelegramClient(cfg), drivers.NewStudentCourseworkRepository(db),
+		drivers.NewTermRepository(db), drivers.NewUserRepository(db), nil, cfg)
+
+	// код из профиля открывает бота ссылкой t.me с командой /start КОД
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	code, err := manager.CreateLinkCode(ctx, student.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "https://t.me/courseforge_bot?start=" + code.Code; code.Link != want {
+		t.Errorf("deep link = %q, want %q", code.Link, want)
+	}
+
+	api.message(10, chatID, "student_tg", "/start "+strings.ToLower(code.Code))
+	api.message(11, chatID, "student_tg", "/mytopic@courseforge_bot")
+	done := make(chan struct{})
+	go func() {
+		manager.Run(ctx)
+		close(done)
+	}()
+	sent := api.waitReplies(t, 2)
+	cancel()
+	<-done
+
+	for _, msg := range sent {
+		if msg.ChatID != chatID {
+			t.Errorf("reply sent to chat %d, want %d", msg.ChatID, chatID)
+		}
+	}
+	if !strings.HasPrefix(sent[0].Text, "Готово! Чат привязан к аккаунту") {
+		t.Errorf("reply to /start = %q", sent[0].Text)
+	}
+	if sent[1].Text != "В текущем семестре тема не назначена." {
+		t.Errorf("reply to /mytopic = %q", sent[1].Text)
+	}
+	// обработанные сообщения не приходят повторно
+	if len(sent) != 2 {
+		t.Errorf("bot sent %d replies, want 2: %v", len(sent), sent)
+	}
+
+	link, err := manager.GetLink(context.Background(), student.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !link.Enabled || !link.Linked || link.Username != "student_tg" {
+		t.Errorf("link = %+v, want chat linked to @student_tg", link)
+	}
+
+	// код одноразовый: другой чат им уже не привяжется
+	update := interfaces.TelegramUpdate{UpdateID: 12, ChatID: 777, Username: "other", Text: "/start " + code.Code}
+	if err := manager.HandleUpdate(context.Background(), update); err != nil {
+		t.Fatal(err)
+	}
+	sent = api.waitReplies(t, 1)
+	if last := sent[len(sent)-1]; last.ChatID != 777 || !strings.HasPrefix(last.Text, "Код не подошёл") {
+		t.Errorf("reply to a reused code = %+v", last)
+	}
+}
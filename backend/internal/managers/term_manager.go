@@ -0,0 +1,118 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// TermManagerImpl реализует interfaces.TermManager
+type TermManagerImpl struct {
+	termRepo interfaces.TermRepository
+}
+
+// NewTermManager создаёт новый TermManager
+func NewTermManager(termRepo interfaces.TermRepository) interfaces.TermManager {
+	return &TermManagerImpl{termRepo: termRepo}
+}
+
+// CreateAcademicYear создаёт учебный год с осенним и весенним семестрами
+func (m *TermManagerImpl) CreateAcademicYear(ctx context.Context, req interfaces.CreateAcademicYearRequest) (*models.AcademicYear, error) {
+	from, to, err := models.AcademicYearBounds(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := m.termRepo.GetYearByName(ctx, req.Name); err == nil {
+		return nil, errors.New("academic year already exists")
+	}
+
+	// по умолчанию осенний семестр с 1 сентября, весенний с 1 февраля
+	autumnStart := from
+	springStart := time.Date(from.Year()+1, time.February, 1, 0, 0, 0, 0, time.UTC)
+	if req.AutumnStartsOn != nil {
+		autumnStart = *req.AutumnStartsOn
+	}
+	if req.SpringStartsOn != nil {
+		springStart = *req.SpringStartsOn
+	}
+	if !autumnStart.Before(springStart) || autumnStart.Before(from) || !springStart.Before(to) {
+		return nil, errors.New("term boundaries must lie within the academic year, autumn first")
+	}
+
+	year := &models.AcademicYear{
+		Name:     req.Name,
+		StartsOn: from,
+		EndsOn:   to,
+		Terms: []models.Term{
+			{Number: 1, Name: "Осенний семестр " + req.Name, StartsOn: autumnStart, EndsOn: springStart},
+			{Number: 2, Name: "Весенний семестр " + req.Name, StartsOn: springStart, EndsOn: to},
+		},
+	}
+	if err := m.termRepo.CreateYear(ctx, year); err != nil {
+		return nil, err
+	}
+	if req.ActivateAutumn {
+		if err := m.termRepo.SetActive(ctx, year.Terms[0].ID); err != nil {
+			return nil, err
+		}
+	}
+	return m.termRepo.GetYearByName(ctx, year.Name)
+}
+
+// ListAcademicYears возвращает все учебные годы с семестрами
+func (m *TermManagerImpl) ListAcademicYears(ctx context.Context) ([]models.AcademicYear, error) {
+	return m.termRepo.ListYears(ctx)
+}
+
+// GetTerm возвращает семестр по ID
+func (m *TermManagerImpl) GetTerm(ctx context.Context, termID uint) (*models.Term, error) {
+	return m.termRepo.GetByID(ctx, termID)
+}
+
+// GetActiveTerm возвращает текущий семестр
+func (m *TermManagerImpl) GetActiveTerm(ctx context.Context) (*models.Term, error) {
+	return m.termRepo.GetActive(ctx)
+}
+
+// ActivateTerm переключает текущий семестр
+func (m *TermManagerImpl) ActivateTerm(ctx context.Context, termID uint) (*models.Term, error) {
+	if err := m.termRepo.SetActive(ctx, termID); err != nil {
+		return nil, err
+	}
+	return m.termRepo.GetByID(ctx, termID)
+}
+
+// resolveTermID возвращает указанный семестр или текущий; 0 - если семестры ещё не заведены
+func resolveTermID(ctx context.Context, termRepo interfaces.TermRepository, termID uint) (uint, error) {
+	if termID != 0 {
+		term, err := termRepo.GetByID(ctx, termID)
+		if err != nil {
+			return 0, err
+		}
+		return term.ID, nil
+	}
+	if term, err := termRepo.GetActive(ctx); err == nil {
+		return term.ID, nil
+	}
+	return 0, nil
+}
+
+// resolveYearTerm находит семестр учебного года, в котором читается дисциплина.
+// Пустой год означает текущий учебный год; без заведённых семестров возвращается 0.
+func resolveYearTerm(ctx context.Context, termRepo interfaces.TermRepository, academicYear string, semester int) (uint, error) {
+	if academicYear == "" {
+		active, err := termRepo.GetActive(ctx)
+		if err != nil {
+			return 0, nil
+		}
+		academicYear = active.AcademicYear.Name
+	}
+	term, err := termRepo.GetByYearAndNumber(ctx, academicYear, models.TermNumberForSemester(semester))
+	if err != nil {
+		return 0, err
+	}
+	return term.ID, nil
+}
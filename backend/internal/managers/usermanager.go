@@ -11,11 +11,12 @@ import (
 // UserManagerImpl реализует интерфейс interfaces.UserManager
 type UserManagerImpl struct {
 	userRepo interfaces.UserRepository
+	webhooks interfaces.WebhookDispatcher
 }
 
 // NewUserManager создаёт новый UserManager
-func NewUserManager(userRepo interfaces.UserRepository) interfaces.UserManager {
-	return &UserManagerImpl{userRepo: userRepo}
+func NewUserManager(userRepo interfaces.UserRepository, webhooks interfaces.WebhookDispatcher) interfaces.UserManager {
+	return &UserManagerImpl{userRepo: userRepo, webhooks: webhooks}
 }
 
 // CreateUser создаёт нового пользователя
@@ -43,6 +44,7 @@ func (m *UserManagerImpl) CreateUser(ctx context.Context, req interfaces.CreateU
 	if err := m.userRepo.Create(ctx, user); err != nil {
 		return nil, err
 	}
+	m.webhooks.Dispatch(ctx, models.WebhookUserCreated, webhookUser(user))
 	return user, nil
 }
 
@@ -56,12 +58,15 @@ func (m *UserManagerImpl) GetUserByEmail(ctx context.Context, email string) (*mo
 	return m.userRepo.GetByEmail(ctx, email)
 }
 
-// UpdateUser обновляет базовые поля пользователя
-func (m *UserManagerImpl) UpdateUser(ctx context.Context, userID uint, req interfaces.UpdateUserRequest) (*models.User, error) {
+// UpdateUser обновляет базовые поля пользователя; version != 0 - версия, которую видел клиент
+func (m *UserManagerImpl) UpdateUser(ctx context.Context, userID uint, req interfaces.UpdateUserRequest, version uint) (*models.User, error) {
 	user, err := m.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
+	if version != 0 && user.Version != version {
+		return nil, interfaces.ErrVersionConflict
+	}
 
 	if req.Email != nil {
 		user.Email = *req.Email
@@ -80,8 +85,8 @@ func (m *UserManagerImpl) UpdateUser(ctx context.Context, userID uint, req inter
 }
 
 // DeleteUser удаляет пользователя
-func (m *UserManagerImpl) DeleteUser(ctx context.Context, userID uint) error {
-	return m.userRepo.Delete(ctx, userID)
+func (m *UserManagerImpl) DeleteUser(ctx context.Context, userID uint, version uint) error {
+	return m.userRepo.Delete(ctx, userID, version)
 }
 
 // ListUsers возвращает список пользователей и общее число
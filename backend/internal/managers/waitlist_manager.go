@@ -0,0 +1,332 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// WaitlistManagerImpl реализует interfaces.WaitlistManager
+type WaitlistManagerImpl struct {
+	waitlistRepo interfaces.WaitlistRepository
+	cwRepo       interfaces.CourseworkRepository
+	scRepo       interfaces.StudentCourseworkRepository
+	workload     interfaces.WorkloadManager
+	uow          interfaces.UnitOfWork
+	events       interfaces.EventPublisher
+	offerTTL     time.Duration
+}
+
+// NewWaitlistManager создаёт новый WaitlistManager
+func NewWaitlistManager(
+	waitlistRepo interfaces.WaitlistRepository,
+	cwRepo interfaces.CourseworkRepository,
+	scRepo interfaces.StudentCourseworkRepository,
+	workload interfaces.WorkloadManager,
+	uow interfaces.UnitOfWork,
+	events interfaces.EventPublisher,
+	offerTTL time.Duration,
+) interfaces.WaitlistManager {
+	return &WaitlistManagerImpl{
+		waitlistRepo: waitlistRepo,
+		cwRepo:       cwRepo,
+		scRepo:       scRepo,
+		workload:     workload,
+		uow:          uow,
+		events:       events,
+		offerTTL:     offerTTL,
+	}
+}
+
+// JoinWaitlist ставит студента в очередь на заполненную курсовую работу
+func (m *WaitlistManagerImpl) JoinWaitlist(ctx context.Context, studentID, courseworkID uint) (*models.WaitlistEntry, error) {
+	if _, err := m.scRepo.GetByStudent(ctx, studentID); err == nil {
+		return nil, errors.New("student already has an assigned coursework")
+	}
+	if _, err := m.waitlistRepo.GetActiveByStudentAndCoursework(ctx, studentID, courseworkID); err == nil {
+		return nil, errors.New("student is already on the waitlist for this coursework")
+	}
+	cw, count, err := m.cwRepo.GetWithStudentCount(ctx, courseworkID)
+	if err != nil {
+		return nil, err
+	}
+	if !cw.IsAvailable {
+		return nil, errors.New("coursework is not available")
+	}
+	reserved, err := m.waitlistRepo.CountOffered(ctx, courseworkID, 0)
+	if err != nil {
+		return nil, err
+	}
+	if count+reserved < cw.MaxStudents {
+		return nil, errors.New("coursework has free slots, assign directly")
+	}
+
+	entry := &models.WaitlistEntry{
+		CourseworkID: courseworkID,
+		StudentID:    studentID,
+		Status:       models.WaitlistWaiting,
+	}
+	if err := m.waitlistRepo.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+	return m.waitlistRepo.GetByID(ctx, entry.ID)
+}
+
+// LeaveWaitlist снимает студента с очереди; освобождённое предложение уходит следующему
+func (m *WaitlistManagerImpl) LeaveWaitlist(ctx context.Context, entryID uint) error {
+	entry, err := m.waitlistRepo.GetByID(ctx, entryID)
+	if err != nil {
+		return err
+	}
+	if !entry.IsActive() {
+		return fmt.Errorf("waitlist entry in status %q cannot be cancelled", entry.Status)
+	}
+	wasOffered := entry.Status == models.WaitlistOffered
+	return m.uow.Do(ctx, func(ctx context.Context) error {
+		if err := m.resolve(ctx, entry, models.WaitlistCancelled); err != nil {
+			return err
+		}
+		if wasOffered {
+			return m.PromoteNext(ctx, entry.CourseworkID)
+		}
+		return nil
+	})
+}
+
+// GetEntry возвращает запись очереди по ID
+func (m *WaitlistManagerImpl) GetEntry(ctx context.Context, entryID uint) (*models.WaitlistEntry, error) {
+	return m.waitlistRepo.GetByID(ctx, entryID)
+}
+
+// GetStudentEntries возвращает очереди, в которых стоит студент
+func (m *WaitlistManagerImpl) GetStudentEntries(ctx context.Context, studentID uint) ([]models.WaitlistEntry, error) {
+	return m.waitlistRepo.GetByStudent(ctx, studentID)
+}
+
+// GetCourseworkWaitlist возвращает активную очередь на тему
+func (m *WaitlistManagerImpl) GetCourseworkWaitlist(ctx context.Context, courseworkID uint) ([]models.WaitlistEntry, error) {
+	return m.waitlistRepo.GetByCoursework(ctx, courseworkID, models.WaitlistOffered, models.WaitlistWaiting)
+}
+
+// AcceptOffer подтверждает предложенное место и назначает студента на тему. Уведомления,
+// обновления мест и вебхуки рассылают подписчики события assignment.created.
+func (m *WaitlistManagerImpl) AcceptOffer(ctx context.Context, entryID uint) (*models.StudentCoursework, error) {
+	entry, err := m.waitlistRepo.GetByID(ctx, entryID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Status != models.WaitlistOffered {
+		return nil, errors.New("no slot has been offered for this entry")
+	}
+	if entry.OfferExpired(time.Now()) {
+		if err := m.expire(ctx, entry); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("offer has expired")
+	}
+	// место занимается условной вставкой в одной транзакции с закрытием очередей студента,
+	// поэтому параллельное прямое назначение не займёт его повторно
+	var assign *models.StudentCoursework
+	err = m.uow.Do(ctx, func(ctx context.Context) error {
+		if _, err := m.scRepo.GetByStudent(ctx, entry.StudentID); err == nil {
+			return errors.New("student already has an assigned coursework")
+		}
+		cw, count, err := m.cwRepo.GetWithStudentCount(ctx, entry.CourseworkID)
+		if err != nil {
+			return err
+		}
+		if count >= cw.MaxStudents {
+			return errors.New("no slots available for this coursework")
+		}
+		if err := m.workload.CheckSupervisionQuota(ctx, cw.TeacherID, cw.TermID); err != nil {
+			return err
+		}
+		now := time.Now()
+
+		assign = &models.StudentCoursework{
+			StudentID:    entry.StudentID,
+			CourseworkID: entry.CourseworkID,
+			Status:       models.StatusAssigned,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+		ok, err := m.scRepo.CreateIfSlotAvailable(ctx, assign, 0)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("no slots available for this coursework")
+		}
+		if err := m.resolve(ctx, entry, models.WaitlistAccepted); err != nil {
+			return err
+		}
+		// студент назначен, остальные его очереди больше не нужны
+		if err := m.cancelOtherEntries(ctx, entry.StudentID, entry.ID); err != nil {
+			return err
+		}
+		return m.events.Publish(ctx, models.EventAssignmentCreated, interfaces.AssignmentEventData{
+			AssignmentID: assign.ID,
+			Status:       assign.Status,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return assign, nil
+}
+
+// DeclineOffer отклоняет предложенное место, оно уходит следующему в очереди
+func (m *WaitlistManagerImpl) DeclineOffer(ctx context.Context, entryID uint) error {
+	entry, err := m.waitlistRepo.GetByID(ctx, entryID)
+	if err != nil {
+		return err
+	}
+	if entry.Status != models.WaitlistOffered {
+		return errors.New("no slot has been offered for this entry")
+	}
+	return m.uow.Do(ctx, func(ctx context.Context) error {
+		if err := m.resolve(ctx, entry, models.WaitlistDeclined); err != nil {
+			return err
+		}
+		return m.PromoteNext(ctx, entry.CourseworkID)
+	})
+}
+
+// WithdrawStudent снимает студента со всех очередей, например после прямого назначения
+func (m *WaitlistManagerImpl) WithdrawStudent(ctx context.Context, studentID uint) error {
+	return m.cancelOtherEntries(ctx, studentID, 0)
+}
+
+// ReservedSlots считает места темы, удерживаемые за студентами из очереди
+func (m *WaitlistManagerImpl) ReservedSlots(ctx context.Context, courseworkID, exceptStudentID uint) (int, error) {
+	return m.waitlistRepo.CountOffered(ctx, courseworkID, exceptStudentID)
+}
+
+// PromoteNext предлагает свободные места темы следующим студентам в очереди. Его вызывают
+// при каждом освобождении места, поэтому здесь же публикуется событие о занятости мест.
+// Уведомления и обновления в реальном времени рассылают подписчики outbox после фиксации
+// транзакции, в которой вызван PromoteNext.
+func (m *WaitlistManagerImpl) PromoteNext(ctx context.Context, courseworkID uint) error {
+	return m.uow.Do(ctx, func(ctx context.Context) error {
+		if err := m.offerFreeSlots(ctx, courseworkID); err != nil {
+			return err
+		}
+		return m.events.Publish(ctx, models.EventSlotsChanged, interfaces.SlotsEventData{CourseworkID: courseworkID})
+	})
+}
+
+// ExpireOffers закрывает просроченные предложения и передаёт места дальше по очереди
+func (m *WaitlistManagerImpl) ExpireOffers(ctx context.Context, now time.Time) (int, error) {
+	expired, err := m.waitlistRepo.GetExpiredOffers(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+	for i := range expired {
+		if err := m.expire(ctx, &expired[i]); err != nil {
+			return i, err
+		}
+	}
+	return len(expired), nil
+}
+
+// Вспомогательные методы
+
+// offerFreeSlots предлагает свободные места темы ожидающим студентам по порядку очереди
+func (m *WaitlistManagerImpl) offerFreeSlots(ctx context.Context, courseworkID uint) error {
+	cw, count, err := m.cwRepo.GetWithStudentCount(ctx, courseworkID)
+	if err != nil {
+		return err
+	}
+	if !cw.IsAvailable {
+		return nil
+	}
+	reserved, err := m.waitlistRepo.CountOffered(ctx, courseworkID, 0)
+	if err != nil {
+		return err
+	}
+	free := cw.MaxStudents - count - reserved
+	if free <= 0 {
+		return nil
+	}
+
+	waiting, err := m.waitlistRepo.GetByCoursework(ctx, courseworkID, models.WaitlistWaiting)
+	if err != nil {
+		return err
+	}
+	for i := range waiting {
+		if free == 0 {
+			break
+		}
+		entry := &waiting[i]
+
+		// студент мог получить тему другим путём, пока стоял в очереди
+		if _, err := m.scRepo.GetByStudent(ctx, entry.StudentID); err == nil {
+			if err := m.resolve(ctx, entry, models.WaitlistCancelled); err != nil {
+				return err
+			}
+			continue
+		}
+
+		now := time.Now()
+		expires := now.Add(m.offerTTL)
+		entry.Status = models.WaitlistOffered
+		entry.OfferedAt = &now
+		entry.OfferExpiresAt = &expires
+		if err := m.waitlistRepo.Update(ctx, entry); err != nil {
+			return err
+		}
+		free--
+
+		if err := m.events.Publish(ctx, models.EventWaitlistOffer, interfaces.WaitlistEventData{EntryID: entry.ID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expire закрывает просроченное предложение и передаёт место следующему в очереди
+func (m *WaitlistManagerImpl) expire(ctx context.Context, entry *models.WaitlistEntry) error {
+	return m.uow.Do(ctx, func(ctx context.Context) error {
+		if err := m.resolve(ctx, entry, models.WaitlistExpired); err != nil {
+			return err
+		}
+		if err := m.events.Publish(ctx, models.EventWaitlistOfferExpired, interfaces.WaitlistEventData{EntryID: entry.ID}); err != nil {
+			return err
+		}
+		return m.PromoteNext(ctx, entry.CourseworkID)
+	})
+}
+
+func (m *WaitlistManagerImpl) resolve(ctx context.Context, entry *models.WaitlistEntry, status models.WaitlistStatus) error {
+	now := time.Now()
+	entry.Status = status
+	entry.ResolvedAt = &now
+	return m.waitlistRepo.Update(ctx, entry)
+}
+
+func (m *WaitlistManagerImpl) cancelOtherEntries(ctx context.Context, studentID, keepID uint) error {
+	entries, err := m.waitlistRepo.GetByStudent(ctx, studentID)
+	if err != nil {
+		return err
+	}
+	for i := range entries {
+		entry := &entries[i]
+		if entry.ID == keepID || !entry.IsActive() {
+			continue
+		}
+		wasOffered := entry.Status == models.WaitlistOffered
+		if err := m.resolve(ctx, entry, models.WaitlistCancelled); err != nil {
+			return err
+		}
+		if wasOffered {
+			if err := m.PromoteNext(ctx, entry.CourseworkID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
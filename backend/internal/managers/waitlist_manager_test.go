@@ -0,0 +1,107 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/config"
+	"github.com/Foxpunk/courseforge/internal/drivers"
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"github.com/Foxpunk/courseforge/internal/testutil"
+)
+
+// recordingNotifier и recordingRealtime запоминают доставленное подписчиками
+type recordingNotifier struct{ events []interfaces.Event }
+
+func (n *recordingNotifier) Notify(_ context.Context, event interfaces.Event) error {
+	n.events = append(n.events, event)
+	return nil
+}
+
+type recordingRealtime struct{ slots []uint }
+
+func (r *recordingRealtime) PublishSlots(_ context.Context, courseworkIDs ...uint) {
+	r.slots = append(r.slots, courseworkIDs...)
+}
+func (r *recordingRealtime) PublishAssignment(context.Context, *models.StudentCoursework, models.EventType) {
+}
+
+func TestPromoteNextDeliversOfferAfterCommit(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	term := testutil.CreateActiveTerm(t, db)
+	teacher := testutil.CreateUser(t, db, models.RoleTeacher, "teacher@example.com")
+	holder := testutil.CreateUser(t, db, models.RoleStudent, "holder@example.com")
+	next := testutil.CreateUser(t, db, models.RoleStudent, "next@example.com")
+	cw := testutil.CreateCoursework(t, db, teacher, term, 1)
+	// единственное место предложено первому студенту, второй ждёт своей очереди
+	offeredAt := time.Now()
+	expiresAt := offeredAt.Add(time.Hour)
+	testutil.MustCreate(t, db, &models.WaitlistEntry{CourseworkID: cw.ID, StudentID: holder.ID, Position: 1,
+		Status: models.WaitlistOffered, OfferedAt: &offeredAt, OfferExpiresAt: &expiresAt})
+	waiting := &models.WaitlistEntry{CourseworkID: cw.ID, StudentID: next.ID, Position: 2, Status: models.WaitlistWaiting}
+	testutil.MustCreate(t, db, waiting)
+
+	cwRepo := drivers.NewCourseworkRepository(db)
+	scRepo := drivers.NewStudentCourseworkRepository(db)
+	waitlistRepo := drivers.NewWaitlistRepository(db)
+	uow := drivers.NewUnitOfWork(db)
+	workload := NewWorkloadManager(drivers.NewSupervisionQuotaRepository(db), drivers.NewTeacherProfileRepository(db),
+		drivers.NewDepartmentRepository(db), drivers.NewUserRepository(db), cwRepo, scRepo, drivers.NewTermRepository(db), config.WorkloadConfig{})
+	bus := NewEventBus(drivers.NewOutboxRepository(db), config.OutboxConfig{BatchSize: 50, MaxAttempts: 1})
+	manager := NewWaitlistManager(waitlistRepo, cwRepo, scRepo, workload, uow, bus, time.Hour)
+	notifier := &recordingNotifier{}
+	realtime := &recordingRealtime{}
+	NewWaitlistSubscribers(waitlistRepo, notifier, realtime).Register(bus)
+
+	// назначение, внутри которого первый студент снимается с очереди, откатилось:
+	// предложение не передаётся и о нём никто не узнаёт
+	errRollback := errors.New("assignment failed")
+	err := uow.Do(ctx, func(ctx context.Context) error {
+		if err := manager.WithdrawStudent(ctx, holder.ID); err != nil {
+			return err
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("rolled back transaction: err = %v", err)
+	}
+	if _, err := bus.ProcessOutbox(ctx, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if len(notifier.events) != 0 || len(realtime.slots) != 0 {
+		t.Errorf("rolled back promotion delivered notifications %v and slot updates %v", notifier.events, realtime.slots)
+	}
+	entry, err := waitlistRepo.GetByID(ctx, waiting.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Status != models.WaitlistWaiting {
+		t.Errorf("waiting entry status after rollback = %s", entry.Status)
+	}
+
+	// после фиксации место предлагается следующему, и подписчики доставляют событие
+	if err := uow.Do(ctx, func(ctx context.Context) error {
+		return manager.WithdrawStudent(ctx, holder.ID)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(notifier.events) != 0 {
+		t.Errorf("notification sent before the outbox was processed: %v", notifier.events)
+	}
+	if done, err := bus.ProcessOutbox(ctx, time.Now()); err != nil || done != 2 {
+		t.Fatalf("outbox: done = %d, err = %v, want offer and slot events", done, err)
+	}
+	if len(notifier.events) != 1 {
+		t.Fatalf("notifications = %v, want one offer", notifier.events)
+	}
+	if n := notifier.events[0]; n.Type != models.EventWaitlistOffer || n.RecipientID != next.ID || n.EntityID != waiting.ID {
+		t.Errorf("notification = %+v, want offer to student %d", n, next.ID)
+	}
+	if len(realtime.slots) != 1 || realtime.slots[0] != cw.ID {
+		t.Errorf("slot updates = %v, want coursework %d", realtime.slots, cw.ID)
+	}
+}
@@ -0,0 +1,81 @@
+package managers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// WaitlistSubscribers - подписчики outbox на события листов ожидания: уведомления
+// о предложенных и истёкших местах и рассылка занятости мест в реальном времени
+type WaitlistSubscribers struct {
+	waitlistRepo interfaces.WaitlistRepository
+	notifier     interfaces.Notifier
+	realtime     interfaces.RealtimePublisher
+}
+
+// NewWaitlistSubscribers создаёт подписчиков на события листов ожидания
+func NewWaitlistSubscribers(
+	waitlistRepo interfaces.WaitlistRepository,
+	notifier interfaces.Notifier,
+	realtime interfaces.RealtimePublisher,
+) *WaitlistSubscribers {
+	return &WaitlistSubscribers{
+		waitlistRepo: waitlistRepo,
+		notifier:     notifier,
+		realtime:     realtime,
+	}
+}
+
+// Register подписывает обработчики на шину событий
+func (s *WaitlistSubscribers) Register(bus interfaces.EventBus) {
+	bus.Subscribe("notifications.waitlist", s.notify, models.EventWaitlistOffer, models.EventWaitlistOfferExpired)
+	bus.Subscribe("realtime.slots", s.publishSlots, models.EventSlotsChanged)
+}
+
+// notify сообщает студенту о предложенном месте или об истечении срока предложения
+func (s *WaitlistSubscribers) notify(ctx context.Context, event interfaces.DomainEvent) error {
+	var data interfaces.WaitlistEventData
+	if err := json.Unmarshal(event.Payload, &data); err != nil {
+		return fmt.Errorf("invalid payload of event %d: %w", event.ID, err)
+	}
+	entry, err := s.waitlistRepo.GetByID(ctx, data.EntryID)
+	if err != nil {
+		return err
+	}
+
+	n := interfaces.Event{
+		Type:        event.Type,
+		RecipientID: entry.StudentID,
+		EntityType:  models.EntityWaitlist,
+		EntityID:    entry.ID,
+	}
+	switch event.Type {
+	case models.EventWaitlistOffer:
+		n.Title = "Освободилось место"
+		n.Message = fmt.Sprintf("В курсовой работе «%s» освободилось место.", entry.Coursework.Title)
+		if entry.OfferExpiresAt != nil {
+			n.Message = fmt.Sprintf("В курсовой работе «%s» освободилось место. Подтвердите его до %s.",
+				entry.Coursework.Title, entry.OfferExpiresAt.Format("02.01.2006 15:04"))
+		}
+	case models.EventWaitlistOfferExpired:
+		n.Title = "Предложение истекло"
+		n.Message = fmt.Sprintf("Срок подтверждения места в курсовой работе «%s» истёк.", entry.Coursework.Title)
+	default:
+		return nil
+	}
+	return s.notifier.Notify(ctx, n)
+}
+
+// publishSlots рассылает новую занятость мест темы подписчикам потока обновлений
+func (s *WaitlistSubscribers) publishSlots(ctx context.Context, event interfaces.DomainEvent) error {
+	var data interfaces.SlotsEventData
+	if err := json.Unmarshal(event.Payload, &data); err != nil {
+		return fmt.Errorf("invalid payload of event %d: %w", event.ID, err)
+	}
+	s.realtime.PublishSlots(ctx, data.CourseworkID)
+	return nil
+}
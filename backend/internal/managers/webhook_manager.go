@@ -0,0 +1,451 @@
+package managers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/config"
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// Заголовки запроса на вебхук. Получатель проверяет подпись: HMAC-SHA256 строки
+// "<timestamp>.<тело запроса>" по ключу вебхука в виде "sha256=<hex>", где timestamp -
+// значение X-CourseForge-Timestamp (Unix-время отправки в секундах). Чтобы перехваченный
+// запрос нельзя было повторить, получатель отклоняет запросы с timestamp, отличающимся от
+// его часов больше чем на 5 минут; повторные попытки доставки подписываются заново.
+const (
+	webhookSignatureHeader = "X-CourseForge-Signature"
+	webhookTimestampHeader = "X-CourseForge-Timestamp"
+	webhookEventHeader     = "X-CourseForge-Event"
+	webhookDeliveryHeader  = "X-CourseForge-Delivery"
+	webhookSecretBytes     = 32
+	webhookEventIDBytes    = 16
+)
+
+// WebhookManagerImpl реализует interfaces.WebhookManager
+type WebhookManagerImpl struct {
+	repo   interfaces.WebhookRepository
+	client interfaces.WebhookClient
+	cfg    config.WebhookConfig
+}
+
+// NewWebhookManager создаёт новый WebhookManager
+func NewWebhookManager(repo interfaces.WebhookRepository, client interfaces.WebhookClient, cfg config.WebhookConfig) interfaces.WebhookManager {
+	return &WebhookManagerImpl{repo: repo, client: client, cfg: cfg}
+}
+
+// Dispatch ставит событие в очередь на все включённые вебхуки, подписанные на него
+func (m *WebhookManagerImpl) Dispatch(ctx context.Context, event models.WebhookEvent, data interface{}) {
+	if err := m.Enqueue(ctx, event, data); err != nil {
+		log.Printf("failed to dispatch webhook event %s: %v", event, err)
+	}
+}
+
+// Enqueue ставит событие в очередь и возвращает ошибку постановки
+func (m *WebhookManagerImpl) Enqueue(ctx context.Context, event models.WebhookEvent, data interface{}) error {
+	hooks, err := m.repo.ListActive(ctx)
+	if err != nil {
+		return err
+	}
+	var subscribed []models.Webhook
+	for _, hook := range hooks {
+		if hook.Subscribed(event) {
+			subscribed = append(subscribed, hook)
+		}
+	}
+	if len(subscribed) == 0 {
+		return nil
+	}
+
+	eventID, payload, err := buildWebhookPayload(event, data)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	deliveries := make([]models.WebhookDelivery, len(subscribed))
+	for i, hook := range subscribed {
+		deliveries[i] = models.WebhookDelivery{
+			WebhookID:     hook.ID,
+			EventID:       eventID,
+			Event:         event,
+			Payload:       payload,
+			Status:        models.DeliveryPending,
+			NextAttemptAt: now,
+		}
+	}
+	return m.repo.CreateDeliveries(ctx, deliveries)
+}
+
+// CreateWebhook регистрирует вебхук и выдаёт ключ подписи
+func (m *WebhookManagerImpl) CreateWebhook(ctx context.Context, req interfaces.CreateWebhookRequest) (*interfaces.WebhookResponse, error) {
+	if err := validateWebhookURL(req.URL); err != nil {
+		return nil, err
+	}
+	events, err := normalizeWebhookEvents(req.Events)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomHex(webhookSecretBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	hook := &models.Webhook{
+		URL:         req.URL,
+		Description: req.Description,
+		Secret:      secret,
+		IsActive:    req.IsActive == nil || *req.IsActive,
+	}
+	hook.SetEvents(events)
+	if err := m.repo.Create(ctx, hook); err != nil {
+		return nil, err
+	}
+	return buildWebhookResponse(hook, true), nil
+}
+
+// GetWebhook возвращает вебхук без ключа подписи
+func (m *WebhookManagerImpl) GetWebhook(ctx context.Context, id uint) (*interfaces.WebhookResponse, error) {
+	hook, err := m.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return buildWebhookResponse(hook, false), nil
+}
+
+// ListWebhooks возвращает все вебхуки
+func (m *WebhookManagerImpl) ListWebhooks(ctx context.Context) ([]interfaces.WebhookResponse, error) {
+	hooks, err := m.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]interfaces.WebhookResponse, len(hooks))
+	for i := range hooks {
+		result[i] = *buildWebhookResponse(&hooks[i], false)
+	}
+	return result, nil
+}
+
+// UpdateWebhook меняет вебхук; при смене ключа новый ключ возвращается в ответе
+func (m *WebhookManagerImpl) UpdateWebhook(ctx context.Context, id uint, req interfaces.UpdateWebhookRequest) (*interfaces.WebhookResponse, error) {
+	hook, err := m.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.URL != nil {
+		if err := validateWebhookURL(*req.URL); err != nil {
+			return nil, err
+		}
+		hook.URL = *req.URL
+	}
+	if req.Description != nil {
+		hook.Description = *req.Description
+	}
+	if req.Events != nil {
+		events, err := normalizeWebhookEvents(req.Events)
+		if err != nil {
+			return nil, err
+		}
+		hook.SetEvents(events)
+	}
+	if req.IsActive != nil {
+		hook.IsActive = *req.IsActive
+	}
+	if req.RotateSecret {
+		if hook.Secret, err = randomHex(webhookSecretBytes); err != nil {
+			return nil, err
+		}
+	}
+	if err := m.repo.Update(ctx, hook); err != nil {
+		return nil, err
+	}
+	return buildWebhookResponse(hook, req.RotateSecret), nil
+}
+
+// DeleteWebhook удаляет вебхук и журнал его доставок
+func (m *WebhookManagerImpl) DeleteWebhook(ctx context.Context, id uint) error {
+	return m.repo.Delete(ctx, id)
+}
+
+// ListDeliveries возвращает журнал доставок вебхука
+func (m *WebhookManagerImpl) ListDeliveries(ctx context.Context, webhookID uint, limit, offset int) (*interfaces.WebhookDeliveryListResponse, error) {
+	if _, err := m.repo.GetByID(ctx, webhookID); err != nil {
+		return nil, err
+	}
+	list, total, err := m.repo.ListDeliveries(ctx, webhookID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return &interfaces.WebhookDeliveryListResponse{Deliveries: list, Total: total}, nil
+}
+
+// GetDelivery возвращает доставку вебхука
+func (m *WebhookManagerImpl) GetDelivery(ctx context.Context, webhookID, deliveryID uint) (*models.WebhookDelivery, error) {
+	delivery, err := m.repo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.WebhookID != webhookID {
+		return nil, fmt.Errorf("webhook delivery with ID %d not found", deliveryID)
+	}
+	return delivery, nil
+}
+
+// Redeliver отправляет то же событие ещё раз новой доставкой; при сбое она повторяется по очереди
+func (m *WebhookManagerImpl) Redeliver(ctx context.Context, webhookID, deliveryID uint) (*models.WebhookDelivery, error) {
+	original, err := m.GetDelivery(ctx, webhookID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	return m.sendNow(ctx, webhookID, models.WebhookDelivery{
+		EventID:      original.EventID,
+		Event:        original.Event,
+		Payload:      original.Payload,
+		RedeliveryOf: &original.ID,
+	})
+}
+
+// Ping отправляет проверочное событие; оно не повторяется при сбое
+func (m *WebhookManagerImpl) Ping(ctx context.Context, webhookID uint) (*models.WebhookDelivery, error) {
+	hook, err := m.repo.GetByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	eventID, payload, err := buildWebhookPayload(models.WebhookPing, struct {
+		WebhookID uint                  `json:"webhook_id"`
+		Events    []models.WebhookEvent `json:"events"`
+	}{hook.ID, hook.EventList()})
+	if err != nil {
+		return nil, err
+	}
+	return m.sendNow(ctx, webhookID, models.WebhookDelivery{
+		EventID: eventID,
+		Event:   models.WebhookPing,
+		Payload: payload,
+	})
+}
+
+// sendNow сохраняет доставку в журнал и сразу отправляет её, не дожидаясь очереди
+func (m *WebhookManagerImpl) sendNow(ctx context.Context, webhookID uint, delivery models.WebhookDelivery) (*models.WebhookDelivery, error) {
+	hook, err := m.repo.GetByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	delivery.WebhookID = hook.ID
+	delivery.Status = models.DeliverySending
+	delivery.Attempts = 1
+	delivery.NextAttemptAt = now
+
+	deliveries := []models.WebhookDelivery{delivery}
+	if err := m.repo.CreateDeliveries(ctx, deliveries); err != nil {
+		return nil, err
+	}
+	created := &deliveries[0]
+	if _, err := m.attempt(ctx, hook, created, now); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// ProcessQueue отправляет порцию доставок, срок которых подошёл
+func (m *WebhookManagerImpl) ProcessQueue(ctx context.Context, now time.Time) (int, error) {
+	deliveries, err := m.repo.ClaimDue(ctx, now, m.cfg.BatchSize, staleSendAfter)
+	if err != nil {
+		return 0, err
+	}
+
+	hooks := make(map[uint]*models.Webhook)
+	delivered := 0
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		hook, ok := hooks[delivery.WebhookID]
+		if !ok {
+			if hook, err = m.repo.GetByID(ctx, delivery.WebhookID); err != nil {
+				return delivered, err
+			}
+			hooks[delivery.WebhookID] = hook
+		}
+		if !hook.IsActive {
+			delivery.Status = models.DeliveryFailed
+			delivery.LastError = "webhook is disabled"
+			if err := m.repo.SaveAttempt(ctx, delivery); err != nil {
+				return delivered, err
+			}
+			continue
+		}
+
+		sent, err := m.attempt(ctx, hook, delivery, now)
+		if err != nil {
+			return delivered, err
+		}
+		if sent {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// attempt отправляет доставку и сохраняет итог; ошибка возвращается только при сбое сохранения
+func (m *WebhookManagerImpl) attempt(ctx context.Context, hook *models.Webhook, delivery *models.WebhookDelivery, now time.Time) (bool, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	headers := map[string]string{
+		webhookSignatureHeader: signWebhookPayload(hook.Secret, timestamp, []byte(delivery.Payload)),
+		webhookTimestampHeader: timestamp,
+		webhookEventHeader:     string(delivery.Event),
+		webhookDeliveryHeader:  delivery.EventID,
+	}
+
+	started := time.Now()
+	res, sendErr := m.client.Post(ctx, hook.URL, headers, []byte(delivery.Payload))
+	delivery.DurationMS = time.Since(started).Milliseconds()
+	delivery.ResponseStatus = 0
+	delivery.ResponseBody = ""
+	if res != nil {
+		delivery.ResponseStatus = res.StatusCode
+		delivery.ResponseBody = string(res.Body)
+		if sendErr == nil && (res.StatusCode < 200 || res.StatusCode > 299) {
+			sendErr = fmt.Errorf("unexpected response status %d", res.StatusCode)
+		}
+	}
+
+	ok := sendErr == nil
+	if ok {
+		at := time.Now()
+		delivery.Status = models.DeliveryDelivered
+		delivery.DeliveredAt = &at
+		delivery.LastError = ""
+	} else {
+		delivery.LastError = sendErr.Error()
+		if delivery.Event != models.WebhookPing && delivery.Attempts < m.cfg.MaxAttempts {
+			delivery.Status = models.DeliveryPending
+			delivery.NextAttemptAt = now.Add(retryDelay(m.cfg.RetryBackoff, delivery.Attempts))
+			log.Printf("webhook delivery %d to %s failed (attempt %d), retry at %s: %v",
+				delivery.ID, hook.URL, delivery.Attempts, delivery.NextAttemptAt.Format(time.RFC3339), sendErr)
+		} else {
+			delivery.Status = models.DeliveryFailed
+			log.Printf("webhook delivery %d to %s failed after %d attempts: %v", delivery.ID, hook.URL, delivery.Attempts, sendErr)
+		}
+	}
+	if err := m.repo.SaveAttempt(ctx, delivery); err != nil {
+		return false, err
+	}
+	return ok, nil
+}
+
+// buildWebhookPayload собирает тело запроса с новым идентификатором события
+func buildWebhookPayload(event models.WebhookEvent, data interface{}) (string, string, error) {
+	eventID, err := randomHex(webhookEventIDBytes)
+	if err != nil {
+		return "", "", err
+	}
+	body, err := json.Marshal(interfaces.WebhookPayload{
+		ID:        eventID,
+		Event:     event,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+	return eventID, string(body), nil
+}
+
+// signWebhookPayload возвращает подпись для заголовка X-CourseForge-Signature: время отправки
+// входит в подпись, поэтому подменить X-CourseForge-Timestamp у старого запроса нельзя
+func signWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func validateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("webhook url must be an absolute http or https url")
+	}
+	return nil
+}
+
+// normalizeWebhookEvents проверяет события подписки и убирает повторы
+func normalizeWebhookEvents(events []models.WebhookEvent) ([]models.WebhookEvent, error) {
+	if len(events) == 0 {
+		return nil, errors.New("at least one event is required")
+	}
+	seen := make(map[models.WebhookEvent]bool, len(events))
+	result := make([]models.WebhookEvent, 0, len(events))
+	for _, e := range events {
+		if !e.IsValid() {
+			return nil, fmt.Errorf("unknown webhook event %q", e)
+		}
+		if !seen[e] {
+			seen[e] = true
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func buildWebhookResponse(hook *models.Webhook, withSecret bool) *interfaces.WebhookResponse {
+	resp := &interfaces.WebhookResponse{
+		ID:          hook.ID,
+		URL:         hook.URL,
+		Description: hook.Description,
+		Events:      hook.EventList(),
+		IsActive:    hook.IsActive,
+		CreatedAt:   hook.CreatedAt,
+		UpdatedAt:   hook.UpdatedAt,
+	}
+	if withSecret {
+		resp.Secret = hook.Secret
+	}
+	return resp
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random value: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// webhookUser - пользователь в данных событий
+func webhookUser(u *models.User) interfaces.WebhookUser {
+	return interfaces.WebhookUser{
+		ID:        u.ID,
+		Email:     u.Email,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		Role:      u.Role,
+	}
+}
+
+// webhookAssignment - данные событий по назначению; sc загружен вместе со студентом и темой
+func webhookAssignment(sc *models.StudentCoursework) interfaces.WebhookAssignmentData {
+	return interfaces.WebhookAssignmentData{
+		AssignmentID:    sc.ID,
+		Status:          sc.Status,
+		Grade:           sc.Grade,
+		Feedback:        sc.Feedback,
+		Student:         webhookUser(&sc.Student),
+		CourseworkID:    sc.CourseworkID,
+		CourseworkTitle: sc.Coursework.Title,
+		SubjectID:       sc.Coursework.SubjectID,
+		TeacherID:       sc.Coursework.TeacherID,
+		TermID:          sc.TermID,
+		AssignedAt:      sc.CreatedAt,
+		UpdatedAt:       sc.UpdatedAt,
+	}
+}
@@ -0,0 +1,212 @@
+package managers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/config"
+	"github.com/Foxpunk/courseforge/internal/drivers"
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"github.com/Foxpunk/courseforge/internal/testutil"
+)
+
+// webhookRequest - запрос, который получил тестовый приёмник вебхуков
+type webhookRequest struct {
+	headers http.Header
+	body    []byte
+}
+
+// webhookReceiver отвечает статусами из statuses по очереди (последний повторяется)
+// и запоминает полученные запросы
+type webhookReceiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []webhookRequest
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	r.requests = append(r.requests, webhookRequest{headers: req.Header.Clone(), body: body})
+	status := r.statuses[len(r.statuses)-1]
+	if n := len(r.requests); n <= len(r.statuses) {
+		status = r.statuses[n-1]
+	}
+	r.mu.Unlock()
+	w.WriteHeader(status)
+}
+
+func (r *webhookReceiver) received() []webhookRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]webhookRequest(nil), r.requests...)
+}
+
+// checkWebhookSignature проверяет подпись так, как это делает получатель:
+// HMAC-SHA256 по "<timestamp>.<body>" ключом вебхука
+func checkWebhookSignature(t *testing.T, secret string, req webhookRequest) {
+	t.Helper()
+	timestamp := req.headers.Get("X-CourseForge-Timestamp")
+	if timestamp == "" {
+		t.Fatal("timestamp header is missing")
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + string(req.body)))
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if got := req.headers.Get("X-CourseForge-Signature"); !hmac.Equal([]byte(got), []byte(want)) {
+		t.Errorf("signature = %q, want %q", got, want)
+	}
+}
+
+func newTestWebhookManager(t *testing.T, maxAttempts int) (interfaces.WebhookManager, *webhookReceiver, *httptest.Server) {
+	t.Helper()
+	db := testutil.NewDB(t)
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+	m := NewWebhookManager(drivers.NewWebhookRepository(db), drivers.NewWebhookClient(time.Second), config.WebhookConfig{
+		MaxAttempts:  maxAttempts,
+		RetryBackoff: time.Minute,
+		BatchSize:    10,
+	})
+	return m, receiver, server
+}
+
+func latestDelivery(t *testing.T, m interfaces.WebhookManager, webhookID uint) models.WebhookDelivery {
+	t.Helper()
+	list, err := m.ListDeliveries(context.Background(), webhookID, 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Deliveries) != 1 {
+		t.Fatalf("%d deliveries, want 1", len(list.Deliveries))
+	}
+	return list.Deliveries[0]
+}
+
+func TestWebhookDeliverySignedAndRetried(t *testing.T) {
+	ctx := context.Background()
+	m, receiver, server := newTestWebhookManager(t, 3)
+	receiver.statuses = []int{http.StatusServiceUnavailable, http.StatusOK}
+
+	hook, err := m.CreateWebhook(ctx, interfaces.CreateWebhookRequest{
+		URL:    server.URL,
+		Events: []models.WebhookEvent{models.WebhookAssignmentCreated},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hook.Secret == "" {
+		t.Fatal("secret is not returned on create")
+	}
+	if err := m.Enqueue(ctx, models.WebhookAssignmentCreated, map[string]uint{"coursework_id": 7}); err != nil {
+		t.Fatal(err)
+	}
+
+	// первая попытка получает 503: доставка остаётся в очереди со сдвигом на RetryBackoff
+	now := time.Now()
+	delivered, err := m.ProcessQueue(ctx, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delivered != 0 {
+		t.Fatalf("delivered = %d after a failed attempt, want 0", delivered)
+	}
+	d := latestDelivery(t, m, hook.ID)
+	if d.Status != models.DeliveryPending || d.Attempts != 1 || d.ResponseStatus != http.StatusServiceUnavailable {
+		t.Fatalf("after failure: status %s, attempts %d, response %d", d.Status, d.Attempts, d.ResponseStatus)
+	}
+	if !strings.Contains(d.LastError, "503") {
+		t.Errorf("last error = %q, want response status", d.LastError)
+	}
+	if diff := d.NextAttemptAt.Sub(now.Add(time.Minute)); diff < -time.Second || diff > time.Second {
+		t.Errorf("next attempt at %s, want about %s", d.NextAttemptAt, now.Add(time.Minute))
+	}
+
+	// до срока повтор не отправляется
+	if _, err := m.ProcessQueue(ctx, now.Add(30*time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(receiver.received()); n != 1 {
+		t.Fatalf("%d requests before the retry is due, want 1", n)
+	}
+
+	delivered, err = m.ProcessQueue(ctx, now.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delivered != 1 {
+		t.Fatalf("delivered = %d on retry, want 1", delivered)
+	}
+	d = latestDelivery(t, m, hook.ID)
+	if d.Status != models.DeliveryDelivered || d.Attempts != 2 || d.DeliveredAt == nil || d.LastError != "" {
+		t.Errorf("after retry: status %s, attempts %d, delivered at %v, error %q", d.Status, d.Attempts, d.DeliveredAt, d.LastError)
+	}
+
+	// каждая попытка подписана ключом вебхука и несёт то же событие и тот же id доставки
+	requests := receiver.received()
+	if len(requests) != 2 {
+		t.Fatalf("%d requests, want 2", len(requests))
+	}
+	for i, req := range requests {
+		checkWebhookSignature(t, hook.Secret, req)
+		if got := req.headers.Get("X-CourseForge-Event"); got != string(models.WebhookAssignmentCreated) {
+			t.Errorf("request %d: event header = %q", i, got)
+		}
+		if got := req.headers.Get("X-CourseForge-Delivery"); got != d.EventID {
+			t.Errorf("request %d: delivery header = %q, want %q", i, got, d.EventID)
+		}
+		var payload interfaces.WebhookPayload
+		if err := json.Unmarshal(req.body, &payload); err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+		if payload.ID != d.EventID || payload.Event != models.WebhookAssignmentCreated {
+			t.Errorf("request %d: payload id %q event %q", i, payload.ID, payload.Event)
+		}
+	}
+	if string(requests[0].body) != string(requests[1].body) {
+		t.Error("retry body differs from the first attempt")
+	}
+}
+
+func TestWebhookDeliveryFailsAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	m, receiver, server := newTestWebhookManager(t, 2)
+	receiver.statuses = []int{http.StatusInternalServerError}
+
+	hook, err := m.CreateWebhook(ctx, interfaces.CreateWebhookRequest{
+		URL:    server.URL,
+		Events: []models.WebhookEvent{models.WebhookAssignmentCreated},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Enqueue(ctx, models.WebhookAssignmentCreated, map[string]uint{"coursework_id": 7}); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	for _, at := range []time.Time{now, now.Add(time.Minute), now.Add(time.Hour)} {
+		if _, err := m.ProcessQueue(ctx, at); err != nil {
+			t.Fatal(err)
+		}
+	}
+	d := latestDelivery(t, m, hook.ID)
+	if d.Status != models.DeliveryFailed || d.Attempts != 2 {
+		t.Errorf("status %s, attempts %d, want failed after 2", d.Status, d.Attempts)
+	}
+	if n := len(receiver.received()); n != 2 {
+		t.Errorf("%d requests, want 2", n)
+	}
+}
@@ -0,0 +1,349 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Foxpunk/courseforge/internal/config"
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// errNoActiveYear - квоты и нагрузка считаются по учебному году, а текущий не определён
+var errNoActiveYear = errors.New("no active academic year, activate a term first")
+
+// WorkloadManagerImpl реализует interfaces.WorkloadManager
+type WorkloadManagerImpl struct {
+	quotaRepo   interfaces.SupervisionQuotaRepository
+	profileRepo interfaces.TeacherProfileRepository
+	deptRepo    interfaces.DepartmentRepository
+	userRepo    interfaces.UserRepository
+	cwRepo      interfaces.CourseworkRepository
+	scRepo      interfaces.StudentCourseworkRepository
+	termRepo    interfaces.TermRepository
+	defaults    config.WorkloadConfig
+}
+
+// NewWorkloadManager создаёт новый WorkloadManager
+func NewWorkloadManager(
+	quotaRepo interfaces.SupervisionQuotaRepository,
+	profileRepo interfaces.TeacherProfileRepository,
+	deptRepo interfaces.DepartmentRepository,
+	userRepo interfaces.UserRepository,
+	cwRepo interfaces.CourseworkRepository,
+	scRepo interfaces.StudentCourseworkRepository,
+	termRepo interfaces.TermRepository,
+	defaults config.WorkloadConfig,
+) interfaces.WorkloadManager {
+	return &WorkloadManagerImpl{
+		quotaRepo:   quotaRepo,
+		profileRepo: profileRepo,
+		deptRepo:    deptRepo,
+		userRepo:    userRepo,
+		cwRepo:      cwRepo,
+		scRepo:      scRepo,
+		termRepo:    termRepo,
+		defaults:    defaults,
+	}
+}
+
+// SetQuota задаёт квоту руководства преподавателя на учебный год
+func (m *WorkloadManagerImpl) SetQuota(ctx context.Context, teacherID, setByID uint, req interfaces.SetSupervisionQuotaRequest) (*models.SupervisionQuota, error) {
+	teacher, err := m.userRepo.GetByID(ctx, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	if !teacher.IsTeacher() {
+		return nil, errors.New("quotas can be set only for teachers")
+	}
+	year, err := m.resolveYear(ctx, req.AcademicYear)
+	if err != nil {
+		return nil, err
+	}
+
+	hours := m.defaults.DefaultHoursPerStudent
+	if existing, err := m.quotaRepo.GetByTeacherAndYear(ctx, teacherID, year.Name); err == nil {
+		hours = existing.HoursPerStudent
+	}
+	if req.HoursPerStudent != nil {
+		hours = *req.HoursPerStudent
+	}
+	quota := &models.SupervisionQuota{
+		TeacherID:       teacherID,
+		AcademicYear:    year.Name,
+		MaxStudents:     req.MaxStudents,
+		MaxTopics:       req.MaxTopics,
+		HoursPerStudent: hours,
+		SetByID:         setByID,
+		UpdatedAt:       time.Now(),
+	}
+	if err := m.quotaRepo.Upsert(ctx, quota); err != nil {
+		return nil, err
+	}
+	saved, err := m.quotaRepo.GetByTeacherAndYear(ctx, teacherID, year.Name)
+	if err != nil {
+		return nil, err
+	}
+	saved.Teacher = *teacher
+	return saved, nil
+}
+
+// GetQuotas возвращает квоты учебного года
+func (m *WorkloadManagerImpl) GetQuotas(ctx context.Context, academicYear string) ([]models.SupervisionQuota, error) {
+	year, err := m.resolveYear(ctx, academicYear)
+	if err != nil {
+		return nil, err
+	}
+	return m.quotaRepo.ListByYear(ctx, year.Name)
+}
+
+// DeleteQuota удаляет индивидуальную квоту; далее действуют лимиты по умолчанию
+func (m *WorkloadManagerImpl) DeleteQuota(ctx context.Context, teacherID uint, academicYear string) error {
+	year, err := m.resolveYear(ctx, academicYear)
+	if err != nil {
+		return err
+	}
+	return m.quotaRepo.Delete(ctx, teacherID, year.Name)
+}
+
+// CanManageTeacher проверяет, заведует ли пользователь кафедрой, к которой относится преподаватель
+func (m *WorkloadManagerImpl) CanManageTeacher(ctx context.Context, userID, teacherID uint) (bool, error) {
+	departments, err := m.deptRepo.GetByHead(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if len(departments) == 0 {
+		return false, nil
+	}
+	profile, err := m.profileRepo.GetByUserID(ctx, teacherID)
+	if err != nil {
+		return false, nil
+	}
+	for _, d := range departments {
+		if d.ID == profile.DepartmentID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetHeadedDepartments возвращает кафедры, которыми заведует пользователь
+func (m *WorkloadManagerImpl) GetHeadedDepartments(ctx context.Context, userID uint) ([]models.Department, error) {
+	return m.deptRepo.GetByHead(ctx, userID)
+}
+
+// SetDepartmentHead назначает заведующего кафедрой; им может быть только преподаватель
+func (m *WorkloadManagerImpl) SetDepartmentHead(ctx context.Context, departmentID uint, userID *uint) error {
+	if userID != nil {
+		user, err := m.userRepo.GetByID(ctx, *userID)
+		if err != nil {
+			return err
+		}
+		if !user.IsTeacher() {
+			return errors.New("department head must be a teacher")
+		}
+	}
+	return m.deptRepo.SetHead(ctx, departmentID, userID)
+}
+
+// CheckTopicQuota проверяет, может ли преподаватель предложить ещё одну тему в учебном году семестра
+func (m *WorkloadManagerImpl) CheckTopicQuota(ctx context.Context, teacherID, termID uint) error {
+	year, err := m.yearOfTerm(ctx, termID)
+	if errors.Is(err, errNoActiveYear) {
+		// без активного семестра учебный год не определён, и квоты не применяются
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	quota, _ := m.effectiveQuota(ctx, teacherID, year.Name)
+	if quota.MaxTopics == 0 {
+		return nil
+	}
+	topics, err := m.cwRepo.CountByTeacher(ctx, teacherID, year.ID)
+	if err != nil {
+		return err
+	}
+	if topics >= quota.MaxTopics {
+		return fmt.Errorf("teacher has reached the topic quota for %s (%d)", year.Name, quota.MaxTopics)
+	}
+	return nil
+}
+
+// CheckSupervisionQuota проверяет, может ли преподаватель взять ещё одного студента в учебном году семестра
+func (m *WorkloadManagerImpl) CheckSupervisionQuota(ctx context.Context, teacherID, termID uint) error {
+	year, err := m.yearOfTerm(ctx, termID)
+	if errors.Is(err, errNoActiveYear) {
+		// без активного семестра учебный год не определён, и квоты не применяются
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	quota, _ := m.effectiveQuota(ctx, teacherID, year.Name)
+	if quota.MaxStudents == 0 {
+		return nil
+	}
+	students, err := m.scRepo.CountByTeacher(ctx, teacherID, year.ID)
+	if err != nil {
+		return err
+	}
+	if students >= quota.MaxStudents {
+		return fmt.Errorf("supervisor has reached the student quota for %s (%d)", year.Name, quota.MaxStudents)
+	}
+	return nil
+}
+
+// GetTeacherWorkload возвращает нагрузку одного преподавателя
+func (m *WorkloadManagerImpl) GetTeacherWorkload(ctx context.Context, teacherID uint, academicYear string) (*interfaces.TeacherWorkload, error) {
+	year, err := m.resolveYear(ctx, academicYear)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := m.profileRepo.GetByUserID(ctx, teacherID)
+	if err != nil {
+		// преподаватель без профиля не привязан к кафедре, но нагрузку всё равно считаем
+		user, uerr := m.userRepo.GetByID(ctx, teacherID)
+		if uerr != nil {
+			return nil, uerr
+		}
+		profile = &models.TeacherProfile{UserID: teacherID, User: *user}
+	}
+	return m.buildWorkload(ctx, profile, year)
+}
+
+// GetWorkloadReport строит отчёт о нагрузке по профилям преподавателей
+func (m *WorkloadManagerImpl) GetWorkloadReport(ctx context.Context, academicYear string, departmentID uint) (*interfaces.WorkloadReport, error) {
+	year, err := m.resolveYear(ctx, academicYear)
+	if err != nil {
+		return nil, err
+	}
+
+	var profiles []models.TeacherProfile
+	if departmentID != 0 {
+		profiles, err = m.profileRepo.GetByDepartment(ctx, departmentID)
+	} else {
+		profiles, err = m.profileRepo.List(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	report := &interfaces.WorkloadReport{
+		AcademicYear: year.Name,
+		DepartmentID: departmentID,
+		Teachers:     make([]interfaces.TeacherWorkload, 0, len(profiles)),
+	}
+	for i := range profiles {
+		w, err := m.buildWorkload(ctx, &profiles[i], year)
+		if err != nil {
+			return nil, err
+		}
+		report.Teachers = append(report.Teachers, *w)
+
+		report.Totals.Teachers++
+		report.Totals.SupervisedStudents += w.SupervisedStudents
+		report.Totals.Capacity += w.MaxStudents
+		report.Totals.Hours += w.Hours
+		switch w.Status {
+		case interfaces.WorkloadOver:
+			report.Totals.Overloaded++
+		case interfaces.WorkloadUnder:
+			report.Totals.Underloaded++
+		}
+	}
+	return report, nil
+}
+
+// Вспомогательные методы
+
+// effectiveQuota возвращает индивидуальную квоту или лимиты по умолчанию; второй результат - задана ли квота явно
+func (m *WorkloadManagerImpl) effectiveQuota(ctx context.Context, teacherID uint, year string) (*models.SupervisionQuota, bool) {
+	if quota, err := m.quotaRepo.GetByTeacherAndYear(ctx, teacherID, year); err == nil {
+		return quota, true
+	}
+	return &models.SupervisionQuota{
+		TeacherID:       teacherID,
+		AcademicYear:    year,
+		MaxStudents:     m.defaults.DefaultMaxStudents,
+		MaxTopics:       m.defaults.DefaultMaxTopics,
+		HoursPerStudent: m.defaults.DefaultHoursPerStudent,
+	}, false
+}
+
+func (m *WorkloadManagerImpl) buildWorkload(ctx context.Context, profile *models.TeacherProfile, year *models.AcademicYear) (*interfaces.TeacherWorkload, error) {
+	topics, err := m.cwRepo.CountByTeacher(ctx, profile.UserID, year.ID)
+	if err != nil {
+		return nil, err
+	}
+	students, err := m.scRepo.CountByTeacher(ctx, profile.UserID, year.ID)
+	if err != nil {
+		return nil, err
+	}
+	quota, explicit := m.effectiveQuota(ctx, profile.UserID, year.Name)
+
+	w := &interfaces.TeacherWorkload{
+		TeacherID:          profile.UserID,
+		TeacherName:        profile.User.GetFullName(),
+		DepartmentID:       profile.DepartmentID,
+		DepartmentName:     profile.Department.DepartmentName,
+		Position:           profile.Position,
+		Topics:             topics,
+		MaxTopics:          quota.MaxTopics,
+		SupervisedStudents: students,
+		MaxStudents:        quota.MaxStudents,
+		HoursPerStudent:    quota.HoursPerStudent,
+		Hours:              float64(students) * quota.HoursPerStudent,
+		PlannedHours:       float64(quota.MaxStudents) * quota.HoursPerStudent,
+		QuotaSet:           explicit,
+	}
+	w.Utilization, w.Status = workloadStatus(students, quota.MaxStudents)
+	return w, nil
+}
+
+// workloadStatus оценивает загрузку по числу студентов относительно квоты
+func workloadStatus(students, limit int) (float64, interfaces.WorkloadStatus) {
+	if limit == 0 {
+		return 0, interfaces.WorkloadUnlimited
+	}
+	utilization := float64(students) / float64(limit)
+	switch {
+	case students > limit:
+		return utilization, interfaces.WorkloadOver
+	case students == limit:
+		return utilization, interfaces.WorkloadFull
+	case utilization < 0.5:
+		return utilization, interfaces.WorkloadUnder
+	default:
+		return utilization, interfaces.WorkloadBalanced
+	}
+}
+
+// resolveYear находит учебный год по названию; пустое название - год активного семестра
+func (m *WorkloadManagerImpl) resolveYear(ctx context.Context, name string) (*models.AcademicYear, error) {
+	if name == "" {
+		return m.yearOfTerm(ctx, 0)
+	}
+	return m.termRepo.GetYearByName(ctx, name)
+}
+
+// yearOfTerm возвращает учебный год семестра; 0 - год активного семестра
+func (m *WorkloadManagerImpl) yearOfTerm(ctx context.Context, termID uint) (*models.AcademicYear, error) {
+	if termID != 0 {
+		term, err := m.termRepo.GetByID(ctx, termID)
+		if err != nil {
+			return nil, err
+		}
+		return &term.AcademicYear, nil
+	}
+	term, err := m.termRepo.GetActive(ctx)
+	if errors.Is(err, interfaces.ErrNotFound) {
+		return nil, errNoActiveYear
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &term.AcademicYear, nil
+}
@@ -0,0 +1,72 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"github.com/Foxpunk/courseforge/internal/config"
+	"github.com/Foxpunk/courseforge/internal/drivers"
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+	"github.com/Foxpunk/courseforge/internal/testutil"
+)
+
+func newTestWorkloadManager(db *gorm.DB, defaults config.WorkloadConfig) interfaces.WorkloadManager {
+	return NewWorkloadManager(drivers.NewSupervisionQuotaRepository(db), drivers.NewTeacherProfileRepository(db),
+		drivers.NewDepartmentRepository(db), drivers.NewUserRepository(db), drivers.NewCourseworkRepository(db),
+		drivers.NewStudentCourseworkRepository(db), drivers.NewTermRepository(db), defaults)
+}
+
+func TestWorkloadWithoutActiveYear(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	teacher := testutil.CreateUser(t, db, models.RoleTeacher, "teacher@example.com")
+	manager := newTestWorkloadManager(db, config.WorkloadConfig{DefaultMaxTopics: 1, DefaultMaxStudents: 1})
+
+	// на свежей базе семестров нет: квоты не применяются, а отчёт по году построить нельзя
+	if err := manager.CheckTopicQuota(ctx, teacher.ID, 0); err != nil {
+		t.Errorf("CheckTopicQuota without an active term: %v, want nil", err)
+	}
+	if err := manager.CheckSupervisionQuota(ctx, teacher.ID, 0); err != nil {
+		t.Errorf("CheckSupervisionQuota without an active term: %v, want nil", err)
+	}
+	if _, err := manager.GetTeacherWorkload(ctx, teacher.ID, ""); !errors.Is(err, errNoActiveYear) {
+		t.Errorf("GetTeacherWorkload without an active term: %v, want errNoActiveYear", err)
+	}
+}
+
+func TestTopicQuotaCountsTermsOfAcademicYear(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.NewDB(t)
+	term := testutil.CreateActiveTerm(t, db)
+	teacher := testutil.CreateUser(t, db, models.RoleTeacher, "teacher@example.com")
+	subject := &models.Subject{Name: "Базы данных", Code: "DB", Semester: 5, IsActive: true}
+	testutil.MustCreate(t, db, subject)
+	manager := newTestWorkloadManager(db, config.WorkloadConfig{DefaultMaxTopics: 1})
+
+	// тема без семестра (созданная до их появления) в квоту года не входит
+	legacy := &models.Coursework{Title: "Старая тема", Description: "Тема без семестра", SubjectID: subject.ID,
+		TeacherID: teacher.ID, MaxStudents: 1, DifficultyLevel: models.Easy}
+	testutil.MustCreate(t, db, legacy)
+	if err := manager.CheckTopicQuota(ctx, teacher.ID, 0); err != nil {
+		t.Fatalf("CheckTopicQuota with only a legacy topic: %v", err)
+	}
+
+	current := &models.Coursework{Title: "Новая тема", Description: "Тема текущего семестра", SubjectID: subject.ID,
+		TeacherID: teacher.ID, MaxStudents: 1, DifficultyLevel: models.Easy, TermID: term.ID}
+	testutil.MustCreate(t, db, current)
+	if err := manager.CheckTopicQuota(ctx, teacher.ID, 0); err == nil {
+		t.Error("CheckTopicQuota passed with the quota of the active year used up")
+	}
+
+	workload, err := manager.GetTeacherWorkload(ctx, teacher.ID, "2025-2026")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if workload.Topics != 1 {
+		t.Errorf("topics in 2025-2026 = %d, want 1", workload.Topics)
+	}
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AcademicYear - учебный год вида "2024-2025", делится на семестры
+type AcademicYear struct {
+	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time      `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Name     string    `json:"name" gorm:"size:9;uniqueIndex;not null" validate:"required,len=9"`
+	StartsOn time.Time `json:"starts_on" gorm:"not null"`
+	EndsOn   time.Time `json:"ends_on" gorm:"not null"`
+
+	// Связи
+	Terms []Term `json:"terms,omitempty" gorm:"foreignKey:AcademicYearID"`
+}
+
+func (AcademicYear) TableName() string {
+	return "academic_years"
+}
+
+// AcademicYearBounds возвращает полуинтервал [from, to) учебного года "2024-2025"
+func AcademicYearBounds(year string) (time.Time, time.Time, error) {
+	var start, end int
+	if _, err := fmt.Sscanf(year, "%d-%d", &start, &end); err != nil || end != start+1 {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid academic year %q, expected format 2024-2025", year)
+	}
+	from := time.Date(start, time.September, 1, 0, 0, 0, 0, time.UTC)
+	return from, from.AddDate(1, 0, 0), nil
+}
+
+// Term - семестр учебного года; активным может быть только один семестр
+type Term struct {
+	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time      `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	AcademicYearID uint      `json:"academic_year_id" gorm:"not null;uniqueIndex:idx_term_year_number"`
+	Number         int       `json:"number" gorm:"not null;uniqueIndex:idx_term_year_number" validate:"oneof=1 2"` // 1 - осенний, 2 - весенний
+	Name           string    `json:"name" gorm:"size:50;not null"`
+	StartsOn       time.Time `json:"starts_on" gorm:"not null"`
+	EndsOn         time.Time `json:"ends_on" gorm:"not null"`
+	IsActive       bool      `json:"is_active" gorm:"default:false;index"`
+
+	// Связи
+	AcademicYear AcademicYear `json:"academic_year,omitempty" gorm:"foreignKey:AcademicYearID"`
+}
+
+func (Term) TableName() string {
+	return "terms"
+}
+
+// TermNumberForSemester возвращает номер семестра в году для семестра учебного плана:
+// нечётные семестры идут осенью, чётные - весной
+func TermNumberForSemester(semester int) int {
+	if semester%2 == 0 {
+		return 2
+	}
+	return 1
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// AuditEntry - запись журнала аудита о событии предметной области. Подписчик audit пишет
+// по одной записи на событие outbox, повторная доставка того же события запись не дублирует.
+type AuditEntry struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	EventID    uint      `json:"event_id" gorm:"not null;uniqueIndex"`
+	EventType  EventType `json:"event_type" gorm:"size:50;not null;index"`
+	Payload    string    `json:"payload" gorm:"type:text;not null"`
+	OccurredAt time.Time `json:"occurred_at" gorm:"not null;index"` // когда событие записано в outbox
+}
+
+func (AuditEntry) TableName() string {
+	return "audit_log"
+}
@@ -30,6 +30,8 @@ type Coursework struct {
 	MaxStudents     int             `json:"max_students" gorm:"default:1" validate:"min=1,max=10"`
 	DifficultyLevel DifficultyLevel `json:"difficulty_level" gorm:"type:varchar(20);check:difficulty_level IN ('easy','medium','hard')"`
 	IsAvailable     bool            `json:"is_available" gorm:"default:true"`
+	TermID          uint            `json:"term_id" gorm:"index"`              // семестр, в котором предложена тема
+	Version         uint            `json:"version" gorm:"not null;default:1"` // растёт при каждом изменении, основа ETag
 
 	// Связи
 	Subject Subject `json:"subject" gorm:"foreignKey:SubjectID"`
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CommitteeRole описывает роль преподавателя в комиссии защиты
+type CommitteeRole string
+
+const (
+	CommitteeChair  CommitteeRole = "chair"
+	CommitteeMember CommitteeRole = "member"
+)
+
+// DefenseRoom представляет аудиторию для проведения защит
+type DefenseRoom struct {
+	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time      `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Name     string `json:"name" gorm:"uniqueIndex;not null;size:50" validate:"required"`
+	Building string `json:"building" gorm:"size:100"`
+	Capacity int    `json:"capacity" gorm:"default:0"`
+}
+
+func (DefenseRoom) TableName() string {
+	return "defense_rooms"
+}
+
+// DefenseSession представляет сессию защит: аудитория, время и комиссия
+type DefenseSession struct {
+	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time      `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Title       string    `json:"title" gorm:"size:300;not null" validate:"required"`
+	SubjectID   uint      `json:"subject_id" gorm:"not null;index" validate:"required"`
+	RoomID      uint      `json:"room_id" gorm:"not null;index" validate:"required"`
+	StartsAt    time.Time `json:"starts_at" gorm:"not null;index"`
+	EndsAt      time.Time `json:"ends_at" gorm:"not null"`
+	SlotMinutes int       `json:"slot_minutes" gorm:"not null;default:20" validate:"min=5,max=240"`
+	TermID      uint      `json:"term_id" gorm:"index"` // семестр, работы которого защищаются
+
+	// Связи
+	Subject   Subject                  `json:"subject" gorm:"foreignKey:SubjectID"`
+	Room      DefenseRoom              `json:"room" gorm:"foreignKey:RoomID"`
+	Committee []DefenseCommitteeMember `json:"committee,omitempty" gorm:"foreignKey:SessionID"`
+	Slots     []DefenseSlot            `json:"slots,omitempty" gorm:"foreignKey:SessionID"`
+}
+
+func (DefenseSession) TableName() string {
+	return "defense_sessions"
+}
+
+// Overlaps проверяет пересечение интервала сессии с [start, end)
+func (s *DefenseSession) Overlaps(start, end time.Time) bool {
+	return s.StartsAt.Before(end) && start.Before(s.EndsAt)
+}
+
+// DefenseCommitteeMember связывает преподавателя с комиссией сессии
+type DefenseCommitteeMember struct {
+	ID        uint          `json:"id" gorm:"primaryKey;autoIncrement"`
+	SessionID uint          `json:"session_id" gorm:"not null;uniqueIndex:idx_committee_session_teacher"`
+	TeacherID uint          `json:"teacher_id" gorm:"not null;uniqueIndex:idx_committee_session_teacher;index"`
+	Role      CommitteeRole `json:"role" gorm:"type:varchar(20);default:'member';check:role IN ('chair','member')"`
+
+	// Связи
+	Teacher User `json:"teacher" gorm:"foreignKey:TeacherID"`
+}
+
+func (DefenseCommitteeMember) TableName() string {
+	return "defense_committee_members"
+}
+
+// DefenseSlot представляет временной слот защиты одного студента
+type DefenseSlot struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	SessionID           uint      `json:"session_id" gorm:"not null;index"`
+	StartsAt            time.Time `json:"starts_at" gorm:"not null"`
+	EndsAt              time.Time `json:"ends_at" gorm:"not null"`
+	StudentCourseworkID *uint     `json:"student_coursework_id,omitempty" gorm:"uniqueIndex"`
+
+	// Связи
+	Session           DefenseSession     `json:"-" gorm:"foreignKey:SessionID"`
+	StudentCoursework *StudentCoursework `json:"student_coursework,omitempty" gorm:"foreignKey:StudentCourseworkID"`
+}
+
+func (DefenseSlot) TableName() string {
+	return "defense_slots"
+}
+
+// IsFree проверяет, свободен ли слот
+func (s *DefenseSlot) IsFree() bool {
+	return s.StudentCourseworkID == nil
+}
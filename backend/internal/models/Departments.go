@@ -15,10 +15,11 @@ type Department struct {
 	DepartmentCode string `json:"department_code" gorm:"uniqueIndex;not null;size:10" validate:"required"`
 	DepartmentName string `json:"department_name" gorm:"not null;size:200" validate:"required"`
 	Description    string `json:"description,omitempty"`
+	HeadUserID     *uint  `json:"head_user_id,omitempty" gorm:"index"` // заведующий кафедрой, управляет квотами руководства
 
-	TeacherProfiles []TeacherProfile `json:"teacher_profiles,omitempty" gorm:"-"`
-	Subjects        []Subject        `json:"subjects,omitempty" gorm:"-"`
-	StudentGroups   []StudentGroup   `json:"student_groups,omitempty" gorm:"-"`
+	TeacherProfiles []TeacherProfile `json:"teacher_profiles,omitempty" gorm:"foreignKey:DepartmentID"`
+	Subjects        []Subject        `json:"subjects,omitempty" gorm:"-"` // у дисциплин пока нет привязки к кафедре
+	StudentGroups   []StudentGroup   `json:"student_groups,omitempty" gorm:"foreignKey:DepartmentID"`
 }
 
 func (Department) TableName() string {
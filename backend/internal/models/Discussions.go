@@ -0,0 +1,122 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ThreadKind - вид обсуждения
+type ThreadKind string
+
+const (
+	ThreadCoursework ThreadKind = "coursework" // открытые вопросы по теме, видны всем
+	ThreadAssignment ThreadKind = "assignment" // переписка студента с руководителем
+)
+
+// DiscussionThread - обсуждение темы или конкретного назначения студента.
+// У открытого обсуждения темы StudentCourseworkID равен 0.
+type DiscussionThread struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	Kind                ThreadKind `json:"kind" gorm:"size:20;not null;uniqueIndex:idx_discussion_thread"`
+	CourseworkID        uint       `json:"coursework_id" gorm:"not null;uniqueIndex:idx_discussion_thread"`
+	StudentCourseworkID uint       `json:"student_coursework_id" gorm:"not null;default:0;uniqueIndex:idx_discussion_thread"`
+	LastCommentAt       *time.Time `json:"last_comment_at,omitempty"`
+
+	// Связи
+	Coursework        Coursework        `json:"coursework" gorm:"foreignKey:CourseworkID"`
+	StudentCoursework StudentCoursework `json:"-" gorm:"foreignKey:StudentCourseworkID"`
+}
+
+func (DiscussionThread) TableName() string {
+	return "discussion_threads"
+}
+
+// Comment - сообщение в обсуждении; Body хранит исходный markdown, BodyHTML - его безопасную разметку
+type Comment struct {
+	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time      `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	ThreadID uint       `json:"thread_id" gorm:"not null;index"`
+	ParentID *uint      `json:"parent_id,omitempty" gorm:"index"` // ответ на сообщение
+	AuthorID uint       `json:"author_id" gorm:"not null;index"`
+	Body     string     `json:"body" gorm:"type:text;not null"`
+	BodyHTML string     `json:"body_html" gorm:"type:text"`
+	EditedAt *time.Time `json:"edited_at,omitempty"`
+
+	// Связи
+	Author      User                `json:"author" gorm:"foreignKey:AuthorID"`
+	Attachments []CommentAttachment `json:"attachments,omitempty" gorm:"foreignKey:CommentID"`
+	Mentions    []CommentMention    `json:"mentions,omitempty" gorm:"foreignKey:CommentID"`
+}
+
+func (Comment) TableName() string {
+	return "comments"
+}
+
+// CommentRevision - прежний текст сообщения, сохраняется при каждой правке
+type CommentRevision struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	CommentID uint      `json:"comment_id" gorm:"not null;index"`
+	Body      string    `json:"body" gorm:"type:text;not null"`
+	EditorID  uint      `json:"editor_id" gorm:"not null"`
+
+	// Связи
+	Editor User `json:"editor" gorm:"foreignKey:EditorID"`
+}
+
+func (CommentRevision) TableName() string {
+	return "comment_revisions"
+}
+
+// CommentAttachment - файл, приложенный к сообщению
+type CommentAttachment struct {
+	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt   time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	CommentID   uint      `json:"comment_id" gorm:"not null;index"`
+	FileName    string    `json:"file_name" gorm:"size:255;not null"`
+	ContentType string    `json:"content_type" gorm:"size:100"`
+	Size        int64     `json:"size"`
+	StorageKey  string    `json:"-" gorm:"size:255;not null"`
+}
+
+func (CommentAttachment) TableName() string {
+	return "comment_attachments"
+}
+
+// CommentMention - упоминание пользователя в сообщении (<@ID> в тексте)
+type CommentMention struct {
+	CommentID uint `json:"comment_id" gorm:"primaryKey"`
+	UserID    uint `json:"user_id" gorm:"primaryKey;index"`
+
+	// Связи
+	User User `json:"user" gorm:"foreignKey:UserID"`
+}
+
+func (CommentMention) TableName() string {
+	return "comment_mentions"
+}
+
+// ThreadReadMark - до какого сообщения пользователь прочитал обсуждение
+type ThreadReadMark struct {
+	ThreadID          uint      `json:"thread_id" gorm:"primaryKey"`
+	UserID            uint      `json:"user_id" gorm:"primaryKey;index"`
+	LastReadCommentID uint      `json:"last_read_comment_id"`
+	ReadAt            time.Time `json:"read_at"`
+}
+
+func (ThreadReadMark) TableName() string {
+	return "thread_read_marks"
+}
+
+// ThreadUnread - число непрочитанных сообщений в обсуждении (результат запроса, не таблица)
+type ThreadUnread struct {
+	ThreadID uint
+	Unread   int
+}
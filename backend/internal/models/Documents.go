@@ -0,0 +1,75 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DocumentKind - вид официального документа
+type DocumentKind string
+
+const (
+	DocumentKindTopicOrder DocumentKind = "topic_order" // приказ о закреплении тем курсовых работ
+)
+
+// DocumentFormat - формат выпущенного документа
+type DocumentFormat string
+
+const (
+	DocumentFormatDOCX DocumentFormat = "docx"
+	DocumentFormatPDF  DocumentFormat = "pdf"
+)
+
+// DocumentTemplate - редактируемый администратором шаблон документа.
+// Header и Footer - text/template, таблица со студентами вставляется между ними.
+type DocumentTemplate struct {
+	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time      `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Kind         DocumentKind `json:"kind" gorm:"size:30;not null;index"`
+	Name         string       `json:"name" gorm:"size:200;not null"`
+	NumberPrefix string       `json:"number_prefix" gorm:"size:20"` // номер документа = префикс + порядковый номер за учебный год
+	Header       string       `json:"header" gorm:"type:text"`
+	Footer       string       `json:"footer" gorm:"type:text"`
+	IsDefault    bool         `json:"is_default" gorm:"default:false"` // шаблон, который берётся, если не указан явно
+	UpdatedByID  uint         `json:"updated_by_id"`
+}
+
+func (DocumentTemplate) TableName() string {
+	return "document_templates"
+}
+
+// IssuedDocument - выпущенный документ. Содержимое хранится как было выпущено;
+// перевыпуск по тем же данным сохраняет номер и увеличивает версию.
+type IssuedDocument struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	Kind         DocumentKind   `json:"kind" gorm:"size:30;not null;uniqueIndex:idx_document_number_version"`
+	AcademicYear string         `json:"academic_year" gorm:"size:9;uniqueIndex:idx_document_number_version"`
+	Sequence     int            `json:"sequence" gorm:"not null;uniqueIndex:idx_document_number_version"`
+	Version      int            `json:"version" gorm:"not null;default:1;uniqueIndex:idx_document_number_version"`
+	Number       string         `json:"number" gorm:"size:40;not null;index"`
+	Format       DocumentFormat `json:"format" gorm:"size:10;not null"`
+	FileName     string         `json:"file_name" gorm:"size:255"`
+	Content      []byte         `json:"-"`
+	IsCurrent    bool           `json:"is_current" gorm:"default:true;index"` // false - заменён более новой версией
+
+	TemplateID  uint  `json:"template_id"`
+	SubjectID   uint  `json:"subject_id" gorm:"not null;index"`
+	GroupID     *uint `json:"group_id,omitempty" gorm:"index"`
+	TermID      uint  `json:"term_id" gorm:"index"`
+	CreatedByID uint  `json:"created_by_id" gorm:"not null"`
+
+	// Связи
+	Subject   Subject       `json:"subject" gorm:"foreignKey:SubjectID"`
+	Group     *StudentGroup `json:"group,omitempty" gorm:"foreignKey:GroupID"`
+	CreatedBy User          `json:"created_by" gorm:"foreignKey:CreatedByID"`
+}
+
+func (IssuedDocument) TableName() string {
+	return "issued_documents"
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GroupSubject - строка учебного плана: группа изучает дисциплину в указанном семестре
+type GroupSubject struct {
+	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time      `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	GroupID   uint `json:"group_id" gorm:"not null;uniqueIndex:idx_group_subject_term"`
+	SubjectID uint `json:"subject_id" gorm:"not null;uniqueIndex:idx_group_subject_term;index"`
+	TermID    uint `json:"term_id" gorm:"not null;uniqueIndex:idx_group_subject_term"`
+
+	// Связи
+	Group   StudentGroup `json:"group" gorm:"foreignKey:GroupID"`
+	Subject Subject      `json:"subject" gorm:"foreignKey:SubjectID"`
+	Term    Term         `json:"term" gorm:"foreignKey:TermID"`
+}
+
+func (GroupSubject) TableName() string {
+	return "group_subjects"
+}
+
+// SemestersOfCourseYear возвращает номера семестров дисциплин курса; при номере
+// семестра учебного года (1 - осенний, 2 - весенний) остаётся только один из них
+func SemestersOfCourseYear(courseYear, termNumber int) []int {
+	if courseYear <= 0 {
+		return nil
+	}
+	autumn, spring := 2*courseYear-1, 2*courseYear
+	switch termNumber {
+	case 1:
+		return []int{autumn}
+	case 2:
+		return []int{spring}
+	}
+	return []int{autumn, spring}
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// IdempotencyStatus - состояние запроса с ключом идемпотентности
+type IdempotencyStatus string
+
+const (
+	IdempotencyPending IdempotencyStatus = "pending" // запрос ещё выполняется
+	IdempotencyDone    IdempotencyStatus = "done"    // ответ сохранён и отдаётся на повторы
+)
+
+// IdempotencyKey - ключ Idempotency-Key, присланный пользователем, с отпечатком запроса и
+// сохранённым ответом. Повтор с тем же ключом получает исходный ответ, а не выполняется заново.
+type IdempotencyKey struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID       uint              `json:"user_id" gorm:"not null;uniqueIndex:idx_idempotency_user_key"`
+	Key          string            `json:"key" gorm:"size:255;not null;uniqueIndex:idx_idempotency_user_key"`
+	Fingerprint  string            `json:"fingerprint" gorm:"size:64;not null"` // sha256 метода, пути и тела
+	Status       IdempotencyStatus `json:"status" gorm:"size:20;not null"`
+	ResponseCode int               `json:"response_code"`
+	ContentType  string            `json:"content_type" gorm:"size:100"`
+	ResponseBody string            `json:"response_body" gorm:"type:text"`
+	ExpiresAt    time.Time         `json:"expires_at" gorm:"not null;index"`
+}
+
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
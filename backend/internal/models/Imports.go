@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ImportKind - что загружается из списка
+type ImportKind string
+
+const (
+	ImportKindStudents ImportKind = "students"
+	ImportKindTeachers ImportKind = "teachers"
+)
+
+// ImportJob - журнал загрузки списка студентов или преподавателей из CSV/XLSX
+type ImportJob struct {
+	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time      `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Kind        ImportKind `json:"kind" gorm:"not null;size:20"`
+	FileName    string     `json:"file_name" gorm:"size:255"`
+	DryRun      bool       `json:"dry_run"`
+	CreatedByID uint       `json:"created_by_id" gorm:"not null;index"`
+
+	Total     int `json:"total"`
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Failed    int `json:"failed"`
+
+	// Связи
+	CreatedBy User             `json:"created_by" gorm:"foreignKey:CreatedByID"`
+	Errors    []ImportRowError `json:"errors,omitempty" gorm:"foreignKey:ImportJobID"`
+}
+
+func (ImportJob) TableName() string {
+	return "import_jobs"
+}
+
+// ImportRowError - строка списка, которую не удалось загрузить
+type ImportRowError struct {
+	ID          uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	ImportJobID uint   `json:"import_job_id" gorm:"not null;index"`
+	Row         int    `json:"row"`
+	Email       string `json:"email" gorm:"size:255"`
+	Message     string `json:"message" gorm:"type:text"`
+}
+
+func (ImportRowError) TableName() string {
+	return "import_row_errors"
+}
@@ -0,0 +1,81 @@
+package models
+
+import "time"
+
+// Locale - язык писем пользователя
+type Locale string
+
+const (
+	LocaleRU Locale = "ru"
+	LocaleEN Locale = "en"
+)
+
+// EmailMode - как пользователь получает уведомления по почте
+type EmailMode string
+
+const (
+	EmailOff       EmailMode = "off"
+	EmailImmediate EmailMode = "immediate" // письмо на каждое уведомление
+	EmailDigest    EmailMode = "digest"    // одна сводка в день
+)
+
+// EmailSettings - почтовые настройки пользователя; без записи действуют значения по умолчанию
+type EmailSettings struct {
+	UserID       uint       `json:"user_id" gorm:"primaryKey"`
+	Locale       Locale     `json:"locale" gorm:"size:5;not null;default:'ru'"`
+	Mode         EmailMode  `json:"mode" gorm:"size:20;not null;default:'immediate';index"`
+	LastDigestAt *time.Time `json:"last_digest_at,omitempty"` // до какого момента уведомления уже вошли в сводку
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+func (EmailSettings) TableName() string {
+	return "email_settings"
+}
+
+// DefaultEmailSettings - настройки пользователя, который их не менял
+func DefaultEmailSettings(userID uint) *EmailSettings {
+	return &EmailSettings{UserID: userID, Locale: LocaleRU, Mode: EmailImmediate}
+}
+
+// EmailKind - вид письма
+type EmailKind string
+
+const (
+	EmailKindNotification EmailKind = "notification"
+	EmailKindDigest       EmailKind = "digest"
+)
+
+// EmailStatus - состояние письма в очереди
+type EmailStatus string
+
+const (
+	EmailPending EmailStatus = "pending" // ждёт отправки или повторной попытки
+	EmailSending EmailStatus = "sending"
+	EmailSent    EmailStatus = "sent"
+	EmailFailed  EmailStatus = "failed" // попытки исчерпаны
+)
+
+// OutgoingEmail - письмо в очереди отправки. Письмо собирается сразу, а отправляется
+// фоновым обработчиком, который при ошибке откладывает повтор с растущей паузой.
+type OutgoingEmail struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID         uint        `json:"user_id" gorm:"not null;index"`
+	Kind           EmailKind   `json:"kind" gorm:"size:20;not null"`
+	NotificationID *uint       `json:"notification_id,omitempty"` // для писем об одном уведомлении
+	To             string      `json:"to" gorm:"size:255;not null"`
+	Subject        string      `json:"subject" gorm:"size:255;not null"`
+	TextBody       string      `json:"-" gorm:"type:text"`
+	HTMLBody       string      `json:"-" gorm:"type:text"`
+	Status         EmailStatus `json:"status" gorm:"size:20;not null;index:idx_outgoing_email_due"`
+	Attempts       int         `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt  time.Time   `json:"next_attempt_at" gorm:"index:idx_outgoing_email_due"`
+	LastError      string      `json:"last_error,omitempty" gorm:"type:text"`
+	SentAt         *time.Time  `json:"sent_at,omitempty"`
+}
+
+func (OutgoingEmail) TableName() string {
+	return "outgoing_emails"
+}
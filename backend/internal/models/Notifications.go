@@ -0,0 +1,115 @@
+package models
+
+import "time"
+
+// EventType - вид события, о котором уведомляется пользователь
+type EventType string
+
+const (
+	EventAssignmentCreated       EventType = "assignment.created"        // студент получил тему
+	EventAssignmentCancelled     EventType = "assignment.cancelled"      // назначение отменено
+	EventAssignmentStatusChanged EventType = "assignment.status_changed" // руководитель сменил статус работы
+	EventAssignmentSubmitted     EventType = "assignment.submitted"      // работа сдана на проверку
+	EventAssignmentCompleted     EventType = "assignment.completed"      // работа принята
+	EventGradeSet                EventType = "grade.set"
+	EventSubmissionCreated       EventType = "submission.created" // загружена новая версия файла
+	EventProposalCreated         EventType = "proposal.created"
+	EventProposalReviewed        EventType = "proposal.reviewed" // принято, отклонено или возвращено
+	EventWaitlistOffer           EventType = "waitlist.offer"
+	EventWaitlistOfferExpired    EventType = "waitlist.offer_expired"
+	EventTeamInvitation          EventType = "team.invitation"
+	EventTeamMemberJoined        EventType = "team.member_joined"
+	EventCommentCreated          EventType = "comment.created"
+	EventCommentMention          EventType = "comment.mention"
+	EventDeadlineApproaching     EventType = "deadline.approaching" // скоро защита или истекает срок
+
+	// EventSlotsChanged - внутреннее событие outbox об изменении занятости мест темы,
+	// уведомлений о нём нет, поэтому в EventTypes его тоже нет
+	EventSlotsChanged EventType = "coursework.slots_changed"
+)
+
+// EventTypes - все виды событий в порядке показа в настройках
+var EventTypes = []EventType{
+	EventAssignmentCreated,
+	EventAssignmentCancelled,
+	EventAssignmentStatusChanged,
+	EventAssignmentSubmitted,
+	EventAssignmentCompleted,
+	EventGradeSet,
+	EventSubmissionCreated,
+	EventProposalCreated,
+	EventProposalReviewed,
+	EventWaitlistOffer,
+	EventWaitlistOfferExpired,
+	EventTeamInvitation,
+	EventTeamMemberJoined,
+	EventCommentCreated,
+	EventCommentMention,
+	EventDeadlineApproaching,
+}
+
+// IsValid проверяет, что вид события известен
+func (t EventType) IsValid() bool {
+	for _, known := range EventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// EntityKind - к какому объекту относится уведомление (для перехода из интерфейса)
+type EntityKind string
+
+const (
+	EntityCoursework EntityKind = "coursework"
+	EntityAssignment EntityKind = "assignment"
+	EntitySubmission EntityKind = "submission"
+	EntityProposal   EntityKind = "proposal"
+	EntityWaitlist   EntityKind = "waitlist"
+	EntityTeam       EntityKind = "team"
+	EntityThread     EntityKind = "thread"
+	EntityDefense    EntityKind = "defense_slot"
+)
+
+// Notification - уведомление пользователя в приложении
+type Notification struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP;index"`
+
+	UserID     uint       `json:"user_id" gorm:"not null;index:idx_notification_user_read"`
+	Event      EventType  `json:"event" gorm:"size:50;not null"`
+	ActorID    *uint      `json:"actor_id,omitempty"` // кто вызвал событие, пусто - система
+	EntityType EntityKind `json:"entity_type,omitempty" gorm:"size:30"`
+	EntityID   uint       `json:"entity_id,omitempty"`
+	Title      string     `json:"title" gorm:"size:255;not null"`
+	Message    string     `json:"message" gorm:"type:text"`
+	ReadAt     *time.Time `json:"read_at,omitempty" gorm:"index:idx_notification_user_read"`
+}
+
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+// NotificationPreference - настройка пользователя по виду события; без записи событие включено
+type NotificationPreference struct {
+	UserID  uint      `json:"user_id" gorm:"primaryKey"`
+	Event   EventType `json:"event" gorm:"primaryKey;size:50"`
+	Enabled bool      `json:"enabled" gorm:"not null"`
+}
+
+func (NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
+
+// DeadlineReminder - отметка об отправленном напоминании, чтобы о сроке напомнить один раз
+type DeadlineReminder struct {
+	UserID     uint       `gorm:"primaryKey"`
+	EntityType EntityKind `gorm:"primaryKey;size:30"`
+	EntityID   uint       `gorm:"primaryKey"`
+	SentAt     time.Time  `gorm:"not null"`
+}
+
+func (DeadlineReminder) TableName() string {
+	return "deadline_reminders"
+}
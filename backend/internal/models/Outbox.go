@@ -0,0 +1,59 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// OutboxStatus - состояние события в outbox
+type OutboxStatus string
+
+const (
+	OutboxPending    OutboxStatus = "pending"    // ждёт доставки или повтора
+	OutboxProcessing OutboxStatus = "processing" // доставляется подписчикам
+	OutboxDone       OutboxStatus = "done"       // все подписчики обработали событие
+	OutboxFailed     OutboxStatus = "failed"     // попытки исчерпаны
+)
+
+// OutboxEvent - событие предметной области, записанное в той же транзакции, что и изменение
+// данных. Диспетчер доставляет его внутренним подписчикам хотя бы один раз; подписчики,
+// уже обработавшие событие, запоминаются и при повторе пропускаются.
+type OutboxEvent struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP;index"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Type          EventType    `json:"type" gorm:"size:50;not null;index"`
+	Payload       string       `json:"payload" gorm:"type:text;not null"`
+	Status        OutboxStatus `json:"status" gorm:"size:20;not null;index:idx_outbox_due"`
+	Attempts      int          `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt time.Time    `json:"next_attempt_at" gorm:"index:idx_outbox_due"`
+	Handled       string       `json:"handled" gorm:"size:255"` // подписчики через запятую
+	LastError     string       `json:"last_error,omitempty" gorm:"type:text"`
+	ProcessedAt   *time.Time   `json:"processed_at,omitempty"`
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
+
+// HandledBy проверяет, обработал ли подписчик событие
+func (e *OutboxEvent) HandledBy(subscriber string) bool {
+	for _, name := range strings.Split(e.Handled, ",") {
+		if name == subscriber {
+			return true
+		}
+	}
+	return false
+}
+
+// MarkHandled запоминает, что подписчик обработал событие
+func (e *OutboxEvent) MarkHandled(subscriber string) {
+	if e.HandledBy(subscriber) {
+		return
+	}
+	if e.Handled != "" {
+		e.Handled += ","
+	}
+	e.Handled += subscriber
+}
@@ -17,8 +17,8 @@ type StudentProfile struct {
 	StudentNumber string `json:"student_number" gorm:"size:20"`
 
 	// Связи
-	User         User         `json:"user" gorm:"-"`
-	StudentGroup StudentGroup `json:"student_group" gorm:"-"`
+	User         User         `json:"user" gorm:"foreignKey:UserID"`
+	StudentGroup StudentGroup `json:"student_group" gorm:"foreignKey:GroupID"`
 }
 
 func (StudentProfile) TableName() string {
@@ -37,8 +37,8 @@ type TeacherProfile struct {
 	AcademicDegree string `json:"academic_degree" gorm:"size:100"`
 
 	// Связи
-	User       User       `json:"user" gorm:"-"`
-	Department Department `json:"department" gorm:"-"`
+	User       User       `json:"user" gorm:"foreignKey:UserID"`
+	Department Department `json:"department" gorm:"foreignKey:DepartmentID"`
 }
 
 func (TeacherProfile) TableName() string {
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RoundStatus описывает этап раунда выбора тем
+type RoundStatus string
+
+const (
+	RoundDraft     RoundStatus = "draft"
+	RoundOpen      RoundStatus = "open"
+	RoundClosed    RoundStatus = "closed"
+	RoundCommitted RoundStatus = "committed"
+)
+
+// SelectionRound представляет раунд выбора тем по предпочтениям студентов
+type SelectionRound struct {
+	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time      `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Title       string      `json:"title" gorm:"size:300;not null" validate:"required"`
+	SubjectID   uint        `json:"subject_id" gorm:"not null;index" validate:"required"`
+	MaxChoices  int         `json:"max_choices" gorm:"not null;default:5" validate:"min=1,max=20"`
+	Status      RoundStatus `json:"status" gorm:"type:varchar(20);default:'draft';check:status IN ('draft','open','closed','committed')"`
+	ClosesAt    *time.Time  `json:"closes_at,omitempty"`
+	CommittedAt *time.Time  `json:"committed_at,omitempty"`
+
+	// Связи
+	Subject Subject `json:"subject" gorm:"foreignKey:SubjectID"`
+}
+
+func (SelectionRound) TableName() string {
+	return "selection_rounds"
+}
+
+// BlocksDirectAssignment проверяет, что темы дисциплины распределяются через раунд
+func (r *SelectionRound) BlocksDirectAssignment() bool {
+	return r.Status == RoundOpen || r.Status == RoundClosed
+}
+
+// TopicPreference - место темы в списке предпочтений студента (1 - самая желанная)
+type TopicPreference struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	RoundID      uint `json:"round_id" gorm:"not null;uniqueIndex:idx_pref_round_student_cw;uniqueIndex:idx_pref_round_student_rank"`
+	StudentID    uint `json:"student_id" gorm:"not null;uniqueIndex:idx_pref_round_student_cw;uniqueIndex:idx_pref_round_student_rank"`
+	CourseworkID uint `json:"coursework_id" gorm:"not null;uniqueIndex:idx_pref_round_student_cw;index"`
+	Rank         int  `json:"rank" gorm:"not null;uniqueIndex:idx_pref_round_student_rank"`
+
+	// Связи
+	Student    User       `json:"student" gorm:"foreignKey:StudentID"`
+	Coursework Coursework `json:"coursework" gorm:"foreignKey:CourseworkID"`
+}
+
+func (TopicPreference) TableName() string {
+	return "topic_preferences"
+}
+
+// ApplicantRanking - место студента в рейтинге руководителя по конкретной теме (1 - лучший)
+type ApplicantRanking struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	RoundID      uint `json:"round_id" gorm:"not null;uniqueIndex:idx_rank_round_cw_student"`
+	CourseworkID uint `json:"coursework_id" gorm:"not null;uniqueIndex:idx_rank_round_cw_student"`
+	StudentID    uint `json:"student_id" gorm:"not null;uniqueIndex:idx_rank_round_cw_student"`
+	Rank         int  `json:"rank" gorm:"not null"`
+}
+
+func (ApplicantRanking) TableName() string {
+	return "applicant_rankings"
+}
@@ -25,8 +25,10 @@ type StudentCoursework struct {
 	UpdatedAt time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
-	StudentID    uint             `json:"student_id" gorm:"not null" validate:"required"`
-	CourseworkID uint             `json:"coursework_id" gorm:"not null" validate:"required"`
+	// у студента одно действующее назначение в семестре; снятые назначения (DeletedAt) не мешают новому
+	StudentID    uint             `json:"student_id" gorm:"not null;uniqueIndex:idx_student_term_active,where:deleted_at IS NULL" validate:"required"`
+	CourseworkID uint             `json:"coursework_id" gorm:"not null;index" validate:"required"`
+	TermID       uint             `json:"term_id" gorm:"index;uniqueIndex:idx_student_term_active"` // копируется из темы при назначении
 	Status       CourseworkStatus `json:"status" gorm:"type:varchar(20);default:'assigned'" validate:"oneof=assigned in_progress submitted reviewed completed failed"`
 	// Coursework
 	SubmittedAt *time.Time `json:"submitted_at,omitempty"`
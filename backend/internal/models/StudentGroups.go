@@ -16,7 +16,7 @@ type StudentGroup struct {
 	CourseYear   int        `json:"course_year"`
 	Specialty    string     `json:"specialty" gorm:"size:100"`
 	DepartmentID uint       `json:"department_id" gorm:"not null;index"`
-	Department   Department `json:"department" gorm:"-"`
+	Department   Department `json:"department" gorm:"foreignKey:DepartmentID"`
 }
 
 func (StudentGroup) TableName() string {
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SimilarityCheckStatus - состояние проверки работы на заимствования
+type SimilarityCheckStatus string
+
+const (
+	CheckPending     SimilarityCheckStatus = "pending"     // в очереди
+	CheckRunning     SimilarityCheckStatus = "running"     // проверяется
+	CheckDone        SimilarityCheckStatus = "done"        // отчёт готов
+	CheckUnsupported SimilarityCheckStatus = "unsupported" // из файла не удалось извлечь текст
+	CheckFailed      SimilarityCheckStatus = "failed"
+)
+
+// Submission - файл, сданный студентом по курсовой работе. Файлы остаются в архиве
+// после окончания семестра: с ними сравниваются работы следующих лет.
+type Submission struct {
+	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time      `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	StudentCourseworkID uint   `json:"student_coursework_id" gorm:"not null;index"`
+	StudentID           uint   `json:"student_id" gorm:"not null;index"`
+	CourseworkID        uint   `json:"coursework_id" gorm:"not null;index"`
+	TermID              uint   `json:"term_id" gorm:"index"`
+	FileName            string `json:"file_name" gorm:"size:255;not null"`
+	ContentType         string `json:"content_type" gorm:"size:100"`
+	Size                int64  `json:"size"`
+	SHA256              string `json:"sha256" gorm:"size:64;index"`
+	StorageKey          string `json:"-" gorm:"size:255;not null"`
+
+	CheckStatus     SimilarityCheckStatus `json:"check_status" gorm:"size:20;default:'pending';index"`
+	CheckError      string                `json:"check_error,omitempty" gorm:"type:text"`
+	SimilarityScore float64               `json:"similarity_score"` // доля текста, найденная в других работах архива
+	CheckedAt       *time.Time            `json:"checked_at,omitempty"`
+
+	// Связи
+	Student    User       `json:"student" gorm:"foreignKey:StudentID"`
+	Coursework Coursework `json:"coursework" gorm:"foreignKey:CourseworkID"`
+}
+
+func (Submission) TableName() string {
+	return "submissions"
+}
+
+// SubmissionFingerprint - извлечённый текст и MinHash-сигнатура работы
+type SubmissionFingerprint struct {
+	SubmissionID uint   `json:"submission_id" gorm:"primaryKey"`
+	ShingleCount int    `json:"shingle_count"`
+	Signature    []byte `json:"-"` // значения MinHash, uint64 little-endian
+	Text         string `json:"-" gorm:"type:text"`
+}
+
+func (SubmissionFingerprint) TableName() string {
+	return "submission_fingerprints"
+}
+
+// SimilarityMatch - совпадение работы с более ранней работой из архива
+type SimilarityMatch struct {
+	ID                  uint    `json:"id" gorm:"primaryKey;autoIncrement"`
+	SubmissionID        uint    `json:"submission_id" gorm:"not null;index"`
+	MatchedSubmissionID uint    `json:"matched_submission_id" gorm:"not null;index"`
+	Similarity          float64 `json:"similarity"` // доля шинглов работы, найденных в совпавшей
+	Jaccard             float64 `json:"jaccard"`    // оценка MinHash
+
+	// Связи
+	MatchedSubmission Submission           `json:"matched_submission" gorm:"foreignKey:MatchedSubmissionID"`
+	Fragments         []SimilarityFragment `json:"fragments,omitempty" gorm:"foreignKey:MatchID"`
+}
+
+func (SimilarityMatch) TableName() string {
+	return "similarity_matches"
+}
+
+// SimilarityFragment - совпавший фрагмент текста; смещения в байтах извлечённого текста
+type SimilarityFragment struct {
+	ID              uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	MatchID         uint   `json:"match_id" gorm:"not null;index"`
+	Text            string `json:"text" gorm:"type:text"`
+	Words           int    `json:"words"`
+	Position        int    `json:"position"`
+	MatchedPosition int    `json:"matched_position"`
+}
+
+func (SimilarityFragment) TableName() string {
+	return "similarity_fragments"
+}
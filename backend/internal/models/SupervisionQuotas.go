@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SupervisionQuota - лимит руководства курсовыми для преподавателя на учебный год
+type SupervisionQuota struct {
+	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time      `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	TeacherID       uint    `json:"teacher_id" gorm:"not null;uniqueIndex:idx_quota_teacher_year" validate:"required"`
+	AcademicYear    string  `json:"academic_year" gorm:"size:9;not null;uniqueIndex:idx_quota_teacher_year" validate:"required,len=9"`
+	MaxStudents     int     `json:"max_students" gorm:"not null" validate:"min=0,max=200"`
+	MaxTopics       int     `json:"max_topics" gorm:"not null" validate:"min=0,max=200"`
+	HoursPerStudent float64 `json:"hours_per_student" gorm:"not null;default:3"`
+	SetByID         uint    `json:"set_by_id"`
+
+	// Связи
+	Teacher User `json:"teacher" gorm:"foreignKey:TeacherID"`
+}
+
+func (SupervisionQuota) TableName() string {
+	return "supervision_quotas"
+}
@@ -1,13 +1,43 @@
 package models
 
-// TeacherSubject связывает преподавателя и дисциплину на конкретный учебный год.
+import "time"
+
+// TeacherSubjectRole - роль преподавателя на дисциплине
+type TeacherSubjectRole string
+
+const (
+	TeacherSubjectRoleLecturer   TeacherSubjectRole = "lecturer"   // читает лекции
+	TeacherSubjectRoleAssistant  TeacherSubjectRole = "assistant"  // ведёт практические занятия
+	TeacherSubjectRoleSupervisor TeacherSubjectRole = "supervisor" // руководит курсовыми работами
+)
+
+// IsValid проверяет, что роль входит в допустимый набор
+func (r TeacherSubjectRole) IsValid() bool {
+	switch r {
+	case TeacherSubjectRoleLecturer, TeacherSubjectRoleAssistant, TeacherSubjectRoleSupervisor:
+		return true
+	}
+	return false
+}
+
+// TeacherSubject связывает преподавателя и дисциплину в конкретном семестре.
+// Ведущий преподаватель у дисциплины в учебном году один - это гарантирует
+// частичный уникальный индекс по (subject_id, academic_year) среди строк с is_lead.
 type TeacherSubject struct {
 	UserID    uint `json:"user_id" gorm:"column:user_id;primaryKey"`
-	SubjectID uint `json:"subject_id" gorm:"column:subject_id;primaryKey"`
+	SubjectID uint `json:"subject_id" gorm:"column:subject_id;primaryKey;uniqueIndex:idx_subject_year_lead,where:is_lead = true"`
+	TermID    uint `json:"term_id" gorm:"column:term_id;primaryKey"`
+
+	// Учебный год семестра, продублирован для ограничения на ведущего преподавателя
+	AcademicYear string             `json:"academic_year" gorm:"size:9;index;uniqueIndex:idx_subject_year_lead,where:is_lead = true"`
+	IsLead       bool               `json:"is_lead" gorm:"default:false"`
+	Role         TeacherSubjectRole `json:"role" gorm:"size:20;default:'supervisor'"`
+	CreatedAt    time.Time          `json:"created_at"`
 
 	// Отношения
 	Teacher User    `json:"teacher" gorm:"foreignKey:UserID;references:ID"`
 	Subject Subject `json:"subject" gorm:"foreignKey:SubjectID;references:ID"`
+	Term    Term    `json:"term" gorm:"foreignKey:TermID;references:ID"`
 }
 
 // TableName задаёт имя таблицы в БД
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TeamGradingMode определяет, как оценивается командная работа
+type TeamGradingMode string
+
+const (
+	GradingTeam       TeamGradingMode = "team"       // одна оценка на всю команду
+	GradingIndividual TeamGradingMode = "individual" // оценка каждому участнику
+)
+
+// InvitationStatus описывает состояние приглашения в команду
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "pending"
+	InvitationAccepted InvitationStatus = "accepted"
+	InvitationDeclined InvitationStatus = "declined"
+	InvitationRevoked  InvitationStatus = "revoked"
+)
+
+// Team представляет команду студентов, выполняющих одну курсовую работу
+type Team struct {
+	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time      `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Name         string          `json:"name" gorm:"size:100;not null" validate:"required,min=2,max=100"`
+	CourseworkID uint            `json:"coursework_id" gorm:"not null;uniqueIndex"`
+	LeaderID     uint            `json:"leader_id" gorm:"not null;index"`
+	GradingMode  TeamGradingMode `json:"grading_mode" gorm:"type:varchar(20);default:'team';check:grading_mode IN ('team','individual')"`
+
+	// Общая сдача работы
+	SubmissionURL     string     `json:"submission_url,omitempty" gorm:"size:500"`
+	SubmissionComment string     `json:"submission_comment,omitempty" gorm:"type:text"`
+	SubmittedByID     *uint      `json:"submitted_by_id,omitempty"`
+	SubmittedAt       *time.Time `json:"submitted_at,omitempty"`
+
+	// Связи
+	Coursework Coursework   `json:"coursework" gorm:"foreignKey:CourseworkID"`
+	Leader     User         `json:"leader" gorm:"foreignKey:LeaderID"`
+	Members    []TeamMember `json:"members" gorm:"foreignKey:TeamID"`
+}
+
+func (Team) TableName() string {
+	return "teams"
+}
+
+// HasMember проверяет, состоит ли студент в команде
+func (t *Team) HasMember(studentID uint) bool {
+	for _, m := range t.Members {
+		if m.StudentID == studentID {
+			return true
+		}
+	}
+	return false
+}
+
+// TeamMember - участник команды с описанием личного вклада
+type TeamMember struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time `json:"joined_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	TeamID           uint   `json:"team_id" gorm:"not null;index"`
+	StudentID        uint   `json:"student_id" gorm:"not null;uniqueIndex"`
+	ContributionNote string `json:"contribution_note,omitempty" gorm:"type:text"`
+
+	// Связи
+	Student User `json:"student" gorm:"foreignKey:StudentID"`
+}
+
+func (TeamMember) TableName() string {
+	return "team_members"
+}
+
+// TeamInvitation - приглашение студента в команду
+type TeamInvitation struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	TeamID      uint             `json:"team_id" gorm:"not null;index"`
+	StudentID   uint             `json:"student_id" gorm:"not null;index"`
+	InvitedByID uint             `json:"invited_by_id" gorm:"not null"`
+	Status      InvitationStatus `json:"status" gorm:"type:varchar(20);default:'pending';check:status IN ('pending','accepted','declined','revoked')"`
+	RespondedAt *time.Time       `json:"responded_at,omitempty"`
+
+	// Связи
+	Team    Team `json:"team" gorm:"foreignKey:TeamID"`
+	Student User `json:"student" gorm:"foreignKey:StudentID"`
+}
+
+func (TeamInvitation) TableName() string {
+	return "team_invitations"
+}
+
+// TeamPost - запись в общем рабочем пространстве команды
+type TeamPost struct {
+	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time      `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	TeamID        uint   `json:"team_id" gorm:"not null;index"`
+	AuthorID      uint   `json:"author_id" gorm:"not null"`
+	Body          string `json:"body" gorm:"type:text;not null"`
+	AttachmentURL string `json:"attachment_url,omitempty" gorm:"size:500"`
+
+	// Связи
+	Author User `json:"author" gorm:"foreignKey:AuthorID"`
+}
+
+func (TeamPost) TableName() string {
+	return "team_posts"
+}
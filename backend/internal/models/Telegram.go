@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// TelegramLink - чат Telegram, привязанный к пользователю; у пользователя один чат
+type TelegramLink struct {
+	UserID    uint      `json:"user_id" gorm:"primaryKey"`
+	CreatedAt time.Time `json:"linked_at" gorm:"default:CURRENT_TIMESTAMP"`
+	ChatID    int64     `json:"-" gorm:"uniqueIndex;not null"`
+	Username  string    `json:"username,omitempty" gorm:"size:100"` // имя в Telegram на момент привязки
+
+	// Связи
+	User User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+func (TelegramLink) TableName() string {
+	return "telegram_links"
+}
+
+// TelegramLinkCode - одноразовый код, который пользователь отправляет боту, чтобы привязать чат
+type TelegramLinkCode struct {
+	Code      string    `json:"code" gorm:"primaryKey;size:16"`
+	CreatedAt time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UserID    uint      `json:"user_id" gorm:"not null;index"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null"`
+}
+
+func (TelegramLinkCode) TableName() string {
+	return "telegram_link_codes"
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ProposalStatus описывает состояние предложенной студентом темы
+type ProposalStatus string
+
+const (
+	ProposalPending          ProposalStatus = "pending"
+	ProposalChangesRequested ProposalStatus = "changes_requested"
+	ProposalAccepted         ProposalStatus = "accepted"
+	ProposalRejected         ProposalStatus = "rejected"
+	ProposalWithdrawn        ProposalStatus = "withdrawn"
+)
+
+// TopicProposal представляет тему курсовой работы, предложенную студентом руководителю
+type TopicProposal struct {
+	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time      `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	StudentID      uint           `json:"student_id" gorm:"not null;index" validate:"required"`
+	TeacherID      uint           `json:"teacher_id" gorm:"not null;index" validate:"required"`
+	SubjectID      uint           `json:"subject_id" gorm:"not null" validate:"required"`
+	Title          string         `json:"title" gorm:"size:300;not null" validate:"required,min=5,max=300"`
+	Description    string         `json:"description" gorm:"type:text;not null" validate:"required,min=20"`
+	Status         ProposalStatus `json:"status" gorm:"type:varchar(20);default:'pending';check:status IN ('pending','changes_requested','accepted','rejected','withdrawn')"`
+	TeacherComment string         `json:"teacher_comment,omitempty" gorm:"type:text"`
+	Revision       int            `json:"revision" gorm:"default:1"`
+	CourseworkID   *uint          `json:"coursework_id,omitempty"`
+	DecidedAt      *time.Time     `json:"decided_at,omitempty"`
+
+	// Связи
+	Student User    `json:"student" gorm:"foreignKey:StudentID"`
+	Teacher User    `json:"teacher" gorm:"foreignKey:TeacherID"`
+	Subject Subject `json:"subject" gorm:"foreignKey:SubjectID"`
+}
+
+func (TopicProposal) TableName() string {
+	return "topic_proposals"
+}
+
+// IsOpen проверяет, ожидает ли предложение решения или доработки
+func (p *TopicProposal) IsOpen() bool {
+	return p.Status == ProposalPending || p.Status == ProposalChangesRequested
+}
@@ -26,6 +26,7 @@ type User struct {
 	LastName     string   `json:"last_name" gorm:"not null;size:100" validate:"required,min=2,max=50"`
 	Role         UserRole `json:"role" gorm:"not null;size:20;check:role IN ('admin','teacher','student');default:'student'" validate:"required,oneof=admin teacher student"`
 	IsActive     bool     `json:"is_active" gorm:"default:true"`
+	Version      uint     `json:"version" gorm:"not null;default:1"` // растёт при каждом изменении, основа ETag
 
 	TeacherSubjects   []Subject   `json:"teacher_subjects,omitempty" gorm:"many2many:teacher_subjects;"`
 	StudentCoursework *Coursework `json:"student_coursework,omitempty" gorm:"-"`
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WaitlistStatus описывает состояние записи в листе ожидания
+type WaitlistStatus string
+
+const (
+	WaitlistWaiting   WaitlistStatus = "waiting"
+	WaitlistOffered   WaitlistStatus = "offered"
+	WaitlistAccepted  WaitlistStatus = "accepted"
+	WaitlistDeclined  WaitlistStatus = "declined"
+	WaitlistExpired   WaitlistStatus = "expired"
+	WaitlistCancelled WaitlistStatus = "cancelled"
+)
+
+// WaitlistEntry - место студента в очереди на заполненную курсовую работу
+type WaitlistEntry struct {
+	ID        uint           `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time      `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	CourseworkID   uint           `json:"coursework_id" gorm:"not null;index:idx_waitlist_cw_position"`
+	StudentID      uint           `json:"student_id" gorm:"not null;index"`
+	Position       int            `json:"position" gorm:"not null;index:idx_waitlist_cw_position"`
+	Status         WaitlistStatus `json:"status" gorm:"type:varchar(20);default:'waiting';check:status IN ('waiting','offered','accepted','declined','expired','cancelled')"`
+	OfferedAt      *time.Time     `json:"offered_at,omitempty"`
+	OfferExpiresAt *time.Time     `json:"offer_expires_at,omitempty" gorm:"index"`
+	ResolvedAt     *time.Time     `json:"resolved_at,omitempty"`
+
+	// Связи
+	Coursework Coursework `json:"coursework" gorm:"foreignKey:CourseworkID"`
+	Student    User       `json:"student" gorm:"foreignKey:StudentID"`
+}
+
+func (WaitlistEntry) TableName() string {
+	return "waitlist_entries"
+}
+
+// IsActive проверяет, что запись ещё стоит в очереди или ожидает ответа на предложение
+func (w *WaitlistEntry) IsActive() bool {
+	return w.Status == WaitlistWaiting || w.Status == WaitlistOffered
+}
+
+// OfferExpired проверяет, истекло ли окно подтверждения предложенного места
+func (w *WaitlistEntry) OfferExpired(now time.Time) bool {
+	return w.Status == WaitlistOffered && w.OfferExpiresAt != nil && now.After(*w.OfferExpiresAt)
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// WebhookEvent - событие, о котором сообщается внешним системам
+type WebhookEvent string
+
+const (
+	WebhookAssignmentCreated WebhookEvent = "assignment.created"
+	WebhookSubmissionCreated WebhookEvent = "submission.created"
+	WebhookGradeSet          WebhookEvent = "grade.set"
+	WebhookUserCreated       WebhookEvent = "user.created"
+	WebhookPing              WebhookEvent = "ping" // проверка адреса, приходит без подписки
+)
+
+// WebhookEvents - события, на которые можно подписать вебхук
+var WebhookEvents = []WebhookEvent{
+	WebhookAssignmentCreated,
+	WebhookSubmissionCreated,
+	WebhookGradeSet,
+	WebhookUserCreated,
+}
+
+// IsValid проверяет, что на событие можно подписаться
+func (e WebhookEvent) IsValid() bool {
+	for _, known := range WebhookEvents {
+		if e == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Webhook - адрес внешней системы, куда отправляются события
+type Webhook struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	URL         string `json:"url" gorm:"size:500;not null"`
+	Description string `json:"description" gorm:"size:255"`
+	Secret      string `json:"-" gorm:"size:64;not null"`  // ключ подписи HMAC
+	Events      string `json:"-" gorm:"size:255;not null"` // события через запятую
+	IsActive    bool   `json:"is_active" gorm:"not null"`
+}
+
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// EventList возвращает события, на которые подписан вебхук
+func (w *Webhook) EventList() []WebhookEvent {
+	events := []WebhookEvent{}
+	for _, e := range strings.Split(w.Events, ",") {
+		if e != "" {
+			events = append(events, WebhookEvent(e))
+		}
+	}
+	return events
+}
+
+// SetEvents задаёт события подписки
+func (w *Webhook) SetEvents(events []WebhookEvent) {
+	parts := make([]string, len(events))
+	for i, e := range events {
+		parts[i] = string(e)
+	}
+	w.Events = strings.Join(parts, ",")
+}
+
+// Subscribed проверяет подписку вебхука на событие
+func (w *Webhook) Subscribed(event WebhookEvent) bool {
+	for _, e := range w.EventList() {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDeliveryStatus - состояние доставки события
+type WebhookDeliveryStatus string
+
+const (
+	DeliveryPending   WebhookDeliveryStatus = "pending"   // ждёт отправки или повтора
+	DeliverySending   WebhookDeliveryStatus = "sending"   // отправляется
+	DeliveryDelivered WebhookDeliveryStatus = "delivered" // получен ответ 2xx
+	DeliveryFailed    WebhookDeliveryStatus = "failed"    // попытки исчерпаны
+)
+
+// WebhookDelivery - доставка события на вебхук и журнал её попыток. Повторная доставка
+// вручную создаёт новую запись с тем же EventID, чтобы получатель мог отбросить дубликат.
+type WebhookDelivery struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CreatedAt time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP;index"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	WebhookID      uint                  `json:"webhook_id" gorm:"not null;index"`
+	EventID        string                `json:"event_id" gorm:"size:32;not null;index"`
+	Event          WebhookEvent          `json:"event" gorm:"size:50;not null"`
+	Payload        string                `json:"payload" gorm:"type:text;not null"`
+	Status         WebhookDeliveryStatus `json:"status" gorm:"size:20;not null;index:idx_webhook_delivery_due"`
+	Attempts       int                   `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at" gorm:"index:idx_webhook_delivery_due"`
+	ResponseStatus int                   `json:"response_status,omitempty"` // код ответа последней попытки
+	ResponseBody   string                `json:"response_body,omitempty" gorm:"type:text"`
+	LastError      string                `json:"last_error,omitempty" gorm:"type:text"`
+	DurationMS     int64                 `json:"duration_ms"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+	RedeliveryOf   *uint                 `json:"redelivery_of,omitempty"` // исходная доставка при повторе вручную
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
@@ -0,0 +1,103 @@
+// Package testutil - общие для тестов пакетов managers и handlers база, данные и заглушки
+package testutil
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/Foxpunk/courseforge/internal/drivers"
+	"github.com/Foxpunk/courseforge/internal/interfaces"
+	"github.com/Foxpunk/courseforge/internal/models"
+)
+
+// NewDB создаёт базу во временном каталоге теста со всеми миграциями
+func NewDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := drivers.InitDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// ожидаемые промахи вроде record not found не засоряют вывод тестов
+	db.Logger = logger.Discard
+	if sqlDB, err := db.DB(); err == nil {
+		t.Cleanup(func() { sqlDB.Close() })
+	}
+	return db
+}
+
+// MustCreate сохраняет запись или останавливает тест
+func MustCreate(t *testing.T, db *gorm.DB, value interface{}) {
+	t.Helper()
+	if err := db.Create(value).Error; err != nil {
+		t.Fatal(err)
+	}
+}
+
+// CreateActiveTerm создаёт учебный год с активным осенним семестром
+func CreateActiveTerm(t *testing.T, db *gorm.DB) *models.Term {
+	t.Helper()
+	starts := time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC)
+	year := &models.AcademicYear{Name: "2025-2026", StartsOn: starts, EndsOn: starts.AddDate(1, 0, 0)}
+	MustCreate(t, db, year)
+	term := &models.Term{
+		AcademicYearID: year.ID,
+		Number:         1,
+		Name:           "Осенний семестр 2025-2026",
+		StartsOn:       starts,
+		EndsOn:         starts.AddDate(0, 5, 0),
+		IsActive:       true,
+	}
+	MustCreate(t, db, term)
+	return term
+}
+
+// CreateUser создаёт активного пользователя с ролью role
+func CreateUser(t *testing.T, db *gorm.DB, role models.UserRole, email string) *models.User {
+	t.Helper()
+	user := &models.User{Email: email, PasswordHash: "x", FirstName: "Тест", LastName: email, Role: role, IsActive: true}
+	MustCreate(t, db, user)
+	return user
+}
+
+// CreateCoursework создаёт доступную тему семестра term с slots местами в новой дисциплине
+func CreateCoursework(t *testing.T, db *gorm.DB, teacher *models.User, term *models.Term, slots int) *models.Coursework {
+	t.Helper()
+	subject := &models.Subject{Name: "Базы данных", Code: "DB", Semester: 5, IsActive: true}
+	MustCreate(t, db, subject)
+	cw := &models.Coursework{
+		Title:           "Проектирование базы данных",
+		Description:     "Тема для проверки назначений",
+		SubjectID:       subject.ID,
+		TeacherID:       teacher.ID,
+		MaxStudents:     slots,
+		DifficultyLevel: models.Medium,
+		IsAvailable:     true,
+		TermID:          term.ID,
+	}
+	MustCreate(t, db, cw)
+	return cw
+}
+
+// Заглушки каналов доставки: тестам важны данные в базе, а не уведомления
+
+type NopNotifier struct{}
+
+func (NopNotifier) Notify(context.Context, interfaces.Event) error { return nil }
+
+type NopRealtime struct{}
+
+func (NopRealtime) PublishSlots(context.Context, ...uint) {}
+func (NopRealtime) PublishAssignment(context.Context, *models.StudentCoursework, models.EventType) {
+}
+
+type NopWebhooks struct{}
+
+func (NopWebhooks) Dispatch(context.Context, models.WebhookEvent, interface{}) {}
+func (NopWebhooks) Enqueue(context.Context, models.WebhookEvent, interface{}) error {
+	return nil
+}